@@ -0,0 +1,262 @@
+package microui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/user/microui-go/types"
+)
+
+// Role says what kind of element an AccessNode is, for screen readers.
+type Role int
+
+const (
+	RoleNone Role = iota
+	RoleWindow
+	RoleDialog // Modal window
+	RolePopup  // Popup, menu or dropdown list
+	RolePanel
+	RoleGroup // Group box
+	RoleLabel
+	RoleText // Wrapped text
+	RoleButton
+	RoleCheckbox
+	RoleRadio
+	RoleSlider
+	RoleSpinButton // Number input
+	RoleTextbox
+	RoleComboBox
+	RoleListBox
+	RoleOption // Item of a list, combo or selectable
+	RoleTab
+	RoleHeader // Collapsible header
+	RoleTreeItem
+	RoleProgress
+	RoleImage
+	RoleStatusBar
+	RoleMenuItem
+)
+
+var roleNames = [...]string{
+	RoleNone: "none", RoleWindow: "window", RoleDialog: "dialog", RolePopup: "popup",
+	RolePanel: "panel", RoleGroup: "group", RoleLabel: "label", RoleText: "text",
+	RoleButton: "button", RoleCheckbox: "checkbox", RoleRadio: "radio",
+	RoleSlider: "slider", RoleSpinButton: "spinbutton", RoleTextbox: "textbox",
+	RoleComboBox: "combobox", RoleListBox: "listbox", RoleOption: "option",
+	RoleTab: "tab", RoleHeader: "header", RoleTreeItem: "treeitem",
+	RoleProgress: "progressbar", RoleImage: "image", RoleStatusBar: "status",
+	RoleMenuItem: "menuitem",
+}
+
+// String returns the role's ARIA-style name, e.g. "checkbox".
+func (r Role) String() string {
+	if r >= 0 && int(r) < len(roleNames) {
+		return roleNames[r]
+	}
+	return "none"
+}
+
+// AccessState flags describe an element's state.
+type AccessState int
+
+const (
+	AccessFocused     AccessState = 1 << iota // Has keyboard or mouse focus
+	AccessDisabled                            // Ignores input (OptNoInteract)
+	AccessChecked                             // Checkbox or radio is on
+	AccessSelected                            // Option or tab is selected
+	AccessExpanded                            // Header, tree node or combo is open
+	AccessReadOnly                            // Textbox can't be edited
+	AccessInvalid                             // Textbox fails validation
+	AccessProtected                           // Password textbox; Value is empty
+	AccessCollapsible                         // Header or tree node that can expand
+)
+
+// AccessNode is one element of the accessibility tree: a window, panel or
+// control with what a screen reader needs to present it.
+type AccessNode struct {
+	ID       ID // Control or container ID (0 for static text)
+	Role     Role
+	Label    string // Name, e.g. the button text or window title
+	Value    string // Current value, e.g. slider value or textbox text
+	State    AccessState
+	Rect     types.Rect // Screen rect
+	Children []*AccessNode
+}
+
+// AccessBridge passes the accessibility tree to a platform accessibility
+// API or a terminal screen reader. See Config.AccessBridge.
+type AccessBridge interface {
+	// UpdateAccessTree is called at the end of every frame with the root
+	// windows, back to front, and the ID of the focused control (0 for
+	// none). The nodes are only valid until the next call.
+	UpdateAccessTree(roots []*AccessNode, focus ID)
+}
+
+// AccessTree returns the accessibility tree of the last frame: its
+// windows, back to front, with the controls in them in submission order.
+// It is nil unless Config.Accessibility or Config.AccessBridge is set.
+func (u *UI) AccessTree() []*AccessNode {
+	return u.accessRoots
+}
+
+// AccessItem describes the last control for screen readers, e.g. to name
+// an icon-only button or to add a custom control to the tree. It replaces
+// the description the control gave itself; a zero role or empty label
+// keeps the control's own.
+//
+//	ui.ButtonOpt("", microui.IconClose, 0)
+//	ui.AccessItem(microui.RoleButton, "Close", "", 0)
+func (u *UI) AccessItem(role Role, label, value string, state AccessState) {
+	if !u.accessOn {
+		return
+	}
+	n := u.accessLast
+	if n == nil || n.ID != u.item.id || u.item.id == 0 {
+		n = u.addAccessNode(u.item.id, RoleNone, "", u.item.rect)
+	}
+	if role != RoleNone {
+		n.Role = role
+	}
+	if label != "" {
+		n.Label = label
+	}
+	n.Value = value
+	n.State |= state
+}
+
+// accessControl adds control id to the accessibility tree.
+func (u *UI) accessControl(id ID, rect types.Rect, role Role, label, value string, state AccessState) *AccessNode {
+	if !u.accessOn {
+		return nil
+	}
+	n := u.addAccessNode(id, role, label, rect)
+	n.Value = value
+	n.State |= state
+	return n
+}
+
+// accessOptState returns the state implied by a control's options.
+func accessOptState(opt int) AccessState {
+	if opt&OptNoInteract != 0 {
+		return AccessDisabled
+	}
+	return 0
+}
+
+// accessExpanded returns the state of a header or tree node.
+func accessExpanded(expanded bool) AccessState {
+	if expanded {
+		return AccessCollapsible | AccessExpanded
+	}
+	return AccessCollapsible
+}
+
+// accessOpen makes n the parent of the nodes that follow in the current
+// container, e.g. for the children of an expanded tree node, until
+// accessClose.
+func (u *UI) accessOpen(n *AccessNode) {
+	if cnt := u.GetCurrentContainer(); n != nil && cnt != nil {
+		cnt.accessOpen = append(cnt.accessOpen, n)
+	}
+}
+
+// accessClose ends the innermost accessOpen of the current container.
+func (u *UI) accessClose() {
+	if cnt := u.GetCurrentContainer(); u.accessOn && cnt != nil && len(cnt.accessOpen) > 0 {
+		cnt.accessOpen = cnt.accessOpen[:len(cnt.accessOpen)-1]
+	}
+}
+
+// accessParent returns the node that nodes added in cnt go in.
+func accessParent(cnt *Container) *AccessNode {
+	if n := len(cnt.accessOpen); n > 0 {
+		return cnt.accessOpen[n-1]
+	}
+	return cnt.access
+}
+
+// accessStatic adds text that isn't a control, such as a label.
+func (u *UI) accessStatic(role Role, text string, rect types.Rect) {
+	if u.accessOn {
+		u.addAccessNode(0, role, text, rect)
+	}
+}
+
+// addAccessNode adds a node to the current container's node.
+func (u *UI) addAccessNode(id ID, role Role, label string, rect types.Rect) *AccessNode {
+	n := &AccessNode{ID: id, Role: role, Label: label, Rect: rect}
+	if id != 0 && (u.input.Focus == id || u.navFocus == id) {
+		n.State |= AccessFocused
+	}
+	if cnt := u.GetCurrentContainer(); cnt != nil {
+		if cnt.disabled {
+			n.State |= AccessDisabled
+		}
+		if parent := accessParent(cnt); parent != nil {
+			parent.Children = append(parent.Children, n)
+		}
+	}
+	u.accessLast = n
+	return n
+}
+
+// accessContainer adds a window or panel that was just begun to the tree,
+// as a root or in the container it is nested in.
+func (u *UI) accessContainer(cnt *Container, role Role, label string) {
+	cnt.access, cnt.accessOpen = nil, cnt.accessOpen[:0]
+	if !u.accessOn {
+		return
+	}
+	if strings.HasPrefix(label, "!") {
+		label = "" // Internal name, e.g. a combo's dropdown
+	}
+	n := &AccessNode{ID: cnt.id, Role: role, Label: label, Rect: cnt.rect}
+	if cnt.disabled {
+		n.State |= AccessDisabled
+	}
+	if cnt.parent == nil {
+		u.accessPending = append(u.accessPending, cnt)
+	} else if parent := accessParent(cnt.parent); parent != nil {
+		parent.Children = append(parent.Children, n)
+	}
+	cnt.access = n
+}
+
+// accessAs makes the node of a panel cnt that was just begun describe the
+// control id drawn as that panel, such as a list box.
+func (u *UI) accessAs(cnt *Container, id ID, role Role, label string) {
+	if n := cnt.access; n != nil {
+		n.ID, n.Role, n.Label = id, role, label
+		if u.input.Focus == id || u.navFocus == id {
+			n.State |= AccessFocused
+		}
+	}
+}
+
+// finishAccessTree orders the frame's windows back to front, and hands
+// the tree to the bridge.
+func (u *UI) finishAccessTree() {
+	slices.SortStableFunc(u.accessPending, func(a, b *Container) int {
+		switch {
+		case u.inFront(a, b):
+			return 1
+		case u.inFront(b, a):
+			return -1
+		}
+		return 0
+	})
+	u.accessRoots = make([]*AccessNode, 0, len(u.accessPending))
+	for _, cnt := range u.accessPending {
+		cnt.access.Rect = cnt.rect // Placed or resized after it began
+		u.accessRoots = append(u.accessRoots, cnt.access)
+	}
+	u.accessPending = u.accessPending[:0]
+	u.accessLast = nil
+	if u.accessBridge != nil {
+		focus := u.input.Focus
+		if u.navFocus != 0 {
+			focus = u.navFocus
+		}
+		u.accessBridge.UpdateAccessTree(u.accessRoots, focus)
+	}
+}
@@ -0,0 +1,158 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// recordingBridge is an AccessBridge that keeps the last tree it was given.
+type recordingBridge struct {
+	roots []*AccessNode
+	focus ID
+	calls int
+}
+
+func (b *recordingBridge) UpdateAccessTree(roots []*AccessNode, focus ID) {
+	b.roots, b.focus = roots, focus
+	b.calls++
+}
+
+func TestAccessTree_DisabledByDefault(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 200, H: 200})
+	ui.Button("OK")
+	ui.EndWindow()
+	ui.EndFrame()
+	if tree := ui.AccessTree(); tree != nil {
+		t.Errorf("AccessTree = %v, want nil", tree)
+	}
+}
+
+func TestAccessTree_Controls(t *testing.T) {
+	ui := New(Config{Accessibility: true})
+	checked := true
+	volume := 0.5
+	ui.BeginFrame()
+	ui.BeginWindow("Settings", types.Rect{X: 0, Y: 0, W: 200, H: 300})
+	ui.LayoutRow(1, []int{-1}, 0)
+	ui.Label("Audio")
+	ui.Checkbox("Mute", &checked)
+	ui.SliderOpt(&volume, 0, 1, 0, "%.1f", 0)
+	ui.Button("OK")
+	ui.EndWindow()
+	ui.EndFrame()
+
+	roots := ui.AccessTree()
+	if len(roots) != 1 {
+		t.Fatalf("got %d roots, want 1", len(roots))
+	}
+	win := roots[0]
+	if win.Role != RoleWindow || win.Label != "Settings" {
+		t.Errorf("root = %v %q, want window \"Settings\"", win.Role, win.Label)
+	}
+	want := []struct {
+		role  Role
+		label string
+		value string
+		state AccessState
+	}{
+		{RoleLabel, "Audio", "", 0},
+		{RoleCheckbox, "Mute", "", AccessChecked},
+		{RoleSlider, "", "0.5", 0},
+		{RoleButton, "OK", "", 0},
+	}
+	if len(win.Children) != len(want) {
+		t.Fatalf("window has %d children, want %d", len(win.Children), len(want))
+	}
+	for i, w := range want {
+		n := win.Children[i]
+		if n.Role != w.role || n.Label != w.label || n.Value != w.value || n.State != w.state {
+			t.Errorf("child %d = %v %q %q %b, want %v %q %q %b", i, n.Role, n.Label, n.Value, n.State, w.role, w.label, w.value, w.state)
+		}
+	}
+}
+
+func TestAccessTree_TreeNodesNest(t *testing.T) {
+	ui := New(Config{Accessibility: true})
+	ui.BeginFrame()
+	ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 200, H: 300})
+	if ui.BeginTreeNodeEx("Root", OptExpanded) {
+		ui.Label("leaf")
+		ui.EndTreeNode()
+	}
+	ui.Label("after")
+	ui.EndWindow()
+	ui.EndFrame()
+
+	win := ui.AccessTree()[0]
+	if len(win.Children) != 2 {
+		t.Fatalf("window has %d children, want 2", len(win.Children))
+	}
+	node := win.Children[0]
+	if node.Role != RoleTreeItem || node.State&AccessExpanded == 0 {
+		t.Errorf("tree node = %v %b, want expanded treeitem", node.Role, node.State)
+	}
+	if len(node.Children) != 1 || node.Children[0].Label != "leaf" {
+		t.Errorf("tree node children = %v, want the leaf label", node.Children)
+	}
+	if win.Children[1].Label != "after" {
+		t.Errorf("second child = %q, want \"after\"", win.Children[1].Label)
+	}
+}
+
+func TestAccessItem_NamesIconButton(t *testing.T) {
+	ui := New(Config{Accessibility: true})
+	ui.BeginFrame()
+	ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 200, H: 200})
+	ui.ButtonOpt("", IconClose, 0)
+	ui.AccessItem(RoleNone, "Close", "", 0)
+	ui.EndWindow()
+	ui.EndFrame()
+
+	win := ui.AccessTree()[0]
+	if len(win.Children) != 1 {
+		t.Fatalf("window has %d children, want 1", len(win.Children))
+	}
+	if n := win.Children[0]; n.Role != RoleButton || n.Label != "Close" {
+		t.Errorf("button = %v %q, want button \"Close\"", n.Role, n.Label)
+	}
+}
+
+func TestAccessBridge_ReceivesTreeAndFocus(t *testing.T) {
+	bridge := &recordingBridge{}
+	ui := New(Config{AccessBridge: bridge})
+	var buf []byte
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Back", types.Rect{X: 0, Y: 0, W: 100, H: 100})
+		ui.EndWindow()
+		ui.BeginWindow("Front", types.Rect{X: 200, Y: 0, W: 200, H: 100})
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.TextboxOpt(&buf, 32, OptPassword)
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	frame()
+	r := ui.GetContainer("Front").Body()
+	ui.MouseMove(r.X+5, r.Y+5)
+	frame()
+	ui.MouseDown(r.X+5, r.Y+5, MouseLeft)
+	buf = []byte("secret")
+	frame()
+
+	if bridge.calls != 3 {
+		t.Fatalf("bridge called %d times, want 3", bridge.calls)
+	}
+	if len(bridge.roots) != 2 || bridge.roots[1].Label != "Front" {
+		t.Fatalf("roots = %v, want Back then Front", bridge.roots)
+	}
+	box := bridge.roots[1].Children[0]
+	if box.Role != RoleTextbox || box.Value != "" || box.State&AccessProtected == 0 {
+		t.Errorf("password box = %v %q %b, want protected textbox without value", box.Role, box.Value, box.State)
+	}
+	if bridge.focus != box.ID || box.State&AccessFocused == 0 {
+		t.Errorf("focus = %d, want the textbox %d", bridge.focus, box.ID)
+	}
+}
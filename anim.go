@@ -0,0 +1,293 @@
+package microui
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+// animKey identifies one animated value. Built-in transitions use their own
+// slots so they never collide with values passed to Animate.
+type animKey struct {
+	id   ID
+	slot int
+}
+
+const (
+	animUser = iota
+	animHover
+	animReveal
+	animFade
+	animScrollbar
+)
+
+// animState is one value being eased towards its target.
+type animState struct {
+	value  float64
+	frame  int  // Last frame the value was advanced
+	moving bool // Short of its target after the last advance
+	size   int  // Header content height measured last frame
+}
+
+const (
+	animSpeed   = 0.25  // Built-in transition speed (fraction per frame)
+	animEpsilon = 0.005 // Values this close to the target snap to it
+
+	// nominalFrame is the frame animation speeds are given for, and
+	// maxFrameDelta the longest a frame counts for when animating.
+	nominalFrame  = time.Second / 60
+	maxFrameDelta = 100 * time.Millisecond
+)
+
+// revealSection clips a header's content while it expands or collapses.
+// Headers have no end call, so a section ends at the next header in the
+// same layout or when that layout is popped.
+type revealSection struct {
+	depth   int // Layout stack depth the header was drawn at
+	startY  int // Layout nextRow where the content starts
+	maxY    int // Layout max.Y before the content
+	clipIdx int // Index of the section's clip command
+	t       float64
+	st      *animState
+}
+
+// Animate eases a value towards target and returns it. The value for id
+// persists across frames: each frame it covers speed (0..1) of the
+// remaining distance, so it starts fast and slows as it arrives. With
+// BeginFrameDelta, speed is per 60th of a second instead, so the value
+// eases at the same pace at any frame rate. The first
+// call for an id returns target. State is dropped for ids not animated in
+// a frame.
+func (u *UI) Animate(id ID, target, speed float64) float64 {
+	return u.animate(animKey{id: id, slot: animUser}, target, speed)
+}
+
+func (u *UI) animate(key animKey, target, speed float64) float64 {
+	st := u.anims[key]
+	if st == nil {
+		u.anims[key] = &animState{value: target, frame: u.frame}
+		return target
+	}
+	if st.frame != u.frame {
+		st.frame = u.frame
+		st.value += (target - st.value) * u.frameFraction(speed)
+		if math.Abs(target-st.value) < animEpsilon {
+			st.value = target
+		}
+		st.moving = st.value != target
+	}
+	return st.value
+}
+
+// frameFraction returns how much of the remaining distance a value easing
+// by speed per nominal frame covers in this frame.
+func (u *UI) frameFraction(speed float64) float64 {
+	speed = math.Max(0, math.Min(speed, 1))
+	if u.frameDelta == nominalFrame || speed == 1 {
+		return speed
+	}
+	frames := float64(min(u.frameDelta, maxFrameDelta)) / float64(nominalFrame)
+	return 1 - math.Pow(1-speed, frames)
+}
+
+// animStart begins an animation at from; the next animate call moves it.
+func (u *UI) animStart(key animKey, from float64) *animState {
+	st := &animState{value: from, frame: u.frame - 1, moving: true}
+	u.anims[key] = st
+	return st
+}
+
+// pruneAnims drops state for values not animated this frame.
+func (u *UI) pruneAnims() {
+	for key, st := range u.anims {
+		if st.frame < u.frame {
+			delete(u.anims, key)
+		}
+	}
+}
+
+// hoverFade returns how far control id has faded towards its hover color.
+// State is only kept while the control is hovered or fading out.
+func (u *UI) hoverFade(id ID, on bool) float64 {
+	key := animKey{id: id, slot: animHover}
+	if _, ok := u.anims[key]; !ok {
+		if !on {
+			return 0
+		}
+		u.animStart(key, 0)
+	}
+	target := 0.0
+	if on {
+		target = 1
+	}
+	t := u.animate(key, target, animSpeed)
+	if t == 0 {
+		delete(u.anims, key)
+	}
+	return t
+}
+
+// headerReveal animates header id towards expanded and reports whether its
+// content should be drawn. While the header is between states the content
+// is drawn inside a clipped section that grows or shrinks each frame.
+func (u *UI) headerReveal(id ID, expanded, toggled bool) bool {
+	key := animKey{id: id, slot: animReveal}
+	st := u.anims[key]
+	if st == nil {
+		if !toggled {
+			return expanded
+		}
+		from := 1.0
+		if expanded {
+			from = 0
+		}
+		st = u.animStart(key, from)
+	}
+	target := 0.0
+	if expanded {
+		target = 1
+	}
+	t := u.animate(key, target, animSpeed)
+	if t == target {
+		delete(u.anims, key)
+		return expanded
+	}
+
+	// Clip to last frame's height so hidden content is culled and can't be
+	// hovered; the exact height is patched in by endReveals
+	layout := u.getLayout()
+	clip := u.GetClipRect()
+	h := int(float64(st.size) * t)
+	if st.size == 0 {
+		h = clip.H
+	}
+	u.PushClip(types.Rect{X: clip.X, Y: layout.body.Y + layout.nextRow, W: clip.W, H: h})
+	u.reveals = append(u.reveals, revealSection{
+		depth:   u.layoutStack.Len(),
+		startY:  layout.nextRow,
+		maxY:    layout.max.Y,
+		clipIdx: u.commands.Len() - 1,
+		t:       t,
+		st:      st,
+	})
+	return true
+}
+
+// endReveals closes header sections opened at layout depth or deeper:
+// the content is clipped to its animated height and the layout continues
+// below the visible part.
+func (u *UI) endReveals(depth int) {
+	for len(u.reveals) > 0 {
+		sec := u.reveals[len(u.reveals)-1]
+		if sec.depth < depth {
+			return
+		}
+		u.reveals = u.reveals[:len(u.reveals)-1]
+
+		layout := u.getLayout()
+		h := layout.nextRow - sec.startY
+		shown := int(float64(h) * sec.t)
+		sec.st.size = h
+
+		u.PopClip()
+		parent := u.GetClipRect()
+		u.commands.cmds[sec.clipIdx].Rect = intersectRect(
+			types.Rect{X: parent.X, Y: layout.body.Y + sec.startY, W: parent.W, H: shown}, parent)
+
+		layout.nextRow = sec.startY + shown
+		if end := layout.body.Y + sec.startY + shown; layout.max.Y > end {
+			layout.max.Y = max(end, sec.maxY)
+		}
+	}
+}
+
+// fadeable reports whether a root container fades in and out. Popups,
+// internal containers and docked windows (which swap as tabs) don't.
+func (u *UI) fadeable(cnt *Container) bool {
+	return cnt.opt&OptPopup == 0 && !strings.HasPrefix(cnt.name, "!") && u.findDock(cnt.name) == nil
+}
+
+// updateWindowFades starts fades for windows that appeared or disappeared
+// this frame and advances running fades. A closed window keeps drawing its
+// last frame's commands until it has faded out.
+func (u *UI) updateWindowFades() {
+	for _, cnt := range u.rootList {
+		key := animKey{id: cnt.id, slot: animFade}
+		if cnt.fadeCmds != nil {
+			// Reopened while fading out: fade back in from here
+			cnt.fadeCmds = nil
+		} else if !containsRoot(u.prevRoots, cnt) && u.fadeable(cnt) {
+			u.animStart(key, 0)
+		}
+		if _, ok := u.anims[key]; ok && u.animate(key, 1, animSpeed) == 1 {
+			delete(u.anims, key)
+		}
+	}
+
+	for _, cnt := range u.prevRoots {
+		if cnt.fadeCmds != nil || containsRoot(u.rootList, cnt) || !u.fadeable(cnt) {
+			continue
+		}
+		cnt.fadeCmds = append([]Command(nil), u.prevCommands.cmds[cnt.headIdx:cnt.tailIdx]...)
+		u.fading = append(u.fading, cnt)
+		key := animKey{id: cnt.id, slot: animFade}
+		if _, ok := u.anims[key]; !ok {
+			u.animStart(key, 1)
+		}
+	}
+
+	n := 0
+	for _, cnt := range u.fading {
+		if cnt.fadeCmds == nil {
+			continue
+		}
+		key := animKey{id: cnt.id, slot: animFade}
+		if u.animate(key, 0, animSpeed) == 0 {
+			cnt.fadeCmds = nil
+			delete(u.anims, key)
+			continue
+		}
+		u.fading[n] = cnt
+		n++
+	}
+	u.fading = u.fading[:n]
+}
+
+// windowAlpha returns the fade opacity of a root container.
+func (u *UI) windowAlpha(cnt *Container) float64 {
+	if st := u.anims[animKey{id: cnt.id, slot: animFade}]; st != nil {
+		return st.value
+	}
+	return 1
+}
+
+func containsRoot(list []*Container, cnt *Container) bool {
+	for _, c := range list {
+		if c == cnt {
+			return true
+		}
+	}
+	return false
+}
+
+// blendColor mixes a and b, t=0 giving a and t=1 giving b.
+func blendColor(a, b color.Color, t float64) color.Color {
+	ca, cb := types.RGBAFromColor(a), types.RGBAFromColor(b)
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5)
+	}
+	return color.RGBA{R: mix(ca.R, cb.R), G: mix(ca.G, cb.G), B: mix(ca.B, cb.B), A: mix(ca.A, cb.A)}
+}
+
+// fadeColor scales the opacity of c by alpha.
+func fadeColor(c color.Color, alpha float64) color.Color {
+	if c == nil {
+		return nil
+	}
+	p := types.RGBAFromColor(c) // premultiplied, so every channel scales
+	scale := func(x uint8) uint8 { return uint8(float64(x) * alpha) }
+	return color.RGBA{R: scale(p.R), G: scale(p.G), B: scale(p.B), A: scale(p.A)}
+}
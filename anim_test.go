@@ -0,0 +1,217 @@
+package microui
+
+import (
+	"image/color"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestAnimate_EasesTowardsTarget(t *testing.T) {
+	ui := New(Config{})
+	id := ID(42)
+
+	ui.BeginFrame()
+	if v := ui.Animate(id, 0, 0.5); v != 0 {
+		t.Fatalf("first call = %v, want the target", v)
+	}
+	ui.EndFrame()
+
+	var got []float64
+	for i := 0; i < 3; i++ {
+		ui.BeginFrame()
+		got = append(got, ui.Animate(id, 1, 0.5))
+		if again := ui.Animate(id, 1, 0.5); again != got[i] {
+			t.Errorf("second call in one frame advanced the value: %v -> %v", got[i], again)
+		}
+		ui.EndFrame()
+	}
+	want := []float64{0.5, 0.75, 0.875}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("frame %d value = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	for i := 0; i < 20; i++ {
+		ui.BeginFrame()
+		got[0] = ui.Animate(id, 1, 0.5)
+		ui.EndFrame()
+	}
+	if got[0] != 1 {
+		t.Errorf("value should snap to the target, got %v", got[0])
+	}
+}
+
+func TestAnimate_StateDroppedWhenUnused(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.Animate(1, 0, 0.5)
+	ui.EndFrame()
+	ui.BeginFrame()
+	ui.EndFrame()
+	ui.BeginFrame()
+	if v := ui.Animate(1, 1, 0.5); v != 1 {
+		t.Errorf("value after a skipped frame = %v, want a fresh start at the target", v)
+	}
+	ui.EndFrame()
+}
+
+func TestAnimate_HoverBlends(t *testing.T) {
+	ui := New(Config{Animations: true})
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.Button("Hover")
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	frame()
+	ui.MouseMove(20, 35)
+	frame()
+	frame()
+
+	base := types.RGBAFromColor(ui.style.Colors.Button)
+	hover := types.RGBAFromColor(ui.style.Colors.ButtonHover)
+	blended := false
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind != CmdRect || cmd.Rect.X != 5 {
+			return
+		}
+		if c := types.RGBAFromColor(cmd.Color); c.R > base.R && c.R < hover.R {
+			blended = true
+		}
+	})
+	if !blended {
+		t.Error("hovered button should be drawn with a color between base and hover")
+	}
+}
+
+// headerFrame draws a header over ten labels and returns whether it
+// reported expanded plus the window's content height.
+func headerFrame(ui *UI) (bool, int) {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	open := ui.Header("Section")
+	if open {
+		for i := 0; i < 10; i++ {
+			ui.Label("row")
+		}
+	}
+	ui.EndWindow()
+	ui.EndFrame()
+	return open, ui.GetContainer("Test").contentSize.Y
+}
+
+func TestAnimate_HeaderCollapse(t *testing.T) {
+	ui := New(Config{Animations: true})
+	_, full := headerFrame(ui)
+
+	ui.MouseMove(20, 35)
+	headerFrame(ui)
+	ui.MouseDown(20, 35, MouseLeft)
+	headerFrame(ui)
+	ui.MouseUp(20, 35, MouseLeft)
+
+	prev := full
+	frames := 0
+	for {
+		open, h := headerFrame(ui)
+		if !open {
+			break
+		}
+		if h > prev || (frames == 0 && h == full) {
+			t.Fatalf("content height should shrink while collapsing: %d -> %d", prev, h)
+		}
+		prev = h
+		if frames++; frames > 60 {
+			t.Fatal("header never finished collapsing")
+		}
+	}
+	if frames < 2 {
+		t.Errorf("collapse finished after %d frames, want an animation", frames)
+	}
+}
+
+// recordRenderer records rect colors drawn by Render.
+type recordRenderer struct {
+	rects []color.Color
+}
+
+func (r *recordRenderer) DrawRect(pos, size types.Vec2, c color.Color) {
+	r.rects = append(r.rects, c)
+}
+func (r *recordRenderer) DrawText(string, types.Vec2, types.Font, color.Color) {}
+func (r *recordRenderer) SetClip(types.Rect)                                   {}
+
+func TestAnimate_WindowFadesOut(t *testing.T) {
+	ui := New(Config{Animations: true})
+	frame := func(show bool) *recordRenderer {
+		ui.BeginFrame()
+		if ui.BeginWindow("Back", types.Rect{X: 0, Y: 0, W: 50, H: 50}) {
+			ui.EndWindow()
+		}
+		if show && ui.BeginWindow("Test", types.Rect{X: 100, Y: 0, W: 200, H: 100}) {
+			ui.EndWindow()
+		}
+		ui.EndFrame()
+		r := &recordRenderer{}
+		ui.Render(r)
+		return r
+	}
+	for i := 0; i < 30; i++ {
+		frame(true)
+	}
+	opaque := len(frame(true).rects)
+
+	r := frame(false)
+	if len(r.rects) != opaque {
+		t.Fatalf("closed window should still draw while fading: %d rects, want %d", len(r.rects), opaque)
+	}
+	_, _, _, a := r.rects[len(r.rects)-1].RGBA()
+	if a == 0 || a == 0xffff {
+		t.Errorf("fading window alpha = %#x, want partially transparent", a)
+	}
+
+	for i := 0; i < 60; i++ {
+		r = frame(false)
+	}
+	if len(r.rects) >= opaque {
+		t.Error("window should stop drawing once faded out")
+	}
+}
+
+func TestAnimate_FrameDelta(t *testing.T) {
+	// Two frames of half the nominal length ease as far as one nominal frame
+	nominal, half := New(Config{}), New(Config{})
+	id := ID(7)
+	for _, ui := range []*UI{nominal, half} {
+		ui.BeginFrameDelta(nominalFrame)
+		ui.Animate(id, 0, 0.5)
+		ui.EndFrame()
+	}
+	nominal.BeginFrame()
+	want := nominal.Animate(id, 1, 0.5)
+	nominal.EndFrame()
+	var got float64
+	for range 2 {
+		half.BeginFrameDelta(nominalFrame / 2)
+		got = half.Animate(id, 1, 0.5)
+		half.EndFrame()
+	}
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("after two half frames value = %v, want %v as after one frame", got, want)
+	}
+	if d := half.FrameDelta(); d != nominalFrame/2 {
+		t.Errorf("FrameDelta = %v, want %v", d, nominalFrame/2)
+	}
+
+	// A long pause counts as maxFrameDelta
+	half.BeginFrameDelta(time.Minute)
+	if v := half.Animate(id, 0, 0.1); v < 0.1 {
+		t.Errorf("value = %v after an idle minute, want it short of the target", v)
+	}
+	half.EndFrame()
+}
@@ -163,3 +163,96 @@ func TestWindow_AutoSizeWithNoTitle(t *testing.T) {
 		t.Errorf("AutoSize window (no title) W = %d, want >= 200", cnt.Rect().W)
 	}
 }
+
+// fitFrame draws a window fitting three 200px labels and a fill-width one.
+func fitFrame(ui *UI) *Container {
+	ui.BeginFrame()
+	if ui.BeginWindowOpt("Fit", types.Rect{X: 100, Y: 100, W: 50, H: 50}, OptFitContent) {
+		ui.LayoutRow(1, []int{200}, 0)
+		for i := 0; i < 3; i++ {
+			ui.Label("Content")
+		}
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Label("Fill")
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+	return ui.GetContainer("Fit")
+}
+
+func TestWindow_FitContentIsStable(t *testing.T) {
+	ui := New(Config{})
+	cnt := fitFrame(ui)
+	if r := cnt.Rect(); r.W != 50 || r.H != 50 {
+		t.Fatalf("first frame rect = %v, want the given size until content is measured", r)
+	}
+	want := fitFrame(ui).Rect()
+	if want.W < 200 || cnt.Body().H < cnt.ContentSize().Y {
+		t.Fatalf("rect = %v, body = %v: content %v doesn't fit", want, cnt.Body(), cnt.ContentSize())
+	}
+	for i := 0; i < 5; i++ {
+		if r := fitFrame(ui).Rect(); r != want {
+			t.Fatalf("frame %d: rect = %v, want it to stay %v", i+3, r, want)
+		}
+	}
+
+	// Size limits still apply
+	cnt.SetMaxSize(150, 0)
+	if r := fitFrame(ui).Rect(); r.W != 150 || r.H != want.H {
+		t.Errorf("rect = %v with a 150px max width", r)
+	}
+}
+
+func TestPanel_FitContent(t *testing.T) {
+	ui := New(Config{})
+	var panel, below types.Rect
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.LayoutRow(1, []int{-1}, -1)
+		ui.BeginPanelOpt("fit", OptFitContent)
+		ui.LayoutRow(1, []int{120}, 0)
+		for i := 0; i < 3; i++ {
+			ui.Label("line")
+		}
+		ui.EndPanel()
+		panel = ui.GetContainer("fit").Rect()
+		below = ui.LayoutNext()
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	frame()
+	frame()
+	// Three 20px rows with 4px spacing, plus 5px padding around them
+	if panel != (types.Rect{X: 5, Y: 29, W: 130, H: 78}) {
+		t.Errorf("panel = %v, want 130x78 at 5,29", panel)
+	}
+	if below.Y != panel.Y+panel.H+4 {
+		t.Errorf("next row at y=%d, want it right below the panel", below.Y)
+	}
+}
+
+func TestLayoutFitContent(t *testing.T) {
+	ui := New(Config{})
+	var size types.Vec2
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.Label("one")
+		ui.Label("two")
+		size = ui.GetContentSize()
+		if size.Y < 100 {
+			ui.LayoutFitContent() // Short enough to shrink to
+		}
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	frame()
+	if size != (types.Vec2{X: 78, Y: 44}) {
+		t.Errorf("GetContentSize = %v mid-frame, want 78x44", size)
+	}
+	frame()
+	if r := ui.GetContainer("W").Rect(); r.W >= 400 || r.H >= 300 {
+		t.Errorf("rect = %v, want the window shrunk to its content", r)
+	}
+}
@@ -0,0 +1,45 @@
+package microui
+
+import "github.com/user/microui-go/types"
+
+// backgroundName is the container name of the layer BeginBackground opens.
+const backgroundName = "!background"
+
+// BeginBackground starts the background layer: content drawn behind every
+// window, such as a game viewport or an animated backdrop. It is rendered
+// first whatever the z-order, never receives mouse input, so windows above
+// it keep their hover and clicks, and its controls are inert. The layer
+// covers the screen set with SetScreenSize, and its layout starts at the
+// top-left without padding. Call it once per frame, outside any window, and
+// finish with EndBackground.
+//
+// Drawing into the background rather than straight onto the target before
+// Render keeps it in the command stream, so it is recorded, replayed and
+// drawn by every renderer like the rest of the UI.
+func (u *UI) BeginBackground() {
+	cnt := u.GetContainer(backgroundName)
+	rect := u.screen
+	if rect.Empty() {
+		rect = unclippedRect
+	}
+	cnt.rect, cnt.body = rect, rect
+	cnt.open = true
+	cnt.opt = OptNoInteract | OptNoFrame | OptNoTitle | OptNoScroll | OptNoResize
+
+	u.PushID(backgroundName)
+	u.containerStack.Push(cnt)
+	u.beginRootContainer(cnt)
+	u.PushClip(rect)
+	u.pushLayout(rect, types.Vec2{})
+}
+
+// EndBackground finishes the layer started by BeginBackground.
+func (u *UI) EndBackground() {
+	u.PopLayout()
+	u.PopClip()
+	if cnt := u.GetCurrentContainer(); cnt != nil {
+		u.endRootContainer(cnt)
+	}
+	u.containerStack.Pop()
+	u.PopID()
+}
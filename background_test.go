@@ -0,0 +1,62 @@
+package microui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestBackground(t *testing.T) {
+	ui := New(Config{})
+	ui.SetScreenSize(640, 480)
+	green := color.RGBA{G: 255, A: 255}
+	var bgRect types.Rect
+	clicked := false
+	frame := func() {
+		ui.BeginFrame()
+		// Begun after the window, yet drawn behind it
+		if ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 200, H: 100}) {
+			ui.EndWindow()
+		}
+		ui.BeginBackground()
+		bgRect = ui.LayoutNext()
+		ui.DrawRect(types.Rect{X: 0, Y: 0, W: 640, H: 480}, green)
+		ui.LayoutSetNext(types.Rect{X: 300, Y: 300, W: 50, H: 20}, false)
+		if ui.Button("inert") {
+			clicked = true
+		}
+		ui.EndBackground()
+		ui.EndFrame()
+	}
+
+	frame()
+	if bgRect.X != 0 || bgRect.Y != 0 {
+		t.Errorf("first background rect = %v, want the top-left of the screen", bgRect)
+	}
+	r := &recordRenderer{}
+	ui.Render(r)
+	if len(r.rects) == 0 || r.rects[0] != green {
+		t.Errorf("first rect drawn = %v, want the background", r.rects)
+	}
+
+	// Neither the window nor the background button gets the mouse over the
+	// background
+	ui.MouseMove(310, 310)
+	frame()
+	ui.MouseDown(310, 310, MouseLeft)
+	frame()
+	ui.MouseUp(310, 310, MouseLeft)
+	frame()
+	if ui.hoverRoot != nil || clicked {
+		t.Errorf("hover root = %v, clicked = %v, want the background to ignore the mouse", ui.hoverRoot, clicked)
+	}
+
+	// Windows over the background still get it
+	ui.MouseMove(50, 50)
+	frame()
+	frame()
+	if ui.hoverRoot != ui.GetContainer("W") {
+		t.Errorf("hover root = %v, want the window over the background", ui.hoverRoot)
+	}
+}
@@ -0,0 +1,305 @@
+package microui
+
+import "unicode"
+
+// TextDirection is the base direction of a run of text.
+type TextDirection int
+
+const (
+	// DirAuto takes the direction from the first strong character: Hebrew
+	// or Arabic makes text right-to-left, a Latin letter left-to-right.
+	DirAuto TextDirection = iota
+	DirLTR
+	DirRTL
+)
+
+// SetTextDirection sets the base direction of labels, wrapped text and
+// text boxes (see Config.TextDirection). OptRTL and OptLTR override it
+// per control.
+func (u *UI) SetTextDirection(dir TextDirection) {
+	u.textDir = dir
+}
+
+// TextDirection returns the base text direction set with SetTextDirection.
+func (u *UI) TextDirection() TextDirection {
+	return u.textDir
+}
+
+// textDirection returns the direction for text drawn with opt: OptRTL or
+// OptLTR, else the UI's direction.
+func (u *UI) textDirection(opt int) TextDirection {
+	switch {
+	case opt&OptRTL != 0:
+		return DirRTL
+	case opt&OptLTR != 0:
+		return DirLTR
+	}
+	return u.textDir
+}
+
+// bidiClass is the part of a rune's bidirectional type the reordering uses.
+type bidiClass uint8
+
+const (
+	bidiN   bidiClass = iota // Neutral: spaces, punctuation, symbols
+	bidiL                    // Strong left-to-right
+	bidiR                    // Strong right-to-left: Hebrew, Arabic and their neighbours
+	bidiEN                   // European digit
+	bidiAN                   // Arabic-Indic digit
+	bidiNSM                  // Combining mark, takes the class before it
+)
+
+func bidiClassOf(r rune) bidiClass {
+	switch {
+	case r >= '0' && r <= '9':
+		return bidiEN
+	case r < 0x80:
+		if 'a' <= r|0x20 && r|0x20 <= 'z' {
+			return bidiL
+		}
+		return bidiN
+	case r >= 0x660 && r <= 0x669, r >= 0x6f0 && r <= 0x6f9:
+		return bidiAN
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return bidiNSM
+	case r >= 0x590 && r <= 0x8ff, r >= 0xfb1d && r <= 0xfdff, r >= 0xfe70 && r <= 0xfefe,
+		r >= 0x10800 && r <= 0x10fff, r >= 0x1e800 && r <= 0x1efff:
+		return bidiR
+	case unicode.IsLetter(r) || unicode.Is(unicode.Mc, r):
+		return bidiL
+	}
+	return bidiN
+}
+
+// hasRTL reports whether s has right-to-left characters, so text without
+// them can skip reordering.
+func hasRTL(s string) bool {
+	for _, r := range s {
+		if r >= 0x590 {
+			if c := bidiClassOf(r); c == bidiR || c == bidiAN {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// baseRTL resolves dir for runes: DirAuto goes by the first strong
+// character and falls back to left-to-right.
+func baseRTL(runes []rune, dir TextDirection) bool {
+	if dir != DirAuto {
+		return dir == DirRTL
+	}
+	for _, r := range runes {
+		switch bidiClassOf(r) {
+		case bidiL:
+			return false
+		case bidiR:
+			return true
+		}
+	}
+	return false
+}
+
+// bidiLine is one line of text laid out for display: its runes in visual
+// order, and where each logical rune went. It follows the Unicode
+// Bidirectional Algorithm (UAX #9) for a single paragraph without
+// explicit embeddings; shaping Arabic into joined forms is left to the
+// font.
+type bidiLine struct {
+	rtl    bool   // Base direction is right-to-left
+	visual []rune // Runes in display order, mirrored brackets swapped
+	pos    []int  // Visual index of each logical rune
+	odd    []bool // Logical rune is laid out right-to-left
+}
+
+// newBidiLine lays out runes with base direction dir.
+func newBidiLine(runes []rune, dir TextDirection) bidiLine {
+	n := len(runes)
+	b := bidiLine{rtl: baseRTL(runes, dir), visual: make([]rune, n), pos: make([]int, n), odd: make([]bool, n)}
+	base := 0
+	if b.rtl {
+		base = 1
+	}
+
+	// Resolve weak types: marks take the class before them, and European
+	// digits act as Arabic digits after right-to-left text, else as
+	// left-to-right letters
+	classes := make([]bidiClass, n)
+	prev, strong := bidiN, bidiL
+	if b.rtl {
+		strong = bidiR
+	}
+	for i, r := range runes {
+		c := bidiClassOf(r)
+		if c == bidiNSM {
+			c = prev
+		}
+		prev = c
+		switch c {
+		case bidiL, bidiR:
+			strong = c
+		case bidiEN:
+			if strong == bidiR {
+				c = bidiAN
+			} else {
+				c = bidiL
+			}
+		}
+		classes[i] = c
+	}
+
+	// Neutrals between text of one direction take it, numbers counting
+	// as right-to-left; other neutrals take the base direction
+	for i := 0; i < n; {
+		if classes[i] != bidiN {
+			i++
+			continue
+		}
+		j := i
+		for j < n && classes[j] == bidiN {
+			j++
+		}
+		before, after := base == 1, base == 1
+		if i > 0 {
+			before = classes[i-1] != bidiL
+		}
+		if j < n {
+			after = classes[j] != bidiL
+		}
+		c := bidiL
+		if before == after && before || before != after && base == 1 {
+			c = bidiR
+		}
+		for k := i; k < j; k++ {
+			classes[k] = c
+		}
+		i = j
+	}
+
+	// Embedding levels; trailing whitespace goes back to the base level
+	levels := make([]int, n)
+	maxLevel := base
+	for i, c := range classes {
+		switch c {
+		case bidiL:
+			levels[i] = base * 2
+		case bidiR:
+			levels[i] = 1
+		default: // Numbers read left-to-right inside right-to-left text
+			levels[i] = 2
+		}
+		maxLevel = max(maxLevel, levels[i])
+	}
+	for i := n - 1; i >= 0 && unicode.IsSpace(runes[i]); i-- {
+		levels[i] = base
+	}
+
+	// Reverse every run at or above each odd level, highest first
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	for level := maxLevel; level >= 1; level-- {
+		for i := 0; i < n; {
+			if levels[order[i]] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < n && levels[order[j]] >= level {
+				j++
+			}
+			for a, z := i, j-1; a < z; a, z = a+1, z-1 {
+				order[a], order[z] = order[z], order[a]
+			}
+			i = j
+		}
+	}
+	// Keep combining marks after their base character in reversed runs
+	for v := 0; v < n; v++ {
+		k := v
+		for k < n && levels[order[k]]%2 == 1 && bidiClassOf(runes[order[k]]) == bidiNSM {
+			k++
+		}
+		if k > v && k < n {
+			base := order[k]
+			copy(order[v+1:k+1], order[v:k])
+			order[v] = base
+			for a, z := v+1, k; a < z; a, z = a+1, z-1 {
+				order[a], order[z] = order[z], order[a]
+			}
+		}
+		v = k
+	}
+	for v, l := range order {
+		b.pos[l] = v
+		b.odd[l] = levels[l]%2 == 1
+		r := runes[l]
+		if b.odd[l] {
+			r = mirrorRune(r)
+		}
+		b.visual[v] = r
+	}
+	return b
+}
+
+// caret returns the x offset of the caret before logical rune i, given
+// offsets of the visual rune boundaries as from types.RuneOffsets: at the
+// trailing edge of the rune before it, or the leading edge of rune 0.
+func (b *bidiLine) caret(offsets []int, i int) int {
+	switch {
+	case len(b.pos) == 0:
+		return 0
+	case i > 0:
+		v := b.pos[i-1]
+		if b.odd[i-1] {
+			return offsets[v]
+		}
+		return offsets[v+1]
+	default:
+		v := b.pos[0]
+		if b.odd[0] {
+			return offsets[v+1]
+		}
+		return offsets[v]
+	}
+}
+
+// mirrorRune returns the mirror image of a bracket, for right-to-left text.
+func mirrorRune(r rune) rune {
+	switch r {
+	case '(':
+		return ')'
+	case ')':
+		return '('
+	case '[':
+		return ']'
+	case ']':
+		return '['
+	case '{':
+		return '}'
+	case '}':
+		return '{'
+	case '<':
+		return '>'
+	case '>':
+		return '<'
+	case '«':
+		return '»'
+	case '»':
+		return '«'
+	}
+	return r
+}
+
+// visualText returns text in display order for base direction dir, and
+// whether the line is right-to-left. Text without right-to-left
+// characters in a left-to-right line comes back unchanged.
+func visualText(text string, dir TextDirection) (string, bool) {
+	if dir != DirRTL && !hasRTL(text) {
+		return text, false
+	}
+	b := newBidiLine([]rune(text), dir)
+	return string(b.visual), b.rtl
+}
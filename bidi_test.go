@@ -0,0 +1,124 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// Hebrew letters alef, bet, gimel and shin, and the qamats vowel point.
+const (
+	alef, bet, gimel, shin = "\u05d0", "\u05d1", "\u05d2", "\u05e9"
+	qamats                 = "\u05b8"
+)
+
+func TestVisualText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		dir  TextDirection
+		want string
+		rtl  bool
+	}{
+		{"latin unchanged", "abc (1)", DirAuto, "abc (1)", false},
+		{"hebrew reversed", alef + bet + gimel, DirAuto, gimel + bet + alef, true},
+		{"hebrew in ltr", "ab " + alef + bet + " cd", DirAuto, "ab " + bet + alef + " cd", false},
+		{"latin in rtl", alef + bet + " cd", DirAuto, "cd " + bet + alef, true},
+		{"numbers stay ltr", alef + " 123", DirAuto, "123 " + alef, true},
+		{"brackets mirrored", alef + "(" + bet + ")", DirAuto, "(" + bet + ")" + alef, true},
+		{"marks follow base", shin + qamats + alef, DirAuto, alef + shin + qamats, true},
+		{"forced rtl", "ab cd", DirRTL, "ab cd", true},
+		{"forced ltr", alef + " ab", DirLTR, alef + " ab", false},
+	}
+	for _, tt := range tests {
+		got, rtl := visualText(tt.text, tt.dir)
+		if got != tt.want || rtl != tt.rtl {
+			t.Errorf("%s: visualText = %q, %v; want %q, %v", tt.name, got, rtl, tt.want, tt.rtl)
+		}
+	}
+}
+
+func TestDrawControlText_RTLAlignsRight(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	rect := types.Rect{X: 0, Y: 0, W: 100, H: 20}
+	ui.DrawControlText(alef+bet, rect, ColorText, 0)
+	ui.DrawControlText("ab", rect, ColorText, OptRTL)
+	ui.EndFrame()
+
+	var cmds []Command
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdText {
+			cmds = append(cmds, cmd)
+		}
+	})
+	want := rect.W - 16 - ui.style.Padding.X
+	if len(cmds) != 2 {
+		t.Fatalf("got %d text commands, want 2", len(cmds))
+	}
+	if cmds[0].Text != bet+alef || cmds[0].Pos.X != want {
+		t.Errorf("hebrew label = %q at x=%d, want %q at x=%d", cmds[0].Text, cmds[0].Pos.X, bet+alef, want)
+	}
+	if cmds[1].Pos.X != want {
+		t.Errorf("OptRTL label at x=%d, want %d", cmds[1].Pos.X, want)
+	}
+}
+
+func TestText_RTLParagraph(t *testing.T) {
+	cmds := textCommands(200, func(ui *UI) { ui.Text(alef + bet + " ok") })
+	if len(cmds) != 1 {
+		t.Fatalf("got %d text commands, want 1", len(cmds))
+	}
+	body := 200 - 2*DefaultStyle().Padding.X
+	if want := "ok " + bet + alef; cmds[0].Text != want {
+		t.Errorf("text = %q, want %q", cmds[0].Text, want)
+	}
+	if right := cmds[0].Pos.X + 5*8; right < body {
+		t.Errorf("line ends at x=%d, want it aligned to the right of the %dpx body", right, body)
+	}
+}
+
+func TestTextbox_RTLCursor(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte(alef + bet + gimel)
+	focusTextbox(ui, &buf)
+
+	// The end of right-to-left text is on the left, so Right moves back
+	ui.textboxCursor = len(buf)
+	pressKey(ui, KeyRight)
+	selectFrame(ui, &buf, "")
+	if want := len(alef + bet); ui.textboxCursor != want {
+		t.Errorf("cursor after right = %d, want %d", ui.textboxCursor, want)
+	}
+	pressKey(ui, KeyLeft)
+	selectFrame(ui, &buf, "")
+	if ui.textboxCursor != len(buf) {
+		t.Errorf("cursor after left = %d, want %d", ui.textboxCursor, len(buf))
+	}
+
+	// The start is at the right edge of the right-aligned text
+	layout := ui.newTextboxLayout(buf, 0, 180)
+	if start, end := layout.caret(buf, 0), layout.caret(buf, len(buf)); start <= end {
+		t.Errorf("caret at start x=%d, at end x=%d; want the start right of the end", start, end)
+	}
+	if spans := layout.spans(buf, 0, len(alef)); len(spans) != 1 || spans[0][0] != layout.caret(buf, len(alef)) {
+		t.Errorf("selection of the first letter = %v, want one span left of the start caret", spans)
+	}
+}
+
+func TestTextbox_RTLFocusKeepsAlignment(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte(alef + bet + gimel)
+	selectFrame(ui, &buf, "")
+	before, ok := drawnTexts(ui)[gimel+bet+alef] // In visual order
+	if !ok {
+		t.Fatal("textbox text not drawn")
+	}
+	focusTextbox(ui, &buf)
+	ui.textboxCursor = 0 // The start, at the right edge
+	selectFrame(ui, &buf, "")
+	after := drawnTexts(ui)[gimel+bet+alef]
+	if ui.textboxScrollX != 0 || after != before {
+		t.Errorf("focused text at %v scrolled %d, want it where it was at %v", after, ui.textboxScrollX, before)
+	}
+}
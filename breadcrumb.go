@@ -0,0 +1,116 @@
+package microui
+
+import (
+	"fmt"
+
+	"github.com/user/microui-go/types"
+)
+
+// breadcrumbSeparator is drawn between the parts of a breadcrumb.
+const breadcrumbSeparator = ">"
+
+// crumb is a part of a breadcrumb as drawn.
+type crumb struct {
+	index int    // Index in the parts Breadcrumb returns when it is clicked
+	text  string // Part, "..." for collapsed parts, or truncated to fit
+	w     int
+}
+
+// Breadcrumb adds a path such as a file browser's current folder, its
+// parts separated by ">", across the next layout cell. Every part but the
+// last, the current location, is a button; returns the index of the part
+// clicked or activated with the keyboard this frame, or -1.
+//
+// When the path is too wide, the parts after the first collapse into
+// "...", which returns the deepest hidden part, and the last part is
+// truncated if it still doesn't fit. Its IDs are scoped by "breadcrumb";
+// see BreadcrumbID.
+func (u *UI) Breadcrumb(parts []string) int {
+	return u.BreadcrumbID("breadcrumb", parts)
+}
+
+// BreadcrumbID is Breadcrumb with its IDs scoped by id (within PushID),
+// for more than one breadcrumb in a container.
+func (u *UI) BreadcrumbID(id string, parts []string) int {
+	rect := u.LayoutNext()
+	if len(parts) == 0 {
+		return -1
+	}
+	u.PushID(id)
+	defer u.PopID()
+
+	sepW := u.style.Font.Width(breadcrumbSeparator) + u.style.Padding.X
+	clicked := -1
+	x := rect.X
+	for i, c := range u.fitCrumbs(parts, rect.W) {
+		if i > 0 {
+			u.DrawControlText(breadcrumbSeparator, types.Rect{X: x, Y: rect.Y, W: sepW, H: rect.H}, ColorText, OptAlignCenter)
+			x += sepW
+		}
+		r := types.Rect{X: x, Y: rect.Y, W: c.w, H: rect.H}
+		x += c.w
+		if c.index == len(parts)-1 {
+			u.DrawControlText(c.text, r, ColorText, 0)
+			u.accessStatic(RoleLabel, parts[c.index], r)
+			continue
+		}
+
+		cid := u.getID(fmt.Sprintf("!crumb:%d", c.index))
+		u.UpdateControl(cid, r)
+		if u.itemClicked(u.activated(cid)) {
+			clicked = c.index
+		}
+		// Parts look like text until hovered or focused
+		if u.input.Hover == cid || u.input.Focus == cid {
+			u.DrawControlFrame(cid, r, ColorButton, 0)
+		}
+		u.DrawControlText(c.text, r, ColorText, 0)
+		u.accessControl(cid, r, RoleButton, parts[c.index], "", 0)
+	}
+	return clicked
+}
+
+// fitCrumbs returns the parts of a breadcrumb to draw in width pixels.
+func (u *UI) fitCrumbs(parts []string, width int) []crumb {
+	font, pad := u.style.Font, u.style.Padding.X
+	crumbW := func(text string) int { return font.Width(text) + pad*2 }
+	sepW := font.Width(breadcrumbSeparator) + pad
+
+	crumbs := make([]crumb, len(parts))
+	used := -sepW
+	for i, part := range parts {
+		crumbs[i] = crumb{index: i, text: part, w: crumbW(part)}
+		used += sepW + crumbs[i].w
+	}
+	if used <= width {
+		return crumbs
+	}
+
+	last := len(crumbs) - 1
+	out := crumbs
+	if last > 1 {
+		// The first part, "...", then as many of the last parts as fit
+		dots := crumbW("...")
+		used = crumbs[0].w + sepW + dots + sepW + crumbs[last].w
+		start := last
+		for start > 1 && used+sepW+crumbs[start-1].w <= width {
+			start--
+			used += sepW + crumbs[start].w
+		}
+		out = nil
+		if used <= width {
+			out = append(out, crumbs[0])
+		} else {
+			used -= crumbs[0].w + sepW
+		}
+		out = append(out, crumb{index: start - 1, text: "...", w: dots})
+		out = append(out, crumbs[start:]...)
+	}
+
+	if used > width {
+		c := &out[len(out)-1]
+		c.w = max(0, c.w-(used-width))
+		c.text = u.TruncateText(c.text, c.w-pad*2)
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// breadcrumbFrame draws a breadcrumb w pixels wide and returns the part
+// clicked this frame, or -1.
+func breadcrumbFrame(ui *UI, parts []string, w int) int {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{w}, 0)
+	clicked := ui.Breadcrumb(parts)
+	ui.EndWindow()
+	ui.EndFrame()
+	return clicked
+}
+
+// drawnTexts returns the text commands of the last frame.
+func drawnTexts(ui *UI) map[string]types.Vec2 {
+	texts := map[string]types.Vec2{}
+	for _, cmd := range ui.RecordFrame().Commands {
+		if cmd.Kind == CmdText {
+			texts[cmd.Text] = cmd.Pos
+		}
+	}
+	return texts
+}
+
+func TestBreadcrumb_Click(t *testing.T) {
+	ui := New(Config{})
+	parts := []string{"home", "user", "docs"}
+	breadcrumbFrame(ui, parts, -1)
+	pos := drawnTexts(ui)["user"]
+
+	ui.MouseMove(pos.X+2, pos.Y+2)
+	breadcrumbFrame(ui, parts, -1)
+	ui.MouseDown(pos.X+2, pos.Y+2, MouseLeft)
+	if got := breadcrumbFrame(ui, parts, -1); got != 1 {
+		t.Errorf("clicked part = %d, want 1", got)
+	}
+	ui.MouseUp(pos.X+2, pos.Y+2, MouseLeft)
+	if got := breadcrumbFrame(ui, parts, -1); got != -1 {
+		t.Errorf("clicked part after release = %d, want -1", got)
+	}
+
+	// The last part is the current location, not a button
+	pos = drawnTexts(ui)["docs"]
+	ui.MouseMove(pos.X+2, pos.Y+2)
+	ui.MouseDown(pos.X+2, pos.Y+2, MouseLeft)
+	if got := breadcrumbFrame(ui, parts, -1); got != -1 {
+		t.Errorf("clicking the last part returned %d, want -1", got)
+	}
+}
+
+func TestBreadcrumb_Keyboard(t *testing.T) {
+	ui := New(Config{})
+	parts := []string{"home", "user", "docs"}
+	breadcrumbFrame(ui, parts, -1)
+
+	// Tab visits home and user, then wraps past the current part
+	pressKey(ui, KeyTab)
+	breadcrumbFrame(ui, parts, -1)
+	pressKey(ui, KeyTab)
+	breadcrumbFrame(ui, parts, -1)
+	pressKey(ui, KeyEnter)
+	if got := breadcrumbFrame(ui, parts, -1); got != 1 {
+		t.Errorf("Enter on the second part returned %d, want 1", got)
+	}
+	pressKey(ui, KeyTab)
+	breadcrumbFrame(ui, parts, -1)
+	pressKey(ui, KeySpace)
+	if got := breadcrumbFrame(ui, parts, -1); got != 0 {
+		t.Errorf("Space after wrapping returned %d, want 0", got)
+	}
+}
+
+func TestBreadcrumb_Collapse(t *testing.T) {
+	ui := New(Config{})
+	parts := []string{"root", "alpha", "beta", "gamma", "delta"}
+	breadcrumbFrame(ui, parts, 230)
+
+	texts := drawnTexts(ui)
+	for _, want := range []string{"root", "...", "gamma", "delta"} {
+		if _, ok := texts[want]; !ok {
+			t.Errorf("collapsed breadcrumb doesn't show %q: %v", want, texts)
+		}
+	}
+	for _, hidden := range []string{"alpha", "beta"} {
+		if _, ok := texts[hidden]; ok {
+			t.Errorf("collapsed breadcrumb shows %q", hidden)
+		}
+	}
+
+	// "..." goes to the deepest hidden part
+	pos := texts["..."]
+	ui.MouseMove(pos.X+2, pos.Y+2)
+	ui.MouseDown(pos.X+2, pos.Y+2, MouseLeft)
+	if got := breadcrumbFrame(ui, parts, 230); got != 2 {
+		t.Errorf("clicking ... returned %d, want 2", got)
+	}
+}
+
+func TestBreadcrumb_TruncatesLastPart(t *testing.T) {
+	ui := New(Config{})
+	crumbs := ui.fitCrumbs([]string{"a", "b", "a very long folder name indeed"}, 120)
+	used := 0
+	for i, c := range crumbs {
+		if i > 0 {
+			used += ui.style.Font.Width(breadcrumbSeparator) + ui.style.Padding.X
+		}
+		used += c.w
+	}
+	if used > 120 {
+		t.Errorf("crumbs are %d wide, want at most 120", used)
+	}
+	if last := crumbs[len(crumbs)-1]; last.index != 2 || last.text == "" || last.text[len(last.text)-3:] != "..." {
+		t.Errorf("last crumb = %+v, want part 2 truncated", last)
+	}
+}
@@ -0,0 +1,135 @@
+package microui
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/user/microui-go/types"
+)
+
+// Zoom limits and wheel step for canvases.
+const (
+	CanvasMinZoom  = 0.125
+	CanvasMaxZoom  = 64.0
+	canvasZoomStep = 1.25
+)
+
+// Canvas is a pannable, zoomable drawing surface returned by BeginCanvas.
+// Canvas coordinates map to the screen as
+// screen = Rect.X + (canvas - PanX) * Zoom, so (PanX, PanY) is the canvas
+// point shown at the top-left corner. The view persists across frames.
+type Canvas struct {
+	Rect    types.Rect // Screen area; drawing is clipped to it
+	PanX    float64    // Canvas X at the left edge
+	PanY    float64    // Canvas Y at the top edge
+	Zoom    float64    // Screen pixels per canvas unit
+	Hovered bool       // Mouse is over the canvas and it receives input
+	Active  bool       // Left button pressed on the canvas and still held
+	Pressed bool       // Left button pressed on the canvas this frame
+
+	ui *UI
+}
+
+// BeginCanvas starts a canvas filling the next layout cell and returns it.
+// The mouse is captured while the left button is held after pressing on
+// the canvas, even if it leaves the rect. The mouse wheel zooms around the
+// cursor and middle-drag pans; OptNoScroll disables both. OptNoFrame skips
+// the background. Finish with EndCanvas.
+func (u *UI) BeginCanvas(name string, opt int) *Canvas {
+	id := u.GetID(name)
+	c, ok := u.canvases[id]
+	if !ok {
+		c = &Canvas{Zoom: 1, ui: u}
+		u.canvases[id] = c
+	}
+	c.Rect = u.LayoutNext()
+	u.UpdateControlOpt(id, c.Rect, opt|OptNoNav)
+
+	c.Hovered = u.MouseOver(c.Rect)
+	c.Pressed = u.input.MousePressed[int(MouseLeft)] && u.input.Focus == id
+	c.Active = u.input.Focus == id && u.input.MouseDown[int(MouseLeft)]
+
+	if opt&OptNoScroll == 0 {
+		u.updateCanvasView(c)
+	}
+
+	if opt&OptNoFrame == 0 {
+		u.DrawFrame(c.Rect, ColorBase)
+	}
+	u.PushClip(c.Rect)
+	return c
+}
+
+// EndCanvas finishes the canvas started by BeginCanvas.
+func (u *UI) EndCanvas() {
+	u.PopClip()
+}
+
+// updateCanvasView applies wheel zoom and middle-drag panning, and
+// two-finger touch panning and pinch zoom.
+func (u *UI) updateCanvasView(c *Canvas) {
+	if c.Hovered && u.input.MouseDown[int(MouseMiddle)] {
+		c.PanX -= float64(u.input.MouseDelta.X) / c.Zoom
+		c.PanY -= float64(u.input.MouseDelta.Y) / c.Zoom
+	}
+
+	if !c.Hovered {
+		return
+	}
+	var zoom float64
+	switch {
+	case u.input.PinchScale != 0:
+		// The two-finger drag pans rather than zooming like the wheel
+		c.PanX += float64(u.input.TouchPan.X) / c.Zoom
+		c.PanY += float64(u.input.TouchPan.Y) / c.Zoom
+		zoom = c.Zoom * u.input.PinchScale
+	case u.input.ScrollDelta.Y > 0:
+		zoom = c.Zoom / canvasZoomStep
+	case u.input.ScrollDelta.Y < 0:
+		zoom = c.Zoom * canvasZoomStep
+	default:
+		return
+	}
+	zoom = math.Max(CanvasMinZoom, math.Min(zoom, CanvasMaxZoom))
+
+	// Keep the canvas point under the cursor fixed
+	mx, my := c.ScreenToCanvas(u.input.MousePos)
+	c.Zoom = zoom
+	c.PanX = mx - float64(u.input.MousePos.X-c.Rect.X)/zoom
+	c.PanY = my - float64(u.input.MousePos.Y-c.Rect.Y)/zoom
+
+	// The wheel was used for zooming; don't scroll the window too
+	u.input.ScrollDelta = types.Vec2{}
+}
+
+// ScreenToCanvas converts a screen position to canvas coordinates.
+func (c *Canvas) ScreenToCanvas(p types.Vec2) (x, y float64) {
+	return c.PanX + float64(p.X-c.Rect.X)/c.Zoom, c.PanY + float64(p.Y-c.Rect.Y)/c.Zoom
+}
+
+// CanvasToScreen converts canvas coordinates to a screen position.
+func (c *Canvas) CanvasToScreen(x, y float64) types.Vec2 {
+	return types.Vec2{
+		X: c.Rect.X + int(math.Floor((x-c.PanX)*c.Zoom)),
+		Y: c.Rect.Y + int(math.Floor((y-c.PanY)*c.Zoom)),
+	}
+}
+
+// Mouse returns the mouse position in canvas coordinates.
+func (c *Canvas) Mouse() (x, y float64) {
+	return c.ScreenToCanvas(c.ui.input.MousePos)
+}
+
+// SetView sets the pan and zoom. Zoom is clamped to the canvas limits.
+func (c *Canvas) SetView(panX, panY, zoom float64) {
+	c.PanX, c.PanY = panX, panY
+	c.Zoom = math.Max(CanvasMinZoom, math.Min(zoom, CanvasMaxZoom))
+}
+
+// DrawRect fills a rect given in canvas coordinates, e.g. one pixel of an
+// image being edited.
+func (c *Canvas) DrawRect(r types.Rect, col color.Color) {
+	p0 := c.CanvasToScreen(float64(r.X), float64(r.Y))
+	p1 := c.CanvasToScreen(float64(r.X+r.W), float64(r.Y+r.H))
+	c.ui.DrawRect(types.Rect{X: p0.X, Y: p0.Y, W: p1.X - p0.X, H: p1.Y - p0.Y}, col)
+}
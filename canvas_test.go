@@ -0,0 +1,133 @@
+package microui
+
+import (
+	"math"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// canvasFrame draws a 200x200 canvas at (5,29) inside a window.
+func canvasFrame(ui *UI) *Canvas {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 200)
+	c := ui.BeginCanvas("canvas", 0)
+	ui.EndCanvas()
+	ui.EndWindow()
+	ui.EndFrame()
+	return c
+}
+
+func TestCanvas_CoordinateConversion(t *testing.T) {
+	ui := New(Config{})
+	c := canvasFrame(ui)
+	if c.Rect != (types.Rect{X: 5, Y: 29, W: 200, H: 200}) {
+		t.Fatalf("canvas rect = %v", c.Rect)
+	}
+	c.SetView(10, 20, 4)
+
+	x, y := c.ScreenToCanvas(types.Vec2{X: 45, Y: 69})
+	if x != 20 || y != 30 {
+		t.Errorf("ScreenToCanvas = (%v, %v), want (20, 30)", x, y)
+	}
+	if p := c.CanvasToScreen(20, 30); p != (types.Vec2{X: 45, Y: 69}) {
+		t.Errorf("CanvasToScreen = %v, want (45, 69)", p)
+	}
+}
+
+func TestCanvas_WheelZoomKeepsCursorPoint(t *testing.T) {
+	ui := New(Config{})
+	canvasFrame(ui)
+	ui.MouseMove(105, 129)
+	c := canvasFrame(ui)
+	bx, by := c.Mouse()
+
+	ui.Scroll(0, -30)
+	c = canvasFrame(ui)
+	if c.Zoom != canvasZoomStep {
+		t.Fatalf("zoom = %v, want %v after wheel up", c.Zoom, canvasZoomStep)
+	}
+	ax, ay := c.Mouse()
+	if math.Abs(ax-bx) > 1e-9 || math.Abs(ay-by) > 1e-9 {
+		t.Errorf("point under cursor moved: (%v, %v) -> (%v, %v)", bx, by, ax, ay)
+	}
+	if ui.GetContainer("Test").scroll.Y != 0 {
+		t.Error("wheel used for zoom should not scroll the window")
+	}
+
+	for i := 0; i < 100; i++ {
+		ui.Scroll(0, 30)
+		c = canvasFrame(ui)
+	}
+	if c.Zoom != CanvasMinZoom {
+		t.Errorf("zoom = %v, want clamped to %v", c.Zoom, CanvasMinZoom)
+	}
+}
+
+func TestCanvas_MiddleDragPans(t *testing.T) {
+	ui := New(Config{})
+	canvasFrame(ui)
+	ui.MouseMove(100, 100)
+	canvasFrame(ui)
+	ui.MouseDown(100, 100, MouseMiddle)
+	canvasFrame(ui)
+	ui.MouseMove(80, 90)
+	c := canvasFrame(ui)
+	if c.PanX != 20 || c.PanY != 10 {
+		t.Errorf("pan = (%v, %v), want (20, 10)", c.PanX, c.PanY)
+	}
+}
+
+func TestCanvas_CapturesLeftDrag(t *testing.T) {
+	ui := New(Config{})
+	canvasFrame(ui)
+	ui.MouseMove(50, 50)
+	canvasFrame(ui)
+	ui.MouseDown(50, 50, MouseLeft)
+	if c := canvasFrame(ui); !c.Pressed || !c.Active {
+		t.Fatalf("press on canvas: Pressed=%v Active=%v", c.Pressed, c.Active)
+	}
+
+	// Dragging outside the canvas keeps the capture
+	ui.MouseMove(300, 250)
+	c := canvasFrame(ui)
+	if !c.Active || c.Pressed {
+		t.Errorf("drag outside: Active=%v Pressed=%v, want captured", c.Active, c.Pressed)
+	}
+	if x, _ := c.Mouse(); x != 295 {
+		t.Errorf("captured mouse x = %v, want 295", x)
+	}
+
+	ui.MouseUp(300, 250, MouseLeft)
+	canvasFrame(ui)
+	if c := canvasFrame(ui); c.Active {
+		t.Error("capture should end on release")
+	}
+}
+
+func TestCanvas_BlockedByWindowAbove(t *testing.T) {
+	ui := New(Config{})
+	frame := func() *Canvas {
+		ui.BeginFrame()
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.LayoutRow(1, []int{200}, 200)
+		c := ui.BeginCanvas("canvas", 0)
+		ui.EndCanvas()
+		ui.EndWindow()
+		ui.BeginWindow("Above", types.Rect{X: 40, Y: 40, W: 100, H: 100})
+		ui.EndWindow()
+		ui.EndFrame()
+		return c
+	}
+	frame()
+	ui.MouseMove(80, 80)
+	frame()
+	if c := frame(); c.Hovered {
+		t.Error("canvas should not be hovered beneath another window")
+	}
+	ui.Scroll(0, -30)
+	if c := frame(); c.Zoom != 1 {
+		t.Error("wheel over a covering window should not zoom the canvas")
+	}
+}
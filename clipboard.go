@@ -0,0 +1,47 @@
+package microui
+
+import "sync"
+
+// Clipboard provides text clipboard access for text controls.
+// Renderers ship implementations backed by their platform; set one via
+// Config.Clipboard or UI.SetClipboard.
+type Clipboard interface {
+	Get() string
+	Set(text string)
+}
+
+// MemoryClipboard is a process-local clipboard.
+// It is the default when no Clipboard is configured, so copy/paste works
+// between controls of the same UI even without platform support.
+type MemoryClipboard struct {
+	mu   sync.Mutex
+	text string
+}
+
+// Get returns the stored text.
+func (c *MemoryClipboard) Get() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.text
+}
+
+// Set stores text.
+func (c *MemoryClipboard) Set(text string) {
+	c.mu.Lock()
+	c.text = text
+	c.mu.Unlock()
+}
+
+// SetClipboard replaces the clipboard provider. Passing nil restores a
+// process-local MemoryClipboard.
+func (u *UI) SetClipboard(cb Clipboard) {
+	if cb == nil {
+		cb = &MemoryClipboard{}
+	}
+	u.clipboard = cb
+}
+
+// Clipboard returns the current clipboard provider.
+func (u *UI) Clipboard() Clipboard {
+	return u.clipboard
+}
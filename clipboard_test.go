@@ -0,0 +1,100 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestClipboard_DefaultIsMemory(t *testing.T) {
+	ui := New(Config{})
+	if _, ok := ui.Clipboard().(*MemoryClipboard); !ok {
+		t.Fatalf("default clipboard = %T, want *MemoryClipboard", ui.Clipboard())
+	}
+}
+
+func TestClipboard_CopyPaste(t *testing.T) {
+	cb := &MemoryClipboard{}
+	ui := New(Config{Clipboard: cb})
+	buf := []byte("hello")
+	focusTextbox(ui, &buf)
+
+	// Select "lo" and copy it
+	ui.KeyDown(KeyShift)
+	pressKey(ui, KeyLeft)
+	selectFrame(ui, &buf, "")
+	pressKey(ui, KeyLeft)
+	selectFrame(ui, &buf, "")
+	ui.KeyUp(KeyShift)
+
+	ui.KeyDown(KeyCtrl)
+	pressKey(ui, KeyC)
+	selectFrame(ui, &buf, "")
+	if got := cb.Get(); got != "lo" {
+		t.Fatalf("clipboard = %q, want %q", got, "lo")
+	}
+
+	// Paste replaces the selection
+	pressKey(ui, KeyV)
+	selectFrame(ui, &buf, "")
+	if string(buf) != "hello" {
+		t.Errorf("buf after paste over selection = %q, want %q", buf, "hello")
+	}
+
+	// Paste again at the cursor
+	pressKey(ui, KeyV)
+	res := selectFrame(ui, &buf, "")
+	ui.KeyUp(KeyCtrl)
+	if string(buf) != "hellolo" {
+		t.Errorf("buf = %q, want %q", buf, "hellolo")
+	}
+	if res&ResChange == 0 {
+		t.Error("paste should report ResChange")
+	}
+}
+
+func TestClipboard_Cut(t *testing.T) {
+	cb := &MemoryClipboard{}
+	ui := New(Config{Clipboard: cb})
+	buf := []byte("hello")
+	focusTextbox(ui, &buf)
+
+	ui.KeyDown(KeyShift)
+	pressKey(ui, KeyHome)
+	selectFrame(ui, &buf, "")
+	ui.KeyUp(KeyShift)
+
+	ui.KeyDown(KeyCtrl)
+	pressKey(ui, KeyX)
+	selectFrame(ui, &buf, "")
+	ui.KeyUp(KeyCtrl)
+
+	if string(buf) != "" {
+		t.Errorf("buf = %q, want empty after cut", buf)
+	}
+	if cb.Get() != "hello" {
+		t.Errorf("clipboard = %q, want %q", cb.Get(), "hello")
+	}
+}
+
+func TestClipboard_PasteRespectsMaxLen(t *testing.T) {
+	cb := &MemoryClipboard{}
+	cb.Set("0123456789")
+	ui := New(Config{Clipboard: cb})
+	buf := []byte("ab")
+	focusTextbox(ui, &buf)
+
+	ui.KeyDown(KeyCtrl)
+	pressKey(ui, KeyV)
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 30)
+	ui.Textbox(&buf, 6)
+	ui.EndWindow()
+	ui.EndFrame()
+	ui.KeyUp(KeyCtrl)
+
+	if string(buf) != "ab012" {
+		t.Errorf("buf = %q, want %q (maxLen 6 leaves room for 5 bytes)", buf, "ab012")
+	}
+}
@@ -0,0 +1,54 @@
+package microui
+
+// listClipFrame tracks the list being clipped this frame.
+type listClipFrame struct {
+	top       int // Layout nextRow of the first item
+	step      int // Item height plus spacing
+	count     int
+	rowHeight int // Row height to restore in EndListClipper
+}
+
+// ListClipper lays out a list of count items, each one layout row of
+// itemHeight, and returns the range [start, end) that intersects the clip
+// rect. Draw only those items, then call EndListClipper; the layout still
+// advances past the whole list so scrolling and content size are correct.
+//
+//	start, end := ui.ListClipper(len(rows), 20)
+//	for i := start; i < end; i++ {
+//		ui.Label(rows[i])
+//	}
+//	ui.EndListClipper()
+func (u *UI) ListClipper(count, itemHeight int) (start, end int) {
+	layout := u.getLayout()
+	if itemHeight <= 0 {
+		itemHeight = u.style.Size.Y + u.style.Padding.Y*2
+	}
+	step := itemHeight + u.style.Spacing
+	top := layout.nextRow
+	u.listClip = listClipFrame{top: top, step: step, count: count, rowHeight: layout.size.Y}
+
+	clip := u.GetClipRect()
+	listY := layout.body.Y + top
+	start = max(0, (clip.Y-listY)/step)
+	end = min(count, (clip.Y+clip.H-listY+step-1)/step)
+	if end < start {
+		end = start
+	}
+
+	// Reserve the whole list so content size includes the skipped items
+	if count > 0 {
+		layout.max.Y = max(layout.max.Y, listY+count*step-u.style.Spacing)
+	}
+
+	layout.nextRow = top + start*step
+	u.LayoutRow(layout.items, nil, itemHeight)
+	return start, end
+}
+
+// EndListClipper moves the layout below the list started by ListClipper.
+func (u *UI) EndListClipper() {
+	layout := u.getLayout()
+	lc := u.listClip
+	layout.nextRow = max(layout.nextRow, lc.top+lc.count*lc.step)
+	u.LayoutRow(layout.items, nil, lc.rowHeight)
+}
@@ -0,0 +1,73 @@
+package microui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// clipperFrame lists count rows in a 400x300 window and returns the
+// visible range plus the rect of a button placed after the list.
+func clipperFrame(ui *UI, count int) (start, end int, after types.Rect) {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	start, end = ui.ListClipper(count, 20)
+	for i := start; i < end; i++ {
+		ui.Label(fmt.Sprintf("row %d", i))
+	}
+	ui.EndListClipper()
+	ui.Button("After")
+	after = ui.lastRect
+	ui.EndWindow()
+	ui.EndFrame()
+	return start, end, after
+}
+
+func TestListClipper_VisibleRange(t *testing.T) {
+	ui := New(Config{})
+	start, end, after := clipperFrame(ui, 10000)
+
+	// Body is 29..300, rows are 24px apart starting at y=29
+	if start != 0 || end != 12 {
+		t.Errorf("range = [%d, %d), want [0, 12)", start, end)
+	}
+	if want := 29 + 10000*24; after.Y != want {
+		t.Errorf("control after list at Y=%d, want %d", after.Y, want)
+	}
+	if h := ui.GetContainer("Test").contentSize.Y; h < 10000*24 {
+		t.Errorf("content height %d should include every row", h)
+	}
+}
+
+func TestListClipper_Scrolled(t *testing.T) {
+	ui := New(Config{})
+	clipperFrame(ui, 10000)
+	ui.GetContainer("Test").scroll.Y = 2400 // 100 rows; row 99 peeks in under the padding
+
+	start, end, _ := clipperFrame(ui, 10000)
+	if start != 99 || end != 112 {
+		t.Errorf("range = [%d, %d), want [99, 112)", start, end)
+	}
+
+	texts := 0
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdText && len(cmd.Text) > 4 && cmd.Text[:4] == "row " {
+			texts++
+		}
+	})
+	if texts == 0 || texts > end-start {
+		t.Errorf("%d row labels emitted, want at most %d", texts, end-start)
+	}
+}
+
+func TestListClipper_Empty(t *testing.T) {
+	ui := New(Config{})
+	start, end, after := clipperFrame(ui, 0)
+	if start != 0 || end != 0 {
+		t.Errorf("range = [%d, %d), want empty", start, end)
+	}
+	if after.Y != 29 {
+		t.Errorf("control after empty list at Y=%d, want 29", after.Y)
+	}
+}
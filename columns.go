@@ -0,0 +1,218 @@
+package microui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/user/microui-go/types"
+)
+
+// columnsState is what a container keeps of a set of Columns between
+// frames.
+type columnsState struct {
+	offsets []float64 // Where each column but the last ends, as a fraction of the width
+	height  int       // Height the columns took last frame, for the separators
+}
+
+// columnSet is a set of Columns being laid out.
+type columnSet struct {
+	state   *columnsState
+	name    string     // ID string of the set, for its separators
+	n       int        // Number of columns
+	index   int        // Column being laid out
+	area    types.Rect // Width the columns share, from their top down
+	gap     int        // Space between columns, where the separators are
+	rowY    int        // Top of the current row of columns
+	bottom  int        // Bottom of the tallest column so far
+	borders bool
+}
+
+// Columns splits the rest of the current window or panel into n columns
+// side by side, for master-detail layouts without nested windows. Each
+// column has a layout of its own, starting with the default row; move on
+// to the next with NextColumn. Columns(1, false) ends the columns, and the
+// layout carries on below the tallest; so does calling Columns again or
+// ending the window or panel.
+//
+//	ui.Columns(2, true)
+//	ui.LayoutRow(1, []int{-1}, 0)
+//	for i, name := range names {
+//		if ui.Selectable(name, selected == i) {
+//			selected = i
+//		}
+//	}
+//	ui.NextColumn()
+//	ui.LayoutRow(1, []int{-1}, 0)
+//	ui.Text(details[selected])
+//	ui.Columns(1, false)
+//
+// The columns start equally wide. The separators between them can be
+// dragged to resize them, and the widths are kept in the container, as a
+// share of its width, by the number of columns, and saved by SaveLayout.
+// With borders the separators are drawn as lines; otherwise they show
+// only under the mouse. Columns count towards the content size, so the
+// container scrolls to show the tallest.
+func (u *UI) Columns(n int, borders bool) {
+	u.endColumns()
+	cnt := u.GetCurrentContainer()
+	if n < 2 || cnt == nil {
+		return
+	}
+	layout := u.getLayout()
+	name := fmt.Sprintf("!columns%d", n)
+	id := u.GetID(name)
+	st := cnt.columns[id]
+	if st == nil || len(st.offsets) != n-1 {
+		st = &columnsState{offsets: make([]float64, n-1)}
+		for i := range st.offsets {
+			st.offsets[i] = float64(i+1) / float64(n)
+		}
+		if cnt.columns == nil {
+			cnt.columns = make(map[ID]*columnsState)
+		}
+		cnt.columns[id] = st
+	}
+
+	top := layout.body.Y + layout.nextRow
+	set := &columnSet{
+		state: st,
+		name:  name,
+		n:     n,
+		area: types.Rect{
+			X: layout.body.X + layout.indent,
+			Y: top,
+			W: max(0, layout.body.W-layout.indent),
+			H: max(0, layout.body.H-layout.nextRow),
+		},
+		gap:     max(u.style.Spacing, 1),
+		rowY:    top,
+		bottom:  top,
+		borders: borders,
+	}
+	u.columnSeparators(set)
+	u.pushColumn(set)
+}
+
+// NextColumn moves on to the next of the Columns being laid out. After
+// the last it starts a new row of columns below the tallest, so a grid of
+// cells lines up. It does nothing outside Columns.
+func (u *UI) NextColumn() {
+	if u.layoutStack.Len() == 0 || u.getLayout().columns == nil {
+		return
+	}
+	set := u.popColumn()
+	set.index++
+	if set.index == set.n {
+		set.index = 0
+		set.rowY = set.bottom + u.style.Spacing
+	}
+	u.pushColumn(set)
+}
+
+// ColumnIndex returns the index of the column being laid out, or 0
+// outside Columns.
+func (u *UI) ColumnIndex() int {
+	if u.layoutStack.Len() == 0 || u.getLayout().columns == nil {
+		return 0
+	}
+	return u.getLayout().columns.index
+}
+
+// endColumns ends the set of Columns being laid out, if any, and carries
+// on the layout around it below its tallest column.
+func (u *UI) endColumns() {
+	if u.layoutStack.Len() == 0 || u.getLayout().columns == nil {
+		return
+	}
+	set := u.popColumn()
+	set.state.height = set.bottom - set.area.Y
+
+	// The columns fill the width, and follow it when it changes
+	layout := u.getLayout()
+	layout.max.X = max(layout.max.X, set.area.X+set.area.W)
+	layout.max.Y = max(layout.max.Y, set.bottom)
+	layout.fillW = true
+	layout.nextRow = set.bottom - layout.body.Y + u.style.Spacing
+	u.LayoutRow(layout.items, nil, layout.size.Y)
+}
+
+// pushColumn pushes the layout of the set's current column.
+func (u *UI) pushColumn(set *columnSet) {
+	left, right := set.edge(set.index), set.edge(set.index+1)-set.gap
+	u.pushLayout(types.Rect{
+		X: left,
+		Y: set.rowY,
+		W: max(0, right-left),
+		H: max(0, set.area.Y+set.area.H-set.rowY),
+	}, types.Vec2{})
+	u.getLayout().columns = set
+}
+
+// popColumn pops the layout of the set's current column, passing how far
+// its content reached on to the set and the layout around it.
+func (u *UI) popColumn() *columnSet {
+	column := u.getLayout()
+	set := column.columns
+	u.PopLayout()
+	layout := u.getLayout()
+	layout.max.X = max(layout.max.X, column.max.X)
+	layout.max.Y = max(layout.max.Y, column.max.Y)
+	set.bottom = max(set.bottom, column.max.Y)
+	return set
+}
+
+// edge returns where column i of the set starts. Edge n is one gap past
+// the right of the last column, so every column ends a gap before the
+// next edge.
+func (s *columnSet) edge(i int) int {
+	switch i {
+	case 0:
+		return s.area.X
+	case s.n:
+		return s.area.X + s.area.W + s.gap
+	}
+	return s.area.X + int(math.Round(float64(s.area.W)*s.state.offsets[i-1]))
+}
+
+// clampColumnOffsets limits loaded column offsets to 0..1, each at least
+// the one before it.
+func clampColumnOffsets(offsets []float64) []float64 {
+	prev := 0.0
+	for i, off := range offsets {
+		if math.IsNaN(off) {
+			off = prev
+		}
+		offsets[i] = max(prev, min(off, 1))
+		prev = offsets[i]
+	}
+	return offsets
+}
+
+// columnSeparators handles dragging the separators between the set's
+// columns and draws them, as tall as the columns were last frame.
+func (u *UI) columnSeparators(set *columnSet) {
+	minW := u.style.Padding.X*2 + 1
+	for i := range set.n - 1 {
+		id := u.GetID(fmt.Sprintf("%s/%d", set.name, i))
+		sep := types.Rect{X: set.edge(i+1) - set.gap, Y: set.area.Y, W: set.gap, H: set.state.height}
+		u.UpdateControlOpt(id, sep, OptNoNav)
+		if u.input.Focus == id && u.input.MouseDown[int(MouseLeft)] && set.area.W > 0 {
+			// Keep both columns beside the separator at least minW wide
+			lo := set.edge(i) + minW + set.gap
+			hi := set.edge(i+2) - set.gap - minW
+			if lo <= hi {
+				e := max(lo, min(u.input.MousePos.X+(set.gap+1)/2, hi))
+				set.state.offsets[i] = float64(e-set.area.X) / float64(set.area.W)
+				sep.X = e - set.gap
+			}
+		}
+
+		line := types.Rect{X: sep.X + (sep.W-1)/2, Y: sep.Y, W: 1, H: sep.H}
+		switch {
+		case u.input.Hover == id || u.input.Focus == id:
+			u.DrawRect(line, u.style.Colors.BaseHover)
+		case set.borders:
+			u.DrawRect(line, u.style.Colors.Border)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package microui
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// columnsApp lays out a list and its details in two columns of a 300x200
+// window, then a button below them, keeping where each went.
+type columnsApp struct {
+	ui                   *UI
+	list, detail, below  types.Rect
+	listIndex, detailIdx int
+}
+
+func (p *columnsApp) frame(rows int) {
+	ui := p.ui
+	ui.BeginFrame()
+	if ui.BeginWindowOpt("Main", types.Rect{W: 300, H: 200}, OptNoResize) {
+		ui.Columns(2, true)
+		ui.LayoutRow(1, []int{-1}, 0)
+		for range rows {
+			ui.Label("item")
+		}
+		p.list, p.listIndex = ui.lastRect, ui.ColumnIndex()
+		ui.NextColumn()
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Label("details")
+		p.detail, p.detailIdx = ui.lastRect, ui.ColumnIndex()
+		ui.Columns(1, false)
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Button("OK")
+		p.below = ui.lastRect
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+func TestColumns_SideBySide(t *testing.T) {
+	p := &columnsApp{ui: New(Config{})}
+	p.frame(3)
+	body := p.ui.GetContainer("Main").Body()
+
+	if top := p.list.Y - 2*(p.list.H+p.ui.style.Spacing); p.detail.Y != top {
+		t.Errorf("details at y=%d, want the top of the first column's y=%d", p.detail.Y, top)
+	}
+	if p.list.X+p.list.W >= p.detail.X {
+		t.Errorf("list %v overlaps details %v", p.list, p.detail)
+	}
+	if p.detail.X+p.detail.W != body.X+body.W-p.ui.style.Padding.X {
+		t.Errorf("details %v don't reach the right of the body %v", p.detail, body)
+	}
+	if p.listIndex != 0 || p.detailIdx != 1 {
+		t.Errorf("ColumnIndex = %d, %d, want 0, 1", p.listIndex, p.detailIdx)
+	}
+	if p.below.Y <= p.list.Y {
+		t.Errorf("button after the columns at y=%d, want it below the tallest at y=%d", p.below.Y, p.list.Y)
+	}
+}
+
+func TestColumns_ContentSizeScrolls(t *testing.T) {
+	p := &columnsApp{ui: New(Config{})}
+	p.frame(20)
+	p.frame(20)
+	cnt := p.ui.GetContainer("Main")
+	if got := cnt.ContentSize().Y; got < p.below.Y+p.below.H-cnt.Body().Y-p.ui.style.Padding.Y {
+		t.Errorf("content height = %d, want it to reach the button below the columns", got)
+	}
+	if cnt.Body().W >= cnt.Rect().W {
+		t.Error("tall columns should give the window a vertical scrollbar")
+	}
+}
+
+func TestColumns_DragSeparatorKeepsWidth(t *testing.T) {
+	p := &columnsApp{ui: New(Config{})}
+	p.frame(3)
+	p.frame(3) // The separators are as tall as the columns were last frame
+	before := p.list.W
+
+	x, y := p.list.X+p.list.W+2, p.list.Y
+	p.ui.MouseMove(x, y)
+	p.frame(3)
+	p.ui.MouseDown(x, y, MouseLeft)
+	p.frame(3)
+	p.ui.MouseMove(x-50, y)
+	p.frame(3)
+	p.ui.MouseUp(x-50, y, MouseLeft)
+	p.frame(3)
+	if p.list.W != before-50 {
+		t.Fatalf("first column %d wide after dragging its separator 50 left, want %d", p.list.W, before-50)
+	}
+
+	data, err := p.ui.SaveLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := &columnsApp{ui: New(Config{})}
+	if err := q.ui.LoadLayout(data); err != nil {
+		t.Fatal(err)
+	}
+	q.frame(3)
+	if q.list.W != p.list.W {
+		t.Errorf("first column %d wide after LoadLayout, want the saved %d", q.list.W, p.list.W)
+	}
+
+	// Dragged all the way left, it stops short of closing the column
+	p.ui.MouseMove(p.list.X+p.list.W+2, y)
+	p.frame(3)
+	p.ui.MouseDown(p.list.X+p.list.W+2, y, MouseLeft)
+	p.frame(3)
+	p.ui.MouseMove(0, y)
+	p.frame(3)
+	if p.list.W <= 0 {
+		t.Errorf("first column %d wide after dragging past the window, want it kept open", p.list.W)
+	}
+}
+
+func TestColumns_NextColumnWraps(t *testing.T) {
+	ui := New(Config{})
+	var cells []types.Rect
+	ui.BeginFrame()
+	if ui.BeginWindow("Grid", types.Rect{W: 300, H: 200}) {
+		ui.Columns(3, false)
+		for i := range 6 {
+			ui.LayoutRow(1, []int{-1}, 10*(i%3+1))
+			ui.Label("cell")
+			cells = append(cells, ui.lastRect)
+			ui.NextColumn()
+		}
+		ui.EndWindow() // Ends the columns
+	}
+	ui.EndFrame()
+
+	if n := ui.layoutStack.Len(); n != 0 {
+		t.Errorf("%d layouts left after ending the window in columns, want 0", n)
+	}
+	for i := 1; i < 3; i++ {
+		if cells[i].Y != cells[0].Y || cells[i].X <= cells[i-1].X {
+			t.Errorf("cell %d at %v, want it right of cell %d at %v", i, cells[i], i-1, cells[i-1])
+		}
+	}
+	if want := cells[2].Y + cells[2].H + ui.style.Spacing; cells[3].Y != want || cells[3].X != cells[0].X {
+		t.Errorf("cell 3 at %v, want a new row at x=%d, y=%d below the tallest", cells[3], cells[0].X, want)
+	}
+}
+
+func TestColumns_EqualWidths(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("Main", types.Rect{W: 300, H: 200})
+	ui.Columns(4, false)
+	st := ui.GetCurrentContainer().columns[ui.GetID("!columns4")]
+	ui.EndWindow()
+	ui.EndFrame()
+	for i, off := range st.offsets {
+		if want := float64(i+1) / 4; math.Abs(off-want) > 1e-9 {
+			t.Errorf("offset %d = %v, want %v", i, off, want)
+		}
+	}
+}
+
+func TestColumns_LoadBadOffsets(t *testing.T) {
+	ui := New(Config{})
+	ui.PushID("Main") // The window scopes the IDs of its columns
+	short, wide, empty := ui.GetID("!columns3"), ui.GetID("!columns2"), ui.GetID("!columns4")
+	ui.PopID()
+	data := fmt.Sprintf(`{"windows": {"Main": {"rect": {"W": 300, "H": 200}, "open": true,
+		"columns": {"%d": [0.5], "%d": [7], "%d": null}}}}`, short, wide, empty)
+	if err := ui.LoadLayout([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	var cells []types.Rect
+	ui.BeginFrame()
+	if ui.BeginWindow("Main", types.Rect{W: 300, H: 200}) {
+		ui.Columns(4, false) // No saved offsets at all
+		ui.Columns(3, false) // One saved offset for two separators
+		ui.Columns(2, false) // Saved offset past the right edge
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Label("left")
+		cells = append(cells, ui.lastRect)
+		ui.NextColumn()
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Label("right")
+		cells = append(cells, ui.lastRect)
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+
+	if got := ui.GetContainer("Main").columns[short].offsets; len(got) != 2 {
+		t.Errorf("%d offsets for 3 columns, want 2", len(got))
+	}
+	if body := ui.GetContainer("Main").Body(); cells[1].X > body.X+body.W {
+		t.Errorf("second column at x=%d, past the body %v", cells[1].X, body)
+	}
+}
@@ -0,0 +1,147 @@
+package microui
+
+import (
+	"fmt"
+
+	"github.com/user/microui-go/types"
+)
+
+// Combo adds a dropdown that selects one of items.
+// label identifies the control and is shown when *selected is out of range.
+// Returns true if the selection changed this frame.
+func (u *UI) Combo(label string, items []string, selected *int) bool {
+	return u.ComboOpt(label, items, selected, 0, 8, 0)
+}
+
+// ComboOpt adds a dropdown with a custom popup width and visible item count.
+// width is the popup width (0 = same as the control). maxVisible limits how
+// many rows are shown before the list scrolls (0 = show all).
+// While the list is open, Up/Down move the highlight, Enter selects and
+// Escape closes it.
+func (u *UI) ComboOpt(label string, items []string, selected *int, width, maxVisible int, opt int) bool {
+	id := u.GetID(label)
+	rect := u.LayoutNext()
+	popupName := fmt.Sprintf("!combo:%d", id)
+	cnt := u.GetContainer(popupName)
+
+	u.UpdateControlOpt(id, rect, opt)
+	changed := false
+	opened := false
+
+	// Clicking the control (or Enter/Space with keyboard focus) toggles the list
+	if u.activated(id) {
+		if cnt.open {
+			cnt.open = false
+		} else {
+			u.OpenPopupAt(popupName, rect)
+			u.comboID = id
+			u.comboHighlight = *selected
+			opened = true
+		}
+	}
+
+	// Draw the closed control: current value plus a dropdown arrow
+	u.DrawControlFrame(id, rect, ColorButton, opt)
+	text := label
+	if *selected >= 0 && *selected < len(items) {
+		text = items[*selected]
+	}
+	arrow := types.Rect{X: rect.X + rect.W - rect.H, Y: rect.Y, W: rect.H, H: rect.H}
+	u.DrawControlText(text, types.Rect{X: rect.X, Y: rect.Y, W: rect.W - arrow.W, H: rect.H}, ColorText, opt)
+	u.DrawIcon(IconExpanded, arrow, u.style.Colors.Text)
+	state := accessOptState(opt)
+	if cnt.open && u.comboID == id {
+		state |= AccessExpanded
+	}
+	u.accessControl(id, rect, RoleComboBox, label, text, state)
+
+	if !cnt.open || u.comboID != id {
+		return false
+	}
+
+	// Keyboard navigation while open
+	if u.input.KeyPressed[KeyDown] && u.comboHighlight < len(items)-1 {
+		u.comboHighlight++
+	}
+	if u.input.KeyPressed[KeyUp] && u.comboHighlight > 0 {
+		u.comboHighlight--
+	}
+	if u.comboHighlight >= len(items) {
+		u.comboHighlight = len(items) - 1
+	}
+	if u.input.KeyPressed[KeyEscape] {
+		cnt.open = false
+		return false
+	}
+	// Skip Enter on the frame it opened the list from keyboard focus
+	if u.input.KeyPressed[KeyEnter] && !opened && u.comboHighlight >= 0 {
+		if *selected != u.comboHighlight {
+			*selected = u.comboHighlight
+			changed = true
+		}
+		cnt.open = false
+		return changed
+	}
+
+	// Size the popup, kept below the control (above near the bottom of the
+	// screen) as it scrolls
+	rowH := u.style.Size.Y + u.style.Padding.Y*2
+	visible := len(items)
+	if maxVisible > 0 && visible > maxVisible {
+		visible = maxVisible
+	}
+	if visible < 1 {
+		visible = 1
+	}
+	if width <= 0 {
+		width = rect.W
+	}
+	bodyH := visible*rowH + (visible-1)*u.style.Spacing + u.style.Padding.Y*2
+	cnt.popupAnchor = rect
+	cnt.rect.W, cnt.rect.H = width, bodyH+u.style.BorderWidth*2
+
+	// Keep the highlighted row in view when navigating with the keyboard
+	if u.comboHighlight >= 0 && (u.input.KeyPressed[KeyDown] || u.input.KeyPressed[KeyUp]) {
+		top := u.comboHighlight * (rowH + u.style.Spacing)
+		viewH := bodyH - u.style.Padding.Y*2
+		if top < cnt.scroll.Y {
+			cnt.scroll.Y = top
+		} else if top+rowH > cnt.scroll.Y+viewH {
+			cnt.scroll.Y = top + rowH - viewH
+		}
+	}
+
+	popupOpt := OptPopup | OptClosed | OptNoTitle | OptNoResize
+	if u.BeginWindowOpt(popupName, cnt.rect, popupOpt) {
+		if cnt.access != nil {
+			cnt.access.Label = label
+		}
+		u.LayoutRow(1, []int{-1}, rowH)
+		for i, item := range items {
+			itemID := u.GetID(fmt.Sprintf("!item%d", i))
+			r := u.LayoutNext()
+			u.UpdateControlOpt(itemID, r, OptNoNav)
+			if u.input.Hover == itemID && u.input.MouseDelta != (types.Vec2{}) {
+				u.comboHighlight = i
+			}
+			if i == u.comboHighlight {
+				u.DrawFrame(r, ColorButtonHover)
+			}
+			u.DrawControlText(item, r, ColorText, 0)
+			var state AccessState
+			if i == *selected {
+				state = AccessSelected
+			}
+			u.accessControl(itemID, r, RoleOption, item, "", state)
+			if u.input.MousePressed[int(MouseLeft)] && u.input.Focus == itemID {
+				if *selected != i {
+					*selected = i
+					changed = true
+				}
+				cnt.open = false
+			}
+		}
+		u.EndWindow()
+	}
+	return changed
+}
@@ -0,0 +1,126 @@
+package microui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+var comboItems = []string{"Apple", "Banana", "Cherry", "Date"}
+
+// comboFrame runs one frame with a single combo at (5,29) 200x20.
+// Its list opens below at y=49 with 20px rows spaced 4px apart, starting at y=54.
+func comboFrame(ui *UI, selected *int, maxVisible int) bool {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 0)
+	changed := ui.ComboOpt("fruit", comboItems, selected, 0, maxVisible, 0)
+	ui.EndWindow()
+	ui.EndFrame()
+	return changed
+}
+
+func comboClick(ui *UI, selected *int, x, y int) bool {
+	ui.MouseMove(x, y)
+	comboFrame(ui, selected, 0)
+	ui.MouseDown(x, y, MouseLeft)
+	changed := comboFrame(ui, selected, 0)
+	ui.MouseUp(x, y, MouseLeft)
+	comboFrame(ui, selected, 0)
+	return changed
+}
+
+func TestCombo_OpenAndSelect(t *testing.T) {
+	ui := New(Config{})
+	selected := 0
+	popup := comboPopup(ui)
+
+	comboClick(ui, &selected, 50, 39)
+	if !popup.Open() {
+		t.Fatal("combo list should be open after clicking the control")
+	}
+
+	// Second row (Banana) spans y=78..98
+	if !comboClick(ui, &selected, 50, 85) {
+		t.Error("selecting an item should report a change")
+	}
+	if selected != 1 {
+		t.Errorf("selected = %d, want 1", selected)
+	}
+	if popup.Open() {
+		t.Error("combo list should close after selecting")
+	}
+}
+
+func TestCombo_Keyboard(t *testing.T) {
+	ui := New(Config{})
+	selected := 0
+	popup := comboPopup(ui)
+
+	comboClick(ui, &selected, 50, 39)
+
+	pressKey(ui, KeyDown)
+	comboFrame(ui, &selected, 0)
+	pressKey(ui, KeyDown)
+	comboFrame(ui, &selected, 0)
+	pressKey(ui, KeyEnter)
+	if !comboFrame(ui, &selected, 0) {
+		t.Error("Enter should report a change")
+	}
+	if selected != 2 {
+		t.Errorf("selected = %d, want 2", selected)
+	}
+	if popup.Open() {
+		t.Error("Enter should close the list")
+	}
+}
+
+func TestCombo_EscapeCloses(t *testing.T) {
+	ui := New(Config{})
+	selected := 3
+	popup := comboPopup(ui)
+
+	comboClick(ui, &selected, 50, 39)
+	pressKey(ui, KeyEscape)
+	comboFrame(ui, &selected, 0)
+	if popup.Open() {
+		t.Error("Escape should close the list")
+	}
+	if selected != 3 {
+		t.Errorf("selected = %d, want unchanged 3", selected)
+	}
+}
+
+func TestCombo_MaxVisibleScrolls(t *testing.T) {
+	ui := New(Config{})
+	selected := 0
+	popup := comboPopup(ui)
+
+	ui.MouseMove(50, 39)
+	comboFrame(ui, &selected, 2)
+	ui.MouseDown(50, 39, MouseLeft)
+	comboFrame(ui, &selected, 2)
+	ui.MouseUp(50, 39, MouseLeft)
+	comboFrame(ui, &selected, 2)
+
+	// Two rows visible: 2*20 + 4 spacing + 10 padding
+	if h := popup.Rect().H; h != 54 {
+		t.Errorf("popup height = %d, want 54", h)
+	}
+
+	for i := 0; i < 3; i++ {
+		pressKey(ui, KeyDown)
+		comboFrame(ui, &selected, 2)
+	}
+	if popup.Scroll().Y == 0 {
+		t.Error("list should scroll to keep the highlighted row visible")
+	}
+}
+
+// comboPopup returns the list container of the "fruit" combo in window "Test".
+func comboPopup(ui *UI) *Container {
+	ui.PushID("Test")
+	defer ui.PopID()
+	return ui.GetContainer(fmt.Sprintf("!combo:%d", ui.GetID("fruit")))
+}
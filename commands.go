@@ -17,6 +17,11 @@ const (
 	CmdBox         // Outline rectangle
 	CmdScrollTrack // Scrollbar track (background)
 	CmdScrollThumb // Scrollbar thumb (draggable)
+	CmdImage       // Application image drawn into Rect
+	CmdNineSlice   // Image stretched into Rect keeping its borders (nine-patch)
+	CmdShadow      // Drop shadow of the window Rect, offset by Pos (Style.WindowShadow)
+	CmdCustom      // Application command with payload Data, see UI.DrawCustom
+	CmdPlot        // Graph of Plot's values drawn into Rect, see UI.PlotLines
 )
 
 // Icon IDs (matching original microui)
@@ -25,8 +30,20 @@ const (
 	IconCheck
 	IconCollapsed
 	IconExpanded
-	IconResize // Resize gripper (not in original microui)
+	IconResize   // Resize gripper (not in original microui)
+	IconRadio    // Radio button dot (not in original microui)
+	IconMaximize // Window maximize button (not in original microui)
+	IconRestore  // Window restore button (not in original microui)
+	IconPin      // Window pin button (not in original microui)
+	// Scrollbar arrow buttons (Style.ScrollbarArrows)
+	IconArrowUp
+	IconArrowDown
+	IconArrowLeft
+	IconArrowRight
 	IconMax
+
+	// IconUser is the first ID for application icons; see RegisterIcon.
+	IconUser = 256
 )
 
 // Command represents a single render command.
@@ -36,10 +53,15 @@ type Command struct {
 	Rect  types.Rect
 	Pos   types.Vec2
 	Size  types.Vec2
-	Text  string
+	Text  string     // CmdText: text; CmdIcon: icon name (see UI.IconName)
 	Color color.Color
 	Icon  int
-	Font types.Font
+	Font  types.Font
+	Image any        // CmdImage: renderer-specific image handle
+	Src   types.Rect   // CmdImage, CmdNineSlice: source region in image pixels (empty = whole image)
+	Slice types.Insets // CmdNineSlice: borders of Src kept at their size
+	Data  any          // CmdCustom: application payload
+	Plot  *types.Plot  // CmdPlot: the plotted values
 }
 
 // CommandBuffer holds render commands for a frame.
@@ -1,6 +1,15 @@
 package microui
 
-import "github.com/user/microui-go/types"
+import (
+	"cmp"
+	"image/color"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
 
 // Container represents a UI container (window, panel, popup).
 type Container struct {
@@ -11,12 +20,62 @@ type Container struct {
 	contentSize types.Vec2 // Tracks actual content size for scrolling
 	scroll      types.Vec2
 	zindex      int
+	zpos        int          // Position in UI.zOrder plus one (0 = not placed yet)
+	zlayer      int          // zLayer when last placed in UI.zOrder
+	rootFrame   int          // Frame the container was last begun as a root
+	lastUsed    int          // Frame it was last returned by GetContainer
+	last        rootSnapshot // What it drew last frame, for dirty regions
 	open        bool
-	opt         int // Options passed to container (for AutoSize, etc.)
+	opt         int                  // Options passed to container (for AutoSize, etc.)
+	collapsed   bool                 // Window shows only its title bar
+	maximized   bool                 // Window fills the screen
+	restoreRect types.Rect           // Rect to return to when un-maximized
+	minSize     types.Vec2           // Smallest size while resizing (0 = no limit)
+	maxSize     types.Vec2           // Largest size while resizing (0 = no limit)
+	aspect      float64              // Width/height ratio kept while resizing (0 = free)
+	toBottom    bool                 // Scroll to the bottom once content is measured
+	scrollGoal  types.Vec2           // Where an animated scroll is heading
+	scrollAnim  bool                 // scroll is easing towards scrollGoal
+	thumbHeld   bool                 // The focused scrollbar's thumb is being dragged
+	thumbGrab   int                  // Where the thumb was grabbed, from its start
+	barShown    time.Time            // Last time auto-hiding scrollbars were scrolled or hot
+	barScroll   types.Vec2           // Scroll offset when barShown was last checked
+	barAlpha    float64              // Opacity the scrollbars were last drawn at
+	barHot      bool                 // A scrollbar was under the mouse or dragged
+	parent      *Container           // Container a panel is nested in (nil for windows)
+	disabled    bool                 // OptNoInteract here or in a parent: controls ignore input
+	measured    bool                 // contentSize has been measured at least once
+	fills       [2]bool              // Content was as wide, as tall as the body because it filled it
+	fitNext     bool                 // LayoutFitContent was called: fit content next frame
+	footer      int                  // Height kept free at the bottom of the body for BeginFooter
+	footerNext  int                  // Footer height measured this frame, reserved next frame
+	footerRect  types.Rect           // Strip below the body the footer is placed in
+	split       float64              // First pane's share of a Splitter kept here
+	columns     map[ID]*columnsState // Column widths of Columns laid out here
+	pinned      bool                 // Drawn above unpinned windows (OptAlwaysOnTop)
+	bg          color.Color          // Background replacing the style's (nil = style)
+	bgFade      float64              // Fraction of the background's alpha taken away
+	viewport    *Viewport            // Viewport the container is drawn in (nil = Render's)
+
+	// Where a popup opened with OpenPopupAt is placed
+	anchored    bool       // Placed next to popupAnchor each frame
+	popupAnchor types.Rect // Rect the popup opens next to
+	popupPlace  Placement  // Preferred side of popupAnchor
+
+	// Node in this frame's accessibility tree, and the tree nodes open in
+	// it, innermost last
+	access     *AccessNode
+	accessOpen []*AccessNode
+
+	// Extra title-bar buttons added with AddTitleButton
+	titleButtons []titleButton
 
 	// Command buffer indices for z-order rendering
 	headIdx int // Command buffer index at container start
 	tailIdx int // Command buffer index at container end
+
+	// Last frame's commands while the container fades out after closing
+	fadeCmds []Command
 }
 
 // ID returns the container's ID.
@@ -52,6 +111,45 @@ func (c *Container) Scroll() types.Vec2 {
 // SetScroll sets the container's scroll offset.
 func (c *Container) SetScroll(s types.Vec2) {
 	c.scroll = s
+	c.scrollAnim = false
+}
+
+// SetScrollAnimated scrolls the container to target over the next few
+// frames, easing out as it arrives. The target is clamped to the content.
+func (c *Container) SetScrollAnimated(target types.Vec2) {
+	c.scrollGoal = target
+	c.scrollAnim = true
+}
+
+// Background returns the color set with SetBackground, or nil when the
+// container uses the style's background.
+func (c *Container) Background() color.Color {
+	return c.bg
+}
+
+// SetBackground replaces the style's background color for this window or
+// panel, e.g. a translucent color for a HUD over game content. nil goes
+// back to the style. A replaced background is a plain rect with the style's
+// border, not a custom DrawFrame or textured frame.
+func (c *Container) SetBackground(bg color.Color) {
+	c.bg = bg
+}
+
+// BackgroundAlpha returns the opacity set with SetBackgroundAlpha.
+func (c *Container) BackgroundAlpha() float64 {
+	return 1 - c.bgFade
+}
+
+// SetBackgroundAlpha makes the container's background, whether the style's
+// or SetBackground's, alpha times as opaque, from 0 (invisible) to 1. The
+// controls and title bar on it keep their own colors.
+func (c *Container) SetBackgroundAlpha(alpha float64) {
+	c.bgFade = 1 - max(0, min(alpha, 1))
+}
+
+// Parent returns the container a panel is nested in, or nil for a window.
+func (c *Container) Parent() *Container {
+	return c.parent
 }
 
 // ZIndex returns the container's z-order.
@@ -64,6 +162,84 @@ func (c *Container) Open() bool {
 	return c.open
 }
 
+// Collapsed returns whether the window is collapsed to its title bar.
+func (c *Container) Collapsed() bool {
+	return c.collapsed
+}
+
+// SetCollapsed collapses the window to its title bar or expands it again.
+func (c *Container) SetCollapsed(collapsed bool) {
+	c.collapsed = collapsed
+}
+
+// Maximized returns whether the window is maximized to fill the screen.
+func (c *Container) Maximized() bool {
+	return c.maximized
+}
+
+// Pinned returns whether the window stays above unpinned windows.
+func (c *Container) Pinned() bool {
+	return c.pinned
+}
+
+// SetPinned keeps the window above unpinned windows, as OptAlwaysOnTop
+// does, or lets it drop back among them.
+func (c *Container) SetPinned(pinned bool) {
+	c.pinned = pinned
+}
+
+// Viewport returns the viewport the window is assigned to, or nil.
+func (c *Container) Viewport() *Viewport {
+	return c.viewport
+}
+
+// SetViewport assigns the window to vp, so RenderViewport for vp draws it
+// and Render and the other viewports don't; nil assigns it to none. Popups
+// opened from the window go to its viewport too.
+func (c *Container) SetViewport(vp *Viewport) {
+	c.viewport = vp
+}
+
+// SetMinSize sets the smallest size the window can be resized to.
+// A zero dimension means no limit.
+func (c *Container) SetMinSize(w, h int) {
+	c.minSize = types.Vec2{X: w, Y: h}
+}
+
+// SetMaxSize sets the largest size the window can be resized to.
+// A zero dimension means no limit.
+func (c *Container) SetMaxSize(w, h int) {
+	c.maxSize = types.Vec2{X: w, Y: h}
+}
+
+// SetAspect keeps the window's width/height ratio while resizing.
+// Zero lets width and height change independently.
+func (c *Container) SetAspect(ratio float64) {
+	c.aspect = ratio
+}
+
+// constrainSize applies the size limits and aspect ratio to a size. The
+// limits win when they conflict with the aspect ratio.
+func (c *Container) constrainSize(w, h int) (int, int) {
+	w = clampLimit(w, c.minSize.X, c.maxSize.X)
+	if c.aspect > 0 {
+		h = int(math.Round(float64(w) / c.aspect))
+	}
+	h = clampLimit(h, c.minSize.Y, c.maxSize.Y)
+	return w, h
+}
+
+// clampLimit clamps v to lo..hi, where a zero limit is ignored.
+func clampLimit(v, lo, hi int) int {
+	if hi > 0 && v > hi {
+		v = hi
+	}
+	if lo > 0 && v < lo {
+		v = lo
+	}
+	return v
+}
+
 // ContentSize returns the container's actual content size.
 // This is useful for calculating scroll ranges.
 func (c *Container) ContentSize() types.Vec2 {
@@ -74,3 +250,71 @@ func (c *Container) ContentSize() types.Vec2 {
 func (c *Container) SetContentSize(s types.Vec2) {
 	c.contentSize = s
 }
+
+// LastUsed returns the frame the container was last begun or looked up
+// with GetContainer in. See Config.RetireContainers.
+func (c *Container) LastUsed() int {
+	return c.lastUsed
+}
+
+// Containers returns the windows, panels and popups the UI keeps state
+// for, sorted by name, e.g. to find containers with generated names that
+// are never freed. Container.LastUsed says when each was last used.
+func (u *UI) Containers() []*Container {
+	list := make([]*Container, 0, len(u.containers))
+	for _, cnt := range u.containers {
+		list = append(list, cnt)
+	}
+	slices.SortFunc(list, func(a, b *Container) int {
+		return cmp.Or(strings.Compare(a.name, b.name), cmp.Compare(a.id, b.id))
+	})
+	return list
+}
+
+// DeleteContainer frees the container named name, if the UI has one: its
+// position, size, scroll, z-order and open state are forgotten, and the
+// next BeginWindow or GetContainer creates it afresh. Call it outside the
+// container's Begin and End calls, e.g. after closing a window for good.
+func (u *UI) DeleteContainer(name string) {
+	if cnt, ok := u.containers[u.getRawID(name)]; ok {
+		u.deleteContainer(cnt)
+	}
+}
+
+// deleteContainer removes cnt from the UI and drops every reference to it.
+func (u *UI) deleteContainer(cnt *Container) {
+	delete(u.containers, cnt.id)
+	if cnt.zpos > 0 {
+		i := cnt.zpos - 1
+		u.zOrder = slices.Delete(u.zOrder, i, i+1)
+		for j := i; j < len(u.zOrder); j++ {
+			u.zOrder[j].zpos = j + 1
+		}
+		cnt.zpos = 0
+	}
+	other := func(c *Container) bool { return c == cnt }
+	u.rootList = slices.DeleteFunc(u.rootList, other)
+	u.prevRoots = slices.DeleteFunc(u.prevRoots, other)
+	u.fading = slices.DeleteFunc(u.fading, other)
+	u.accessPending = slices.DeleteFunc(u.accessPending, other)
+	u.forgetTreeNodes(cnt.id)
+	for _, ref := range [...]**Container{
+		&u.hoverRoot, &u.nextHoverRoot, &u.scrollTarget, &u.scrollPanel,
+		&u.focusWindow, &u.dockDrag, &u.modal,
+	} {
+		if *ref == cnt {
+			*ref = nil
+		}
+	}
+}
+
+// pruneContainers frees the containers not used for Config.RetireContainers
+// frames. Windows still fading out and an open modal dialog are kept.
+func (u *UI) pruneContainers() {
+	for _, cnt := range u.containers {
+		if u.frame-cnt.lastUsed <= u.retireFrames || cnt == u.modal || slices.Contains(u.fading, cnt) {
+			continue
+		}
+		u.deleteContainer(cnt)
+	}
+}
@@ -168,3 +168,88 @@ func TestBringToFront(t *testing.T) {
 
 	ui.EndFrame()
 }
+
+func TestRetireContainers(t *testing.T) {
+	ui := New(Config{RetireContainers: 3})
+	frame := func(names ...string) {
+		ui.BeginFrame()
+		for _, name := range names {
+			if ui.BeginWindow(name, types.Rect{X: 0, Y: 0, W: 100, H: 100}) {
+				ui.LayoutRow(1, []int{-1}, 40)
+				ui.BeginPanel("panel")
+				ui.EndPanel()
+				ui.EndWindow()
+			}
+		}
+		ui.EndFrame()
+	}
+
+	frame("Item 1", "Item 2", "Keep")
+	for range 4 {
+		frame("Keep")
+	}
+
+	var names []string
+	for _, cnt := range ui.Containers() {
+		names = append(names, cnt.Name())
+		if cnt.LastUsed() != ui.Frame() {
+			t.Errorf("%q last used in frame %d, want %d", cnt.Name(), cnt.LastUsed(), ui.Frame())
+		}
+	}
+	if len(names) != 2 || names[0] != "Keep" || names[1] != "panel" {
+		t.Errorf("containers after retiring = %q, want [Keep panel]", names)
+	}
+	for _, cnt := range ui.zOrder {
+		if cnt.Name() != "Keep" {
+			t.Errorf("retired window %q still in the z-order", cnt.Name())
+		}
+	}
+}
+
+func TestRetireContainers_OffByDefault(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("Once", types.Rect{X: 0, Y: 0, W: 100, H: 100})
+	ui.EndWindow()
+	ui.EndFrame()
+	for range 10 {
+		ui.BeginFrame()
+		ui.EndFrame()
+	}
+	if n := len(ui.Containers()); n != 1 {
+		t.Errorf("%d containers, want the window kept", n)
+	}
+}
+
+func TestDeleteContainer(t *testing.T) {
+	ui := New(Config{})
+	ui.MouseMove(50, 50)
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Back", types.Rect{X: 0, Y: 0, W: 100, H: 100})
+		ui.EndWindow()
+		ui.BeginWindow("Temp", types.Rect{X: 20, Y: 20, W: 100, H: 100})
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	frame()
+	frame()
+	temp := ui.GetContainer("Temp")
+	temp.SetRect(types.Rect{X: 30, Y: 30, W: 60, H: 60})
+	if ui.HoveredWindow() != temp {
+		t.Fatal("Temp should be under the mouse")
+	}
+
+	ui.DeleteContainer("Temp")
+	if ui.HoveredWindow() != nil {
+		t.Error("a deleted window is still the hover root")
+	}
+	if len(ui.zOrder) != 1 || ui.zOrder[0].zpos != 1 {
+		t.Errorf("z-order after deleting = %d windows, want Back alone at position 1", len(ui.zOrder))
+	}
+	frame()
+	if got := ui.GetContainer("Temp"); got == temp || got.Rect() != (types.Rect{X: 20, Y: 20, W: 100, H: 100}) {
+		t.Errorf("Temp should start over, got rect %v", got.Rect())
+	}
+	ui.DeleteContainer("Missing") // No-op
+}
@@ -0,0 +1,65 @@
+package microui
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestCull_LongListDropsHiddenText(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	for i := 0; i < 200; i++ {
+		ui.Label(fmt.Sprintf("row %d", i))
+	}
+	ui.EndWindow()
+	ui.EndFrame()
+
+	texts := 0
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdText {
+			texts++
+		}
+	})
+	if texts == 0 || texts > 20 {
+		t.Errorf("%d text commands emitted, want only the visible rows", texts)
+	}
+	stats := ui.Stats()
+	if stats.Culled < 180 {
+		t.Errorf("Culled = %d, want the hidden rows counted", stats.Culled)
+	}
+	if stats.Commands != ui.commands.Len() {
+		t.Errorf("Commands = %d, want %d", stats.Commands, ui.commands.Len())
+	}
+}
+
+func TestCull_DrawRectOutsideClip(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.PushClip(types.Rect{X: 0, Y: 0, W: 100, H: 100})
+	before := ui.commands.Len()
+	ui.DrawRect(types.Rect{X: 200, Y: 200, W: 10, H: 10}, color.White)
+	ui.DrawText("hidden", types.Vec2{X: 0, Y: 150}, nil, color.White)
+	if ui.commands.Len() != before {
+		t.Error("commands outside the clip rect should be dropped")
+	}
+	ui.DrawRect(types.Rect{X: 95, Y: 95, W: 10, H: 10}, color.White)
+	ui.DrawText("visible", types.Vec2{X: 10, Y: 10}, nil, color.White)
+	if ui.commands.Len() != before+2 {
+		t.Error("partially visible commands should be kept")
+	}
+	ui.PopClip()
+	ui.EndFrame()
+
+	if ui.Stats().Culled != 2 {
+		t.Errorf("Culled = %d, want 2", ui.Stats().Culled)
+	}
+	ui.BeginFrame()
+	if ui.Stats().Culled != 0 {
+		t.Error("stats should reset each frame")
+	}
+	ui.EndFrame()
+}
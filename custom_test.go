@@ -0,0 +1,45 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// customRenderer records the custom commands it is given.
+type customRenderer struct {
+	recordRenderer
+	rects []types.Rect
+	data  []any
+}
+
+func (r *customRenderer) DrawCustom(rect types.Rect, data any) {
+	r.rects = append(r.rects, rect)
+	r.data = append(r.data, data)
+}
+
+func TestDrawCustom(t *testing.T) {
+	type effect struct{ name string }
+	ui := New(Config{})
+	ui.BeginFrame()
+	if ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 200, H: 100}) {
+		ui.DrawCustom(types.Rect{X: 10, Y: 30, W: 50, H: 50}, effect{"glow"})
+		ui.DrawCustom(types.Rect{X: 500, Y: 500, W: 50, H: 50}, effect{"hidden"})
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+
+	r := &customRenderer{}
+	ui.Render(r)
+	if len(r.data) != 1 || r.data[0] != (effect{"glow"}) || r.rects[0] != (types.Rect{X: 10, Y: 30, W: 50, H: 50}) {
+		t.Errorf("custom commands = %v %v, want only the visible glow", r.rects, r.data)
+	}
+
+	// Renderers without DrawCustom skip it, and replays keep the payload
+	ui.Render(&recordRenderer{})
+	r = &customRenderer{}
+	ui.RecordFrame().Replay(r)
+	if len(r.data) != 1 {
+		t.Errorf("replayed custom commands = %v, want the glow", r.data)
+	}
+}
@@ -0,0 +1,200 @@
+package microui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+// weekdayNames head the calendar columns, Sunday first like time.Weekday.
+var weekdayNames = [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// DatePicker adds a control showing the date of *t that opens a calendar
+// popup: a month grid with buttons for the previous and next month and a
+// year spinner. Picking a day keeps the time of day and location of *t.
+// Escape closes the calendar. Returns true if the date changed this frame.
+// Its ID comes from the address of t, like Checkbox; see DatePickerID.
+func (u *UI) DatePicker(t *time.Time) bool {
+	return u.datePicker(u.getIDFromPtr(t), t)
+}
+
+// DatePickerID is DatePicker with an ID from id (scoped by PushID) instead
+// of the address of t.
+func (u *UI) DatePickerID(id string, t *time.Time) bool {
+	return u.datePicker(u.getID(id), t)
+}
+
+// TimePicker adds a control showing the time of *t (hours and minutes)
+// that opens a popup with an hour and a minute spinner. Changing them
+// keeps the date and location of *t and clears the seconds. Enter or
+// Escape closes the popup. Returns true if the time changed this frame.
+// Its ID comes from the address of t, like Checkbox; see TimePickerID.
+func (u *UI) TimePicker(t *time.Time) bool {
+	return u.timePicker(u.getIDFromPtr(t), t)
+}
+
+// TimePickerID is TimePicker with an ID from id (scoped by PushID) instead
+// of the address of t.
+func (u *UI) TimePickerID(id string, t *time.Time) bool {
+	return u.timePicker(u.getID(id), t)
+}
+
+// pickerControl draws a picker's closed control showing text and toggles
+// its popup when activated. It returns the popup container and whether
+// it is open for this picker.
+func (u *UI) pickerControl(id ID, popupName, text string, onOpen func()) (*Container, bool) {
+	rect := u.LayoutNext()
+	cnt := u.GetContainer(popupName)
+
+	u.UpdateControl(id, rect)
+	if u.activated(id) {
+		if cnt.open {
+			cnt.open = false
+		} else {
+			u.OpenPopupAt(popupName, rect)
+			u.pickerID = id
+			onOpen()
+		}
+	}
+
+	u.DrawControlFrame(id, rect, ColorButton, 0)
+	arrow := types.Rect{X: rect.X + rect.W - rect.H, Y: rect.Y, W: rect.H, H: rect.H}
+	u.DrawControlText(text, types.Rect{X: rect.X, Y: rect.Y, W: rect.W - arrow.W, H: rect.H}, ColorText, 0)
+	u.DrawIcon(IconExpanded, arrow, u.style.Colors.Text)
+	var state AccessState
+	if cnt.open && u.pickerID == id {
+		state = AccessExpanded
+	}
+	u.accessControl(id, rect, RoleComboBox, "", text, state)
+
+	if !cnt.open || u.pickerID != id {
+		return cnt, false
+	}
+	if u.input.KeyPressed[KeyEscape] {
+		cnt.open = false
+		return cnt, false
+	}
+	// Below the control, following it as it scrolls; the caller sets the size
+	cnt.popupAnchor = rect
+	return cnt, true
+}
+
+func (u *UI) datePicker(id ID, t *time.Time) bool {
+	popupName := fmt.Sprintf("!date:%d", id)
+	cnt, open := u.pickerControl(id, popupName, t.Format("2006-01-02"), func() {
+		u.pickerMonth = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	})
+	if !open {
+		return false
+	}
+
+	// Size the grid so the header (prev, month, next, year) fits above it
+	rowH := u.style.Size.Y + u.style.Padding.Y*2
+	sp := u.style.Spacing
+	cellW := max(rowH, u.style.Font.Width("00")+u.style.Padding.X*2)
+	monthW := u.style.Font.Width("September") + u.style.Padding.X*2
+	yearW := u.style.Font.Width("0000") + u.style.Padding.X*2 + rowH*2
+	inner := max(7*cellW+6*sp, 2*cellW+monthW+yearW+3*sp)
+	cellW = (inner - 6*sp) / 7
+	monthW = inner - 2*cellW - yearW - 3*sp
+	cnt.rect.W = inner + u.style.Padding.X*2 + u.style.BorderWidth*2
+	cnt.rect.H = 8*rowH + 7*sp + u.style.Padding.Y*2 + u.style.BorderWidth*2
+
+	changed := false
+	popupOpt := OptPopup | OptClosed | OptNoTitle | OptNoResize | OptNoScroll
+	if !u.BeginWindowOpt(popupName, cnt.rect, popupOpt) {
+		return false
+	}
+
+	month := u.pickerMonth
+	u.LayoutRow(4, []int{cellW, monthW, cellW, yearW}, rowH)
+	if u.Button("<") {
+		month = month.AddDate(0, -1, 0)
+	}
+	u.LabelOpt(month.Month().String(), OptAlignCenter)
+	if u.Button(">") {
+		month = month.AddDate(0, 1, 0)
+	}
+	year := float64(month.Year())
+	if u.number(u.GetID("!year"), &year, 1, 9999, 1, "%.0f", OptSpinner|OptAlignCenter) {
+		month = time.Date(int(year), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	u.pickerMonth = month
+
+	cols := make([]int, 7)
+	for i := range cols {
+		cols[i] = cellW
+	}
+	u.LayoutRow(7, cols, rowH)
+	for _, name := range weekdayNames {
+		u.LabelOpt(name, OptAlignCenter)
+	}
+
+	// Six weeks cover any month; cells outside it stay empty
+	first := int(month.Weekday())
+	days := month.AddDate(0, 1, -1).Day()
+	for cell := 0; cell < 42; cell++ {
+		r := u.LayoutNext()
+		day := cell - first + 1
+		if day < 1 || day > days {
+			continue
+		}
+		dayID := u.GetID(fmt.Sprintf("!day%d", day))
+		u.UpdateControlOpt(dayID, r, OptNoNav)
+		selected := t.Year() == month.Year() && t.Month() == month.Month() && t.Day() == day
+		if selected {
+			u.DrawFrame(r, ColorButtonFocus)
+		} else {
+			u.DrawControlFrame(dayID, r, ColorButton, 0)
+		}
+		u.DrawControlText(fmt.Sprint(day), r, ColorText, OptAlignCenter)
+		if u.activated(dayID) {
+			picked := time.Date(month.Year(), month.Month(), day,
+				t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+			changed = !picked.Equal(*t)
+			*t = picked
+			cnt.open = false
+		}
+	}
+	u.EndWindow()
+	return changed
+}
+
+func (u *UI) timePicker(id ID, t *time.Time) bool {
+	popupName := fmt.Sprintf("!time:%d", id)
+	cnt, open := u.pickerControl(id, popupName, t.Format("15:04"), func() {})
+	if !open {
+		return false
+	}
+	if u.input.KeyPressed[KeyEnter] {
+		cnt.open = false
+		return false
+	}
+
+	rowH := u.style.Size.Y + u.style.Padding.Y*2
+	sp := u.style.Spacing
+	spinW := u.style.Font.Width("00") + u.style.Padding.X*2 + rowH*2
+	colonW := u.style.Font.Width(":")
+	cnt.rect.W = 2*spinW + colonW + 2*sp + u.style.Padding.X*2 + u.style.BorderWidth*2
+	cnt.rect.H = rowH + u.style.Padding.Y*2 + u.style.BorderWidth*2
+
+	popupOpt := OptPopup | OptClosed | OptNoTitle | OptNoResize | OptNoScroll
+	if !u.BeginWindowOpt(popupName, cnt.rect, popupOpt) {
+		return false
+	}
+	hour, minute := float64(t.Hour()), float64(t.Minute())
+	u.LayoutRow(3, []int{spinW, colonW, spinW}, rowH)
+	changed := u.number(u.GetID("!hour"), &hour, 0, 23, 1, "%02.0f", OptSpinner|OptAlignCenter)
+	u.LabelOpt(":", OptAlignCenter)
+	changed = u.number(u.GetID("!minute"), &minute, 0, 59, 1, "%02.0f", OptSpinner|OptAlignCenter) || changed
+	u.EndWindow()
+
+	if !changed {
+		return false
+	}
+	picked := time.Date(t.Year(), t.Month(), t.Day(), int(hour), int(minute), 0, 0, t.Location())
+	changed = !picked.Equal(*t)
+	*t = picked
+	return changed
+}
@@ -0,0 +1,114 @@
+package microui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+// pickerFrame runs one frame with a 200px wide picker at (5,29). Its popup
+// opens below at (5,49) with 20px rows spaced 4px apart, starting at y=54.
+func pickerFrame(ui *UI, picker func(*time.Time) bool, t *time.Time) bool {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 400})
+	ui.LayoutRow(1, []int{200}, 0)
+	changed := picker(t)
+	ui.EndWindow()
+	ui.EndFrame()
+	return changed
+}
+
+func pickerClick(ui *UI, picker func(*time.Time) bool, t *time.Time, x, y int) bool {
+	ui.MouseMove(x, y)
+	pickerFrame(ui, picker, t)
+	ui.MouseDown(x, y, MouseLeft)
+	changed := pickerFrame(ui, picker, t)
+	ui.MouseUp(x, y, MouseLeft)
+	pickerFrame(ui, picker, t)
+	return changed
+}
+
+func TestDatePicker_PickDay(t *testing.T) {
+	ui := New(Config{})
+	date := time.Date(2024, time.February, 10, 12, 30, 0, 0, time.UTC)
+	picker := func(t *time.Time) bool { return ui.DatePickerID("date", t) }
+
+	pickerClick(ui, picker, &date, 50, 39)
+	popup := findPopup(ui, "!date:")
+	if popup == nil || !popup.Open() {
+		t.Fatal("calendar should open after clicking the control")
+	}
+
+	// 29px cells spaced 4px from x=10; grid rows from y=102. February 2024
+	// starts on a Thursday, so the 15th is in the third row, fifth column.
+	if !pickerClick(ui, picker, &date, 150, 160) {
+		t.Error("picking a day should report a change")
+	}
+	want := time.Date(2024, time.February, 15, 12, 30, 0, 0, time.UTC)
+	if !date.Equal(want) {
+		t.Errorf("date = %v, want %v", date, want)
+	}
+	if popup.Open() {
+		t.Error("calendar should close after picking a day")
+	}
+}
+
+func TestDatePicker_NextMonth(t *testing.T) {
+	ui := New(Config{})
+	date := time.Date(2024, time.February, 10, 12, 30, 0, 0, time.UTC)
+	picker := func(t *time.Time) bool { return ui.DatePickerID("date", t) }
+
+	pickerClick(ui, picker, &date, 50, 39)
+	if pickerClick(ui, picker, &date, 140, 60) { // ">"
+		t.Error("changing the month should not change the date")
+	}
+	// March 2024 starts on a Friday, sixth column of the first row
+	pickerClick(ui, picker, &date, 185, 110)
+	want := time.Date(2024, time.March, 1, 12, 30, 0, 0, time.UTC)
+	if !date.Equal(want) {
+		t.Errorf("date = %v, want %v", date, want)
+	}
+}
+
+func TestDatePicker_Escape(t *testing.T) {
+	ui := New(Config{})
+	date := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+	picker := func(t *time.Time) bool { return ui.DatePickerID("date", t) }
+
+	pickerClick(ui, picker, &date, 50, 39)
+	popup := findPopup(ui, "!date:")
+	ui.KeyDown(KeyEscape)
+	pickerFrame(ui, picker, &date)
+	ui.KeyUp(KeyEscape)
+	if popup.Open() {
+		t.Error("Escape should close the calendar")
+	}
+}
+
+func TestTimePicker_Spinners(t *testing.T) {
+	ui := New(Config{})
+	tm := time.Date(2024, time.February, 10, 12, 30, 15, 0, time.UTC)
+	picker := func(t *time.Time) bool { return ui.TimePickerID("time", t) }
+
+	pickerClick(ui, picker, &tm, 50, 39)
+	// The hour spinner spans x=10..76 with its + button at the right end
+	if !pickerClick(ui, picker, &tm, 66, 64) {
+		t.Error("stepping the hour should report a change")
+	}
+	want := time.Date(2024, time.February, 10, 13, 30, 0, 0, time.UTC)
+	if !tm.Equal(want) {
+		t.Errorf("time = %v, want %v", tm, want)
+	}
+}
+
+// findPopup returns the open container whose name starts with prefix.
+func findPopup(ui *UI, prefix string) *Container {
+	for _, cnt := range ui.containers {
+		if cnt.open && strings.HasPrefix(cnt.name, prefix) {
+			return cnt
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package microui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/user/microui-go/types"
+)
+
+// debugWindowName is the container name of the window shown by DebugWindow.
+const debugWindowName = "Debug"
+
+// debugFrame holds what DebugWindow shows about one frame.
+type debugFrame struct {
+	stats     FrameStats
+	clipDepth int  // Deepest clip stack
+	hoverIDs  []ID // ID stack when the hovered control was updated
+}
+
+// keyNames are the names of the non-letter keys, for DebugWindow and
+// shortcuts.
+var keyNames = [...]string{
+	KeyShift: "Shift", KeyCtrl: "Ctrl", KeyAlt: "Alt", KeyEnter: "Enter",
+	KeyBackspace: "Backspace", KeyDelete: "Delete", KeyEscape: "Escape",
+	KeyLeft: "Left", KeyRight: "Right", KeyUp: "Up", KeyDown: "Down",
+	KeyHome: "Home", KeyEnd: "End", KeyPageUp: "PageUp", KeyPageDown: "PageDown",
+	KeyTab: "Tab", KeySpace: "Space", KeyMeta: "Meta",
+}
+
+// DebugWindow shows a window with the UI's internal state: the previous
+// frame's command counts and deepest clip stack, every open container
+// with its z-index and rect, hover and focus IDs with the ID stack of the
+// hovered control, input state and pool sizes. The container receiving
+// mouse input is marked with a star. Call it at the end of the frame so
+// the state of the rest of the frame is complete.
+func (u *UI) DebugWindow() {
+	// Snapshot before the window adds its own state
+	prev := u.debugPrev
+	hoverRoot, nextHover := u.hoverRoot, u.nextHoverRoot
+	input := u.input
+	containers := make([]*Container, 0, len(u.containers))
+	for _, cnt := range u.containers {
+		if cnt.open && cnt.name != debugWindowName {
+			containers = append(containers, cnt)
+		}
+	}
+	slices.SortFunc(containers, func(a, b *Container) int {
+		switch {
+		case u.inFront(a, b):
+			return -1 // Front first
+		case u.inFront(b, a):
+			return 1
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	if !u.BeginWindow(debugWindowName, types.Rect{X: 10, Y: 10, W: 320, H: 420}) {
+		return
+	}
+	defer u.EndWindow()
+
+	row := func(label, format string, args ...any) {
+		u.LayoutRow(2, []int{110, -1}, 0)
+		u.Label(label)
+		u.Label(fmt.Sprintf(format, args...))
+	}
+	name := func(cnt *Container) string {
+		if cnt == nil {
+			return "-"
+		}
+		return cnt.name
+	}
+
+	if u.Header("Frame") {
+		row("Frame", "%d", u.frame)
+		row("Commands", "%d", prev.stats.Commands)
+		row("Culled", "%d", prev.stats.Culled)
+		row("Clip depth", "%d", prev.clipDepth)
+		row("Scale", "%.2f", u.scale)
+	}
+
+	if u.Header("Containers") {
+		for _, cnt := range containers {
+			r := cnt.rect
+			mark := ""
+			if cnt == hoverRoot {
+				mark = " *"
+			}
+			row(cnt.name+mark, "z%d %d,%d %dx%d", cnt.zindex, r.X, r.Y, r.W, r.H)
+		}
+	}
+
+	if u.Header("Focus") {
+		row("Hover", "%08x", uint32(input.Hover))
+		row("Focus", "%08x", uint32(input.Focus))
+		row("Keyboard", "%08x", uint32(u.navFocus))
+		row("Hover root", "%s", name(hoverRoot))
+		row("Next root", "%s", name(nextHover))
+		row("Drag", "%08x", uint32(u.dragID))
+		ids := make([]string, len(prev.hoverIDs))
+		for i, id := range prev.hoverIDs {
+			ids[i] = fmt.Sprintf("%08x", uint32(id))
+		}
+		row("Hover IDs", "%s", strings.Join(ids, " > "))
+	}
+
+	if u.Header("Input") {
+		row("Mouse", "%d,%d (%+d,%+d)", input.MousePos.X, input.MousePos.Y, input.MouseDelta.X, input.MouseDelta.Y)
+		row("Buttons", "L%v R%v M%v", input.MouseDown[MouseLeft], input.MouseDown[MouseRight], input.MouseDown[MouseMiddle])
+		row("Scroll", "%d,%d", input.ScrollDelta.X, input.ScrollDelta.Y)
+		var keys []string
+		for k, down := range input.KeyDown {
+			if down {
+				keys = append(keys, keyName(k))
+			}
+		}
+		slices.Sort(keys)
+		row("Keys", "%s", strings.Join(keys, " "))
+		row("Text", "%q", input.TextInput)
+	}
+
+	if u.Header("Pools") {
+		row("Command buffer", "%d / %d", prev.stats.Commands, cap(u.commands.cmds))
+		row("Containers", "%d", len(u.containers))
+		row("Windows", "%d", u.windowPool.Len())
+		row("Tree nodes", "%d", len(u.treeNodeState))
+		row("List boxes", "%d", len(u.listBoxes))
+		row("Tab bars", "%d", len(u.tabBars))
+		row("Canvases", "%d", len(u.canvases))
+		row("Animations", "%d", len(u.anims))
+	}
+}
+
+// keyName returns a key's name for display.
+func keyName(k Key) string {
+	if k >= KeyA && k <= KeyZ {
+		return string(rune('A' + k - KeyA))
+	}
+	if int(k) < len(keyNames) && keyNames[k] != "" {
+		return keyNames[k]
+	}
+	return fmt.Sprintf("Key(%d)", int(k))
+}
+
+// debugEndFrame makes this frame's debug state the one DebugWindow shows
+// next frame.
+func (u *UI) debugEndFrame() {
+	u.debugCur.stats = u.Stats()
+	u.debugPrev, u.debugCur = u.debugCur, u.debugPrev
+	u.debugCur.clipDepth = 0
+	u.debugCur.hoverIDs = u.debugCur.hoverIDs[:0]
+}
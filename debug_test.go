@@ -0,0 +1,59 @@
+package microui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestDebugWindow_ShowsState(t *testing.T) {
+	ui := New(Config{})
+	frame := func() []string {
+		ui.BeginFrame()
+		if ui.BeginWindow("Main", types.Rect{X: 400, Y: 0, W: 200, H: 100}) {
+			ui.Button("OK")
+			ui.EndWindow()
+		}
+		ui.DebugWindow()
+		ui.EndFrame()
+		var texts []string
+		for _, cmd := range ui.RecordFrame().Commands {
+			if cmd.Kind == CmdText {
+				texts = append(texts, cmd.Text)
+			}
+		}
+		return texts
+	}
+
+	ui.MouseMove(450, 40) // Over the OK button
+	frame()
+	ui.GetContainer("Debug").SetRect(types.Rect{X: 0, Y: 0, W: 320, H: 1000}) // Everything unscrolled
+	ui.KeyDown(KeyShift)
+	texts := frame()
+
+	for _, want := range []string{"Debug", "Frame", "Commands", "Containers", "Main *", "Focus", "Input", "Shift", "Pools"} {
+		if !slices.Contains(texts, want) {
+			t.Errorf("debug window does not show %q", want)
+		}
+	}
+	if ui.debugPrev.stats.Commands == 0 {
+		t.Error("last frame's command count not recorded")
+	}
+	if ui.debugPrev.clipDepth == 0 {
+		t.Error("last frame's clip depth not recorded")
+	}
+	// The button's ID stack starts with its window
+	if ids := ui.debugPrev.hoverIDs; len(ids) == 0 || ids[0] != ui.getRawID("Main") {
+		t.Errorf("hover ID stack = %v, want it to start with the Main window", ids)
+	}
+}
+
+func TestKeyName(t *testing.T) {
+	tests := map[Key]string{KeyEnter: "Enter", KeyA: "A", KeyZ: "Z", KeySpace: "Space", Key(200): "Key(200)"}
+	for k, want := range tests {
+		if got := keyName(k); got != want {
+			t.Errorf("keyName(%d) = %q, want %q", k, got, want)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package microui
+
+import (
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/user/microui-go/types"
+)
+
+// maxDirtyRegions is how many separate regions a frame reports before they
+// are merged into their bounding box.
+const maxDirtyRegions = 32
+
+// rootSnapshot is what a root container drew in the previous frame,
+// compared against the current frame to find dirty regions.
+type rootSnapshot struct {
+	head, tail     int // Command range in UI.prevCommands
+	zindex, zlayer int
+	frame          int // Frame it was taken in
+}
+
+// DirtyRegions returns the areas of the screen that look different from
+// the previous frame, with Config.DirtyRegions set. It is updated by
+// EndFrame: the whole screen after the first frame and after SetScreenSize
+// or SetScale change it, and an empty list when nothing changed. The
+// slice is reused by the next frame.
+//
+// Changes are found by comparing each window's commands with last frame's,
+// so images and custom commands are compared by handle and payload only;
+// report content changed in place with AddDirtyRegion.
+func (u *UI) DirtyRegions() []types.Rect {
+	return u.dirty
+}
+
+// AddDirtyRegion marks r as changed in the current frame, e.g. for a
+// texture the UI draws that was updated in place.
+func (u *UI) AddDirtyRegion(r types.Rect) {
+	u.dirtyPending = append(u.dirtyPending, r)
+}
+
+// snapshotRoots remembers what the previous frame drew, after its commands
+// moved to u.prevCommands. Called from BeginFrameAt.
+func (u *UI) snapshotRoots() {
+	for _, cnt := range u.rootList {
+		cnt.last = rootSnapshot{
+			head: cnt.headIdx, tail: cnt.tailIdx,
+			zindex: cnt.zindex, zlayer: cnt.zlayer,
+			frame: u.frame - 1,
+		}
+	}
+	u.prevOverlay = append(u.prevOverlay[:0], u.overlay...)
+}
+
+// updateDirtyRegions works out DirtyRegions. Called from EndFrame.
+func (u *UI) updateDirtyRegions() {
+	u.dirty = u.dirty[:0]
+	defer func() { u.dirtyPending = u.dirtyPending[:0] }()
+	if u.dirtyAll || u.frame == 1 {
+		u.dirtyAll = false
+		u.dirty = append(u.dirty, u.screenArea())
+		return
+	}
+	for _, r := range u.dirtyPending {
+		u.addDirty(r)
+	}
+
+	if len(u.rootList) == 0 && len(u.prevRoots) == 0 {
+		u.diffCommands(u.prevCommands.cmds, u.commands.cmds)
+	}
+	for _, cnt := range u.rootList {
+		cur := u.commands.cmds[cnt.headIdx:cnt.tailIdx]
+		if cnt.last.frame != u.frame-1 {
+			u.addDirtyCommands(cur) // Opened or shown
+			continue
+		}
+		old := u.prevCommands.cmds[cnt.last.head:cnt.last.tail]
+		if cnt.zindex != cnt.last.zindex || cnt.zlayer != cnt.last.zlayer || u.windowAlpha(cnt) < 1 {
+			// Restacked or fading in: it covers others differently
+			u.addDirtyCommands(old)
+			u.addDirtyCommands(cur)
+			continue
+		}
+		u.diffCommands(old, cur)
+	}
+	for _, cnt := range u.prevRoots {
+		if !u.begunThisFrame(cnt) && cnt.last.frame == u.frame-1 {
+			u.addDirtyCommands(u.prevCommands.cmds[cnt.last.head:cnt.last.tail]) // Closed
+		}
+	}
+	for _, cnt := range u.fading {
+		u.addDirtyCommands(cnt.fadeCmds)
+	}
+	u.diffCommands(u.prevOverlay, u.overlay)
+	u.mergeDirty()
+}
+
+// diffCommands marks dirty where cur draws differently from old.
+func (u *UI) diffCommands(old, cur []Command) {
+	if len(old) != len(cur) {
+		u.addDirtyCommands(old)
+		u.addDirtyCommands(cur)
+		return
+	}
+	screen := u.screenArea()
+	clip := screen
+	for i := range cur {
+		a, b := old[i], cur[i]
+		same := sameCommand(a, b)
+		if a.Kind == CmdClip || b.Kind == CmdClip {
+			if !same {
+				// Everything after draws clipped differently
+				u.addDirtyCommands(old)
+				u.addDirtyCommands(cur)
+				return
+			}
+			clip = intersectRect(b.Rect, screen)
+			continue
+		}
+		if !same {
+			u.addDirty(u.commandArea(a, clip))
+			u.addDirty(u.commandArea(b, clip))
+		}
+	}
+}
+
+// addDirtyCommands marks the whole area cmds draw to dirty.
+func (u *UI) addDirtyCommands(cmds []Command) {
+	screen := u.screenArea()
+	clip := screen
+	for _, cmd := range cmds {
+		if cmd.Kind == CmdClip {
+			clip = intersectRect(cmd.Rect, screen)
+			continue
+		}
+		u.addDirty(u.commandArea(cmd, clip))
+	}
+}
+
+// commandArea returns the part of clip cmd draws to.
+func (u *UI) commandArea(cmd Command, clip types.Rect) types.Rect {
+	if r, ok := u.commandBounds(cmd); ok {
+		return intersectRect(r, clip)
+	}
+	return types.Rect{}
+}
+
+// addDirty adds r to the dirty regions, merging it into one that already
+// contains it.
+func (u *UI) addDirty(r types.Rect) {
+	if r.Empty() {
+		return
+	}
+	for _, d := range u.dirty {
+		if intersectRect(d, r) == r {
+			return
+		}
+	}
+	u.dirty = append(u.dirty, r)
+}
+
+// mergeDirty joins overlapping dirty regions until none overlap, and all of
+// them into one when there are too many to be worth handling separately.
+func (u *UI) mergeDirty() {
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(u.dirty); i++ {
+			for j := i + 1; j < len(u.dirty); j++ {
+				if intersectRect(u.dirty[i], u.dirty[j]).Empty() {
+					continue
+				}
+				u.dirty[i] = unionRect(u.dirty[i], u.dirty[j])
+				u.dirty = append(u.dirty[:j], u.dirty[j+1:]...)
+				merged = true
+				j--
+			}
+		}
+	}
+	if len(u.dirty) > maxDirtyRegions {
+		all := u.dirty[0]
+		for _, r := range u.dirty[1:] {
+			all = unionRect(all, r)
+		}
+		u.dirty = append(u.dirty[:0], all)
+	}
+}
+
+// screenArea returns the screen, or an unclipped area before
+// SetScreenSize.
+func (u *UI) screenArea() types.Rect {
+	if u.screen.Empty() {
+		return unclippedRect
+	}
+	return u.screen
+}
+
+// unionRect returns the smallest rect containing a and b.
+func unionRect(a, b types.Rect) types.Rect {
+	x, y := min(a.X, b.X), min(a.Y, b.Y)
+	return types.Rect{X: x, Y: y, W: max(a.X+a.W, b.X+b.W) - x, H: max(a.Y+a.H, b.Y+b.H) - y}
+}
+
+// sameCommand reports whether a and b draw the same thing.
+func sameCommand(a, b Command) bool {
+	return a.Kind == b.Kind && a.Rect == b.Rect && a.Pos == b.Pos && a.Size == b.Size &&
+		a.Text == b.Text && a.Icon == b.Icon && a.Src == b.Src && a.Slice == b.Slice &&
+		sameColor(a.Color, b.Color) && sameValue(a.Font, b.Font) &&
+		sameValue(a.Image, b.Image) && sameValue(a.Data, b.Data) && samePlot(a.Plot, b.Plot)
+}
+
+// samePlot compares plots by value.
+func samePlot(a, b *types.Plot) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Kind == b.Kind && a.Min == b.Min && a.Max == b.Max &&
+		slices.EqualFunc(a.Values, b.Values, func(x, y float64) bool { return math.Float64bits(x) == math.Float64bits(y) })
+}
+
+// sameColor compares colors by value, whatever their color model.
+func sameColor(a, b any) bool {
+	ca, oka := a.(interface{ RGBA() (r, g, b, a uint32) })
+	cb, okb := b.(interface{ RGBA() (r, g, b, a uint32) })
+	if !oka || !okb {
+		return a == nil && b == nil
+	}
+	r1, g1, b1, a1 := ca.RGBA()
+	r2, g2, b2, a2 := cb.RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
+
+// sameValue compares handles and payloads with ==, treating values that
+// can't be compared as different. Comparable types can still hold
+// uncomparable values in interface fields, so the values are checked, not
+// just their types.
+func sameValue(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	return reflect.ValueOf(a).Comparable() && reflect.ValueOf(b).Comparable() && a == b
+}
@@ -0,0 +1,109 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+var dirtyWinRect = types.Rect{X: 10, Y: 10, W: 200, H: 150}
+
+func dirtyFrame(ui *UI, show bool) {
+	ui.BeginFrame()
+	if show && ui.BeginWindow("W", dirtyWinRect) {
+		ui.Button("OK")
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+// dirtyCovers reports whether the dirty regions together cover r.
+func dirtyCovers(ui *UI, r types.Rect) bool {
+	for _, d := range ui.DirtyRegions() {
+		if intersectRect(d, r) == r {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDirtyRegions(t *testing.T) {
+	ui := New(Config{DirtyRegions: true})
+	ui.SetScreenSize(640, 480)
+	dirtyFrame(ui, true)
+	if got := ui.DirtyRegions(); len(got) != 1 || got[0] != (types.Rect{W: 640, H: 480}) {
+		t.Fatalf("first frame dirty = %v, want the whole screen", got)
+	}
+
+	dirtyFrame(ui, true)
+	if got := ui.DirtyRegions(); len(got) != 0 {
+		t.Errorf("unchanged frame dirty = %v, want none", got)
+	}
+
+	// Hovering the button redraws just the button
+	// (hover takes effect once the window is the hover root, a frame later)
+	ui.MouseMove(20, 40)
+	var got []types.Rect
+	for range 2 {
+		dirtyFrame(ui, true)
+		got = append(got, ui.DirtyRegions()...)
+	}
+	if len(got) != 1 || got[0].W > dirtyWinRect.W-8 || got[0].H > 30 || !got[0].Contains(types.Vec2{X: 20, Y: 40}) {
+		t.Errorf("hover dirty = %v, want only the button", got)
+	}
+	dirtyFrame(ui, true)
+	if got := ui.DirtyRegions(); len(got) != 0 {
+		t.Errorf("steady hover dirty = %v, want none", got)
+	}
+
+	// Moving the window dirties where it was and where it is
+	ui.GetContainer("W").SetRect(types.Rect{X: 300, Y: 200, W: 200, H: 150})
+	dirtyFrame(ui, true)
+	if !dirtyCovers(ui, dirtyWinRect) || !dirtyCovers(ui, types.Rect{X: 300, Y: 200, W: 200, H: 150}) {
+		t.Errorf("moved dirty = %v, want the old and new rects", ui.DirtyRegions())
+	}
+
+	// Closing it dirties the area it covered
+	dirtyFrame(ui, false)
+	if !dirtyCovers(ui, types.Rect{X: 300, Y: 200, W: 200, H: 150}) {
+		t.Errorf("closed dirty = %v, want its rect", ui.DirtyRegions())
+	}
+
+	ui.SetScreenSize(800, 600)
+	dirtyFrame(ui, false)
+	if got := ui.DirtyRegions(); len(got) != 1 || got[0] != (types.Rect{W: 800, H: 600}) {
+		t.Errorf("after resize dirty = %v, want the whole screen", got)
+	}
+
+	ui.AddDirtyRegion(types.Rect{X: 1, Y: 2, W: 3, H: 4})
+	dirtyFrame(ui, false)
+	if got := ui.DirtyRegions(); len(got) != 1 || got[0] != (types.Rect{X: 1, Y: 2, W: 3, H: 4}) {
+		t.Errorf("AddDirtyRegion dirty = %v, want the added rect", got)
+	}
+}
+
+func TestMergeDirty(t *testing.T) {
+	ui := New(Config{})
+	ui.dirty = []types.Rect{{X: 0, Y: 0, W: 10, H: 10}, {X: 50, Y: 50, W: 5, H: 5}, {X: 5, Y: 5, W: 10, H: 10}}
+	ui.mergeDirty()
+	if len(ui.dirty) != 2 || ui.dirty[0] != (types.Rect{W: 15, H: 15}) {
+		t.Errorf("merged = %v, want the overlapping pair joined", ui.dirty)
+	}
+}
+
+func TestDirtyRegions_UncomparablePayload(t *testing.T) {
+	// A comparable struct type holding a slice: == on it panics
+	type payload struct{ v any }
+	ui := New(Config{DirtyRegions: true})
+	for range 2 {
+		ui.BeginFrame()
+		if ui.BeginWindow("W", dirtyWinRect) {
+			ui.DrawCustom(types.Rect{X: 20, Y: 40, W: 10, H: 10}, payload{v: []int{1}})
+			ui.EndWindow()
+		}
+		ui.EndFrame()
+	}
+	if !dirtyCovers(ui, types.Rect{X: 20, Y: 40, W: 10, H: 10}) {
+		t.Error("a payload that can't be compared should count as changed")
+	}
+}
@@ -0,0 +1,433 @@
+package microui
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/user/microui-go/types"
+)
+
+// Dock zones for dropping a window onto a dock node.
+const (
+	DockNone   = iota
+	DockLeft   // Split the node; the window takes the left part
+	DockRight  // Split the node; the window takes the right part
+	DockTop    // Split the node; the window takes the top part
+	DockBottom // Split the node; the window takes the bottom part
+	DockCenter // Add the window as a tab of the node
+)
+
+// Split directions of an interior dock node.
+const (
+	dockLeaf   = iota
+	dockSplitH // Children side by side
+	dockSplitV // Children stacked
+)
+
+// dockNode is either a leaf holding tabbed windows or a split with two children.
+// Exported fields are persisted by SaveDockLayout.
+type dockNode struct {
+	Split    int         `json:"split,omitempty"`
+	Ratio    float64     `json:"ratio,omitempty"` // First child's share of the split
+	Children []*dockNode `json:"children,omitempty"`
+	Windows  []string    `json:"windows,omitempty"` // Docked window titles (leaf only)
+	Active   int         `json:"active,omitempty"`  // Visible tab (leaf only)
+
+	parent *dockNode
+	rect   types.Rect // Computed by DockSpace each frame
+}
+
+// dockSpace is a screen region windows can be docked into.
+type dockSpace struct {
+	root  *dockNode
+	rect  types.Rect
+	frame int // Last frame DockSpace was called
+}
+
+// DockSpace declares a region that windows can be docked into by dragging
+// their title bar over it. Call it each frame before the windows it hosts.
+// Docked windows fill their pane, lose their resize handle and share a pane
+// as tabs; the gaps between panes can be dragged to resize them.
+func (u *UI) DockSpace(name string, rect types.Rect) {
+	ds, ok := u.dockSpaces[name]
+	if !ok {
+		ds = &dockSpace{root: &dockNode{}}
+		u.dockSpaces[name] = ds
+	}
+	ds.rect = rect
+	ds.frame = u.frame
+	u.layoutDockNode(ds.root, rect)
+	u.updateDockSplitters(ds.root, "!docksplit:"+name)
+
+	if u.dockDrag == nil {
+		return
+	}
+	node, zone := u.dockTarget(ds)
+	if zone == DockNone {
+		return
+	}
+	if !u.input.MouseDown[int(MouseLeft)] {
+		u.dockWindow(node, zone, u.dockDrag)
+		u.dockDrag = nil
+		return
+	}
+	u.drawDockPreview(dockZoneRect(node.rect, zone))
+}
+
+// DockWindow docks the named window into the root of a dock space.
+// zone is one of DockLeft, DockRight, DockTop, DockBottom or DockCenter.
+// Use it to build an initial layout before the user rearranges it.
+func (u *UI) DockWindow(space, window string, zone int) {
+	ds, ok := u.dockSpaces[space]
+	if !ok {
+		ds = &dockSpace{root: &dockNode{}}
+		u.dockSpaces[space] = ds
+	}
+	u.undockWindow(window)
+	u.dockWindow(ds.root, zone, u.GetContainer(window))
+}
+
+// UndockWindow removes the named window from any dock space.
+func (u *UI) UndockWindow(window string) {
+	u.undockWindow(window)
+}
+
+// SaveDockLayout serializes all dock spaces to JSON.
+func (u *UI) SaveDockLayout() ([]byte, error) {
+	return json.Marshal(u.dockRoots())
+}
+
+// LoadDockLayout restores dock spaces saved by SaveDockLayout,
+// replacing any existing ones. A malformed layout is an error and leaves
+// the dock spaces as they were.
+func (u *UI) LoadDockLayout(data []byte) error {
+	var roots map[string]*dockNode
+	if err := json.Unmarshal(data, &roots); err != nil {
+		return err
+	}
+	if err := checkDockRoots(roots); err != nil {
+		return err
+	}
+	u.setDockRoots(roots)
+	return nil
+}
+
+// checkDockRoots reports the first malformed node of loaded dock trees:
+// a split without exactly two children, a leaf with children, or an
+// active tab that isn't one of the leaf's windows. Ratios are clamped to
+// the range the splitters allow.
+func checkDockRoots(roots map[string]*dockNode) error {
+	var check func(n *dockNode) error
+	check = func(n *dockNode) error {
+		switch n.Split {
+		case dockLeaf:
+			if len(n.Children) > 0 {
+				return fmt.Errorf("tabbed pane has %d child panes", len(n.Children))
+			}
+			if n.Active < 0 || n.Active >= max(len(n.Windows), 1) {
+				return fmt.Errorf("active tab %d of %d", n.Active, len(n.Windows))
+			}
+		case dockSplitH, dockSplitV:
+			if len(n.Children) != 2 || n.Children[0] == nil || n.Children[1] == nil {
+				return fmt.Errorf("split has %d child panes, want 2", len(n.Children))
+			}
+			n.Ratio = min(max(n.Ratio, 0.1), 0.9)
+			for _, c := range n.Children {
+				if err := check(c); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unknown split %d", n.Split)
+		}
+		return nil
+	}
+	for name, root := range roots {
+		if root == nil {
+			continue
+		}
+		if err := check(root); err != nil {
+			return fmt.Errorf("microui: dock space %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// dockRoots returns the root node of each dock space by name.
+func (u *UI) dockRoots() map[string]*dockNode {
+	roots := make(map[string]*dockNode, len(u.dockSpaces))
+	for name, ds := range u.dockSpaces {
+		roots[name] = ds.root
+	}
+	return roots
+}
+
+// setDockRoots replaces all dock spaces with the given trees.
+func (u *UI) setDockRoots(roots map[string]*dockNode) {
+	u.dockSpaces = make(map[string]*dockSpace, len(roots))
+	for name, root := range roots {
+		if root == nil {
+			root = &dockNode{}
+		}
+		linkDockParents(root, nil)
+		u.dockSpaces[name] = &dockSpace{root: root}
+	}
+}
+
+func linkDockParents(n, parent *dockNode) {
+	n.parent = parent
+	for _, c := range n.Children {
+		linkDockParents(c, n)
+	}
+}
+
+// findDock returns the leaf holding window, if its dock space was
+// submitted this frame.
+func (u *UI) findDock(window string) *dockNode {
+	for _, ds := range u.dockSpaces {
+		if ds.frame != u.frame {
+			continue
+		}
+		if leaf := findDockLeaf(ds.root, window); leaf != nil {
+			return leaf
+		}
+	}
+	return nil
+}
+
+func findDockLeaf(n *dockNode, window string) *dockNode {
+	if n.Split == dockLeaf {
+		if slices.Contains(n.Windows, window) {
+			return n
+		}
+		return nil
+	}
+	for _, c := range n.Children {
+		if leaf := findDockLeaf(c, window); leaf != nil {
+			return leaf
+		}
+	}
+	return nil
+}
+
+// dockGap returns the splitter width between panes.
+func (u *UI) dockGap() int {
+	return max(u.style.Spacing, 1)
+}
+
+// layoutDockNode assigns rects to n and its children.
+func (u *UI) layoutDockNode(n *dockNode, rect types.Rect) {
+	n.rect = rect
+	if n.Split == dockLeaf {
+		return
+	}
+	gap := u.dockGap()
+	a, b := rect, rect
+	if n.Split == dockSplitH {
+		a.W = int(float64(rect.W-gap) * n.Ratio)
+		b.X = rect.X + a.W + gap
+		b.W = rect.W - a.W - gap
+	} else {
+		a.H = int(float64(rect.H-gap) * n.Ratio)
+		b.Y = rect.Y + a.H + gap
+		b.H = rect.H - a.H - gap
+	}
+	u.layoutDockNode(n.Children[0], a)
+	u.layoutDockNode(n.Children[1], b)
+}
+
+// updateDockSplitters lets the gaps between panes be dragged. Each gap's
+// ID is path, the dock space and child indexes down to its split, so it
+// lasts when the tree is rebuilt, e.g. by LoadDockLayout.
+func (u *UI) updateDockSplitters(n *dockNode, path string) {
+	if n.Split == dockLeaf {
+		return
+	}
+	gap := u.dockGap()
+	a := n.Children[0].rect
+	r := types.Rect{X: a.X + a.W, Y: n.rect.Y, W: gap, H: n.rect.H}
+	if n.Split == dockSplitV {
+		r = types.Rect{X: n.rect.X, Y: a.Y + a.H, W: n.rect.W, H: gap}
+	}
+	id := u.GetID(path)
+	u.UpdateControlOpt(id, r, OptNoNav)
+	if u.input.Focus == id && u.input.MouseDown[int(MouseLeft)] {
+		var ratio float64
+		if n.Split == dockSplitH {
+			ratio = float64(u.input.MousePos.X-n.rect.X) / float64(max(n.rect.W-gap, 1))
+		} else {
+			ratio = float64(u.input.MousePos.Y-n.rect.Y) / float64(max(n.rect.H-gap, 1))
+		}
+		n.Ratio = min(max(ratio, 0.1), 0.9)
+		u.layoutDockNode(n, n.rect)
+	}
+	for i, c := range n.Children {
+		u.updateDockSplitters(c, fmt.Sprintf("%s/%d", path, i))
+	}
+}
+
+// dockTarget returns the leaf under the mouse and the zone within it.
+// The middle of a pane tabs into it; the outer thirds split it.
+func (u *UI) dockTarget(ds *dockSpace) (*dockNode, int) {
+	mp := u.input.MousePos
+	if !ds.rect.Contains(mp) {
+		return nil, DockNone
+	}
+	n := ds.root
+	for n.Split != dockLeaf {
+		if n.Children[0].rect.Contains(mp) {
+			n = n.Children[0]
+		} else if n.Children[1].rect.Contains(mp) {
+			n = n.Children[1]
+		} else {
+			return nil, DockNone // On a splitter
+		}
+	}
+	if len(n.Windows) == 0 {
+		return n, DockCenter
+	}
+
+	r := n.rect
+	fx := float64(mp.X-r.X) / float64(max(r.W, 1))
+	fy := float64(mp.Y-r.Y) / float64(max(r.H, 1))
+	switch {
+	case fx < 1.0/3 && fx <= fy && fx <= 1-fy:
+		return n, DockLeft
+	case fx > 2.0/3 && 1-fx <= fy && 1-fx <= 1-fy:
+		return n, DockRight
+	case fy < 1.0/3:
+		return n, DockTop
+	case fy > 2.0/3:
+		return n, DockBottom
+	}
+	return n, DockCenter
+}
+
+// dockZoneRect returns the area a window would occupy if dropped in zone.
+func dockZoneRect(r types.Rect, zone int) types.Rect {
+	switch zone {
+	case DockLeft:
+		r.W /= 2
+	case DockRight:
+		r.X += r.W / 2
+		r.W -= r.W / 2
+	case DockTop:
+		r.H /= 2
+	case DockBottom:
+		r.Y += r.H / 2
+		r.H -= r.H / 2
+	}
+	return r
+}
+
+// drawDockPreview highlights the drop area above every window.
+func (u *UI) drawDockPreview(r types.Rect) {
+	cnt := u.GetContainer("!dockpreview")
+	u.BringToFront(cnt)
+	cnt.rect = r
+	opt := OptNoInteract | OptNoTitle | OptNoResize | OptNoScroll | OptNoFrame
+	if u.BeginWindowOpt("!dockpreview", r, opt) {
+		sel := u.style.Colors.Selection
+		if sel == nil {
+			sel = u.style.Colors.ButtonActive
+		}
+		c := types.RGBAFromColor(sel)
+		c.A = 96
+		u.DrawRect(r, c.ToColor())
+		u.DrawBox(r, sel)
+		u.EndWindow()
+	}
+}
+
+// dockWindow docks cnt into node according to zone.
+func (u *UI) dockWindow(node *dockNode, zone int, cnt *Container) {
+	name := cnt.name
+	// Docked windows sit beneath floating ones
+	cnt.zindex = 1
+	if cnt.zpos > 0 {
+		u.restack(cnt)
+	}
+
+	if zone == DockCenter || (node.Split == dockLeaf && len(node.Windows) == 0) {
+		node.Windows = append(node.Windows, name)
+		node.Active = len(node.Windows) - 1
+		return
+	}
+
+	// Split: node's current content moves into a child next to the new pane
+	old := &dockNode{Split: node.Split, Ratio: node.Ratio, Children: node.Children,
+		Windows: node.Windows, Active: node.Active}
+	for _, c := range old.Children {
+		c.parent = old
+	}
+	added := &dockNode{Windows: []string{name}}
+	node.Windows, node.Active = nil, 0
+	node.Ratio = 0.5
+	switch zone {
+	case DockLeft, DockTop:
+		node.Children = []*dockNode{added, old}
+	default:
+		node.Children = []*dockNode{old, added}
+	}
+	if zone == DockLeft || zone == DockRight {
+		node.Split = dockSplitH
+	} else {
+		node.Split = dockSplitV
+	}
+	old.parent, added.parent = node, node
+}
+
+// undockWindow removes window from its leaf, collapsing emptied splits.
+func (u *UI) undockWindow(window string) {
+	for _, ds := range u.dockSpaces {
+		leaf := findDockLeaf(ds.root, window)
+		if leaf == nil {
+			continue
+		}
+		i := slices.Index(leaf.Windows, window)
+		leaf.Windows = slices.Delete(leaf.Windows, i, i+1)
+		if leaf.Active >= len(leaf.Windows) {
+			leaf.Active = max(len(leaf.Windows)-1, 0)
+		}
+		if len(leaf.Windows) == 0 && leaf.parent != nil {
+			// Replace the parent split with the remaining sibling
+			p := leaf.parent
+			sib := p.Children[0]
+			if sib == leaf {
+				sib = p.Children[1]
+			}
+			p.Split, p.Ratio, p.Children = sib.Split, sib.Ratio, sib.Children
+			p.Windows, p.Active = sib.Windows, sib.Active
+			for _, c := range p.Children {
+				c.parent = p
+			}
+		}
+		return
+	}
+}
+
+// drawDockTabs draws one tab per window sharing leaf in the title bar.
+func (u *UI) drawDockTabs(leaf *dockNode, titleRect types.Rect) {
+	x := titleRect.X
+	for i, name := range leaf.Windows {
+		w := u.style.Font.Width(name) + u.style.Padding.X*2
+		r := types.Rect{X: x, Y: titleRect.Y, W: min(w, titleRect.X+titleRect.W-x), H: titleRect.H}
+		if r.W <= 0 {
+			break
+		}
+		x += w + u.style.Spacing
+
+		id := u.GetID("!docktab:" + name)
+		u.UpdateControlOpt(id, r, OptNoNav)
+		if u.input.MousePressed[int(MouseLeft)] && u.input.Focus == id {
+			leaf.Active = i
+		}
+		if i == leaf.Active {
+			u.DrawFrame(r, ColorWindowBG)
+		} else if u.input.Hover == id {
+			u.DrawFrame(r, ColorButtonHover)
+		}
+		u.DrawControlText(name, r, ColorTitleText, 0)
+	}
+}
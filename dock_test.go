@@ -0,0 +1,202 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+var dockRect = types.Rect{X: 0, Y: 0, W: 400, H: 300}
+
+// dockFrame draws a dock space plus the named windows and reports which
+// windows were visible.
+func dockFrame(ui *UI, windows ...string) map[string]bool {
+	shown := map[string]bool{}
+	ui.BeginFrame()
+	ui.DockSpace("main", dockRect)
+	for i, name := range windows {
+		if ui.BeginWindow(name, types.Rect{X: 500 + i*10, Y: 50, W: 150, H: 100}) {
+			shown[name] = true
+			ui.EndWindow()
+		}
+	}
+	ui.EndFrame()
+	return shown
+}
+
+func TestDock_SplitLeftRight(t *testing.T) {
+	ui := New(Config{})
+	ui.DockWindow("main", "A", DockCenter)
+	ui.DockWindow("main", "B", DockRight)
+	shown := dockFrame(ui, "A", "B")
+
+	if !shown["A"] || !shown["B"] {
+		t.Fatalf("both docked windows should be shown, got %v", shown)
+	}
+	a, b := ui.GetContainer("A").rect, ui.GetContainer("B").rect
+	if a != (types.Rect{X: 0, Y: 0, W: 198, H: 300}) {
+		t.Errorf("A rect = %v", a)
+	}
+	if b != (types.Rect{X: 202, Y: 0, W: 198, H: 300}) {
+		t.Errorf("B rect = %v", b)
+	}
+}
+
+func TestDock_CenterTabs(t *testing.T) {
+	ui := New(Config{})
+	ui.DockWindow("main", "A", DockCenter)
+	ui.DockWindow("main", "B", DockCenter)
+	shown := dockFrame(ui, "A", "B")
+	if shown["A"] || !shown["B"] {
+		t.Fatalf("only the last docked tab should show, got %v", shown)
+	}
+
+	// Tab "A" is the first tab in B's title bar
+	ui.MouseMove(10, 10)
+	dockFrame(ui, "A", "B")
+	ui.MouseDown(10, 10, MouseLeft)
+	dockFrame(ui, "A", "B")
+	ui.MouseUp(10, 10, MouseLeft)
+	dockFrame(ui, "A", "B")
+	shown = dockFrame(ui, "A", "B")
+	if !shown["A"] || shown["B"] {
+		t.Errorf("clicking tab A should switch to it, got %v", shown)
+	}
+}
+
+func TestDock_DragToDockAndTearOff(t *testing.T) {
+	ui := New(Config{})
+	ui.DockWindow("main", "A", DockCenter)
+	dockFrame(ui, "A", "B")
+
+	// Drag B by its title bar (floating at 510,50) onto the right third of A
+	ui.MouseMove(530, 60)
+	dockFrame(ui, "A", "B")
+	ui.MouseDown(530, 60, MouseLeft)
+	dockFrame(ui, "A", "B")
+	for _, x := range []int{450, 380, 350} {
+		ui.MouseMove(x, 150)
+		dockFrame(ui, "A", "B")
+	}
+	ui.MouseUp(350, 150, MouseLeft)
+	dockFrame(ui, "A", "B")
+	dockFrame(ui, "A", "B")
+
+	if b := ui.GetContainer("B").rect; b.X != 202 || b.W != 198 {
+		t.Fatalf("B should be docked on the right, rect = %v", b)
+	}
+
+	// Drag B's title bar away to tear it off; A fills the space again
+	ui.MouseMove(300, 10)
+	dockFrame(ui, "A", "B")
+	ui.MouseDown(300, 10, MouseLeft)
+	dockFrame(ui, "A", "B")
+	ui.MouseMove(300, 60)
+	dockFrame(ui, "A", "B")
+	ui.MouseUp(300, 60, MouseLeft)
+	dockFrame(ui, "A", "B")
+	dockFrame(ui, "A", "B")
+
+	if a := ui.GetContainer("A").rect; a != dockRect {
+		t.Errorf("A should fill the dock space after tear-off, rect = %v", a)
+	}
+}
+
+func TestDock_SplitterResizes(t *testing.T) {
+	ui := New(Config{})
+	ui.DockWindow("main", "A", DockCenter)
+	ui.DockWindow("main", "B", DockRight)
+	dockFrame(ui, "A", "B")
+
+	ui.MouseMove(200, 150) // in the gap
+	dockFrame(ui, "A", "B")
+	ui.MouseDown(200, 150, MouseLeft)
+	dockFrame(ui, "A", "B")
+	ui.MouseMove(100, 150)
+	dockFrame(ui, "A", "B")
+	ui.MouseUp(100, 150, MouseLeft)
+	dockFrame(ui, "A", "B")
+
+	if a := ui.GetContainer("A").rect; a.W != 100 {
+		t.Errorf("A width = %d, want 100 after dragging the splitter", a.W)
+	}
+}
+
+func TestDock_SplitterSurvivesReload(t *testing.T) {
+	ui := New(Config{})
+	ui.DockWindow("main", "A", DockCenter)
+	ui.DockWindow("main", "B", DockRight)
+	dockFrame(ui, "A", "B")
+
+	ui.MouseMove(200, 150) // in the gap
+	dockFrame(ui, "A", "B")
+	ui.MouseDown(200, 150, MouseLeft)
+	dockFrame(ui, "A", "B")
+
+	// Rebuilding the tree mid-drag keeps the splitter's ID
+	data, err := ui.SaveDockLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.LoadDockLayout(data); err != nil {
+		t.Fatal(err)
+	}
+	ui.MouseMove(100, 150)
+	dockFrame(ui, "A", "B")
+	ui.MouseUp(100, 150, MouseLeft)
+	dockFrame(ui, "A", "B")
+
+	if a := ui.GetContainer("A").rect; a.W != 100 {
+		t.Errorf("A width = %d, want 100 after dragging the reloaded splitter", a.W)
+	}
+}
+
+func TestDock_SaveLoad(t *testing.T) {
+	ui := New(Config{})
+	ui.DockWindow("main", "A", DockCenter)
+	ui.DockWindow("main", "B", DockBottom)
+	data, err := ui.SaveDockLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ui2 := New(Config{})
+	if err := ui2.LoadDockLayout(data); err != nil {
+		t.Fatal(err)
+	}
+	dockFrame(ui2, "A", "B")
+	if b := ui2.GetContainer("B").rect; b.Y != 152 || b.H != 148 {
+		t.Errorf("restored B rect = %v, want bottom pane", b)
+	}
+
+	// Undocking after load must collapse the split (parents relinked)
+	ui2.UndockWindow("B")
+	dockFrame(ui2, "A")
+	if a := ui2.GetContainer("A").rect; a != dockRect {
+		t.Errorf("A rect = %v, want full dock space", a)
+	}
+}
+
+func TestDock_LoadMalformed(t *testing.T) {
+	for _, data := range []string{
+		`{"main": {"windows": ["A"], "active": 3}}`,
+		`{"main": {"windows": ["A"], "active": -1}}`,
+		`{"main": {"split": 1, "children": [{"windows": ["A"]}]}}`,
+		`{"main": {"split": 2, "children": [{"windows": ["A"]}, null]}}`,
+		`{"main": {"split": 7}}`,
+		`{"main": {"windows": ["A"], "children": [{}]}}`,
+	} {
+		ui := New(Config{})
+		ui.DockWindow("main", "B", DockCenter)
+		if err := ui.LoadDockLayout([]byte(data)); err == nil {
+			t.Errorf("LoadDockLayout(%s) = nil, want an error", data)
+		}
+		// The dock space is left as it was, and drawing it doesn't panic
+		if shown := dockFrame(ui, "A", "B"); !shown["B"] {
+			t.Errorf("after LoadDockLayout(%s), B isn't shown", data)
+		}
+		if b := ui.GetContainer("B").rect; b != dockRect {
+			t.Errorf("after LoadDockLayout(%s), B rect = %v, want the dock space kept", data, b)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package microui
 
 import (
+	"image/color"
 	"testing"
 
 	"github.com/user/microui-go/types"
@@ -206,3 +207,49 @@ func TestDrawFrame_PublicMethod(t *testing.T) {
 		t.Errorf("DrawFrame method should call callback once, got %d calls", callCount)
 	}
 }
+
+// windowFill returns the color of the CmdRect filling the window rect, or
+// nil if none does.
+func windowFill(ui *UI, rect types.Rect) (c color.Color) {
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdRect && cmd.Rect == rect && c == nil {
+			c = cmd.Color
+		}
+	})
+	return c
+}
+
+func TestContainer_Background(t *testing.T) {
+	rect := types.Rect{X: 0, Y: 0, W: 200, H: 150}
+	ui := New(Config{})
+	frame := func(opt int) {
+		ui.BeginFrame()
+		if ui.BeginWindowOpt("HUD", rect, opt) {
+			ui.Label("score")
+			ui.EndWindow()
+		}
+		ui.EndFrame()
+	}
+	frame(0)
+	if !sameColor(windowFill(ui, rect), ui.style.Colors.WindowBg) {
+		t.Fatalf("window fill = %v, want the style's", windowFill(ui, rect))
+	}
+
+	hud := ui.GetContainer("HUD")
+	hud.SetBackground(color.RGBA{R: 100, A: 255})
+	hud.SetBackgroundAlpha(0.5)
+	frame(0)
+	if got := types.RGBAFromColor(windowFill(ui, rect)); got.R != 50 || got.A != 127 {
+		t.Errorf("window fill = %v, want the override at half alpha", got)
+	}
+
+	hud.SetBackground(nil)
+	hud.SetBackgroundAlpha(1)
+	frame(OptNoBackground)
+	if c := windowFill(ui, rect); c != nil {
+		t.Errorf("OptNoBackground window filled with %v", c)
+	}
+	if iconRect(ui, IconClose).Empty() {
+		t.Error("OptNoBackground should keep the title bar")
+	}
+}
@@ -0,0 +1,51 @@
+package microui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DumpFrame describes the frame's draw commands as text, one command per
+// line in the order Render draws them, for golden-file tests that catch
+// layout regressions:
+//
+//	window "Main" 10,10 200x150
+//	  clip 10,10 200x150
+//	  rect 10,10 200x24 #191919ff
+//	  text 16,15 "Main" #e6e6e6ff
+//	overlay
+//	  text 0,0 "fps 60" #ffffffff
+//
+// Each window's commands follow a line with its name and rect, so a change
+// shows up in the window it happened in. Colors are written as
+// non-premultiplied #rrggbbaa whatever type they were given as, and the
+// clip that turns clipping off as "clip none". Unlike RecordFrame the
+// output holds nothing that changes from one frame to the next, such as
+// the frame number, so two runs drawing the same UI dump the same text.
+// Fading windows do dump faded colors; turn Config.Animations off in tests
+// that compare dumps.
+func (u *UI) DumpFrame() string {
+	var sb strings.Builder
+	indent := ""
+	line := func(cmd Command) {
+		sb.WriteString(indent)
+		if cmd.Kind == CmdClip && cmd.Rect == unclippedRect {
+			sb.WriteString("clip none")
+		} else {
+			sb.WriteString(recordCommand(cmd).String())
+		}
+		sb.WriteByte('\n')
+	}
+	u.eachWindow(line, func(cnt *Container) bool {
+		r := cnt.rect
+		fmt.Fprintf(&sb, "window %q %d,%d %dx%d\n", cnt.name, r.X, r.Y, r.W, r.H)
+		indent = "  "
+		return true
+	})
+	if len(u.overlay) > 0 {
+		sb.WriteString("overlay\n")
+		indent = "  "
+		u.eachOverlay(line)
+	}
+	return sb.String()
+}
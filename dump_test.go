@@ -0,0 +1,51 @@
+package microui
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func dumpFrame(ui *UI, label string) string {
+	ui.BeginFrame()
+	if ui.BeginWindow("Back", types.Rect{X: 0, Y: 0, W: 200, H: 100}) {
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Label(label)
+		ui.EndWindow()
+	}
+	if ui.BeginWindow("Front", types.Rect{X: 50, Y: 50, W: 200, H: 100}) {
+		ui.EndWindow()
+	}
+	ui.OverlayDrawRect(types.Rect{X: 1, Y: 2, W: 3, H: 4}, color.RGBA{R: 128, A: 128})
+	ui.EndFrame()
+	return ui.DumpFrame()
+}
+
+func TestDumpFrame_Format(t *testing.T) {
+	got := dumpFrame(New(Config{}), "Hello")
+	back := strings.Index(got, "window \"Back\" 0,0 200x100\n")
+	front := strings.Index(got, "window \"Front\" 50,50 200x100\n")
+	overlay := strings.Index(got, "overlay\n  clip none\n  rect 1,2 3x4 #ff000080\n")
+	if back != 0 || front < back || overlay < front {
+		t.Fatalf("want Back, Front, then the overlay with its color unpremultiplied, got:\n%s", got)
+	}
+	if !strings.Contains(got[:front], "  text ") || !strings.Contains(got[:front], `"Hello"`) {
+		t.Errorf("Back's label isn't listed under it:\n%s", got)
+	}
+}
+
+func TestDumpFrame_Stable(t *testing.T) {
+	ui := New(Config{})
+	first := dumpFrame(ui, "Hello")
+	if again := dumpFrame(ui, "Hello"); again != first {
+		t.Errorf("dump changed between identical frames:\n%s\nthen:\n%s", first, again)
+	}
+	if other := dumpFrame(New(Config{}), "Hello"); other != first {
+		t.Errorf("another UI drawing the same frame dumped:\n%s\nwant:\n%s", other, first)
+	}
+	if changed := dumpFrame(ui, "World"); changed == first {
+		t.Error("dump didn't change with the label")
+	}
+}
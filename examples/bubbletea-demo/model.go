@@ -8,6 +8,8 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/extras/console"
+	"github.com/user/microui-go/extras/palette"
 	"github.com/user/microui-go/metaballs"
 	"github.com/user/microui-go/render/bubbletea"
 	"github.com/user/microui-go/types"
@@ -48,11 +50,12 @@ type Model struct {
 
 	// Demo state
 	checks    [3]bool
+	radio     int
 	sliderVal float64
 	textBuf   []byte
 
 	// Additional demo state (matching ebiten demo)
-	logBuf       string    // Event log buffer
+	logs         *console.Console // Event log
 	numberVal    float64   // Number input value
 	sliderStep   float64   // Slider with step value
 	readOnlyBuf  []byte    // Read-only textbox buffer
@@ -83,6 +86,8 @@ type Model struct {
 	logWindowOpen        bool
 	metaballsWindowOpen  bool
 	showWindowsMenu     bool // Toggle for windows restore menu
+	commands            *palette.Palette // Command palette (Ctrl+P)
+	theme               string // Current theme name from the registry
 	wantsQuit           bool // Signal to quit application
 
 	// Window dimensions
@@ -111,6 +116,11 @@ type Model struct {
 	cachedView tea.View
 }
 
+// windowOpt is used by every demo window: windows start closed (so a
+// closed window isn't reopened) and can be collapsed to their title bar or
+// maximized, which helps fit all of them on small terminals.
+const windowOpt = microui.OptClosed | microui.OptCollapsible | microui.OptMaximizable
+
 // windowPositions defines the ideal grid layout for large terminals
 var windowPositions = []struct {
 	name string
@@ -257,14 +267,7 @@ func (m *Model) tileWindows() {
 
 // writeLog adds a message to the event log
 func (m *Model) writeLog(text string) {
-	if len(m.logBuf) > 0 {
-		m.logBuf += "\n"
-	}
-	m.logBuf += text
-	// Limit log size to prevent memory growth
-	if len(m.logBuf) > 1000 {
-		m.logBuf = m.logBuf[len(m.logBuf)-800:]
-	}
+	m.logs.Add(console.LevelInfo, text)
 }
 
 // tuiDrawFrame is a custom DrawFrame for TUI that draws backgrounds and window borders.
@@ -272,6 +275,9 @@ func (m *Model) writeLog(text string) {
 func tuiDrawFrame(ui *microui.UI, rect types.Rect, colorID int) {
 	// Draw the filled background
 	c := ui.GetColorByID(colorID)
+	if colorID == microui.ColorStatusBar {
+		c = bubbletea.StatusBarBg
+	}
 	ui.DrawRect(rect, c)
 
 	// Only draw border for window backgrounds
@@ -312,10 +318,13 @@ func NewModel(colorMode bubbletea.ColorMode) *Model {
 	style := microui.TUIStyle()
 	style.Colors = theme
 	style.Font = font
+	// Classic Turbo Vision shadow: 2 cells right, 1 down, 40% brightness
+	style.WindowShadow = microui.Shadow{Offset: types.Vec2{X: 2, Y: 1}}
 
 	ui := microui.New(microui.Config{
-		Style:     style,
-		DrawFrame: tuiDrawFrame, // Custom DrawFrame for TUI window borders
+		Style:             style,
+		DrawFrame:         tuiDrawFrame, // Custom DrawFrame for TUI window borders
+		ConstrainToScreen: true,         // Keep windows reachable on small terminals
 	})
 
 	// Enable microui debug logging to diagnose close button
@@ -342,7 +351,7 @@ func NewModel(colorMode bubbletea.ColorMode) *Model {
 	metaCfg.BallCount = 4 // Fewer balls for TUI clarity
 	metaField := metaballs.New(metaCfg)
 
-	return &Model{
+	m := &Model{
 		ui:                  ui,
 		renderer:            renderer,
 		font:                font,
@@ -352,6 +361,7 @@ func NewModel(colorMode bubbletea.ColorMode) *Model {
 		numberVal:           42.0,
 		sliderStep:          50.0,
 		readOnlyBuf:         []byte("Read-only text"),
+		logs:                console.New(500),
 		demoWindowOpen:      true,
 		inputWindowOpen:     true,
 		scrollWindowOpen:    true,
@@ -363,6 +373,7 @@ func NewModel(colorMode bubbletea.ColorMode) *Model {
 		enhancedWindowOpen:  true,
 		logWindowOpen:       true,
 		metaballsWindowOpen: true,
+		theme:               microui.ThemeBorland,
 		width:               0, // Set by WindowSizeMsg before first render
 		height:              0,
 		lastFPSUpdate:       time.Now(),
@@ -375,6 +386,21 @@ func NewModel(colorMode bubbletea.ColorMode) *Model {
 		metaHue:        0.0,
 		metaSaturation: 0.8,
 	}
+	m.commands = m.newCommands()
+	return m
+}
+
+// newCommands creates the command palette opened with Ctrl+P.
+func (m *Model) newCommands() *palette.Palette {
+	p := palette.New()
+	p.Rows = 8
+	p.Register("Cascade Windows", "", m.cascadeWindows)
+	p.Register("Tile Windows", "", m.tileWindows)
+	p.Register("Next Theme", "", m.cycleTheme)
+	p.Register("Toggle Metaballs", "", func() { m.metaballsWindowOpen = !m.metaballsWindowOpen })
+	p.Register("Clear Event Log", "", m.logs.Clear)
+	p.Register("Quit", "Ctrl+C", func() { m.wantsQuit = true })
+	return p
 }
 
 // frameTickMsg triggers a frame render
@@ -426,6 +452,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.renderer.Resize(msg.Width, msg.Height)
+		m.ui.SetScreenSize(msg.Width, msg.Height)
 
 	case tea.KeyPressMsg:
 		debugLog("KeyPress: %s", msg.String())
@@ -433,8 +460,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
-		// Handle Escape to toggle windows menu
-		if msg.String() == "esc" {
+		if msg.String() == "ctrl+p" {
+			m.commands.Open(m.ui)
+			return m, nil
+		}
+		// Handle Escape to toggle windows menu, unless it closes the palette
+		if msg.String() == "esc" && !m.commands.IsOpen(m.ui) {
 			m.showWindowsMenu = !m.showWindowsMenu
 			return m, nil
 		}
@@ -534,16 +565,15 @@ func (m *Model) View() tea.View {
 
 	// Build demo UI
 	m.buildDemoUI()
+	m.commands.Draw(m.ui)
+	m.buildStatusBar()
 
 	// End frame to finalize container command ranges
 	m.ui.EndFrame()
 
-	// Render with per-container shadows (correct z-order)
-	// For each container in z-order: draw shadow, then render container
-	m.renderWithShadows()
-
-	// Draw status bar at bottom (overwrites anything beneath)
-	m.drawStatusBar()
+	// Render each container in z-order (shadows included), then the
+	// metaballs into their window's viewport
+	m.renderContainers()
 
 	// Check if content actually changed using hash
 	contentHash := m.renderer.ContentHash()
@@ -575,11 +605,12 @@ func (m *Model) buildDemoUI() {
 
 	// Demo Window - use OptClosed to prevent auto-reopen after close button click
 	if m.demoWindowOpen {
-		if m.ui.BeginWindowOpt("Demo", m.getWindowRect("Demo"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Demo", m.getWindowRect("Demo"), windowOpt)
+		if res.Visible {
 			// Header: Test Buttons (expanded by default)
 			m.ui.LayoutRow(1, []int{-1}, 0)
 			if m.ui.HeaderEx("Test Buttons", microui.OptExpanded) {
-				m.ui.LayoutRow(2, []int{14, 14}, 1)
+				m.ui.LayoutRowWeights([]float64{1, 1}, 1)
 				if m.ui.Button("Button 1") {
 					m.writeLog("Button 1")
 					debugLog("!!! Button 1 CLICKED !!!")
@@ -597,6 +628,7 @@ func (m *Model) buildDemoUI() {
 				m.ui.Checkbox("Check 1", &m.checks[0])
 				m.ui.Checkbox("Check 2", &m.checks[1])
 				m.ui.Checkbox("Check 3", &m.checks[2])
+				m.ui.RadioGroup(&m.radio, []string{"Radio 1", "Radio 2", "Radio 3"})
 			}
 
 			// Header: Slider (expanded by default)
@@ -616,7 +648,8 @@ func (m *Model) buildDemoUI() {
 			m.ui.Checkbox("Metaballs", &m.metaballsWindowOpen)
 
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			// Close button was clicked
 			m.demoWindowOpen = false
 			debugLog("Demo window closed by user")
@@ -625,7 +658,8 @@ func (m *Model) buildDemoUI() {
 
 	// Input Window - use OptClosed to prevent auto-reopen after close button click
 	if m.inputWindowOpen {
-		if m.ui.BeginWindowOpt("Input", m.getWindowRect("Input"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Input", m.getWindowRect("Input"), windowOpt)
+		if res.Visible {
 			m.ui.LayoutRow(1, []int{-1}, 1)
 			m.ui.Label("Type here:")
 			oldLen := len(m.textBuf)
@@ -634,7 +668,8 @@ func (m *Model) buildDemoUI() {
 				debugLog("Textbox result=%d bufLen=%d content=%q", result, len(m.textBuf), string(m.textBuf))
 			}
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			// Close button was clicked
 			m.inputWindowOpen = false
 			debugLog("Input window closed by user")
@@ -643,7 +678,8 @@ func (m *Model) buildDemoUI() {
 
 	// Scroll Test Window - demonstrates both scrollbars
 	if m.scrollWindowOpen {
-		if m.ui.BeginWindowOpt("Scroll Test", m.getWindowRect("Scroll Test"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Scroll Test", m.getWindowRect("Scroll Test"), windowOpt)
+		if res.Visible {
 			// Set panel size to fill remaining window space (-1 = fill)
 			m.ui.LayoutRow(1, []int{-1}, -1)
 
@@ -660,7 +696,8 @@ func (m *Model) buildDemoUI() {
 
 			m.ui.EndPanel()
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			m.scrollWindowOpen = false
 			debugLog("Scroll Test window closed by user")
 		}
@@ -668,10 +705,12 @@ func (m *Model) buildDemoUI() {
 
 	// Color Palette Window - shows how colors render in different modes
 	if m.paletteWindowOpen {
-		if m.ui.BeginWindowOpt("Color Palette", m.getWindowRect("Color Palette"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Color Palette", m.getWindowRect("Color Palette"), windowOpt)
+		if res.Visible {
 			m.buildColorPalette()
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			m.paletteWindowOpen = false
 			debugLog("Color Palette window closed by user")
 		}
@@ -679,7 +718,8 @@ func (m *Model) buildDemoUI() {
 
 	// Box Test Window - demonstrates box-drawing characters with layout-relative positions
 	if m.boxTestWindowOpen {
-		if m.ui.BeginWindowOpt("Box Test", m.getWindowRect("Box Test"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Box Test", m.getWindowRect("Box Test"), windowOpt)
+		if res.Visible {
 			m.ui.LayoutRow(1, []int{-1}, 1)
 			m.ui.Label("Box drawing:")
 
@@ -692,7 +732,8 @@ func (m *Model) buildDemoUI() {
 			m.ui.DrawBox(rect2, boxColor)
 
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			m.boxTestWindowOpen = false
 			debugLog("Box Test window closed by user")
 		}
@@ -700,7 +741,8 @@ func (m *Model) buildDemoUI() {
 
 	// Tree & Text Window - demonstrates tree nodes and text wrapping
 	if m.treeWindowOpen {
-		if m.ui.BeginWindowOpt("Tree & Text", m.getWindowRect("Tree & Text"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Tree & Text", m.getWindowRect("Tree & Text"), windowOpt)
+		if res.Visible {
 			m.ui.LayoutRow(2, []int{18, -1}, -1)
 
 			// Left column - tree
@@ -736,7 +778,8 @@ func (m *Model) buildDemoUI() {
 			m.ui.LayoutEndColumn()
 
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			m.treeWindowOpen = false
 			debugLog("Tree & Text window closed by user")
 		}
@@ -744,7 +787,8 @@ func (m *Model) buildDemoUI() {
 
 	// Popup Demo Window
 	if m.popupWindowOpen {
-		if m.ui.BeginWindowOpt("Popup Demo", m.getWindowRect("Popup Demo"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Popup Demo", m.getWindowRect("Popup Demo"), windowOpt)
+		if res.Visible {
 			m.ui.LayoutRow(1, []int{-1}, 1)
 			m.ui.Label("Click for popup:")
 			m.ui.LayoutRow(1, []int{-1}, 1)
@@ -752,7 +796,8 @@ func (m *Model) buildDemoUI() {
 				m.ui.OpenPopup("demo_popup")
 			}
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			m.popupWindowOpen = false
 			debugLog("Popup Demo window closed by user")
 		}
@@ -760,7 +805,8 @@ func (m *Model) buildDemoUI() {
 
 	// Column Layout Window - demonstrates multi-column layouts
 	if m.columnWindowOpen {
-		if m.ui.BeginWindowOpt("Column Layout", m.getWindowRect("Column Layout"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Column Layout", m.getWindowRect("Column Layout"), windowOpt)
+		if res.Visible {
 			// Two-column layout
 			m.ui.LayoutRow(2, []int{12, -1}, 6)
 
@@ -786,7 +832,8 @@ func (m *Model) buildDemoUI() {
 			m.ui.LayoutEndColumn()
 
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			m.columnWindowOpen = false
 			debugLog("Column Layout window closed by user")
 		}
@@ -794,7 +841,8 @@ func (m *Model) buildDemoUI() {
 
 	// Enhanced Controls Window - slider with step, number, read-only textbox
 	if m.enhancedWindowOpen {
-		if m.ui.BeginWindowOpt("Enhanced Controls", m.getWindowRect("Enhanced Controls"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Enhanced Controls", m.getWindowRect("Enhanced Controls"), windowOpt)
+		if res.Visible {
 			// Slider with step
 			m.ui.LayoutRow(1, []int{-1}, 1)
 			m.ui.Label("Slider (step=10):")
@@ -811,7 +859,8 @@ func (m *Model) buildDemoUI() {
 			m.ui.TextboxOpt(&m.readOnlyBuf, 64, microui.OptNoInteract)
 
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			m.enhancedWindowOpen = false
 			debugLog("Enhanced Controls window closed by user")
 		}
@@ -819,21 +868,13 @@ func (m *Model) buildDemoUI() {
 
 	// Event Log Window - shows logged events
 	if m.logWindowOpen {
-		if m.ui.BeginWindowOpt("Event Log", m.getWindowRect("Event Log"), microui.OptClosed) {
-			// Panel for scrollable log
-			m.ui.LayoutRow(1, []int{-1}, -1)
-			m.ui.BeginPanel("LogPanel")
-			// Dense log: use Text() which now has tight line spacing
-			if len(m.logBuf) > 0 {
-				m.ui.LayoutRow(1, []int{-1}, 0)
-				m.ui.Text(m.logBuf)
-			} else {
-				m.ui.LayoutRow(1, []int{-1}, 1)
-				m.ui.Label("(interact...)")
-			}
-			m.ui.EndPanel()
+		res := m.ui.BeginWindowEx("Event Log", m.getWindowRect("Event Log"), windowOpt)
+		if res.Visible {
+			// Filter, copy and clear toolbar above a bounded, auto-scrolling log
+			m.logs.Draw(m.ui)
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			m.logWindowOpen = false
 			debugLog("Event Log window closed by user")
 		}
@@ -841,9 +882,10 @@ func (m *Model) buildDemoUI() {
 
 	// Metaballs Viewport Window - shows metaball animation through half-block characters
 	// Acts as a "porthole" into the animation - coordinates are screen-relative, not window-relative
-	// Content is rendered in renderWithShadows() after container background
+	// Content is rendered in renderContainers() after container background
 	if m.metaballsWindowOpen {
-		if m.ui.BeginWindowOpt("Metaballs", m.getWindowRect("Metaballs"), microui.OptClosed) {
+		res := m.ui.BeginWindowEx("Metaballs", m.getWindowRect("Metaballs"), windowOpt)
+		if res.Visible {
 			// 1 cell gap below title
 			m.ui.Space(1)
 
@@ -871,7 +913,8 @@ func (m *Model) buildDemoUI() {
 			m.metaViewport = m.ui.LayoutNext()
 
 			m.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			m.metaballsWindowOpen = false
 			debugLog("Metaballs window closed by user")
 		}
@@ -892,7 +935,7 @@ func (m *Model) buildDemoUI() {
 	// Windows menu (Esc to toggle)
 	if m.showWindowsMenu {
 		// Center the menu
-		menuW, menuH := 22, 22
+		menuW, menuH := 22, 23
 		menuX := (m.width - menuW) / 2
 		menuY := (m.height - menuH) / 2
 		if menuX < 0 {
@@ -974,6 +1017,9 @@ func (m *Model) buildDemoUI() {
 				m.metaballsWindowOpen = true
 				m.tileWindows()
 			}
+			if m.ui.Button("Theme: " + m.theme) {
+				m.cycleTheme()
+			}
 			m.ui.Space(1)
 			if m.ui.Button("Close") {
 				m.showWindowsMenu = false
@@ -986,6 +1032,24 @@ func (m *Model) buildDemoUI() {
 	}
 }
 
+// cycleTheme switches to the next registered theme. Only the colors are
+// taken so the TUI cell metrics are kept.
+func (m *Model) cycleTheme() {
+	names := microui.Themes()
+	next := names[0]
+	for i, name := range names {
+		if name == m.theme {
+			next = names[(i+1)%len(names)]
+			break
+		}
+	}
+	theme, _ := microui.Theme(next)
+	style := m.ui.Style()
+	style.Colors = theme.Colors
+	m.ui.SetStyle(style)
+	m.theme = next
+}
+
 // buildColorPalette draws color swatches to visualize color mode differences.
 func (m *Model) buildColorPalette() {
 	// 16 ANSI colors - these should look identical in all modes
@@ -1069,13 +1133,10 @@ func (m *Model) buildColorPalette() {
 	m.ui.DrawRect(rect, bubbletea.ShadowFg) // Dark gray
 }
 
-// renderWithShadows renders all containers with proper z-ordered shadows.
-// For each container (back to front): draw shadow, then render container.
-// This ensures shadows appear behind windows but in front of windows further back.
-func (m *Model) renderWithShadows() {
-	// Shadow factor: 0.4 = 40% brightness (60% darker)
-	const shadowFactor = 0.4
-
+// renderContainers renders all containers back to front. Each container's
+// commands start with its shadow (Style.WindowShadow), so shadows fall on
+// windows further back but under the windows in front.
+func (m *Model) renderContainers() {
 	// Get containers sorted by z-index (back to front)
 	containers := m.ui.RootContainersSorted()
 
@@ -1084,24 +1145,6 @@ func (m *Model) renderWithShadows() {
 			continue
 		}
 
-		rect := cnt.Rect()
-
-		// Draw shadow BEFORE this container's content
-		// Right shadow: 2 cells wide, starts 1 cell down from top
-		m.renderer.DrawShadow(types.Rect{
-			X: rect.X + rect.W,
-			Y: rect.Y + 1,
-			W: 2,
-			H: rect.H,
-		}, shadowFactor)
-		// Bottom shadow: stops before right shadow to avoid double-darkening corner
-		m.renderer.DrawShadow(types.Rect{
-			X: rect.X + 2,
-			Y: rect.Y + rect.H,
-			W: rect.W - 2, // Don't overlap with right shadow
-			H: 1,
-		}, shadowFactor)
-
 		// Render this container's commands
 		m.ui.RenderContainer(cnt, m.renderer)
 
@@ -1128,37 +1171,25 @@ func (m *Model) renderWithShadows() {
 	}
 }
 
-// drawStatusBar draws a status/hint bar at the bottom of the screen.
-func (m *Model) drawStatusBar() {
-	if m.height < 1 {
+// buildStatusBar adds the key hints and FPS bar along the bottom of the
+// screen.
+func (m *Model) buildStatusBar() {
+	if !m.ui.BeginStatusBar() {
 		return
 	}
-	y := m.height - 1
-
-	// Left: key hints
-	left := " Ctrl+C Quit │ Esc Windows │ Drag titles"
-	// Right: FPS counter
-	right := fmt.Sprintf("FPS:%.0f ", m.currentFPS)
-
-	// Fill entire bottom row with background first
-	for x := 0; x < m.width; x++ {
-		m.renderer.SetCellFull(x, y, ' ', bubbletea.StatusBarFg, bubbletea.StatusBarBg)
-	}
-
-	// Draw left-aligned text
-	for i, r := range left {
-		if i < m.width {
-			m.renderer.SetCellFull(i, y, r, bubbletea.StatusBarFg, bubbletea.StatusBarBg)
-		}
-	}
+	left := "Ctrl+C Quit │ Ctrl+P Commands │ Esc Windows │ Drag titles"
+	right := fmt.Sprintf("FPS:%.0f", m.currentFPS)
+	m.ui.StatusBarSection(0, m.ui.TextWidth(left))
+	m.statusText(left)
+	m.ui.StatusBarSection(microui.OptAlignRight, m.ui.TextWidth(right))
+	m.statusText(right)
+	m.ui.EndStatusBar()
+}
 
-	// Draw right-aligned FPS
-	rightStart := m.width - len(right)
-	if rightStart > len(left) { // Don't overlap with left text
-		for i, r := range right {
-			m.renderer.SetCellFull(rightStart+i, y, r, bubbletea.StatusBarFg, bubbletea.StatusBarBg)
-		}
-	}
+// statusText draws text in the status bar's colors in the next cell.
+func (m *Model) statusText(text string) {
+	r := m.ui.LayoutNext()
+	m.ui.DrawText(text, types.Vec2{X: r.X, Y: r.Y}, nil, bubbletea.StatusBarFg)
 }
 
 // handleKeyPress bridges Bubble Tea key events to microui.
@@ -1182,10 +1213,38 @@ func handleKeyPress(ui *microui.UI, msg tea.KeyPressMsg) {
 		debugLog("  -> Right")
 		ui.KeyDown(microui.KeyRight)
 		ui.KeyUp(microui.KeyRight)
+	case tea.KeyUp:
+		debugLog("  -> Up")
+		ui.KeyDown(microui.KeyUp)
+		ui.KeyUp(microui.KeyUp)
+	case tea.KeyDown:
+		debugLog("  -> Down")
+		ui.KeyDown(microui.KeyDown)
+		ui.KeyUp(microui.KeyDown)
+	case tea.KeyEscape:
+		debugLog("  -> Escape")
+		ui.KeyDown(microui.KeyEscape)
+		ui.KeyUp(microui.KeyEscape)
 	case tea.KeyEnter:
 		debugLog("  -> Enter")
 		ui.KeyDown(microui.KeyEnter)
 		ui.KeyUp(microui.KeyEnter)
+	case tea.KeyTab:
+		// Terminals send Shift+Tab as a single event
+		debugLog("  -> Tab")
+		shift := key.Mod.Contains(tea.ModShift)
+		if shift {
+			ui.KeyDown(microui.KeyShift)
+		}
+		ui.KeyDown(microui.KeyTab)
+		ui.KeyUp(microui.KeyTab)
+		if shift {
+			ui.KeyUp(microui.KeyShift)
+		}
+	case tea.KeySpace:
+		debugLog("  -> Space")
+		ui.KeyDown(microui.KeySpace)
+		ui.KeyUp(microui.KeySpace)
 	}
 	// Text input is handled in Update() after BeginFrame
 }
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"image/color"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/extras/console"
 	uirenderer "github.com/user/microui-go/render/ebiten"
 	"github.com/user/microui-go/render/ebiten/atlas"
 	"github.com/user/microui-go/types"
@@ -31,10 +33,11 @@ type Game struct {
 
 	// Demo state
 	checks     [3]bool
+	radio      int
 	bgColor    [3]float64
 	sliderVal  float64
 	clickCount int
-	logBuf     string
+	logs       *console.Console
 	lastMouse  bool
 
 	// New control state
@@ -46,10 +49,8 @@ type Game struct {
 	readOnlyBuf []byte
 	showNoTitle bool
 	showNoClose bool
-
-	// Key repeat state
-	heldKeys       map[ebiten.Key]time.Time // When each key was first pressed
-	lastRepeatTime map[ebiten.Key]time.Time // When each key last repeated
+	theme       int     // Index into microui.Themes()
+	uiScale     float64 // UI scale factor for high-DPI displays
 
 	// Metaballs background
 	metaballs       *Metaballs
@@ -95,7 +96,10 @@ func NewGame() *Game {
 	style.Font = layoutFont
 
 	ui := microui.New(microui.Config{
-		Style: style,
+		Style:             style,
+		Animations:        true,
+		ConstrainToScreen: true,
+		SmoothScroll:      true,
 	})
 
 	// Create renderer with atlas font and icon provider
@@ -109,6 +113,7 @@ func NewGame() *Game {
 
 	// Explicitly open windows (needed when using OptClosed to prevent auto-reopen)
 	ui.OpenWindow("Demo Window")
+	ui.GetContainer("Demo Window").SetMinSize(240, 300) // Minimum size like the C demo
 	ui.OpenWindow("Input Controls")
 	ui.OpenWindow("Collapsible Controls")
 	ui.OpenWindow("Popup Demo")
@@ -119,13 +124,16 @@ func NewGame() *Game {
 	ui.OpenWindow("Fixed Size")
 	ui.OpenWindow("NoTitle Window")
 
-	return &Game{
+	g := &Game{
 		ui:              ui,
 		renderer:        renderer,
 		checks:          [3]bool{true, false, true},
 		bgColor:         [3]float64{50, 50, 60},
 		sliderVal:       0.5,
+		logs:            console.New(500),
 		textboxBuf:      []byte("Edit me!"),
+		theme:           sort.SearchStrings(microui.Themes(), microui.ThemeDark),
+		uiScale:         1,
 		numberVal:       42.0,
 		numberVal2:      100.0,
 		sliderStep:      5.0,
@@ -133,8 +141,6 @@ func NewGame() *Game {
 		readOnlyBuf:     []byte("Read-only text"),
 		showNoTitle:     true,
 		showNoClose:     true,
-		heldKeys:        make(map[ebiten.Key]time.Time),
-		lastRepeatTime:  make(map[ebiten.Key]time.Time),
 		metaballs:       NewMetaballs(metaConfig),
 		enableMetaballs: true,
 		metaResolution:  4.0,
@@ -152,13 +158,13 @@ func NewGame() *Game {
 		logWindowOpen:         true,
 		enhancedWindowOpen:    true,
 	}
+	// Escape toggles the windows menu, unless a textbox is being edited
+	ui.RegisterShortcut("escape", func() { g.showWindowsMenu = !g.showWindowsMenu })
+	return g
 }
 
 func (g *Game) writeLog(text string) {
-	if len(g.logBuf) > 0 {
-		g.logBuf += "\n"
-	}
-	g.logBuf += text
+	g.logs.Add(console.LevelInfo, text)
 }
 
 func (g *Game) Update() error {
@@ -198,24 +204,12 @@ func (g *Game) Update() error {
 
 	// Handle keyboard input AFTER BeginFrame (which clears old input)
 	g.handleKeyboard()
+	g.handleGamepad()
 
 	// === Demo Window (matches C microui demo) ===
 	// Column 1, Row 1 - Main demo window
 	if g.demoWindowOpen {
 		if g.ui.BeginWindowOpt("Demo Window", types.Rect{X: 10, Y: 10, W: 280, H: 420}, microui.OptClosed) {
-			// Enforce minimum size like C demo
-		win := g.ui.GetCurrentContainer()
-		if win != nil {
-			r := win.Rect()
-			if r.W < 240 {
-				r.W = 240
-			}
-			if r.H < 300 {
-				r.H = 300
-			}
-			win.SetRect(r)
-		}
-
 		// Window Info header (collapsed by default)
 		g.ui.LayoutRow(1, []int{-1}, 0)
 		if g.ui.Header("Window Info") {
@@ -233,7 +227,8 @@ func (g *Game) Update() error {
 		// Test Buttons header (expanded by default)
 		g.ui.LayoutRow(1, []int{-1}, 0)
 		if g.ui.HeaderEx("Test Buttons", microui.OptExpanded) {
-			g.ui.LayoutRow(3, []int{86, -110, -1}, 0)
+			g.ui.LayoutRowWeights([]float64{3, 2, 2}, 0)
+			g.ui.LayoutAlign(microui.OptAlignRight)
 			g.ui.Label("Test buttons 1:")
 			if g.ui.Button("Button 1") {
 				g.writeLog("Pressed button 1")
@@ -255,6 +250,23 @@ func (g *Game) Update() error {
 			}
 		}
 
+		// Appearance header (collapsed by default)
+		g.ui.LayoutRow(1, []int{-1}, 0)
+		if g.ui.Header("Appearance") {
+			names := microui.Themes()
+			g.ui.LayoutRow(2, []int{54, -1}, 0)
+			g.ui.Label("Theme:")
+			if g.ui.Combo("theme", names, &g.theme) {
+				theme, _ := microui.Theme(names[g.theme])
+				g.ui.SetStyle(theme)
+				g.writeLog("Theme: " + names[g.theme])
+			}
+			g.ui.Label("Scale:")
+			if g.ui.SliderOpt(&g.uiScale, 1, 2, 0.25, "%.2f", 0) {
+				g.ui.SetScale(g.uiScale)
+			}
+		}
+
 		// Tree and Text header (expanded by default)
 		g.ui.LayoutRow(1, []int{-1}, 0)
 		if g.ui.HeaderEx("Tree and Text", microui.OptExpanded) {
@@ -280,7 +292,7 @@ func (g *Game) Update() error {
 				g.ui.EndTreeNode()
 			}
 			if g.ui.BeginTreeNode("Test 2") {
-				g.ui.LayoutRow(2, []int{54, 54}, 0)
+				g.ui.LayoutRowWeights([]float64{1, 1}, 0)
 				if g.ui.Button("Button 3") {
 					g.writeLog("Pressed button 3")
 				}
@@ -299,6 +311,7 @@ func (g *Game) Update() error {
 				g.ui.Checkbox("Checkbox 1", &g.checks[0])
 				g.ui.Checkbox("Checkbox 2", &g.checks[1])
 				g.ui.Checkbox("Checkbox 3", &g.checks[2])
+				g.ui.RadioGroup(&g.radio, []string{"Radio 1", "Radio 2", "Radio 3"})
 				g.ui.EndTreeNode()
 			}
 			g.ui.LayoutEndColumn()
@@ -499,7 +512,9 @@ func (g *Game) Update() error {
 	// === Window Features Demo (drag & resize) ===
 	// Column 3, Row 2
 	if g.featuresWindowOpen {
-		if g.ui.BeginWindowOpt("Window Features", types.Rect{X: 590, Y: 200, W: 280, H: 180}, microui.OptClosed) {
+		opt := microui.OptClosed | microui.OptCollapsible | microui.OptMaximizable
+		res := g.ui.BeginWindowEx("Window Features", types.Rect{X: 590, Y: 200, W: 280, H: 180}, opt)
+		if res.Visible {
 			g.ui.LayoutRow(1, []int{-1}, 0)
 
 			g.ui.Text("Drag this window by the title bar. Resize it by dragging the bottom-right corner.")
@@ -510,11 +525,16 @@ func (g *Game) Update() error {
 			g.ui.Label("- Drag corner to resize")
 			g.ui.Label("- Click to bring to front")
 			g.ui.Label("- Scroll wheel to scroll")
+			g.ui.Label("- Title buttons collapse/maximize")
 
 			g.ui.EndWindow()
-		} else {
+		}
+		if res.Closed {
 			g.featuresWindowOpen = false
 		}
+		if res.BroughtToFront {
+			g.writeLog("Window Features raised")
+		}
 	}
 
 	// === Fixed Size Window (no resize) ===
@@ -537,17 +557,8 @@ func (g *Game) Update() error {
 	// Column 3, Row 4
 	if g.logWindowOpen {
 		if g.ui.BeginWindowOpt("Event Log", types.Rect{X: 590, Y: 480, W: 280, H: 170}, microui.OptClosed) {
-		// Use a panel for scrollable log content - explicit height to fill window body
-		g.ui.LayoutRow(1, []int{-1}, 120) // Panel fills most of window body (150 - title - padding)
-		g.ui.BeginPanel("LogPanel")
-		g.ui.LayoutRow(1, []int{-1}, 0)
-		if len(g.logBuf) > 0 {
-			// Show log lines - Text handles word wrap
-			g.ui.Text(g.logBuf)
-		} else {
-			g.ui.Label("(interact with controls)")
-		}
-			g.ui.EndPanel()
+			// Filter, copy and clear toolbar above a bounded, auto-scrolling log
+			g.logs.Draw(g.ui)
 
 			g.ui.EndWindow()
 		} else {
@@ -684,205 +695,107 @@ func (g *Game) Update() error {
 		}
 	}
 
+	// Status bar along the bottom of the screen, above the windows
+	if g.ui.BeginStatusBar() {
+		hint := "ESC: Windows Menu"
+		fps := fmt.Sprintf("FPS: %.0f", ebiten.ActualFPS())
+		g.ui.StatusBarSection(0, g.ui.TextWidth(hint))
+		g.ui.Label(hint)
+		g.ui.StatusBarSection(microui.OptAlignRight, g.ui.TextWidth(fps))
+		g.ui.Label(fps)
+		g.ui.EndStatusBar()
+	}
+
 	g.ui.EndFrame()
 
 	return nil
 }
 
-// Key repeat timing constants
-const (
-	keyRepeatDelay    = 400 * time.Millisecond // Initial delay before repeat starts
-	keyRepeatInterval = 50 * time.Millisecond  // Interval between repeats
-)
-
-// handleKeyboard processes keyboard input for textbox with key repeat support
-func (g *Game) handleKeyboard() {
-	now := time.Now()
+// uiKeys maps ebiten keys to microui keys. microui repeats held keys
+// itself, so only presses and releases are reported.
+var uiKeys = map[ebiten.Key]microui.Key{
+	ebiten.KeyShift:     microui.KeyShift,
+	ebiten.KeyControl:   microui.KeyCtrl,
+	ebiten.KeyAlt:       microui.KeyAlt,
+	ebiten.KeyMeta:      microui.KeyMeta,
+	ebiten.KeyBackspace: microui.KeyBackspace,
+	ebiten.KeyEnter:     microui.KeyEnter,
+	ebiten.KeyDelete:    microui.KeyDelete,
+	ebiten.KeyEscape:    microui.KeyEscape,
+	ebiten.KeyLeft:      microui.KeyLeft,
+	ebiten.KeyRight:     microui.KeyRight,
+	ebiten.KeyHome:      microui.KeyHome,
+	ebiten.KeyEnd:       microui.KeyEnd,
+	ebiten.KeyTab:       microui.KeyTab,
+	ebiten.KeySpace:     microui.KeySpace,
+}
 
-	// Handle Escape to toggle windows menu
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		g.showWindowsMenu = !g.showWindowsMenu
+func init() {
+	for k := ebiten.KeyA; k <= ebiten.KeyZ; k++ {
+		uiKeys[k] = microui.KeyA + microui.Key(k-ebiten.KeyA)
 	}
+}
 
-	// Text input - handle initial press via AppendInputChars
-	chars := ebiten.AppendInputChars(nil)
-	for _, c := range chars {
-		g.ui.TextInput(string(c))
-	}
+// charKeys are the other keys that type characters; they are all
+// reported as microui.KeyChar so held characters repeat.
+var charKeys = []ebiten.Key{
+	ebiten.Key0, ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4,
+	ebiten.Key5, ebiten.Key6, ebiten.Key7, ebiten.Key8, ebiten.Key9,
+	ebiten.KeyMinus, ebiten.KeyEqual, ebiten.KeyBracketLeft,
+	ebiten.KeyBracketRight, ebiten.KeyBackslash, ebiten.KeySemicolon,
+	ebiten.KeyApostrophe, ebiten.KeyComma, ebiten.KeyPeriod, ebiten.KeySlash,
+	ebiten.KeyGraveAccent,
+}
 
-	// Helper for key handling with repeat support
-	handleKeyWithRepeat := func(ebitenKey ebiten.Key, muiKey microui.Key) {
+// handleKeyboard forwards key presses and typed text to the UI
+func (g *Game) handleKeyboard() {
+	// Keys before text, so microui knows which key typed it
+	for ebitenKey, muiKey := range uiKeys {
 		if inpututil.IsKeyJustPressed(ebitenKey) {
 			g.ui.KeyDown(muiKey)
-			g.heldKeys[ebitenKey] = now
-			g.lastRepeatTime[ebitenKey] = now
-		} else if ebiten.IsKeyPressed(ebitenKey) {
-			// Key is held - check for repeat
-			if pressTime, ok := g.heldKeys[ebitenKey]; ok {
-				timeSincePress := now.Sub(pressTime)
-				timeSinceRepeat := now.Sub(g.lastRepeatTime[ebitenKey])
-
-				// After initial delay, repeat at interval
-				if timeSincePress >= keyRepeatDelay && timeSinceRepeat >= keyRepeatInterval {
-					// Simulate fresh key press by releasing then pressing
-					// This is needed because KeyDown only sets KeyPressed on initial press
-					g.ui.KeyUp(muiKey)
-					g.ui.KeyDown(muiKey)
-					g.lastRepeatTime[ebitenKey] = now
-				}
-			}
 		}
 		if inpututil.IsKeyJustReleased(ebitenKey) {
 			g.ui.KeyUp(muiKey)
-			delete(g.heldKeys, ebitenKey)
-			delete(g.lastRepeatTime, ebitenKey)
 		}
 	}
-
-	// Special keys with repeat support
-	handleKeyWithRepeat(ebiten.KeyBackspace, microui.KeyBackspace)
-	handleKeyWithRepeat(ebiten.KeyEnter, microui.KeyEnter)
-	handleKeyWithRepeat(ebiten.KeyDelete, microui.KeyDelete)
-	handleKeyWithRepeat(ebiten.KeyLeft, microui.KeyLeft)
-	handleKeyWithRepeat(ebiten.KeyRight, microui.KeyRight)
-	handleKeyWithRepeat(ebiten.KeyHome, microui.KeyHome)
-	handleKeyWithRepeat(ebiten.KeyEnd, microui.KeyEnd)
-
-	// Character key repeat for text input
-	// We need to handle printable characters separately since AppendInputChars
-	// only gives us the initial press
-	g.handleCharacterRepeat(now)
-}
-
-// handleCharacterRepeat handles key repeat for printable characters
-func (g *Game) handleCharacterRepeat(now time.Time) {
-	// List of printable character keys to check for repeat
-	charKeys := []ebiten.Key{
-		ebiten.KeyA, ebiten.KeyB, ebiten.KeyC, ebiten.KeyD, ebiten.KeyE,
-		ebiten.KeyF, ebiten.KeyG, ebiten.KeyH, ebiten.KeyI, ebiten.KeyJ,
-		ebiten.KeyK, ebiten.KeyL, ebiten.KeyM, ebiten.KeyN, ebiten.KeyO,
-		ebiten.KeyP, ebiten.KeyQ, ebiten.KeyR, ebiten.KeyS, ebiten.KeyT,
-		ebiten.KeyU, ebiten.KeyV, ebiten.KeyW, ebiten.KeyX, ebiten.KeyY,
-		ebiten.KeyZ,
-		ebiten.Key0, ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4,
-		ebiten.Key5, ebiten.Key6, ebiten.Key7, ebiten.Key8, ebiten.Key9,
-		ebiten.KeySpace, ebiten.KeyMinus, ebiten.KeyEqual, ebiten.KeyBracketLeft,
-		ebiten.KeyBracketRight, ebiten.KeyBackslash, ebiten.KeySemicolon,
-		ebiten.KeyApostrophe, ebiten.KeyComma, ebiten.KeyPeriod, ebiten.KeySlash,
-		ebiten.KeyGraveAccent,
+	charHeld := false
+	for _, k := range charKeys {
+		if inpututil.IsKeyJustPressed(k) {
+			g.ui.KeyUp(microui.KeyChar) // A new press restarts the repeat
+			g.ui.KeyDown(microui.KeyChar)
+		}
+		charHeld = charHeld || ebiten.IsKeyPressed(k)
+	}
+	if !charHeld {
+		g.ui.KeyUp(microui.KeyChar)
 	}
 
-	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
-
-	for _, key := range charKeys {
-		if inpututil.IsKeyJustPressed(key) {
-			// Initial press is handled by AppendInputChars, just track timing
-			g.heldKeys[key] = now
-			g.lastRepeatTime[key] = now
-		} else if ebiten.IsKeyPressed(key) {
-			// Key is held - check for repeat
-			if pressTime, ok := g.heldKeys[key]; ok {
-				timeSincePress := now.Sub(pressTime)
-				timeSinceRepeat := now.Sub(g.lastRepeatTime[key])
-
-				if timeSincePress >= keyRepeatDelay && timeSinceRepeat >= keyRepeatInterval {
-					// Generate the character for this key
-					if char := keyToChar(key, shift); char != 0 {
-						g.ui.TextInput(string(char))
-					}
-					g.lastRepeatTime[key] = now
-				}
-			}
-		} else {
-			// Key released
-			delete(g.heldKeys, key)
-			delete(g.lastRepeatTime, key)
-		}
+	for _, c := range ebiten.AppendInputChars(nil) {
+		g.ui.TextInput(string(c))
 	}
 }
 
-// keyToChar converts an ebiten key to its character representation
-func keyToChar(key ebiten.Key, shift bool) rune {
-	// Letters
-	if key >= ebiten.KeyA && key <= ebiten.KeyZ {
-		base := 'a' + rune(key-ebiten.KeyA)
-		if shift {
-			return base - 32 // Convert to uppercase
-		}
-		return base
-	}
+// gamepadNav maps standard gamepad buttons to UI navigation.
+var gamepadNav = map[ebiten.StandardGamepadButton]microui.Nav{
+	ebiten.StandardGamepadButtonLeftTop:     microui.NavUp,
+	ebiten.StandardGamepadButtonLeftBottom:  microui.NavDown,
+	ebiten.StandardGamepadButtonLeftLeft:    microui.NavLeft,
+	ebiten.StandardGamepadButtonLeftRight:   microui.NavRight,
+	ebiten.StandardGamepadButtonRightBottom: microui.NavActivate,
+}
 
-	// Numbers and their shift symbols
-	if key >= ebiten.Key0 && key <= ebiten.Key9 {
-		if shift {
-			symbols := []rune{')', '!', '@', '#', '$', '%', '^', '&', '*', '('}
-			return symbols[key-ebiten.Key0]
+// handleGamepad drives UI focus with the D-pad and A button
+func (g *Game) handleGamepad() {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for btn, nav := range gamepadNav {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, btn) {
+				g.ui.NavInput(nav)
+			}
 		}
-		return '0' + rune(key-ebiten.Key0)
-	}
-
-	// Special characters
-	switch key {
-	case ebiten.KeySpace:
-		return ' '
-	case ebiten.KeyMinus:
-		if shift {
-			return '_'
-		}
-		return '-'
-	case ebiten.KeyEqual:
-		if shift {
-			return '+'
-		}
-		return '='
-	case ebiten.KeyBracketLeft:
-		if shift {
-			return '{'
-		}
-		return '['
-	case ebiten.KeyBracketRight:
-		if shift {
-			return '}'
-		}
-		return ']'
-	case ebiten.KeyBackslash:
-		if shift {
-			return '|'
-		}
-		return '\\'
-	case ebiten.KeySemicolon:
-		if shift {
-			return ':'
-		}
-		return ';'
-	case ebiten.KeyApostrophe:
-		if shift {
-			return '"'
-		}
-		return '\''
-	case ebiten.KeyComma:
-		if shift {
-			return '<'
-		}
-		return ','
-	case ebiten.KeyPeriod:
-		if shift {
-			return '>'
-		}
-		return '.'
-	case ebiten.KeySlash:
-		if shift {
-			return '?'
-		}
-		return '/'
-	case ebiten.KeyGraveAccent:
-		if shift {
-			return '~'
-		}
-		return '`'
 	}
-
-	return 0
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
@@ -903,32 +816,11 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 	g.renderer.SetTarget(screen)
 	g.ui.Render(g.renderer)
-
-	// Draw status bar at bottom
-	g.drawStatusBar(screen)
-}
-
-func (g *Game) drawStatusBar(screen *ebiten.Image) {
-	w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
-	barHeight := 20
-	barY := h - barHeight
-
-	// Status bar background - use window bg color from style
-	style := g.ui.Style()
-	g.renderer.DrawRect(types.Vec2{X: 0, Y: barY}, types.Vec2{X: w, Y: barHeight}, style.Colors.WindowBg)
-
-	// ESC hint on left side
-	escText := "ESC: Windows Menu"
-	g.renderer.DrawText(escText, types.Vec2{X: 8, Y: barY + 3}, nil, style.Colors.Text)
-
-	// FPS on right side
-	fpsText := fmt.Sprintf("FPS: %.0f", ebiten.ActualFPS())
-	textWidth := style.Font.Width(fpsText)
-	g.renderer.DrawText(fpsText, types.Vec2{X: w - textWidth - 8, Y: barY + 3}, nil, style.Colors.Text)
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	g.screenW = outsideWidth
 	g.screenH = outsideHeight
+	g.ui.SetScreenSize(outsideWidth, outsideHeight)
 	return outsideWidth, outsideHeight
 }
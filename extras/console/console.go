@@ -0,0 +1,336 @@
+// Package console is a log window: a ring buffer of messages drawn with a
+// color per severity, a filter box, an auto-scroll toggle, buttons to
+// copy and clear the log, and an optional command line with history.
+//
+//	logs := console.New(1000)
+//	logs.OnCommand = func(line string) { runCommand(line) }
+//	log.SetOutput(logs) // Standard log output goes to the console too
+//	...
+//	logs.Warnf("texture %s not found", name)
+//	...
+//	logs.Window(ui, "Console", types.Rect{X: 10, Y: 300, W: 400, H: 200})
+//
+// Messages can be added from any goroutine. Once the buffer is full, each
+// new message replaces the oldest.
+package console
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/types"
+)
+
+// Level is a message's severity.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = [...]string{"debug", "info", "warn", "error"}
+
+// String returns the level's name, e.g. "warn".
+func (l Level) String() string {
+	if l < 0 || int(l) >= len(levelNames) {
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// Entry is a message in the log.
+type Entry struct {
+	Time  time.Time
+	Level Level
+	Text  string
+}
+
+// historyLimit is how many commands the command line remembers.
+const historyLimit = 100
+
+// Console holds the log and the state of its controls. Create it with New.
+type Console struct {
+	// OnCommand is called with each line entered in the command line,
+	// after it is echoed to the log. The command line is hidden while it
+	// is nil.
+	OnCommand func(line string)
+
+	// AutoScroll keeps the newest message in view. It is on by default
+	// and the console's checkbox toggles it.
+	AutoScroll bool
+
+	// Colors overrides the text color of each level; nil entries use the
+	// defaults, derived from the style's text and Invalid colors.
+	Colors [LevelError + 1]color.Color
+
+	mu      sync.Mutex
+	entries []Entry // Ring buffer, oldest at start once full
+	start   int
+	limit   int
+	grew    bool // Messages were added since the last Draw
+
+	id      string // PushID scope, so consoles in one window don't clash
+	filter  []byte
+	visible []int // Entries matching filter this frame
+	input   []byte
+	history []string
+	histPos int    // Index in history being edited; len(history) for a new line
+	draft   string // The new line, while browsing history
+}
+
+// New creates a console keeping the last capacity messages (1000 if
+// capacity is 0 or less).
+func New(capacity int) *Console {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	c := &Console{AutoScroll: true, limit: capacity}
+	c.id = fmt.Sprintf("console%p", c)
+	return c
+}
+
+// Add appends a message at level.
+func (c *Console) Add(level Level, text string) {
+	e := Entry{Time: time.Now(), Level: level, Text: text}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) < c.limit {
+		c.entries = append(c.entries, e)
+	} else {
+		c.entries[c.start] = e
+		c.start = (c.start + 1) % c.limit
+	}
+	c.grew = true
+}
+
+// Debugf adds a debug message formatted like fmt.Sprintf.
+func (c *Console) Debugf(format string, args ...any) {
+	c.Add(LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// Infof adds an info message formatted like fmt.Sprintf.
+func (c *Console) Infof(format string, args ...any) {
+	c.Add(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warnf adds a warning formatted like fmt.Sprintf.
+func (c *Console) Warnf(format string, args ...any) {
+	c.Add(LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Errorf adds an error message formatted like fmt.Sprintf.
+func (c *Console) Errorf(format string, args ...any) {
+	c.Add(LevelError, fmt.Sprintf(format, args...))
+}
+
+// Write adds each line of p as an info message, so the console can be
+// the output of a log.Logger or any other io.Writer.
+func (c *Console) Write(p []byte) (int, error) {
+	for line := range bytes.Lines(p) {
+		c.Add(LevelInfo, string(bytes.TrimRight(line, "\r\n")))
+	}
+	return len(p), nil
+}
+
+// Clear removes every message.
+func (c *Console) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = c.entries[:0]
+	c.start = 0
+}
+
+// Len returns the number of messages held.
+func (c *Console) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Entries returns a copy of the messages, oldest first.
+func (c *Console) Entries() []Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Entry, len(c.entries))
+	for i := range out {
+		out[i] = c.at(i)
+	}
+	return out
+}
+
+// at returns the i'th oldest entry. c.mu must be held.
+func (c *Console) at(i int) Entry {
+	return c.entries[(c.start+i)%len(c.entries)]
+}
+
+// History returns the commands entered, oldest first.
+func (c *Console) History() []string {
+	return slices.Clone(c.history)
+}
+
+// Window draws the console in a window titled title, filling its body.
+func (c *Console) Window(ui *microui.UI, title string, rect types.Rect) {
+	if !ui.BeginWindow(title, rect) {
+		return
+	}
+	c.Draw(ui)
+	ui.EndWindow()
+}
+
+// Draw draws the console's toolbar, log and command line in the rest of
+// the current container.
+func (c *Console) Draw(ui *microui.UI) {
+	c.mu.Lock()
+	line := c.draw(ui)
+	c.mu.Unlock()
+	// Run outside the lock so the command can log
+	if line != "" {
+		c.Add(LevelInfo, "> "+line)
+		c.OnCommand(line)
+	}
+}
+
+// draw draws the console and returns the command entered, if any. c.mu
+// must be held.
+func (c *Console) draw(ui *microui.UI) string {
+	ui.PushID(c.id)
+	defer ui.PopID()
+
+	style := ui.Style()
+	font := style.Font
+	pad, spacing := style.Padding.X, style.Spacing
+	rowH := style.Size.Y + style.Padding.Y*2
+	textW := func(s string) int { return font.Width(s) + pad*2 }
+
+	// Toolbar: the filter box takes the width the other controls leave
+	autoLabel := "Auto-scroll"
+	autoW, copyW, clearW := rowH+textW(autoLabel), textW("Copy"), textW("Clear")
+	ui.LayoutRow(5, []int{textW("Filter"), -(autoW + copyW + clearW + spacing*3 + 1), autoW, copyW, clearW}, 0)
+	ui.Label("Filter")
+	ui.TextboxID("filter", &c.filter, 256, 0)
+	if ui.CheckboxID("autoscroll", autoLabel, &c.AutoScroll) && c.AutoScroll {
+		c.grew = true
+	}
+	copyLog := ui.Button("Copy")
+	if ui.Button("Clear") {
+		c.entries, c.start = c.entries[:0], 0
+	}
+
+	c.filterEntries()
+	if copyLog {
+		lines := make([]string, len(c.visible))
+		for i, e := range c.visible {
+			lines[i] = c.at(e).Text
+		}
+		ui.Clipboard().Set(strings.Join(lines, "\n"))
+	}
+
+	// The log fills the body, leaving a row for the command line
+	logH := -1
+	if c.OnCommand != nil {
+		logH -= rowH + spacing
+	}
+	ui.LayoutRow(1, []int{-1}, logH)
+	ui.BeginPanel("log")
+	if c.AutoScroll && c.grew {
+		ui.ScrollToBottom("log")
+	}
+	c.grew = false
+	ui.LayoutRow(1, []int{-1}, 0)
+	start, end := ui.ListClipper(len(c.visible), 0)
+	for _, i := range c.visible[start:end] {
+		e := c.at(i)
+		r := ui.LayoutNext()
+		pos := types.Vec2{X: r.X + pad, Y: r.Y + (r.H-font.Height())/2}
+		ui.DrawText(e.Text, pos, nil, c.color(ui, e.Level))
+	}
+	ui.EndListClipper()
+	ui.EndPanel()
+
+	if c.OnCommand == nil {
+		return ""
+	}
+	return c.commandLine(ui)
+}
+
+// filterEntries lists the entries whose text contains the filter,
+// ignoring case. c.mu must be held.
+func (c *Console) filterEntries() {
+	c.visible = c.visible[:0]
+	filter := strings.ToLower(string(c.filter))
+	for i := range c.entries {
+		if filter == "" || strings.Contains(strings.ToLower(c.at(i).Text), filter) {
+			c.visible = append(c.visible, i)
+		}
+	}
+}
+
+// color returns the text color of level.
+func (c *Console) color(ui *microui.UI, level Level) color.Color {
+	if level >= 0 && level <= LevelError && c.Colors[level] != nil {
+		return c.Colors[level]
+	}
+	colors := ui.Style().Colors
+	switch level {
+	case LevelDebug:
+		text := types.RGBAFromColor(colors.Text)
+		text.A /= 2
+		return text.ToColor()
+	case LevelWarn:
+		return color.RGBA{R: 230, G: 180, B: 40, A: 255}
+	case LevelError:
+		return colors.Invalid
+	}
+	return colors.Text
+}
+
+// commandLine draws the command line, where Up and Down step through the
+// history, and returns the line entered with Enter, if any. c.mu must be
+// held.
+func (c *Console) commandLine(ui *microui.UI) string {
+	ui.LayoutRow(1, []int{-1}, 0)
+	res := ui.TextboxID("command", &c.input, 1024, 0)
+	if res&microui.ResActive != 0 {
+		switch {
+		case ui.KeyPressed(microui.KeyUp) && c.histPos > 0:
+			if c.histPos == len(c.history) {
+				c.draft = string(c.input)
+			}
+			c.histPos--
+			c.input = append(c.input[:0], c.history[c.histPos]...)
+		case ui.KeyPressed(microui.KeyDown) && c.histPos < len(c.history):
+			c.histPos++
+			line := c.draft
+			if c.histPos < len(c.history) {
+				line = c.history[c.histPos]
+			}
+			c.input = append(c.input[:0], line...)
+		}
+	}
+	if res&microui.ResSubmit == 0 {
+		return ""
+	}
+	line := strings.TrimSpace(string(c.input))
+	c.input, c.draft = c.input[:0], ""
+	if line == "" {
+		c.histPos = len(c.history)
+		return ""
+	}
+	if n := len(c.history); n == 0 || c.history[n-1] != line {
+		c.history = append(c.history, line)
+		if len(c.history) > historyLimit {
+			c.history = c.history[len(c.history)-historyLimit:]
+		}
+	}
+	c.histPos = len(c.history)
+	return line
+}
@@ -0,0 +1,140 @@
+package console
+
+import (
+	"slices"
+	"testing"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/types"
+	"github.com/user/microui-go/uitest"
+)
+
+func texts(entries []Entry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.Text
+	}
+	return out
+}
+
+func TestConsole_RingBuffer(t *testing.T) {
+	c := New(3)
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		c.Infof("%s", s)
+	}
+	if got := texts(c.Entries()); !slices.Equal(got, []string{"c", "d", "e"}) {
+		t.Errorf("entries = %q, want the last three", got)
+	}
+	c.Clear()
+	if c.Len() != 0 {
+		t.Errorf("Len after Clear = %d, want 0", c.Len())
+	}
+}
+
+func TestConsole_Write(t *testing.T) {
+	c := New(0)
+	c.Write([]byte("first\nsecond\r\n"))
+	if got := texts(c.Entries()); !slices.Equal(got, []string{"first", "second"}) {
+		t.Errorf("entries = %q, want one per line", got)
+	}
+}
+
+func TestConsole_LevelColors(t *testing.T) {
+	c := New(0)
+	ui := microui.New(microui.Config{})
+	colors := ui.Style().Colors
+	if got := c.color(ui, LevelError); got != colors.Invalid {
+		t.Errorf("error color = %v, want the Invalid color", got)
+	}
+	if got := c.color(ui, LevelInfo); got != colors.Text {
+		t.Errorf("info color = %v, want the text color", got)
+	}
+	c.Colors[LevelInfo] = colors.Border
+	if got := c.color(ui, LevelInfo); got != colors.Border {
+		t.Errorf("overridden info color = %v, want %v", got, colors.Border)
+	}
+}
+
+// consoleRect is where consoleHarness draws the console window.
+var consoleRect = types.Rect{X: 0, Y: 0, W: 400, H: 200}
+
+func consoleHarness(t *testing.T, c *Console) *uitest.Harness {
+	return uitest.New(t, microui.Config{}, func(ui *microui.UI) {
+		c.Window(ui, "Console", consoleRect)
+	})
+}
+
+func TestConsole_FilterAndCopy(t *testing.T) {
+	c := New(0)
+	c.Infof("loading map")
+	c.Warnf("texture missing")
+	c.Errorf("map failed")
+	h := consoleHarness(t, c)
+	h.AssertVisible("texture missing")
+
+	h.ClickAt(100, 40) // The filter box, right of its label
+	h.Type("MAP")
+	h.AssertVisible("loading map")
+	h.AssertVisible("map failed")
+	h.AssertNotVisible("texture missing")
+
+	h.Click("Copy")
+	if got := h.UI.Clipboard().Get(); got != "loading map\nmap failed" {
+		t.Errorf("copied %q, want the filtered lines", got)
+	}
+}
+
+func TestConsole_AutoScroll(t *testing.T) {
+	c := New(0)
+	for i := range 50 {
+		c.Infof("line %d", i)
+	}
+	h := consoleHarness(t, c)
+	h.Frame()
+	h.AssertVisible("line 49")
+
+	h.Click("Auto-scroll")
+	c.Infof("line 50")
+	h.Frames(2)
+	h.AssertNotVisible("line 50")
+
+	h.Click("Auto-scroll")
+	h.AssertVisible("line 50")
+}
+
+func TestConsole_CommandHistory(t *testing.T) {
+	c := New(0)
+	var ran []string
+	c.OnCommand = func(line string) {
+		ran = append(ran, line)
+		c.Infof("ran %s", line)
+	}
+	h := consoleHarness(t, c)
+	h.ClickAt(200, consoleRect.H-15) // The command line along the bottom
+
+	for _, cmd := range []string{"help", "quit"} {
+		h.Type(cmd)
+		h.Press(microui.KeyEnter)
+	}
+	if !slices.Equal(ran, []string{"help", "quit"}) {
+		t.Fatalf("ran %q, want help and quit", ran)
+	}
+	h.AssertVisible("> help")
+	h.AssertVisible("ran quit")
+
+	for _, step := range []struct {
+		key  microui.Key
+		want string
+	}{
+		{microui.KeyUp, "quit"},
+		{microui.KeyUp, "help"},
+		{microui.KeyUp, "help"},
+		{microui.KeyDown, "quit"},
+		{microui.KeyDown, ""},
+	} {
+		h.Press(step.key)
+		if got := string(c.input); got != step.want {
+			t.Errorf("after %v, command line = %q, want %q", step.key, got, step.want)
+		}
+	}
+}
@@ -0,0 +1,369 @@
+// Package form builds settings and debug panels from structs. Each
+// exported field of a struct becomes a labelled control bound to it,
+// chosen by the field's type or its `ui` tag:
+//
+//	type Settings struct {
+//		Volume     float64 `ui:"slider,min=0,max=1,step=0.05"`
+//		Difficulty string  `ui:"combo,options=Easy|Normal|Hard"`
+//		Fullscreen bool
+//		PlayerName string `ui:"label=Name,maxlen=16"`
+//		Seed       int    `ui:"-"`
+//	}
+//
+//	form.Window(ui, "Settings", types.Rect{X: 20, Y: 20, W: 300, H: 240}, &settings)
+//
+// The tag starts with the kind of control, which may be left out to use
+// the default for the field's type:
+//
+//	checkbox  bool fields (the default)
+//	number    draggable number for int, uint and float fields (the default)
+//	slider    slider for int, uint and float fields
+//	textbox   string fields (the default)
+//	combo     dropdown for string fields, or int fields holding the index
+//	label     read-only text of the value (the default for other types)
+//
+// and is followed by key=value options: label (the text shown, else the
+// field name split into words), min and max (a slider's range, 0 to 1 for
+// floats and 0 to 100 for integers; numbers are clamped to either when
+// given, and a slider given only one is shown as a number), step, format
+// (a printf verb for the value), options (combo items separated by |) and
+// maxlen (a textbox's length, 256 by default). A tag of "-" hides the
+// field. Nested structs become collapsible headers.
+package form
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/types"
+)
+
+// Window draws a window titled title holding the fields of the struct v
+// points to. It returns true if a field changed this frame.
+func Window(ui *microui.UI, title string, rect types.Rect, v any) bool {
+	if !ui.BeginWindow(title, rect) {
+		return false
+	}
+	changed := Fields(ui, v)
+	ui.EndWindow()
+	return changed
+}
+
+// Fields draws the fields of the struct v points to in the current
+// container, one row each, for embedding in a window of your own. It
+// returns true if a field changed this frame. It panics if v isn't a
+// pointer to a struct or a tag is invalid.
+func Fields(ui *microui.UI, v any) bool {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("form: Fields needs a non-nil pointer to a struct, got %T", v))
+	}
+	return structFields(ui, rv.Elem())
+}
+
+// kind is the control a field is shown with.
+type kind int
+
+const (
+	kindLabel kind = iota
+	kindCheckbox
+	kindNumber
+	kindSlider
+	kindTextbox
+	kindCombo
+	kindStruct
+)
+
+var kindNames = map[string]kind{
+	"label": kindLabel, "checkbox": kindCheckbox, "number": kindNumber,
+	"slider": kindSlider, "textbox": kindTextbox, "combo": kindCombo,
+}
+
+// field is a parsed struct field.
+type field struct {
+	index    int
+	name     string // Field name, used for IDs
+	label    string
+	kind     kind
+	min, max float64 // Range, open ended where not given
+	step     float64
+	format   string
+	options  []string
+	maxLen   int
+}
+
+// fieldCache holds the parsed fields of each struct type.
+var fieldCache sync.Map // reflect.Type -> []field
+
+// fieldsOf returns the visible fields of struct type t.
+func fieldsOf(t reflect.Type) []field {
+	if fs, ok := fieldCache.Load(t); ok {
+		return fs.([]field)
+	}
+	var fs []field
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("ui")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		f, err := parseField(sf, tag)
+		if err != nil {
+			panic(fmt.Sprintf("form: %s.%s: %v", t.Name(), sf.Name, err))
+		}
+		f.index = i
+		fs = append(fs, f)
+	}
+	fieldCache.Store(t, fs)
+	return fs
+}
+
+// parseField reads a field's tag and checks it suits the field's type.
+func parseField(sf reflect.StructField, tag string) (field, error) {
+	f := field{name: sf.Name, label: words(sf.Name), kind: -1, maxLen: 256}
+	var minSet, maxSet bool
+	for i, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		key, value, isOption := strings.Cut(part, "=")
+		if !isOption {
+			k, ok := kindNames[part]
+			if i > 0 || !ok {
+				if part == "" {
+					continue
+				}
+				return f, fmt.Errorf("unknown control %q", part)
+			}
+			f.kind = k
+			continue
+		}
+		var err error
+		switch key {
+		case "label":
+			f.label = value
+		case "min":
+			f.min, err = strconv.ParseFloat(value, 64)
+			minSet = true
+		case "max":
+			f.max, err = strconv.ParseFloat(value, 64)
+			maxSet = true
+		case "step":
+			f.step, err = strconv.ParseFloat(value, 64)
+		case "format":
+			f.format = value
+		case "options":
+			f.options = strings.Split(value, "|")
+		case "maxlen":
+			f.maxLen, err = strconv.Atoi(value)
+		default:
+			return f, fmt.Errorf("unknown option %q", key)
+		}
+		if err != nil {
+			return f, fmt.Errorf("option %s: %w", key, err)
+		}
+	}
+
+	t := sf.Type
+	isInt := t.Kind() >= reflect.Int && t.Kind() <= reflect.Uintptr
+	isNum := isInt || t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+	if f.kind < 0 {
+		switch {
+		case t.Kind() == reflect.Bool:
+			f.kind = kindCheckbox
+		case t.Kind() == reflect.String && f.options != nil:
+			f.kind = kindCombo
+		case t.Kind() == reflect.String:
+			f.kind = kindTextbox
+		case isNum:
+			f.kind = kindNumber
+		case t.Kind() == reflect.Struct:
+			f.kind = kindStruct
+		default:
+			f.kind = kindLabel
+		}
+	}
+	var ok bool
+	switch f.kind {
+	case kindLabel, kindStruct:
+		ok = true
+	case kindCheckbox:
+		ok = t.Kind() == reflect.Bool
+	case kindNumber, kindSlider:
+		ok = isNum
+	case kindTextbox:
+		ok = t.Kind() == reflect.String
+	case kindCombo:
+		ok = t.Kind() == reflect.String || isInt
+	}
+	if !ok {
+		return f, fmt.Errorf("can't show a %s field with tag %q", t, tag)
+	}
+
+	if f.kind == kindSlider && !minSet && !maxSet {
+		minSet, maxSet = true, true
+		f.max = 1
+		if isInt {
+			f.max = 100
+		}
+	}
+	if f.kind == kindSlider && minSet != maxSet {
+		f.kind = kindNumber // A slider needs both ends
+	}
+	if !minSet {
+		f.min = math.Inf(-1)
+	}
+	if !maxSet {
+		f.max = math.Inf(1)
+	}
+	if f.step == 0 && isInt {
+		f.step = 1
+	}
+	if f.format == "" {
+		f.format = "%.2f"
+		if isInt {
+			f.format = "%.0f"
+		}
+	}
+	return f, nil
+}
+
+// words splits a Go identifier into words: "MaxHTTPRetries" becomes
+// "Max HTTP Retries".
+func words(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// structFields draws the fields of struct value sv.
+func structFields(ui *microui.UI, sv reflect.Value) bool {
+	fs := fieldsOf(sv.Type())
+	labelW := 0
+	if font := ui.Style().Font; font != nil {
+		for _, f := range fs {
+			if f.kind != kindStruct && f.kind != kindCheckbox {
+				labelW = max(labelW, font.Width(f.label))
+			}
+		}
+	}
+	labelW += ui.Style().Padding.X * 2
+
+	changed := false
+	for _, f := range fs {
+		fv := sv.Field(f.index)
+		if f.kind == kindStruct {
+			if ui.Header(f.label) {
+				ui.PushID(f.name)
+				changed = structFields(ui, fv) || changed
+				ui.PopID()
+			}
+			continue
+		}
+		ui.LayoutRow(2, []int{labelW, -1}, 0)
+		if f.kind == kindCheckbox {
+			ui.LayoutNext() // The checkbox shows the label
+		} else {
+			ui.Label(f.label)
+		}
+		changed = control(ui, &f, fv) || changed
+	}
+	return changed
+}
+
+// control draws the control for field f holding fv.
+func control(ui *microui.UI, f *field, fv reflect.Value) bool {
+	switch f.kind {
+	case kindCheckbox:
+		b := fv.Bool()
+		if !ui.CheckboxID(f.name, f.label, &b) {
+			return false
+		}
+		fv.SetBool(b)
+
+	case kindNumber, kindSlider:
+		x := number(fv)
+		if f.kind == kindSlider {
+			if !ui.SliderID(f.name, &x, f.min, f.max, f.step, f.format, 0) {
+				return false
+			}
+		} else {
+			if !ui.NumberID(f.name, &x, f.step, f.format, 0) {
+				return false
+			}
+			x = math.Max(f.min, math.Min(x, f.max))
+		}
+		if !setNumber(fv, x) {
+			return false
+		}
+
+	case kindTextbox:
+		buf := []byte(fv.String())
+		if ui.TextboxID(f.name, &buf, f.maxLen, 0)&microui.ResChange == 0 {
+			return false
+		}
+		fv.SetString(string(buf))
+
+	case kindCombo:
+		var i int
+		if fv.Kind() == reflect.String {
+			i = slices.Index(f.options, fv.String())
+		} else {
+			i = int(number(fv))
+		}
+		if !ui.Combo(f.label, f.options, &i) {
+			return false
+		}
+		if fv.Kind() == reflect.String {
+			fv.SetString(f.options[i])
+		} else {
+			setNumber(fv, float64(i))
+		}
+
+	default:
+		ui.Label(fmt.Sprint(fv.Interface()))
+		return false
+	}
+	return true
+}
+
+// number returns a numeric field's value as a float64.
+func number(fv reflect.Value) float64 {
+	switch {
+	case fv.CanInt():
+		return float64(fv.Int())
+	case fv.CanUint():
+		return float64(fv.Uint())
+	}
+	return fv.Float()
+}
+
+// setNumber stores x in a numeric field, rounding it for integers, and
+// reports whether the field changed.
+func setNumber(fv reflect.Value, x float64) bool {
+	old := number(fv)
+	switch {
+	case fv.CanInt():
+		fv.SetInt(int64(math.Round(x)))
+	case fv.CanUint():
+		fv.SetUint(uint64(math.Round(math.Max(x, 0))))
+	default:
+		fv.SetFloat(x)
+	}
+	return number(fv) != old
+}
@@ -0,0 +1,135 @@
+package form
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/types"
+	"github.com/user/microui-go/uitest"
+)
+
+type audio struct {
+	Muted bool
+}
+
+type settings struct {
+	Volume     float64 `ui:"slider,min=0,max=10,step=1"`
+	Difficulty string  `ui:"combo,options=Easy|Normal|Hard"`
+	Fullscreen bool
+	PlayerName string `ui:"label=Name"`
+	MaxFPS     int
+	Audio      audio
+	Seed       int `ui:"-"`
+	secret     int
+}
+
+func formHarness(t *testing.T, s *settings, changed *bool) *uitest.Harness {
+	return uitest.New(t, microui.Config{}, func(ui *microui.UI) {
+		if Window(ui, "Settings", types.Rect{X: 0, Y: 0, W: 400, H: 400}, s) {
+			*changed = true
+		}
+	})
+}
+
+func TestWindow_ShowsFields(t *testing.T) {
+	s := settings{Difficulty: "Normal", PlayerName: "ada", MaxFPS: 60}
+	var changed bool
+	h := formHarness(t, &s, &changed)
+	for _, label := range []string{"Volume", "Difficulty", "Normal", "Fullscreen", "Name", "ada", "Max FPS", "60", "Audio", "Muted"} {
+		h.AssertVisible(label)
+	}
+	h.AssertNotVisible("Seed")
+	h.AssertNotVisible("secret")
+	if changed {
+		t.Error("drawing the form reported a change")
+	}
+}
+
+func TestWindow_BindsFields(t *testing.T) {
+	s := settings{Difficulty: "Normal"}
+	var changed bool
+	h := formHarness(t, &s, &changed)
+
+	h.Click("Fullscreen")
+	if !s.Fullscreen || !changed {
+		t.Errorf("Fullscreen = %v, changed = %v after click, want true, true", s.Fullscreen, changed)
+	}
+
+	h.Click("Normal")
+	h.Click("Hard")
+	if s.Difficulty != "Hard" {
+		t.Errorf("Difficulty = %q, want \"Hard\"", s.Difficulty)
+	}
+
+	h.Click("Muted")
+	if !s.Audio.Muted {
+		t.Error("nested Muted checkbox didn't set the field")
+	}
+}
+
+func TestParseField_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		v    any
+	}{
+		{"unknown control", &struct {
+			X int `ui:"dial"`
+		}{}},
+		{"wrong type", &struct {
+			X string `ui:"slider"`
+		}{}},
+		{"bad number", &struct {
+			X int `ui:"slider,max=lots"`
+		}{}},
+		{"not a pointer", struct{}{}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Fields didn't panic", tc.name)
+				}
+			}()
+			ui := microui.New(microui.Config{})
+			ui.BeginFrame()
+			ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 200, H: 200})
+			Fields(ui, tc.v)
+		}()
+	}
+}
+
+func TestParseField_OneBound(t *testing.T) {
+	typ := reflect.TypeFor[struct {
+		Count int     `ui:"min=0"`
+		Level float64 `ui:"slider,max=5"`
+	}]()
+	count, err := parseField(typ.Field(0), typ.Field(0).Tag.Get("ui"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count.min != 0 || !math.IsInf(count.max, 1) {
+		t.Errorf("min=0 gives range %v to %v, want 0 to +Inf", count.min, count.max)
+	}
+	level, err := parseField(typ.Field(1), typ.Field(1).Tag.Get("ui"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level.kind != kindNumber || !math.IsInf(level.min, -1) || level.max != 5 {
+		t.Errorf("slider,max=5 gives kind %d, range %v to %v; want a number up to 5", level.kind, level.min, level.max)
+	}
+}
+
+func TestWords(t *testing.T) {
+	for in, want := range map[string]string{
+		"Volume":         "Volume",
+		"PlayerName":     "Player Name",
+		"MaxFPS":         "Max FPS",
+		"MaxHTTPRetries": "Max HTTP Retries",
+		"Level2Boss":     "Level2 Boss",
+	} {
+		if got := words(in); got != want {
+			t.Errorf("words(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Package inspector shows arbitrary Go values as a tree of editable
+// properties, e.g. to tune game entities while the game runs:
+//
+//	if ui.BeginWindow("Inspector", types.Rect{X: 10, Y: 10, W: 320, H: 400}) {
+//		inspector.Inspect(ui, "Player", &player)
+//		ui.EndWindow()
+//	}
+//
+// Structs, slices, arrays and maps become tree nodes, and their leaves a
+// row of name and value. Values reached through a pointer or a map can be
+// edited: booleans with a checkbox, numbers with a draggable number field
+// and strings with a text box. Unexported fields, values behind interfaces
+// and other types are shown read-only.
+package inspector
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+
+	microui "github.com/user/microui-go"
+)
+
+// Inspect draws v under a tree node labelled label, expanded at first. v
+// should be a pointer for its values to be editable. It returns true if
+// a value changed this frame.
+func Inspect(ui *microui.UI, label string, v any) bool {
+	in := inspector{ui: ui, visited: make(map[uintptr]bool)}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() && isTree(rv.Elem()) {
+		in.visited[rv.Pointer()] = true
+		rv = rv.Elem()
+	}
+	if !isTree(rv) {
+		return in.value(label, rv)
+	}
+	if !ui.BeginTreeNodeEx(label, microui.OptExpanded) {
+		return false
+	}
+	changed := in.children(rv)
+	ui.EndTreeNode()
+	return changed
+}
+
+// inspector walks one Inspect call's value.
+type inspector struct {
+	ui      *microui.UI
+	visited map[uintptr]bool // Pointers on the current path, to stop at cycles
+}
+
+// isTree reports whether v is shown as a tree node rather than a row.
+func isTree(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Array:
+		return true
+	case reflect.Slice, reflect.Map:
+		return !v.IsNil()
+	}
+	return false
+}
+
+// value draws v named name: a tree node for containers, else a row.
+func (in *inspector) value(name string, v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		in.row(name, "nil")
+		return false
+	case reflect.Pointer:
+		if v.IsNil() {
+			in.row(name, "nil")
+			return false
+		}
+		p := v.Pointer()
+		if in.visited[p] {
+			in.row(name, "(cycle)")
+			return false
+		}
+		in.visited[p] = true
+		defer delete(in.visited, p)
+		return in.value(name, v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			in.row(name, "nil")
+			return false
+		}
+		return in.value(name, v.Elem())
+	}
+	if !isTree(v) {
+		return in.leaf(name, v)
+	}
+	if !in.ui.BeginTreeNode(name) {
+		return false
+	}
+	changed := in.children(v)
+	in.ui.EndTreeNode()
+	return changed
+}
+
+// children draws the fields, elements or entries of container v.
+func (in *inspector) children(v reflect.Value) bool {
+	changed := false
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := range v.NumField() {
+			changed = in.value(t.Field(i).Name, v.Field(i)) || changed
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			changed = in.value(fmt.Sprintf("[%d]", i), v.Index(i)) || changed
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, compareKeys)
+		// Map entries can't be set in place, so a copy is edited and
+		// stored back
+		editable := v.CanInterface()
+		for _, k := range keys {
+			e := v.MapIndex(k)
+			if editable {
+				c := reflect.New(e.Type()).Elem()
+				c.Set(e)
+				e = c
+			}
+			if in.value(fmt.Sprint(k), e) && editable {
+				v.SetMapIndex(k, e)
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// compareKeys orders map keys: numbers and strings by value, others by
+// their printed form.
+func compareKeys(a, b reflect.Value) int {
+	switch {
+	case a.CanInt():
+		return cmp.Compare(a.Int(), b.Int())
+	case a.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint())
+	case a.CanFloat():
+		return cmp.Compare(a.Float(), b.Float())
+	case a.Kind() == reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// row starts a row for name, showing text in the value column unless it
+// is empty.
+func (in *inspector) row(name, text string) {
+	in.ui.LayoutRowWeights([]float64{2, 3}, 0)
+	in.ui.Label(name)
+	if text != "" {
+		in.ui.Label(text)
+	}
+}
+
+// leaf draws a row for v with a control to edit it if it can be set.
+func (in *inspector) leaf(name string, v reflect.Value) bool {
+	if !v.CanSet() {
+		in.row(name, fmt.Sprint(v))
+		return false
+	}
+	in.row(name, "")
+	ui := in.ui
+	switch {
+	case v.Kind() == reflect.Bool:
+		b := v.Bool()
+		if !ui.CheckboxID(name, "", &b) {
+			return false
+		}
+		v.SetBool(b)
+	case v.CanInt(), v.CanUint():
+		var x float64
+		if v.CanInt() {
+			x = float64(v.Int())
+		} else {
+			x = float64(v.Uint())
+		}
+		if !ui.NumberID(name, &x, 1, "%.0f", 0) {
+			return false
+		}
+		if v.CanInt() {
+			v.SetInt(int64(math.Round(x)))
+		} else {
+			v.SetUint(uint64(math.Round(math.Max(x, 0))))
+		}
+	case v.CanFloat():
+		x := v.Float()
+		if !ui.NumberID(name, &x, 0.01, "%.3f", 0) {
+			return false
+		}
+		v.SetFloat(x)
+	case v.Kind() == reflect.String:
+		buf := []byte(v.String())
+		if ui.TextboxID(name, &buf, 1024, 0)&microui.ResChange == 0 {
+			return false
+		}
+		v.SetString(string(buf))
+	default:
+		ui.Label(fmt.Sprint(v))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package inspector
+
+import (
+	"testing"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/types"
+	"github.com/user/microui-go/uitest"
+)
+
+type stats struct {
+	HP    int
+	Speed float64
+}
+
+type entity struct {
+	Name    string
+	Visible bool
+	Stats   stats
+	Tags    []string
+	Counts  map[string]int
+	Parent  *entity
+	secret  int
+}
+
+func inspectHarness(t *testing.T, e *entity, changed *bool) *uitest.Harness {
+	return uitest.New(t, microui.Config{}, func(ui *microui.UI) {
+		if ui.BeginWindow("Inspector", types.Rect{X: 0, Y: 0, W: 400, H: 500}) {
+			if Inspect(ui, "Player", e) {
+				*changed = true
+			}
+			ui.EndWindow()
+		}
+	})
+}
+
+func TestInspect_ShowsTree(t *testing.T) {
+	e := &entity{Name: "hero", Stats: stats{HP: 42}, Tags: []string{"fast"}, Counts: map[string]int{"kills": 7}, secret: 99}
+	e.Parent = e
+	var changed bool
+	h := inspectHarness(t, e, &changed)
+	for _, label := range []string{"Player", "Name", "hero", "Visible", "Stats", "Tags", "Counts", "Parent", "secret", "99"} {
+		h.AssertVisible(label)
+	}
+	// Nested nodes start collapsed
+	h.AssertNotVisible("HP")
+
+	h.Click("Stats")
+	h.AssertVisible("HP")
+	h.AssertVisible("42")
+	h.Click("Counts")
+	h.AssertVisible("kills")
+	h.AssertVisible("7")
+	h.Click("Parent")
+	h.AssertVisible("(cycle)")
+	if changed {
+		t.Error("expanding nodes reported a change")
+	}
+}
+
+func TestInspect_EditsLeaves(t *testing.T) {
+	e := &entity{Counts: map[string]int{"kills": 7}}
+	var changed bool
+	h := inspectHarness(t, e, &changed)
+
+	it, ok := h.Find("Visible")
+	if !ok {
+		t.Fatal("no Visible row")
+	}
+	// The checkbox fills the value column
+	r := it.Visible()
+	h.ClickAt(300, r.Y+r.H/2)
+	if !e.Visible || !changed {
+		t.Errorf("Visible = %v, changed = %v, want true, true", e.Visible, changed)
+	}
+}
+
+func TestInspect_EditsMapEntries(t *testing.T) {
+	counts := map[string]bool{"on": false}
+	var changed bool
+	h := uitest.New(t, microui.Config{}, func(ui *microui.UI) {
+		if ui.BeginWindow("Inspector", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+			if Inspect(ui, "Flags", counts) {
+				changed = true
+			}
+			ui.EndWindow()
+		}
+	})
+	it, ok := h.Find("on")
+	if !ok {
+		t.Fatal("no map entry row")
+	}
+	r := it.Visible()
+	h.ClickAt(300, r.Y+r.H/2)
+	if !counts["on"] || !changed {
+		t.Errorf("counts[on] = %v, changed = %v, want true, true", counts["on"], changed)
+	}
+}
+
+func TestInspect_ReadOnlyWithoutPointer(t *testing.T) {
+	var changed bool
+	h := uitest.New(t, microui.Config{}, func(ui *microui.UI) {
+		if ui.BeginWindow("Inspector", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+			changed = Inspect(ui, "Stats", stats{HP: 5}) || changed
+			ui.EndWindow()
+		}
+	})
+	it, ok := h.Find("5")
+	if !ok {
+		t.Fatal("no HP value")
+	}
+	r := it.Visible()
+	h.ClickAt(r.X+1, r.Y+r.H/2)
+	if changed {
+		t.Error("a value passed by copy was edited")
+	}
+}
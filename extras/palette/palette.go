@@ -0,0 +1,299 @@
+// Package palette is a command palette: a modal dialog, opened with Ctrl+P
+// (Cmd+P on macOS), listing the application's commands filtered by what is
+// typed. It is used from the keyboard alone: type part of a name, step
+// through the matches with Up and Down, run one with Enter, or close the
+// dialog with Escape.
+//
+//	p := palette.New()
+//	p.Register("Save", "Ctrl+S", save)
+//	p.Register("Toggle Dark Mode", "", toggleTheme)
+//	...
+//	p.Draw(ui) // Every frame, after the application's windows
+//
+// Matching is fuzzy: the typed characters must appear in the name in order,
+// not next to each other, so "tdm" finds "Toggle Dark Mode". Matches at
+// the start of words and runs of characters rank first.
+package palette
+
+import (
+	"image/color"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/types"
+)
+
+// Command is an entry of the palette.
+type Command struct {
+	Name     string
+	Shortcut string // Shown beside the name, e.g. "Ctrl+S"; only a hint
+	Run      func()
+}
+
+// match is a command that matches the query.
+type match struct {
+	index     int   // Index in Palette.commands
+	score     int   // Higher is better
+	positions []int // Byte offsets of the matched characters in the name
+}
+
+// Palette holds the commands and the state of the dialog. Create it with
+// New.
+type Palette struct {
+	// Title names the dialog's window; "Commands" by default. Palettes
+	// drawn with the same UI need different titles.
+	Title string
+
+	// Rows is how many commands the list shows before it scrolls; 10 by
+	// default.
+	Rows int
+
+	commands []Command
+	query    []byte
+	matches  []match
+	selected int  // Index in matches
+	opened   bool // Opened since the last Draw; scroll the list to the top
+	reveal   bool // Scroll the selected row into view
+}
+
+// New creates an empty palette.
+func New() *Palette {
+	return &Palette{Title: "Commands", Rows: 10}
+}
+
+// Register adds a command. shortcut is shown beside its name and may be
+// empty; binding the key is up to the application.
+func (p *Palette) Register(name, shortcut string, run func()) {
+	p.commands = append(p.commands, Command{Name: name, Shortcut: shortcut, Run: run})
+}
+
+// Commands returns the registered commands in the order they were added.
+func (p *Palette) Commands() []Command {
+	return slices.Clone(p.commands)
+}
+
+// Open opens the palette with an empty query.
+func (p *Palette) Open(ui *microui.UI) {
+	p.query = p.query[:0]
+	p.selected = 0
+	p.opened = true
+	ui.GetContainer(p.Title).SetRect(p.rect(ui))
+	ui.OpenModal(p.Title)
+}
+
+// Close closes the palette.
+func (p *Palette) Close(ui *microui.UI) {
+	ui.CloseModal(p.Title)
+}
+
+// IsOpen reports whether the palette is open.
+func (p *Palette) IsOpen(ui *microui.UI) bool {
+	return ui.GetContainer(p.Title).Open()
+}
+
+// rect returns where the dialog opens: centered near the top of the
+// screen, wide enough for about 60 characters.
+func (p *Palette) rect(ui *microui.UI) types.Rect {
+	style := ui.Style()
+	rowH := style.Size.Y + style.Padding.Y*2
+	w := style.Font.Width("M")*60 + style.Padding.X*4
+	h := style.TitleHeight + style.BorderWidth*2 + style.Padding.Y*4 + (rowH+style.Spacing)*(max(p.Rows, 1)+1)
+	screen := ui.ScreenSize()
+	if screen.X <= 0 || screen.Y <= 0 {
+		return types.Rect{X: 40, Y: 40, W: w, H: h}
+	}
+	w, h = min(w, screen.X), min(h, screen.Y)
+	return types.Rect{X: (screen.X - w) / 2, Y: (screen.Y - h) / 8, W: w, H: h}
+}
+
+// Draw opens or closes the palette on Ctrl+P and draws it while open. A
+// command chosen with Enter or a click runs after the dialog closes.
+func (p *Palette) Draw(ui *microui.UI) {
+	if ui.KeyMods()&(microui.ModCtrl|microui.ModMeta) != 0 && ui.KeyPressed(microui.KeyP) {
+		if p.IsOpen(ui) {
+			p.Close(ui)
+		} else {
+			p.Open(ui)
+		}
+	}
+	if !ui.BeginModal(p.Title, p.rect(ui)) {
+		return
+	}
+	run := p.draw(ui)
+	ui.EndModal()
+	if run != nil {
+		p.Close(ui)
+		run()
+	}
+}
+
+// draw draws the query and the list of matches, returning the command to
+// run, if any.
+func (p *Palette) draw(ui *microui.UI) func() {
+	style := ui.Style()
+
+	ui.LayoutRow(1, []int{-1}, 0)
+	// The query keeps focus while the palette is open, so typing always
+	// filters
+	ui.SetFocus(ui.GetID("query"))
+	res := ui.TextboxID("query", &p.query, 256, 0)
+	if res&microui.ResChange != 0 {
+		p.selected = 0 // The best match of the new query
+	}
+	p.filter()
+
+	switch {
+	case ui.KeyPressed(microui.KeyUp):
+		p.move(-1)
+	case ui.KeyPressed(microui.KeyDown):
+		p.move(1)
+	case ui.KeyPressed(microui.KeyPageUp):
+		p.move(-max(p.Rows-1, 1))
+	case ui.KeyPressed(microui.KeyPageDown):
+		p.move(max(p.Rows-1, 1))
+	}
+	if p.opened {
+		p.opened = false
+		p.reveal = true
+		ui.ScrollTo("list", types.Vec2{})
+	}
+
+	var run func()
+	if res&microui.ResSubmit != 0 && len(p.matches) > 0 {
+		run = p.commandRun(p.matches[p.selected].index)
+	}
+
+	ui.LayoutRow(1, []int{-1}, -1)
+	ui.BeginPanel("list")
+	ui.LayoutRow(1, []int{-1}, 0)
+	if len(p.matches) == 0 {
+		ui.Label("No matching commands")
+	}
+	highlight := style.Colors.FocusRing
+	if highlight == nil {
+		highlight = style.Colors.Text
+	}
+	hint := types.RGBAFromColor(style.Colors.Text)
+	hint.A /= 2
+	for i, m := range p.matches {
+		cmd := p.commands[m.index]
+		r := ui.LayoutNext()
+		hovered := ui.MouseOver(r)
+		switch {
+		case i == p.selected:
+			ui.DrawFrame(r, microui.ColorButtonFocus)
+		case hovered:
+			ui.DrawFrame(r, microui.ColorButtonHover)
+		}
+		if hovered && ui.MousePressed(microui.MouseLeft) {
+			p.selected = i
+			run = p.commandRun(m.index)
+		}
+		if i == p.selected && p.reveal {
+			ui.EnsureVisible(r)
+			p.reveal = false
+		}
+		drawName(ui, cmd.Name, m.positions, r, highlight)
+		if cmd.Shortcut != "" {
+			pos := types.Vec2{
+				X: r.X + r.W - style.Font.Width(cmd.Shortcut) - style.Padding.X,
+				Y: r.Y + (r.H-style.Font.Height())/2,
+			}
+			ui.DrawText(cmd.Shortcut, pos, nil, hint.ToColor())
+		}
+	}
+	ui.EndPanel()
+	return run
+}
+
+// commandRun returns the function that runs command i, or a no-op if it
+// has none, so choosing it still closes the palette.
+func (p *Palette) commandRun(i int) func() {
+	if run := p.commands[i].Run; run != nil {
+		return run
+	}
+	return func() {}
+}
+
+// drawName draws a command name in r with its matched characters in
+// highlight.
+func drawName(ui *microui.UI, name string, positions []int, r types.Rect, highlight color.Color) {
+	style := ui.Style()
+	font := style.Font
+	pos := types.Vec2{X: r.X + style.Padding.X, Y: r.Y + (r.H-font.Height())/2}
+	ui.DrawText(name, pos, nil, style.Colors.Text)
+	for _, i := range positions {
+		_, n := utf8.DecodeRuneInString(name[i:])
+		ui.DrawText(name[i:i+n], types.Vec2{X: pos.X + font.Width(name[:i]), Y: pos.Y}, nil, highlight)
+	}
+}
+
+// move moves the selection by delta matches, stopping at either end.
+func (p *Palette) move(delta int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	p.selected = max(0, min(p.selected+delta, len(p.matches)-1))
+	p.reveal = true
+}
+
+// filter lists the commands matching the query, best first, keeping the
+// selection within them.
+func (p *Palette) filter() {
+	p.matches = p.matches[:0]
+	query := string(p.query)
+	for i, cmd := range p.commands {
+		if score, positions, ok := fuzzyMatch(query, cmd.Name); ok {
+			p.matches = append(p.matches, match{index: i, score: score, positions: positions})
+		}
+	}
+	slices.SortStableFunc(p.matches, func(a, b match) int { return b.score - a.score })
+	p.selected = max(0, min(p.selected, len(p.matches)-1))
+}
+
+// fuzzyMatch reports whether the characters of query appear in name in
+// order, ignoring case, and scores the match: each character counts, more
+// at the start of a word or right after the previous match, and less the
+// further it is from the previous match. positions are the byte offsets of
+// the matched characters in name.
+func fuzzyMatch(query, name string) (score int, positions []int, ok bool) {
+	q := []rune(query)
+	qi := 0
+	prev := rune(' ')
+	last := -2 // Rune index of the previous match
+	ri := 0
+	for i, r := range name {
+		if qi < len(q) && unicode.ToLower(r) == unicode.ToLower(q[qi]) {
+			score++
+			if ri == last+1 {
+				score += 6
+			} else if qi > 0 {
+				score -= min(ri-last-1, 3)
+			}
+			if wordStart(prev, r) {
+				score += 8
+			}
+			positions = append(positions, i)
+			last = ri
+			qi++
+		}
+		prev = r
+		ri++
+	}
+	if qi < len(q) {
+		return 0, nil, false
+	}
+	return score, positions, true
+}
+
+// wordStart reports whether r, following prev, starts a word: it follows
+// a space or punctuation, or is an upper-case letter after a lower-case
+// one.
+func wordStart(prev, r rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(r)
+}
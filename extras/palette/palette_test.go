@@ -0,0 +1,114 @@
+package palette
+
+import (
+	"slices"
+	"testing"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/uitest"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	if _, _, ok := fuzzyMatch("tdm", "Toggle Dark Mode"); !ok {
+		t.Error(`"tdm" should match "Toggle Dark Mode"`)
+	}
+	if _, _, ok := fuzzyMatch("mdt", "Toggle Dark Mode"); ok {
+		t.Error(`"mdt" should not match "Toggle Dark Mode": the letters are out of order`)
+	}
+	_, positions, _ := fuzzyMatch("SAV", "Save")
+	if !slices.Equal(positions, []int{0, 1, 2}) {
+		t.Errorf("positions = %v, want [0 1 2]", positions)
+	}
+
+	// Word starts and runs beat scattered letters
+	words, _, _ := fuzzyMatch("of", "Open File")
+	scattered, _, _ := fuzzyMatch("of", "Show Info")
+	if words <= scattered {
+		t.Errorf("score at word starts = %d, want more than %d", words, scattered)
+	}
+	run, _, _ := fuzzyMatch("fil", "File")
+	gaps, _, _ := fuzzyMatch("fil", "Fit Image Left")
+	if run <= gaps {
+		t.Errorf("score of a run = %d, want more than %d", run, gaps)
+	}
+}
+
+func TestPalette_Filter(t *testing.T) {
+	p := New()
+	for _, name := range []string{"Show Info", "Open File", "Quit"} {
+		p.Register(name, "", nil)
+	}
+	p.query = []byte("of")
+	p.filter()
+	var names []string
+	for _, m := range p.matches {
+		names = append(names, p.commands[m.index].Name)
+	}
+	if !slices.Equal(names, []string{"Open File", "Show Info"}) {
+		t.Errorf("matches = %q, want the best match first", names)
+	}
+}
+
+// paletteHarness registers three commands, recording the ones run.
+func paletteHarness(t *testing.T) (*uitest.Harness, *Palette, *[]string) {
+	var ran []string
+	p := New()
+	for _, name := range []string{"New File", "Open File", "Toggle Dark Mode"} {
+		p.Register(name, "", func() { ran = append(ran, name) })
+	}
+	p.commands[0].Shortcut = "Ctrl+N"
+	h := uitest.New(t, microui.Config{}, func(ui *microui.UI) {
+		ui.SetScreenSize(800, 600)
+		p.Draw(ui)
+	})
+	return h, p, &ran
+}
+
+// ctrlP presses Ctrl+P.
+func ctrlP(h *uitest.Harness) {
+	h.UI.KeyDown(microui.KeyCtrl)
+	h.Press(microui.KeyP)
+	h.UI.KeyUp(microui.KeyCtrl)
+	h.Frame()
+}
+
+func TestPalette_Keyboard(t *testing.T) {
+	h, p, ran := paletteHarness(t)
+	h.AssertNotVisible("Open File")
+
+	ctrlP(h)
+	if !p.IsOpen(h.UI) {
+		t.Fatal("Ctrl+P should open the palette")
+	}
+	h.AssertVisible("Open File")
+	h.AssertVisible("Ctrl+N")
+
+	h.Type("file")
+	h.AssertNotVisible("Toggle Dark Mode")
+	h.Press(microui.KeyDown)
+	h.Press(microui.KeyEnter)
+	if !slices.Equal(*ran, []string{"Open File"}) {
+		t.Errorf("ran %q, want the second match", *ran)
+	}
+	if p.IsOpen(h.UI) {
+		t.Error("running a command should close the palette")
+	}
+
+	// Reopening starts with an empty query; Escape closes without running
+	ctrlP(h)
+	h.AssertVisible("Toggle Dark Mode")
+	h.Press(microui.KeyEscape)
+	if p.IsOpen(h.UI) || len(*ran) != 1 {
+		t.Errorf("Escape: open = %v, ran %q", p.IsOpen(h.UI), *ran)
+	}
+}
+
+func TestPalette_Click(t *testing.T) {
+	h, p, ran := paletteHarness(t)
+	p.Open(h.UI)
+	h.Frame()
+	h.Click("Toggle Dark Mode")
+	if !slices.Equal(*ran, []string{"Toggle Dark Mode"}) {
+		t.Errorf("ran %q, want the clicked command", *ran)
+	}
+}
@@ -0,0 +1,270 @@
+package microui
+
+import (
+	"strings"
+
+	"github.com/user/microui-go/types"
+)
+
+// focusEntry is a control that can receive keyboard focus this frame.
+type focusEntry struct {
+	id   ID
+	rect types.Rect
+}
+
+// KeyboardFocus returns the control focused with Tab/Shift-Tab, or 0 if
+// focus is held by the mouse or nothing is focused.
+func (u *UI) KeyboardFocus() ID {
+	return u.navFocus
+}
+
+// registerFocusable adds a control to this frame's focus order.
+// Controls beneath an open modal are skipped so Tab stays inside it.
+func (u *UI) registerFocusable(id ID, rect types.Rect) {
+	if u.modal != nil {
+		blocked := true
+		for i := 0; i < u.containerStack.Len(); i++ {
+			if cnt := u.containerStack.items[i]; !u.inputBlockedByModal(cnt) {
+				blocked = false
+				break
+			}
+		}
+		if blocked {
+			return
+		}
+	}
+	u.focusList = append(u.focusList, focusEntry{id: id, rect: rect})
+
+	if u.focusNext || u.focusWindow != nil && u.rootContainer() == u.focusWindow {
+		u.focusNext, u.focusWindow = false, nil
+		u.setNavFocus(id)
+	}
+	if u.revealFocus && id == u.navFocus {
+		u.revealFocus = false
+		u.EnsureVisible(rect)
+	}
+}
+
+// FocusControl gives control id keyboard focus, as if it was tabbed to. A
+// focused textbox can be typed into straight away. See GetID for the ID of
+// a control with a label, or GetItemID right after the control.
+func (u *UI) FocusControl(id ID) {
+	u.setNavFocus(id)
+	u.input.UpdatedFocus = true
+}
+
+// FocusNext gives keyboard focus to the next control that can take it,
+// e.g. the first textbox of a form when its window opens:
+//
+//	if !dialogOpen {
+//		dialogOpen = true
+//		ui.FocusNext()
+//	}
+//	ui.Textbox(&name, 64)
+func (u *UI) FocusNext() {
+	u.focusNext = true
+}
+
+// FocusNextWindow brings the open window furthest back to the front and
+// gives its first control keyboard focus, so calling it repeatedly, e.g.
+// on Ctrl+Tab, cycles through the windows. It does nothing while a modal
+// dialog is open.
+func (u *UI) FocusNextWindow() {
+	if u.modal != nil {
+		return
+	}
+	var next *Container
+	for _, cnt := range u.containers {
+		if !cnt.open || cnt.parent != nil || cnt.opt&OptPopup != 0 || passthrough(cnt) || strings.HasPrefix(cnt.name, "!") {
+			continue
+		}
+		if next == nil || u.inFront(next, cnt) || cnt.zindex == next.zindex && cnt.id < next.id {
+			next = cnt
+		}
+	}
+	if next == nil {
+		return
+	}
+	u.BringToFront(next)
+	u.focusWindow, u.focusWindowBegun = next, false
+}
+
+// rootContainer returns the window or popup the current container is in.
+func (u *UI) rootContainer() *Container {
+	cnt := u.GetCurrentContainer()
+	for cnt != nil && cnt.parent != nil {
+		cnt = cnt.parent
+	}
+	return cnt
+}
+
+// defaultActivated reports whether Enter clicks the OptDefault button
+// being updated: its window is in front and no other control has focus,
+// though a textbox being typed into passes Enter on to submit the form.
+func (u *UI) defaultActivated() bool {
+	if !u.input.KeyPressed[KeyEnter] {
+		return false
+	}
+	root := u.rootContainer()
+	if root == nil || u.inputBlockedByModal(root) {
+		return false
+	}
+	for _, cnt := range u.containers {
+		if cnt.open && cnt.parent == nil && !passthrough(cnt) && u.inFront(cnt, root) {
+			return false
+		}
+	}
+	return u.input.Focus == 0 || u.input.Focus == u.lastTextboxID
+}
+
+// activated reports whether control id was clicked this frame: a mouse
+// press while it has focus, or Enter/Space/NavActivate while it has
+// keyboard focus.
+func (u *UI) activated(id ID) bool {
+	if u.input.Focus != id {
+		return false
+	}
+	if u.input.MousePressed[int(MouseLeft)] {
+		return true
+	}
+	return u.navFocus == id && (u.input.KeyPressed[KeyEnter] || u.input.KeyPressed[KeySpace] ||
+		u.input.NavPressed[NavActivate])
+}
+
+// activatedRepeat is activated for a button that also clicks again while
+// the mouse holds it down, after Config.ButtonRepeatDelay and then every
+// Config.ButtonRepeatInterval. It pauses while the mouse is off the button.
+func (u *UI) activatedRepeat(id ID) bool {
+	if u.activated(id) {
+		u.buttonRepeat = id
+		u.buttonRepeatNext = u.frameTime.Add(u.buttonRepeatDelay)
+		return true
+	}
+	if u.buttonRepeat != id {
+		return false
+	}
+	if u.input.Focus != id || !u.input.MouseDown[int(MouseLeft)] {
+		u.buttonRepeat = 0
+		return false
+	}
+	now := u.frameTime
+	if !u.item.hovered || now.Before(u.buttonRepeatNext) {
+		return false
+	}
+	// At most one click per frame, like key repeat
+	u.buttonRepeatNext = u.buttonRepeatNext.Add(u.buttonRepeatInterval)
+	if u.buttonRepeatNext.Before(now) {
+		u.buttonRepeatNext = now.Add(u.buttonRepeatInterval)
+	}
+	return true
+}
+
+// updateFocusNav moves keyboard focus on Tab/Shift-Tab and directional
+// navigation. Called from EndFrame once this frame's focus order is known.
+func (u *UI) updateFocusNav() {
+	// Mouse interaction takes focus back from the keyboard
+	if u.input.MousePressed[int(MouseLeft)] || u.input.Focus != u.navFocus {
+		u.navFocus = 0
+	}
+	if len(u.focusList) == 0 {
+		return
+	}
+
+	for dir := NavUp; dir <= NavRight; dir++ {
+		if u.input.NavPressed[dir] {
+			u.navMove(dir)
+		}
+	}
+
+	if !u.input.KeyPressed[KeyTab] {
+		return
+	}
+	// Terminals deliver Shift+Tab as one event, so a shift press this
+	// frame counts even if the key was already released
+	back := u.input.KeyDown[KeyShift] || u.input.KeyPressed[KeyShift]
+
+	cur := -1
+	for i, e := range u.focusList {
+		if e.id == u.navFocus {
+			cur = i
+			break
+		}
+	}
+	n := len(u.focusList)
+	var next int
+	switch {
+	case cur < 0 && back:
+		next = n - 1
+	case cur < 0:
+		next = 0
+	case back:
+		next = (cur - 1 + n) % n
+	default:
+		next = (cur + 1) % n
+	}
+	u.setNavFocus(u.focusList[next].id)
+}
+
+// navMove focuses the nearest control in direction dir from the current
+// keyboard focus, judged by rect centers. Candidates off-axis are penalised
+// so moving down prefers the control directly below over a closer one
+// diagonally. With nothing focused, the first control is chosen.
+func (u *UI) navMove(dir Nav) {
+	cur := -1
+	for i, e := range u.focusList {
+		if e.id == u.navFocus {
+			cur = i
+			break
+		}
+	}
+	if cur < 0 {
+		u.setNavFocus(u.focusList[0].id)
+		return
+	}
+
+	from := u.focusList[cur].rect
+	fx, fy := from.X+from.W/2, from.Y+from.H/2
+	best, bestScore := -1, 0
+	for i, e := range u.focusList {
+		if i == cur {
+			continue
+		}
+		dx := e.rect.X + e.rect.W/2 - fx
+		dy := e.rect.Y + e.rect.H/2 - fy
+		var along, across int
+		switch dir {
+		case NavUp:
+			along, across = -dy, dx
+		case NavDown:
+			along, across = dy, dx
+		case NavLeft:
+			along, across = -dx, dy
+		case NavRight:
+			along, across = dx, dy
+		}
+		if along <= 0 {
+			continue
+		}
+		score := along + 2*abs(across)
+		if best < 0 || score < bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best >= 0 {
+		u.setNavFocus(u.focusList[best].id)
+	}
+}
+
+// setNavFocus gives id keyboard focus.
+func (u *UI) setNavFocus(id ID) {
+	u.navFocus = id
+	u.input.Focus = id
+	u.revealFocus = true
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
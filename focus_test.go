@@ -0,0 +1,332 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// focusFrame draws three buttons followed by a textbox and returns which
+// buttons were clicked.
+func focusFrame(ui *UI, buf *[]byte) (clicked [3]bool) {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 0)
+	clicked[0] = ui.Button("One")
+	clicked[1] = ui.Button("Two")
+	clicked[2] = ui.Button("Three")
+	ui.Textbox(buf, 64)
+	ui.EndWindow()
+	ui.EndFrame()
+	return clicked
+}
+
+func buttonID(ui *UI, label string) ID {
+	ui.PushID("Test")
+	id := ui.GetID(label)
+	ui.PopID()
+	return id
+}
+
+func TestFocusNav_TabCycles(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	focusFrame(ui, &buf)
+
+	want := []string{"One", "Two", "Three"}
+	for _, label := range want {
+		pressKey(ui, KeyTab)
+		focusFrame(ui, &buf)
+		if got := ui.KeyboardFocus(); got != buttonID(ui, label) {
+			t.Fatalf("after Tab focus = %d, want %q", got, label)
+		}
+	}
+
+	// Textbox next, then wrap to the first button
+	pressKey(ui, KeyTab)
+	focusFrame(ui, &buf)
+	if ui.KeyboardFocus() != ui.getIDFromPtr(&buf) {
+		t.Fatalf("Tab should reach the textbox")
+	}
+	pressKey(ui, KeyTab)
+	focusFrame(ui, &buf)
+	if ui.KeyboardFocus() != buttonID(ui, "One") {
+		t.Errorf("Tab should wrap to the first control")
+	}
+}
+
+func TestFocusNav_ShiftTabGoesBack(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	focusFrame(ui, &buf)
+
+	ui.KeyDown(KeyShift)
+	pressKey(ui, KeyTab)
+	focusFrame(ui, &buf)
+	if ui.KeyboardFocus() != ui.getIDFromPtr(&buf) {
+		t.Fatalf("Shift-Tab with nothing focused should focus the last control")
+	}
+	pressKey(ui, KeyTab)
+	focusFrame(ui, &buf)
+	ui.KeyUp(KeyShift)
+	if ui.KeyboardFocus() != buttonID(ui, "Three") {
+		t.Errorf("Shift-Tab should move to the previous control")
+	}
+}
+
+func TestFocusNav_PersistsAndActivates(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	focusFrame(ui, &buf)
+	pressKey(ui, KeyTab)
+	focusFrame(ui, &buf)
+	pressKey(ui, KeyTab)
+	focusFrame(ui, &buf)
+
+	// Focus survives frames without input
+	for i := 0; i < 3; i++ {
+		if clicked := focusFrame(ui, &buf); clicked != [3]bool{} {
+			t.Fatalf("no button should fire without input, got %v", clicked)
+		}
+	}
+	if ui.KeyboardFocus() != buttonID(ui, "Two") {
+		t.Fatalf("keyboard focus lost between frames")
+	}
+
+	pressKey(ui, KeyEnter)
+	if clicked := focusFrame(ui, &buf); !clicked[1] || clicked[0] || clicked[2] {
+		t.Errorf("Enter should activate only the focused button, got %v", clicked)
+	}
+	pressKey(ui, KeySpace)
+	if clicked := focusFrame(ui, &buf); !clicked[1] {
+		t.Errorf("Space should activate the focused button")
+	}
+}
+
+func TestFocusNav_TextboxAcceptsTyping(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	focusFrame(ui, &buf)
+	for i := 0; i < 4; i++ {
+		pressKey(ui, KeyTab)
+		focusFrame(ui, &buf)
+	}
+
+	ui.BeginFrame()
+	ui.TextInput("hi")
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 0)
+	ui.Button("One")
+	ui.Button("Two")
+	ui.Button("Three")
+	ui.Textbox(&buf, 64)
+	ui.EndWindow()
+	ui.EndFrame()
+
+	if string(buf) != "hi" {
+		t.Errorf("buf = %q, want %q", buf, "hi")
+	}
+}
+
+func TestFocusNav_MouseClearsKeyboardFocus(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	focusFrame(ui, &buf)
+	pressKey(ui, KeyTab)
+	focusFrame(ui, &buf)
+
+	ui.MouseMove(300, 250)
+	ui.MouseDown(300, 250, MouseLeft)
+	focusFrame(ui, &buf)
+	ui.MouseUp(300, 250, MouseLeft)
+	focusFrame(ui, &buf)
+	if ui.KeyboardFocus() != 0 {
+		t.Errorf("mouse click should clear keyboard focus")
+	}
+}
+
+func TestFocusNav_ExcludesWindowChrome(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	focusFrame(ui, &buf)
+	if len(ui.focusList) != 4 {
+		t.Errorf("focus order has %d entries, want 4 (title bar, close and resize excluded)", len(ui.focusList))
+	}
+}
+
+// gridFrame draws a 2x2 button grid:
+//
+//	A B
+//	C D
+func gridFrame(ui *UI) (clicked map[string]bool) {
+	clicked = map[string]bool{}
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(2, []int{100, 100}, 0)
+	for _, label := range []string{"A", "B", "C", "D"} {
+		clicked[label] = ui.Button(label)
+	}
+	ui.EndWindow()
+	ui.EndFrame()
+	return clicked
+}
+
+func TestFocusNav_DirectionalMoves(t *testing.T) {
+	ui := New(Config{})
+	gridFrame(ui)
+
+	// First nav input focuses the first control
+	ui.NavInput(NavDown)
+	gridFrame(ui)
+	if ui.KeyboardFocus() != buttonID(ui, "A") {
+		t.Fatalf("first nav should focus A")
+	}
+
+	steps := []struct {
+		nav  Nav
+		want string
+	}{
+		{NavRight, "B"},
+		{NavDown, "D"},
+		{NavLeft, "C"},
+		{NavUp, "A"},
+		{NavUp, "A"}, // nothing above: stay put
+	}
+	for _, s := range steps {
+		ui.NavInput(s.nav)
+		gridFrame(ui)
+		if ui.KeyboardFocus() != buttonID(ui, s.want) {
+			t.Fatalf("nav %d: focus is not %s", s.nav, s.want)
+		}
+	}
+}
+
+func TestFocusNav_ActivatePressesButton(t *testing.T) {
+	ui := New(Config{})
+	gridFrame(ui)
+	ui.NavInput(NavRight)
+	gridFrame(ui)
+	ui.NavInput(NavRight)
+	gridFrame(ui)
+
+	ui.NavInput(NavActivate)
+	clicked := gridFrame(ui)
+	if !clicked["B"] || clicked["A"] {
+		t.Errorf("NavActivate should press B, got %v", clicked)
+	}
+	if clicked := gridFrame(ui); clicked["B"] {
+		t.Error("NavActivate should only fire for one frame")
+	}
+}
+
+// formApp is a window with a textbox, a Cancel button and a default OK
+// button, and a second window with a button.
+type formApp struct {
+	ui       *UI
+	name     []byte
+	ok       int
+	focusBox bool
+	typed    string // Typed after BeginFrame
+	boxID    ID
+	cancelID ID
+	otherID  ID
+}
+
+func (a *formApp) frame() {
+	a.ui.BeginFrame()
+	a.ui.TextInput(a.typed)
+	a.typed = ""
+	if a.ui.BeginWindow("Form", types.Rect{X: 0, Y: 0, W: 300, H: 200}) {
+		a.ui.LayoutRow(1, []int{150}, 0)
+		if a.focusBox {
+			a.focusBox = false
+			a.ui.FocusNext()
+		}
+		a.ui.Textbox(&a.name, 32)
+		a.boxID = a.ui.GetItemID()
+		a.ui.Button("Cancel")
+		a.cancelID = a.ui.GetItemID()
+		if a.ui.ButtonOpt("OK", 0, OptDefault) {
+			a.ok++
+		}
+		a.ui.EndWindow()
+	}
+	if a.ui.BeginWindow("Other", types.Rect{X: 310, Y: 0, W: 200, H: 200}) {
+		a.ui.LayoutRow(1, []int{100}, 0)
+		a.ui.Button("Other")
+		a.otherID = a.ui.GetItemID()
+		a.ui.EndWindow()
+	}
+	a.ui.EndFrame()
+}
+
+func TestFocusNext_TypesIntoTextbox(t *testing.T) {
+	a := &formApp{ui: New(Config{}), name: make([]byte, 0, 32), focusBox: true}
+	a.frame()
+	a.ui.BringToFront(a.ui.GetContainer("Form"))
+	a.typed = "hi"
+	a.frame()
+	if string(a.name) != "hi" {
+		t.Errorf("text = %q, want the focused textbox to take typing", a.name)
+	}
+
+	// Enter in the textbox clicks the default button
+	a.ui.KeyDown(KeyEnter)
+	a.frame()
+	a.ui.KeyUp(KeyEnter)
+	if a.ok != 1 {
+		t.Errorf("ok = %d, want Enter to click the default button", a.ok)
+	}
+}
+
+func TestDefaultButton_NotWhenOtherControlFocusedOrBehind(t *testing.T) {
+	a := &formApp{ui: New(Config{}), name: make([]byte, 0, 32)}
+	a.frame()
+	a.ui.BringToFront(a.ui.GetContainer("Form"))
+	a.ui.FocusControl(a.cancelID)
+	a.frame()
+	a.ui.KeyDown(KeyEnter)
+	a.frame()
+	a.ui.KeyUp(KeyEnter)
+	if a.ok != 0 {
+		t.Error("Enter on a focused Cancel button should not click OK")
+	}
+
+	// Without focus Enter clicks OK, unless another window is in front
+	a.ui.FocusControl(0)
+	a.frame()
+	a.ui.KeyDown(KeyEnter)
+	a.frame()
+	a.ui.KeyUp(KeyEnter)
+	if a.ok != 1 {
+		t.Fatalf("ok = %d, want Enter to click OK with nothing focused", a.ok)
+	}
+	a.ui.BringToFront(a.ui.GetContainer("Other"))
+	a.frame()
+	a.ui.KeyDown(KeyEnter)
+	a.frame()
+	a.ui.KeyUp(KeyEnter)
+	if a.ok != 1 {
+		t.Error("Enter should not click the default button of a window behind another")
+	}
+}
+
+func TestFocusNextWindow(t *testing.T) {
+	a := &formApp{ui: New(Config{}), name: make([]byte, 0, 32)}
+	a.frame()
+	a.ui.BringToFront(a.ui.GetContainer("Form"))
+	a.frame()
+	a.ui.FocusNextWindow()
+	a.frame()
+	if got := a.ui.KeyboardFocus(); got != a.otherID {
+		t.Errorf("focus = %d, want the first control of the window brought forward", got)
+	}
+	a.ui.FocusNextWindow()
+	a.frame()
+	if got := a.ui.KeyboardFocus(); got != a.boxID {
+		t.Errorf("focus = %d, want the textbox back in the first window", got)
+	}
+	if form, other := a.ui.GetContainer("Form"), a.ui.GetContainer("Other"); form.zindex < other.zindex {
+		t.Error("cycling should bring the form back in front")
+	}
+}
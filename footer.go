@@ -0,0 +1,60 @@
+package microui
+
+import "github.com/user/microui-go/types"
+
+// BeginFooter starts a strip pinned to the bottom of the current window or
+// panel body, e.g. for OK and Cancel buttons that stay in view however long
+// the content is. The content scrolls above the strip. Call it directly in
+// the window or panel, after its content, and finish with EndFooter.
+//
+// The footer's height is measured as it ends, so the strip appears from the
+// frame after it is first used and follows changes a frame late.
+func (u *UI) BeginFooter() {
+	var r types.Rect
+	if cnt := u.GetCurrentContainer(); cnt != nil {
+		r = cnt.footerRect
+	}
+	// Clip to the strip rather than the body above it
+	u.PopClip()
+	u.PushClip(r)
+	body := types.Rect{
+		X: r.X + u.style.Padding.X,
+		Y: r.Y,
+		W: max(0, r.W-u.style.Padding.X*2),
+		H: max(0, r.H-u.style.Padding.Y),
+	}
+	u.pushLayout(body, types.Vec2{})
+}
+
+// EndFooter finishes the footer started by BeginFooter.
+func (u *UI) EndFooter() {
+	layout := u.getLayout()
+	h := layout.max.Y - layout.body.Y
+	u.PopLayout()
+	u.PopClip()
+	cnt := u.GetCurrentContainer()
+	if cnt == nil {
+		u.PushClip(unclippedRect)
+		return
+	}
+	u.PushClip(cnt.body)
+	if h > 0 {
+		cnt.footerNext = h + u.style.Padding.Y
+	}
+}
+
+// beginFooterSpace takes the footer height measured last frame as the
+// space to reserve this frame.
+func (u *UI) beginFooterSpace(cnt *Container) {
+	cnt.footer = cnt.footerNext
+	cnt.footerNext = 0
+}
+
+// reserveFooter takes the footer's strip off the bottom of body and
+// remembers it for BeginFooter. Called before the scrollbars are placed so
+// they stop above the footer.
+func (u *UI) reserveFooter(cnt *Container, body *types.Rect) {
+	h := min(cnt.footer, max(0, body.H))
+	body.H -= h
+	cnt.footerRect = types.Rect{X: body.X, Y: body.Y + body.H, W: body.W, H: h}
+}
@@ -0,0 +1,97 @@
+package microui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// footerApp is a window with more rows than fit and an OK button in its
+// footer.
+type footerApp struct {
+	ui     *UI
+	ok     types.Rect
+	clicks int
+}
+
+func (a *footerApp) frame() {
+	a.ui.BeginFrame()
+	if a.ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+		for i := 0; i < 40; i++ {
+			a.ui.Label(fmt.Sprint("row ", i))
+		}
+		a.ui.BeginFooter()
+		a.ui.LayoutRow(1, []int{100}, 0)
+		if a.ui.Button("OK") {
+			a.clicks++
+		}
+		a.ok = a.ui.lastRect
+		a.ui.EndFooter()
+		a.ui.EndWindow()
+	}
+	a.ui.EndFrame()
+}
+
+func TestFooter_PinnedBelowScrollingContent(t *testing.T) {
+	a := &footerApp{ui: New(Config{})}
+	a.frame()
+	a.frame()
+	cnt := a.ui.GetContainer("Test")
+	body := cnt.Body()
+	if a.ok.Y != body.Y+body.H || a.ok.H != 20 {
+		t.Fatalf("OK at %v, want it just below the body ending at y=%d", a.ok, body.Y+body.H)
+	}
+	if cnt.footerRect.Y+cnt.footerRect.H != a.ok.Y+a.ok.H+5 {
+		t.Errorf("footer %v should end 5px below the OK button %v", cnt.footerRect, a.ok)
+	}
+
+	// Scrolling the content leaves the footer in place
+	okRect := a.ok
+	a.ui.MouseMove(200, 100)
+	a.frame()
+	a.ui.Scroll(0, 100)
+	a.frame()
+	a.frame()
+	if cnt.scroll.Y == 0 || a.ok != okRect {
+		t.Errorf("scroll=%d OK=%v, want scrolled content and OK at %v", cnt.scroll.Y, a.ok, okRect)
+	}
+	// The scroll range ends with the last row above the footer
+	if max := cnt.contentSize.Y + 10 - cnt.body.H; cnt.scroll.Y > max {
+		t.Errorf("scroll %d past the end %d", cnt.scroll.Y, max)
+	}
+
+	a.ui.MouseMove(okRect.X+10, okRect.Y+10)
+	a.frame()
+	a.ui.MouseDown(okRect.X+10, okRect.Y+10, MouseLeft)
+	a.frame()
+	a.ui.MouseUp(okRect.X+10, okRect.Y+10, MouseLeft)
+	a.frame()
+	if a.clicks != 1 {
+		t.Errorf("clicks = %d, want the footer button clickable", a.clicks)
+	}
+}
+
+func TestFooter_FitContentIncludesFooter(t *testing.T) {
+	ui := New(Config{})
+	var ok types.Rect
+	frame := func() {
+		ui.BeginFrame()
+		if ui.BeginWindowOpt("Fit", types.Rect{X: 0, Y: 0, W: 300, H: 300}, OptFitContent) {
+			ui.Label("content")
+			ui.BeginFooter()
+			ui.Button("OK")
+			ok = ui.lastRect
+			ui.EndFooter()
+			ui.EndWindow()
+		}
+		ui.EndFrame()
+	}
+	for i := 0; i < 4; i++ {
+		frame()
+	}
+	cnt := ui.GetContainer("Fit")
+	if r := cnt.Rect(); ok.Y+ok.H > r.Y+r.H || ok.Y < cnt.Body().Y+cnt.Body().H {
+		t.Errorf("OK at %v, want it below the body inside the window %v", ok, r)
+	}
+}
@@ -0,0 +1,131 @@
+package microui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"reflect"
+
+	"github.com/user/microui-go/types"
+)
+
+// FrameChanged reports whether the frame just ended would draw anything
+// different from the last frame FrameChanged was called in, so a loop
+// running at a fixed rate can skip Render and presenting while the UI is
+// idle:
+//
+//	ui.EndFrame()
+//	if ui.FrameChanged() {
+//		ui.Render(renderer)
+//	}
+//
+// It hashes the commands Render would issue, in drawing order, along with
+// the screen size and scale; the first call always reports a change.
+// Images, fonts and custom payloads are hashed by handle, so content
+// changed in place isn't seen. Calling it again in the same frame gives
+// the same answer.
+func (u *UI) FrameChanged() bool {
+	if u.frameHashFrame != 0 && u.frameHashFrame == u.frame {
+		return u.frameChanged
+	}
+	h := newFrameHasher()
+	h.rect(u.screen)
+	h.uint(math.Float64bits(u.scale))
+	u.eachRendered(h.command, nil)
+	u.frameChanged = u.frameHashFrame == 0 || uint64(*h) != u.frameHash
+	u.frameHash, u.frameHashFrame = uint64(*h), u.frame
+	return u.frameChanged
+}
+
+// frameHasher is an FNV-1a hash of a command stream.
+type frameHasher uint64
+
+func newFrameHasher() *frameHasher {
+	h := frameHasher(14695981039346656037)
+	return &h
+}
+
+func (h *frameHasher) uint(v uint64) {
+	for i := 0; i < 8; i++ {
+		*h = (*h ^ frameHasher(v&0xff)) * 1099511628211
+		v >>= 8
+	}
+}
+
+func (h *frameHasher) int(v int) { h.uint(uint64(v)) }
+
+func (h *frameHasher) string(s string) {
+	h.int(len(s))
+	for i := 0; i < len(s); i++ {
+		*h = (*h ^ frameHasher(s[i])) * 1099511628211
+	}
+}
+
+func (h *frameHasher) rect(r types.Rect) {
+	h.int(r.X)
+	h.int(r.Y)
+	h.int(r.W)
+	h.int(r.H)
+}
+
+func (h *frameHasher) color(c color.Color) {
+	if c == nil {
+		h.int(-1)
+		return
+	}
+	r, g, b, a := c.RGBA()
+	h.uint(uint64(r)<<48 | uint64(g)<<32 | uint64(b)<<16 | uint64(a))
+}
+
+// value hashes an image, font or payload by identity: pointers, slices
+// and maps by address, other values by their printed form.
+func (h *frameHasher) value(v any) {
+	switch v := v.(type) {
+	case nil:
+		h.int(-1)
+		return
+	case string:
+		h.string(v)
+		return
+	case int:
+		h.int(v)
+		return
+	}
+	rv := reflect.ValueOf(v)
+	h.string(rv.Type().String())
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		h.uint(uint64(rv.Pointer()))
+	default:
+		h.string(fmt.Sprint(v))
+	}
+}
+
+func (h *frameHasher) command(cmd Command) {
+	h.int(int(cmd.Kind))
+	h.rect(cmd.Rect)
+	h.int(cmd.Pos.X)
+	h.int(cmd.Pos.Y)
+	h.int(cmd.Size.X)
+	h.int(cmd.Size.Y)
+	h.string(cmd.Text)
+	h.color(cmd.Color)
+	h.int(cmd.Icon)
+	h.value(cmd.Font)
+	h.value(cmd.Image)
+	h.rect(cmd.Src)
+	h.int(cmd.Slice.Top)
+	h.int(cmd.Slice.Right)
+	h.int(cmd.Slice.Bottom)
+	h.int(cmd.Slice.Left)
+	h.value(cmd.Data)
+	if p := cmd.Plot; p != nil {
+		h.int(int(p.Kind))
+		h.uint(math.Float64bits(p.Min))
+		h.uint(math.Float64bits(p.Max))
+		h.int(len(p.Values))
+		for _, v := range p.Values {
+			h.uint(math.Float64bits(v))
+		}
+	}
+}
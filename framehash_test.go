@@ -0,0 +1,52 @@
+package microui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestFrameChanged(t *testing.T) {
+	ui := New(Config{})
+	label, overlay := "a", false
+	frame := func() bool {
+		ui.BeginFrame()
+		if ui.BeginWindow("W", types.Rect{X: 10, Y: 10, W: 200, H: 150}) {
+			ui.Label(label)
+			ui.EndWindow()
+		}
+		if overlay {
+			ui.OverlayDrawRect(types.Rect{W: 5, H: 5}, color.White)
+		}
+		ui.EndFrame()
+		return ui.FrameChanged()
+	}
+	if !frame() {
+		t.Error("first frame should report a change")
+	}
+	if frame() {
+		t.Error("identical frame should report no change")
+	}
+	if ui.FrameChanged() {
+		t.Error("asking again in the same frame should give the same answer")
+	}
+
+	label = "b"
+	if !frame() {
+		t.Error("changed label should report a change")
+	}
+	if frame() {
+		t.Error("steady label should report no change")
+	}
+
+	overlay = true
+	if !frame() {
+		t.Error("overlay drawn after the windows should report a change")
+	}
+
+	ui.SetScale(2)
+	if !frame() {
+		t.Error("new scale should report a change")
+	}
+}
@@ -3,10 +3,13 @@ module github.com/user/microui-go
 go 1.25.0
 
 require (
-	charm.land/bubbletea/v2 v2.0.0-rc.2 // indirect
+	charm.land/bubbletea/v2 v2.0.0-rc.2
+	github.com/charmbracelet/colorprofile v0.3.3
+	github.com/charmbracelet/ultraviolet v0.0.0-20251116181749-377898bcce38
+)
+
+require (
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
-	github.com/charmbracelet/colorprofile v0.3.3 // indirect
-	github.com/charmbracelet/ultraviolet v0.0.0-20251116181749-377898bcce38 // indirect
 	github.com/charmbracelet/x/ansi v0.11.1 // indirect
 	github.com/charmbracelet/x/term v0.2.2 // indirect
 	github.com/charmbracelet/x/termios v0.1.1 // indirect
@@ -0,0 +1,71 @@
+package microui
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+// checkGoroutine panics if Config.CheckGoroutine is set and the calling
+// goroutine isn't the one that owns the UI, the one that first called
+// BeginFrame. method names the UI method for the message.
+func (u *UI) checkGoroutine(method string) {
+	if !u.checkGo {
+		return
+	}
+	id := goroutineID()
+	if method == "BeginFrame" {
+		u.owner.CompareAndSwap(0, id)
+	}
+	if owner := u.owner.Load(); owner != 0 && owner != id {
+		panic(fmt.Sprintf("microui: UI.%s called on goroutine %d, but the UI's frames are built on goroutine %d; "+
+			"call the UI from one goroutine, or set Config.QueueInput to send input from others", method, id, owner))
+	}
+}
+
+// goroutineID returns the ID of the calling goroutine, read from the
+// header of its stack trace, "goroutine 42 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+// inputMethod returns the name of the UI method that sends ev.
+func inputMethod(ev InputEvent) string {
+	switch e := ev.(type) {
+	case MouseMoveEvent:
+		return "MouseMove"
+	case MouseEvent:
+		if e.Down {
+			return "MouseDown"
+		}
+		return "MouseUp"
+	case ScrollEvent:
+		return "Scroll"
+	case NavEvent:
+		return "NavInput"
+	case KeyEvent:
+		if e.Down {
+			return "KeyDown"
+		}
+		return "KeyUp"
+	case TextEvent:
+		return "TextChar"
+	case TouchEvent:
+		switch e.Phase {
+		case TouchBegan:
+			return "TouchBegin"
+		case TouchMoved:
+			return "TouchMove"
+		}
+		return "TouchEnd"
+	}
+	return fmt.Sprintf("%T", ev)
+}
@@ -0,0 +1,56 @@
+package microui
+
+import (
+	"strings"
+	"testing"
+)
+
+// onOtherGoroutine runs f on a new goroutine and returns what it panicked
+// with, or nil.
+func onOtherGoroutine(f func()) (recovered any) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() { recovered = recover() }()
+		f()
+	}()
+	<-done
+	return recovered
+}
+
+func TestCheckGoroutine_PanicsOnOtherGoroutine(t *testing.T) {
+	ui := New(Config{CheckGoroutine: true})
+	ui.BeginFrame()
+	ui.EndFrame()
+	ui.MouseMove(1, 1) // The owner may send input
+
+	r := onOtherGoroutine(func() { ui.MouseDown(5, 5, MouseLeft) })
+	msg, _ := r.(string)
+	if !strings.Contains(msg, "UI.MouseDown called on goroutine") {
+		t.Fatalf("MouseDown on another goroutine panicked with %v, want a message naming it", r)
+	}
+	if r := onOtherGoroutine(func() { ui.BeginFrame() }); r == nil {
+		t.Error("BeginFrame on another goroutine should panic")
+	}
+	if r := onOtherGoroutine(func() { ui.Render(&viewportRenderer{}) }); r == nil {
+		t.Error("Render on another goroutine should panic")
+	}
+}
+
+func TestCheckGoroutine_QueuedInputAllowed(t *testing.T) {
+	ui := New(Config{CheckGoroutine: true, QueueInput: true})
+	ui.BeginFrame()
+	ui.EndFrame()
+	if r := onOtherGoroutine(func() { ui.MouseMove(5, 5); ui.TextInput("a") }); r != nil {
+		t.Errorf("queued input from another goroutine panicked: %v", r)
+	}
+}
+
+func TestCheckGoroutine_OffByDefault(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.EndFrame()
+	if r := onOtherGoroutine(func() { ui.KeyDown(KeyA) }); r != nil {
+		t.Errorf("input from another goroutine panicked without CheckGoroutine: %v", r)
+	}
+}
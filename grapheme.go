@@ -0,0 +1,153 @@
+package microui
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Grapheme clusters are what a user sees as one character: a letter with
+// its combining marks, an emoji with its skin tone or a ZWJ family, a flag
+// made of two regional indicators, or a CR LF pair. Text editing moves and
+// deletes whole clusters so it never leaves half of one behind. The rules
+// follow Unicode's extended grapheme clusters (UAX #29) closely enough for
+// editing, without Prepend characters or Indic conjuncts.
+
+// graphemeClass is the part of a rune's Grapheme_Cluster_Break property the
+// segmenter uses.
+type graphemeClass uint8
+
+const (
+	gcOther graphemeClass = iota
+	gcCR
+	gcLF
+	gcControl
+	gcExtend // Combining marks, variation selectors, emoji modifiers
+	gcZWJ
+	gcSpacingMark
+	gcRegional // Regional indicator, half of a flag
+	gcPictographic
+	gcL   // Hangul leading jamo
+	gcV   // Hangul vowel jamo
+	gcT   // Hangul trailing jamo
+	gcLV  // Hangul syllable without a trailing consonant
+	gcLVT // Hangul syllable with one
+)
+
+func graphemeClassOf(r rune) graphemeClass {
+	switch {
+	case r == '\r':
+		return gcCR
+	case r == '\n':
+		return gcLF
+	case r < 0x20 || r >= 0x7f && r < 0xa0:
+		return gcControl
+	case r < 0x300:
+		if r == 0xa9 || r == 0xae {
+			return gcPictographic
+		}
+		return gcOther
+	case r == 0x200d:
+		return gcZWJ
+	case r == 0x200c, r >= 0x1f3fb && r <= 0x1f3ff, r >= 0xe0020 && r <= 0xe007f,
+		unicode.In(r, unicode.Mn, unicode.Me):
+		return gcExtend
+	case unicode.Is(unicode.Mc, r):
+		return gcSpacingMark
+	case r == 0x2028 || r == 0x2029 || unicode.In(r, unicode.Cc, unicode.Cf):
+		return gcControl
+	case r >= 0x1f1e6 && r <= 0x1f1ff:
+		return gcRegional
+	case r >= 0x1100 && r <= 0x115f, r >= 0xa960 && r <= 0xa97c:
+		return gcL
+	case r >= 0x1160 && r <= 0x11a7, r >= 0xd7b0 && r <= 0xd7c6:
+		return gcV
+	case r >= 0x11a8 && r <= 0x11ff, r >= 0xd7cb && r <= 0xd7fb:
+		return gcT
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return gcLV
+		}
+		return gcLVT
+	case r == 0x203c, r == 0x2049, r == 0x2122, r == 0x2139,
+		r >= 0x2194 && r <= 0x21aa, r >= 0x2300 && r <= 0x23ff,
+		r >= 0x25aa && r <= 0x27bf, r >= 0x2b00 && r <= 0x2bff,
+		r == 0x3030, r == 0x303d, r == 0x3297, r == 0x3299,
+		r >= 0x1f000 && r <= 0x1faff:
+		return gcPictographic
+	}
+	return gcOther
+}
+
+// nextGrapheme returns the end of the grapheme cluster starting at pos in b.
+func nextGrapheme(b []byte, pos int) int {
+	if pos >= len(b) {
+		return len(b)
+	}
+	r, size := utf8.DecodeRune(b[pos:])
+	prev := graphemeClassOf(r)
+	pos += size
+	if prev == gcControl || prev == gcLF {
+		return pos
+	}
+	pictSeq := prev == gcPictographic // Pictographic Extend*, for ZWJ sequences
+	regional := 0
+	if prev == gcRegional {
+		regional = 1
+	}
+	for pos < len(b) {
+		r, size := utf8.DecodeRune(b[pos:])
+		next := graphemeClassOf(r)
+		join := false
+		switch {
+		case prev == gcCR:
+			join = next == gcLF
+		case next == gcExtend || next == gcZWJ || next == gcSpacingMark:
+			join = true
+		case prev == gcL:
+			join = next == gcL || next == gcV || next == gcLV || next == gcLVT
+		case prev == gcV || prev == gcLV:
+			join = next == gcV || next == gcT
+		case prev == gcLVT || prev == gcT:
+			join = next == gcT
+		case prev == gcZWJ:
+			join = pictSeq && next == gcPictographic
+		case prev == gcRegional:
+			join = next == gcRegional && regional%2 == 1
+		}
+		if !join {
+			break
+		}
+		switch next {
+		case gcRegional:
+			regional++
+		case gcPictographic:
+			pictSeq = true
+		case gcExtend:
+		case gcZWJ:
+			// Keeps pictSeq for the pictograph it joins
+		default:
+			pictSeq = false
+		}
+		if prev == gcZWJ && next != gcPictographic {
+			pictSeq = false
+		}
+		prev = next
+		pos += size
+	}
+	return pos
+}
+
+// prevGrapheme returns the start of the grapheme cluster that ends at pos
+// in b. Boundaries depend on what comes before, so it segments b from the
+// start; text boxes hold little enough text for that to be cheap.
+func prevGrapheme(b []byte, pos int) int {
+	start := 0
+	for start < pos {
+		end := nextGrapheme(b, start)
+		if end >= pos {
+			break
+		}
+		start = end
+	}
+	return start
+}
@@ -0,0 +1,104 @@
+package microui
+
+import (
+	"slices"
+	"testing"
+)
+
+func graphemes(s string) []string {
+	var out []string
+	b := []byte(s)
+	for pos := 0; pos < len(b); {
+		end := nextGrapheme(b, pos)
+		out = append(out, s[pos:end])
+		pos = end
+	}
+	return out
+}
+
+func TestGraphemes(t *testing.T) {
+	const (
+		man, woman, girl = "\U0001f468", "\U0001f469", "\U0001f467"
+		zwj              = "\u200d"
+		jp, fr, d        = "\U0001f1ef\U0001f1f5", "\U0001f1eb\U0001f1f7", "\U0001f1e9"
+	)
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"ascii", "ab", []string{"a", "b"}},
+		{"combining marks", "e\u0323\u0301x", []string{"e\u0323\u0301", "x"}},
+		{"crlf", "a\r\n\nb", []string{"a", "\r\n", "\n", "b"}},
+		{"skin tone", thumbsUp + "!", []string{thumbsUp, "!"}},
+		{"zwj family", man + zwj + woman + zwj + girl + "x", []string{man + zwj + woman + zwj + girl, "x"}},
+		{"zwj after letter", "a" + zwj + woman, []string{"a" + zwj, woman}},
+		{"variation selector", "\u2764\ufe0f.", []string{"\u2764\ufe0f", "."}},
+		{"flags", jp + fr + d, []string{jp, fr, d}},
+		{"hangul jamo", "\u1100\u1161\u11a8\uac00", []string{"\u1100\u1161\u11a8", "\uac00"}},
+	}
+	for _, tt := range tests {
+		if got := graphemes(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: clusters = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// thumbsUp is a thumbs up with a medium skin tone; thumbsUpText puts it
+// between a letter and an e with a combining acute accent.
+const (
+	thumbsUp     = "\U0001f44d\U0001f3fd"
+	thumbsUpText = "a" + thumbsUp + "e\u0301"
+)
+
+func TestPrevGrapheme(t *testing.T) {
+	b := []byte(thumbsUpText)
+	if got, want := prevGrapheme(b, len(b)), len("a"+thumbsUp); got != want {
+		t.Errorf("prevGrapheme(end) = %d, want %d", got, want)
+	}
+	if got := prevGrapheme(b, len("a"+thumbsUp)); got != 1 {
+		t.Errorf("prevGrapheme(after emoji) = %d, want 1", got)
+	}
+	if got := prevGrapheme(b, 1); got != 0 {
+		t.Errorf("prevGrapheme(1) = %d, want 0", got)
+	}
+}
+
+func TestTextbox_EditsWholeGraphemes(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte(thumbsUpText)
+	focusTextbox(ui, &buf)
+
+	pressKey(ui, KeyLeft)
+	selectFrame(ui, &buf, "")
+	if want := len("a" + thumbsUp); ui.textboxCursor != want {
+		t.Fatalf("cursor after left = %d, want %d, before the accented e", ui.textboxCursor, want)
+	}
+
+	pressKey(ui, KeyBackspace)
+	selectFrame(ui, &buf, "")
+	if string(buf) != "ae\u0301" {
+		t.Errorf("buf after backspace = %q, want the emoji and its skin tone removed", buf)
+	}
+
+	pressKey(ui, KeyDelete)
+	selectFrame(ui, &buf, "")
+	if string(buf) != "a" {
+		t.Errorf("buf after delete = %q, want the e and its accent removed", buf)
+	}
+}
+
+func TestTextbox_ClickDoesNotSplitGrapheme(t *testing.T) {
+	ui := New(Config{})
+	// MockFont draws every rune 8px wide, so the accent sits at 8-16px
+	buf := []byte("e\u0301x")
+	ui.MouseMove(10+9, 39)
+	selectFrame(ui, &buf, "")
+	ui.MouseDown(10+9, 39, MouseLeft)
+	selectFrame(ui, &buf, "")
+	// 9px is nearest the boundary inside the cluster at 8px; of the
+	// cluster's own boundaries, 16px is nearer than 0
+	if want := len("e\u0301"); ui.textboxCursor != want {
+		t.Errorf("cursor = %d, want %d, after the accent", ui.textboxCursor, want)
+	}
+}
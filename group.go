@@ -0,0 +1,82 @@
+package microui
+
+import (
+	"image/color"
+
+	"github.com/user/microui-go/types"
+)
+
+// groupFrame tracks a group box being built.
+type groupFrame struct {
+	rect  types.Rect // Layout cell the group started in
+	label string
+}
+
+// BeginGroup starts a group box: a border with label inset in its top
+// edge around the controls up to EndGroup, as in settings dialogs. The
+// group takes the width of the next layout cell and grows to fit its
+// contents, which are laid out inside the border as in a column, one
+// full-width control per row until LayoutRow changes it. The label
+// is pushed on the ID stack, so controls in different groups can share
+// labels.
+//
+// The border is drawn by DrawFrame with ColorGroup once the contents are
+// known, so it is drawn over them.
+func (u *UI) BeginGroup(label string) {
+	rect := u.LayoutNext()
+	u.groupStack.Push(groupFrame{rect: rect, label: label})
+	u.PushID(label)
+	title := u.style.Font.Height()
+	body := types.Rect{
+		X: rect.X + u.style.Padding.X,
+		Y: rect.Y + title + u.style.Padding.Y,
+		W: max(0, rect.W-u.style.Padding.X*2),
+		H: max(0, rect.H-title-u.style.Padding.Y*2),
+	}
+	u.pushLayout(body, types.Vec2{})
+	u.LayoutRow(1, []int{-1}, 0)
+	u.accessOpen(u.accessControl(0, rect, RoleGroup, label, "", 0))
+}
+
+// EndGroup finishes the group started by BeginGroup and draws its border.
+// The layout continues below the group.
+func (u *UI) EndGroup() {
+	if u.groupStack.Len() == 0 {
+		return
+	}
+	g := u.groupStack.Pop()
+	inner := u.getLayout()
+	bottom := max(inner.max.Y, inner.body.Y) + u.style.Padding.Y
+	u.PopLayout()
+	u.PopID()
+	u.accessClose()
+
+	font := u.style.Font
+	box := types.Rect{X: g.rect.X, Y: g.rect.Y + font.Height()/2, W: g.rect.W}
+	box.H = bottom - box.Y
+	u.DrawFrame(box, ColorGroup)
+	if g.label != "" {
+		pad := u.style.Padding.X
+		bg := types.Rect{X: box.X + pad, Y: g.rect.Y, W: font.Width(g.label) + pad, H: font.Height()}
+		u.DrawRect(bg, u.groupBackground())
+		u.DrawText(g.label, types.Vec2{X: bg.X + pad/2, Y: bg.Y}, nil, u.style.Colors.Text)
+	}
+
+	parent := u.getLayout()
+	if next := bottom + u.style.Spacing - parent.body.Y; next > parent.nextRow {
+		parent.nextRow = next
+	}
+	parent.max.X = max(parent.max.X, g.rect.X+g.rect.W)
+	parent.max.Y = max(parent.max.Y, bottom)
+}
+
+// groupBackground returns the color behind the current container's
+// content, which hides the group border where the label crosses it.
+func (u *UI) groupBackground() color.Color {
+	for c := u.GetCurrentContainer(); c != nil && c.parent != nil; c = c.parent {
+		if c.opt&OptNoFrame == 0 {
+			return u.style.Colors.PanelBg
+		}
+	}
+	return u.style.Colors.WindowBg
+}
@@ -0,0 +1,53 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestGroup_LayoutAndBorder(t *testing.T) {
+	ui := New(Config{})
+	var first, last, after types.Rect
+	var inner, outer ID
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 0)
+	ui.BeginGroup("Audio")
+	inner = ui.GetID("Volume")
+	first = ui.LayoutNext()
+	last = ui.LayoutNext()
+	ui.EndGroup()
+	outer = ui.GetID("Volume")
+	after = ui.LayoutNext()
+	ui.EndWindow()
+	ui.EndFrame()
+
+	// Contents sit below the 16px label, inside the padding
+	if first != (types.Rect{X: 10, Y: 50, W: 190, H: 20}) || last.Y != 74 {
+		t.Errorf("contents at %v and %v, want them inside the group", first, last)
+	}
+	if after.Y != last.Y+last.H+5+4 {
+		t.Errorf("row after the group at y=%d, want %d below its border", after.Y, last.Y+last.H+5+4)
+	}
+	if inner == outer {
+		t.Error("the group label should scope IDs")
+	}
+
+	var box types.Rect
+	var label bool
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdBox && cmd.Rect.W == 200 {
+			box = cmd.Rect
+		}
+		if cmd.Kind == CmdText && cmd.Text == "Audio" {
+			label = true
+		}
+	})
+	if want := (types.Rect{X: 5, Y: 37, W: 200, H: last.Y + last.H + 5 - 37}); box != want {
+		t.Errorf("border = %v, want %v", box, want)
+	}
+	if !label {
+		t.Error("the group label should be drawn")
+	}
+}
@@ -0,0 +1,40 @@
+package microui
+
+// builtinIconNames names the icons every renderer draws.
+var builtinIconNames = [IconMax]string{
+	IconClose:      "close",
+	IconCheck:      "check",
+	IconCollapsed:  "collapsed",
+	IconExpanded:   "expanded",
+	IconResize:     "resize",
+	IconRadio:      "radio",
+	IconMaximize:   "maximize",
+	IconRestore:    "restore",
+	IconPin:        "pin",
+	IconArrowUp:    "arrow-up",
+	IconArrowDown:  "arrow-down",
+	IconArrowLeft:  "arrow-left",
+	IconArrowRight: "arrow-right",
+}
+
+// RegisterIcon names an application icon, so it can be passed to ButtonOpt
+// and DrawIcon like the built-in ones. Use IDs from IconUser up. The name
+// reaches renderers implementing NamedIconRenderer, which look it up
+// through their icon callback (e.g. a rune for the terminal renderer, a
+// sprite for the ebiten renderer); Style.Icons can map the ID to an image
+// instead. Registering an ID again renames it.
+func (u *UI) RegisterIcon(id int, name string) {
+	u.iconNames[id] = name
+}
+
+// IconName returns the name of a built-in or registered icon, or "" for
+// an unknown ID.
+func (u *UI) IconName(id int) string {
+	if name, ok := u.iconNames[id]; ok {
+		return name
+	}
+	if id > 0 && id < IconMax {
+		return builtinIconNames[id]
+	}
+	return ""
+}
@@ -144,7 +144,72 @@ func TestIconConstants(t *testing.T) {
 	if IconExpanded != 4 {
 		t.Errorf("IconExpanded = %d, want 4", IconExpanded)
 	}
-	if IconMax != 6 {
-		t.Errorf("IconMax = %d, want 6", IconMax)
+	if IconRadio != 6 {
+		t.Errorf("IconRadio = %d, want 6", IconRadio)
+	}
+	if IconMaximize != 7 {
+		t.Errorf("IconMaximize = %d, want 7", IconMaximize)
+	}
+	if IconRestore != 8 {
+		t.Errorf("IconRestore = %d, want 8", IconRestore)
+	}
+	if IconPin != 9 {
+		t.Errorf("IconPin = %d, want 9", IconPin)
+	}
+	if IconArrowRight != 13 {
+		t.Errorf("IconArrowRight = %d, want 13", IconArrowRight)
+	}
+	if IconMax != 14 {
+		t.Errorf("IconMax = %d, want 14", IconMax)
+	}
+}
+
+// namedIconRenderer records DrawNamedIcon calls.
+type namedIconRenderer struct {
+	recordRenderer
+	names []string
+}
+
+func (r *namedIconRenderer) DrawIcon(id int, rect types.Rect, c color.Color) {
+	r.names = append(r.names, "unnamed")
+}
+
+func (r *namedIconRenderer) DrawNamedIcon(id int, name string, rect types.Rect, c color.Color) {
+	r.names = append(r.names, name)
+}
+
+func TestRegisterIcon(t *testing.T) {
+	const iconStar = IconUser + 1
+	ui := New(Config{})
+	if ui.IconName(IconClose) != "close" || ui.IconName(IconRestore) != "restore" {
+		t.Errorf("built-in names = %q, %q", ui.IconName(IconClose), ui.IconName(IconRestore))
+	}
+	if name := ui.IconName(iconStar); name != "" {
+		t.Errorf("unregistered icon name = %q, want empty", name)
+	}
+	ui.RegisterIcon(iconStar, "star")
+	if name := ui.IconName(iconStar); name != "star" {
+		t.Errorf("registered icon name = %q, want star", name)
+	}
+
+	ui.BeginFrame()
+	ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.ButtonOpt("", iconStar, 0)
+	ui.EndWindow()
+	ui.EndFrame()
+
+	r := &namedIconRenderer{}
+	ui.Render(r)
+	found := false
+	for _, name := range r.names {
+		if name == "unnamed" {
+			t.Error("DrawIcon should not be called on a NamedIconRenderer")
+		}
+		if name == "star" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("renderer got icons %q, want star among them", r.names)
 	}
 }
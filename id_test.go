@@ -1,6 +1,10 @@
 package microui
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
 
 func TestIDStack_PushPop(t *testing.T) {
 	ui := New(Config{})
@@ -52,3 +56,77 @@ func TestIDStack_Nested(t *testing.T) {
 
 	ui.EndFrame()
 }
+
+// movingValues runs frames where the control's value moves to a new
+// address every frame, as when its slice is reallocated.
+type movingValues struct {
+	ui   *UI
+	text []byte
+	on   bool
+}
+
+func (m *movingValues) frame(typed string, draw func(ui *UI, text *[]byte, on *bool)) {
+	text, on := append([]byte(nil), m.text...), m.on
+	m.ui.BeginFrame()
+	m.ui.TextInput(typed)
+	m.ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	draw(m.ui, &text, &on)
+	m.ui.EndWindow()
+	m.ui.EndFrame()
+	m.text, m.on = text, on
+}
+
+func TestStableID_TextboxKeepsFocusWhenBufferMoves(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		draw func(ui *UI, text *[]byte, on *bool)
+		want string
+	}{
+		{"TextboxID", func(ui *UI, text *[]byte, _ *bool) { ui.TextboxID("name", text, 32, 0) }, "abX"},
+		{"Textbox", func(ui *UI, text *[]byte, _ *bool) { ui.Textbox(text, 32) }, "ab"}, // Focus lost with the old address
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &movingValues{ui: New(Config{}), text: []byte("ab")}
+			m.ui.MouseMove(20, 35)
+			m.frame("", tt.draw)
+			m.ui.MouseDown(20, 35, MouseLeft)
+			m.frame("", tt.draw)
+			m.ui.MouseUp(20, 35, MouseLeft)
+			m.frame("", tt.draw)
+			m.ui.KeyDown(KeyEnd)
+			m.frame("", tt.draw)
+			m.ui.KeyUp(KeyEnd)
+			m.frame("X", tt.draw)
+			if string(m.text) != tt.want {
+				t.Errorf("text = %q, want %q", m.text, tt.want)
+			}
+		})
+	}
+}
+
+func TestStableID_CheckboxClickWhenValueMoves(t *testing.T) {
+	draw := func(ui *UI, _ *[]byte, on *bool) { ui.CheckboxID("opt", "Option", on) }
+	m := &movingValues{ui: New(Config{})}
+	m.ui.MouseMove(10, 35)
+	m.frame("", draw)
+	m.ui.MouseDown(10, 35, MouseLeft)
+	m.frame("", draw)
+	m.ui.MouseUp(10, 35, MouseLeft)
+	m.frame("", draw)
+	if !m.on {
+		t.Error("CheckboxID did not toggle")
+	}
+}
+
+func TestStableID_ScopedByIDStack(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	a := ui.getID("value")
+	ui.PushID("row2")
+	b := ui.getID("value")
+	ui.PopID()
+	ui.EndFrame()
+	if a == b {
+		t.Error("the same control ID in different PushID scopes should differ")
+	}
+}
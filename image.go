@@ -0,0 +1,96 @@
+package microui
+
+import (
+	"image/color"
+
+	"github.com/user/microui-go/types"
+)
+
+// Image draws an application image into rect, e.g. a thumbnail or sprite
+// preview; pass ui.LayoutNext() as rect to place it in the layout. handle
+// is passed to the renderer untouched (*ebiten.Image for the ebiten
+// renderer, image.Image for the terminal renderer). src selects a region of
+// the image in pixels, an empty src meaning the whole image, and tint
+// multiplies its colors (nil = unchanged).
+func (u *UI) Image(handle any, rect, src types.Rect, tint color.Color) {
+	if tint == nil {
+		tint = color.White
+	}
+	u.PushCommand(Command{Kind: CmdImage, Rect: rect, Image: handle, Src: src, Color: tint})
+}
+
+// IconProvider maps icon IDs to regions of an image, so icons can come
+// from a sprite atlas instead of the renderer's built-in glyphs. Icons it
+// doesn't know (ok = false) are still drawn by the renderer.
+type IconProvider interface {
+	IconImage(id int) (img any, src types.Rect, ok bool)
+}
+
+// IconAtlas is an IconProvider backed by one sprite sheet. Draw the icons
+// white so they take the color of the control they are drawn on.
+type IconAtlas struct {
+	Image   any                // Renderer image handle
+	Regions map[int]types.Rect // Icon ID -> region of Image in pixels
+}
+
+// IconImage implements IconProvider.
+func (a *IconAtlas) IconImage(id int) (any, types.Rect, bool) {
+	src, ok := a.Regions[id]
+	return a.Image, src, ok
+}
+
+// ImageButton adds a button showing an image instead of a label, e.g. a
+// toolbar button using a region of a sprite atlas. The image is drawn at
+// its src size (times the UI scale), centered and shrunk to fit the
+// button; an empty src fills the button. Returns true if clicked, and
+// again while held with OptRepeat.
+func (u *UI) ImageButton(id string, img any, src types.Rect, opt int) bool {
+	bid := u.getID(id)
+	rect := u.LayoutNext()
+	u.UpdateControlOpt(bid, rect, opt)
+	clicked := u.activated(bid)
+	if opt&OptRepeat != 0 {
+		clicked = u.activatedRepeat(bid)
+	}
+	u.itemClicked(clicked)
+	u.DrawControlFrame(bid, rect, ColorButton, opt)
+	u.Image(img, fitImage(rect, src, u.scale), src, nil)
+	u.accessControl(bid, rect, RoleButton, "", "", accessOptState(opt))
+	if clicked {
+		u.emit(UIEventButton, bid, id, 0, rect)
+	}
+	return clicked
+}
+
+// fitImage returns the rect an image region of src's size is drawn in:
+// scaled by scale, shrunk to fit rect keeping its aspect ratio, and
+// centered. An empty src fills rect.
+func fitImage(rect, src types.Rect, scale float64) types.Rect {
+	if src.W <= 0 || src.H <= 0 {
+		return rect
+	}
+	w := float64(src.W) * scale
+	h := float64(src.H) * scale
+	if f := min(float64(rect.W)/w, float64(rect.H)/h); f < 1 {
+		w, h = w*f, h*f
+	}
+	iw, ih := int(w), int(h)
+	return types.Rect{X: rect.X + (rect.W-iw)/2, Y: rect.Y + (rect.H-ih)/2, W: iw, H: ih}
+}
+
+// NineSlice is a nine-patch texture: a region of an image whose borders
+// keep their size while the edges and center stretch to fill a rect.
+type NineSlice struct {
+	Image  any          // Renderer image handle
+	Src    types.Rect   // Region of Image in pixels
+	Border types.Insets // Widths of the fixed borders within Src
+}
+
+// DrawNineSlice draws a nine-patch texture stretched over rect, tinted with
+// tint (nil = unchanged). Frames set in Style.Frames are drawn with it.
+func (u *UI) DrawNineSlice(rect types.Rect, ns NineSlice, tint color.Color) {
+	if tint == nil {
+		tint = color.White
+	}
+	u.PushCommand(Command{Kind: CmdNineSlice, Rect: rect, Image: ns.Image, Src: ns.Src, Slice: ns.Border, Color: tint})
+}
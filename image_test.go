@@ -0,0 +1,193 @@
+package microui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// imageRenderer records DrawImage calls.
+type imageRenderer struct {
+	recordRenderer
+	images []Command
+}
+
+func (r *imageRenderer) DrawImage(img any, rect, src types.Rect, tint color.Color) {
+	r.images = append(r.images, Command{Image: img, Rect: rect, Src: src, Color: tint})
+}
+
+func TestImage_Command(t *testing.T) {
+	type sprite struct{ name string }
+	handle := &sprite{"hero"}
+	src := types.Rect{X: 16, Y: 0, W: 16, H: 16}
+
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{64}, 64)
+	rect := ui.LayoutNext()
+	ui.Image(handle, rect, src, nil)
+	ui.EndWindow()
+	ui.EndFrame()
+
+	r := &imageRenderer{}
+	ui.Render(r)
+	if len(r.images) != 1 {
+		t.Fatalf("DrawImage called %d times, want 1", len(r.images))
+	}
+	got := r.images[0]
+	if got.Image != handle || got.Rect != rect || got.Src != src {
+		t.Errorf("DrawImage(%v, %v, %v), want (%v, %v, %v)", got.Image, got.Rect, got.Src, handle, rect, src)
+	}
+	if got.Color != color.White {
+		t.Errorf("nil tint should be passed as white, got %v", got.Color)
+	}
+}
+
+func TestImage_Culled(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 100, H: 100})
+	ui.Image("img", types.Rect{X: 500, Y: 500, W: 32, H: 32}, types.Rect{}, nil)
+	ui.EndWindow()
+	ui.EndFrame()
+
+	if ui.Stats().Culled == 0 {
+		t.Error("an image outside the window should be culled")
+	}
+	// Renderers without DrawImage still render everything else
+	ui.Render(&recordRenderer{})
+}
+
+// imageButtonFrame draws one 40x30 image button at (5,29).
+func imageButtonFrame(ui *UI, src types.Rect) bool {
+	ui.BeginFrame()
+	ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{40}, 30)
+	clicked := ui.ImageButton("save", "atlas", src, 0)
+	ui.EndWindow()
+	ui.EndFrame()
+	return clicked
+}
+
+func TestImageButton(t *testing.T) {
+	ui := New(Config{})
+	src := types.Rect{X: 32, Y: 0, W: 16, H: 16}
+	imageButtonFrame(ui, src)
+
+	var img *Command
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdImage {
+			img = &cmd
+		}
+	})
+	if img == nil {
+		t.Fatal("ImageButton should draw its image")
+	}
+	if want := (types.Rect{X: 17, Y: 36, W: 16, H: 16}); img.Rect != want || img.Src != src {
+		t.Errorf("image drawn at %v from %v, want %v from %v", img.Rect, img.Src, want, src)
+	}
+
+	ui.MouseMove(20, 40)
+	imageButtonFrame(ui, src)
+	ui.MouseDown(20, 40, MouseLeft)
+	if !imageButtonFrame(ui, src) {
+		t.Error("clicking the image button should return true")
+	}
+}
+
+func TestFitImage(t *testing.T) {
+	rect := types.Rect{X: 0, Y: 0, W: 20, H: 10}
+	tests := []struct {
+		src   types.Rect
+		scale float64
+		want  types.Rect
+	}{
+		{types.Rect{W: 8, H: 8}, 1, types.Rect{X: 6, Y: 1, W: 8, H: 8}},
+		{types.Rect{W: 8, H: 8}, 2, types.Rect{X: 5, Y: 0, W: 10, H: 10}},
+		{types.Rect{W: 40, H: 10}, 1, types.Rect{X: 0, Y: 2, W: 20, H: 5}},
+		{types.Rect{}, 1, rect},
+	}
+	for _, tt := range tests {
+		if got := fitImage(rect, tt.src, tt.scale); got != tt.want {
+			t.Errorf("fitImage(%v, %v, %v) = %v, want %v", rect, tt.src, tt.scale, got, tt.want)
+		}
+	}
+}
+
+func TestStyleIcons_Atlas(t *testing.T) {
+	style := DefaultStyle()
+	style.Icons = &IconAtlas{
+		Image:   "atlas",
+		Regions: map[int]types.Rect{IconCheck: {X: 16, W: 16, H: 16}},
+	}
+	ui := New(Config{Style: style})
+	ui.BeginFrame()
+	tint := color.RGBA{R: 255, A: 255}
+	ui.DrawIcon(IconCheck, types.Rect{X: 10, Y: 10, W: 24, H: 24}, tint)
+	ui.DrawIcon(IconClose, types.Rect{X: 40, Y: 10, W: 24, H: 24}, tint)
+	ui.EndFrame()
+
+	var kinds []CommandKind
+	ui.commands.Each(func(cmd Command) {
+		switch cmd.Kind {
+		case CmdImage:
+			want := Command{Kind: CmdImage, Image: "atlas", Rect: types.Rect{X: 14, Y: 14, W: 16, H: 16}, Src: types.Rect{X: 16, W: 16, H: 16}, Color: tint}
+			if cmd.Image != want.Image || cmd.Rect != want.Rect || cmd.Src != want.Src || cmd.Color != want.Color {
+				t.Errorf("atlas icon = %+v, want %+v", cmd, want)
+			}
+		case CmdIcon:
+			if cmd.Icon != IconClose {
+				t.Errorf("only the unmapped icon should fall back to CmdIcon, got %d", cmd.Icon)
+			}
+		}
+		kinds = append(kinds, cmd.Kind)
+	})
+	if len(kinds) != 2 {
+		t.Errorf("got %d commands, want one image and one icon", len(kinds))
+	}
+}
+
+func TestStyleFrames_NineSlice(t *testing.T) {
+	frame := NineSlice{
+		Image:  "skin",
+		Src:    types.Rect{X: 0, Y: 0, W: 12, H: 12},
+		Border: types.Insets{Left: 4, Top: 4, Right: 4, Bottom: 4},
+	}
+	style := DefaultStyle()
+	style.Frames = map[int]NineSlice{ColorButton: frame}
+	ui := New(Config{Style: style})
+	imageButtonFrame(ui, types.Rect{W: 16, H: 16})
+
+	var slices []Command
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdNineSlice {
+			slices = append(slices, cmd)
+		}
+	})
+	if len(slices) != 1 {
+		t.Fatalf("got %d nine-slice commands, want 1 for the button", len(slices))
+	}
+	cmd := slices[0]
+	if want := (types.Rect{X: 5, Y: 29, W: 40, H: 30}); cmd.Rect != want || cmd.Src != frame.Src || cmd.Slice != frame.Border {
+		t.Errorf("nine-slice = %v from %v border %v, want %v from %v border %v",
+			cmd.Rect, cmd.Src, cmd.Slice, want, frame.Src, frame.Border)
+	}
+
+	// A renderer with only DrawImage gets the nine parts
+	r := &imageRenderer{}
+	ui.Render(r)
+	var parts []Command
+	for _, img := range r.images {
+		if img.Image == "skin" {
+			parts = append(parts, img)
+		}
+	}
+	if len(parts) != 9 {
+		t.Fatalf("DrawImage called %d times for the frame, want 9", len(parts))
+	}
+	if center := parts[4]; center.Rect != (types.Rect{X: 9, Y: 33, W: 32, H: 22}) || center.Src != (types.Rect{X: 4, Y: 4, W: 4, H: 4}) {
+		t.Errorf("center drawn at %v from %v", center.Rect, center.Src)
+	}
+}
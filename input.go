@@ -1,6 +1,12 @@
 package microui
 
-import "github.com/user/microui-go/types"
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
 
 // MouseButton represents a mouse button.
 type MouseButton int
@@ -32,99 +38,262 @@ const (
 	KeyPageDown
 	KeyTab
 	KeySpace
+
+	// Letter keys, used for shortcuts such as Ctrl+C / Ctrl+V.
+	// Backends should report these in addition to the typed text.
+	KeyA
+	KeyB
+	KeyC
+	KeyD
+	KeyE
+	KeyF
+	KeyG
+	KeyH
+	KeyI
+	KeyJ
+	KeyK
+	KeyL
+	KeyM
+	KeyN
+	KeyO
+	KeyP
+	KeyQ
+	KeyR
+	KeyS
+	KeyT
+	KeyU
+	KeyV
+	KeyW
+	KeyX
+	KeyY
+	KeyZ
+
+	// KeyMeta is the Cmd (macOS) or Windows key. It acts like KeyCtrl for
+	// shortcuts. It comes after the letters so recorded key values stay
+	// the same.
+	KeyMeta
+
+	// KeyChar is any other key that types a character, such as a digit or
+	// punctuation. Backends report it so the character repeats while held.
+	KeyChar
+)
+
+// Default key repeat timing (see Config.KeyRepeatDelay).
+const (
+	defaultKeyRepeatDelay    = 400 * time.Millisecond
+	defaultKeyRepeatInterval = 50 * time.Millisecond
+)
+
+// KeyMod is a set of held modifier keys.
+type KeyMod int
+
+const (
+	ModShift KeyMod = 1 << iota
+	ModCtrl
+	ModAlt
+	ModMeta
+)
+
+// modKeys maps each modifier to its key.
+var modKeys = [...]struct {
+	mod KeyMod
+	key Key
+}{{ModShift, KeyShift}, {ModCtrl, KeyCtrl}, {ModAlt, KeyAlt}, {ModMeta, KeyMeta}}
+
+// Nav is a directional navigation command, typically from a
+// gamepad D-pad or stick.
+type Nav int
+
+const (
+	NavUp Nav = iota
+	NavDown
+	NavLeft
+	NavRight
+	NavActivate // Press the focused control (gamepad A / cross)
+	navCount
 )
 
 // InputEvent is a union type for input events.
 type InputEvent interface {
 	isInput()
+	// Timestamp returns when the event happened. A zero time means it
+	// happened when BeginFrame received it.
+	Timestamp() time.Time
 }
 
-// MouseEvent represents a mouse event.
+// MouseEvent represents a mouse button press or release.
 type MouseEvent struct {
-	X, Y  int
+	X, Y int
 	Btn  MouseButton
 	Down bool
+	Time time.Time
+}
+
+func (MouseEvent) isInput()               {}
+func (e MouseEvent) Timestamp() time.Time { return e.Time }
+
+// MouseMoveEvent represents the mouse moving to X, Y.
+type MouseMoveEvent struct {
+	X, Y int
+	Time time.Time
 }
 
-func (MouseEvent) isInput() {}
+func (MouseMoveEvent) isInput()               {}
+func (e MouseMoveEvent) Timestamp() time.Time { return e.Time }
+
+// ScrollEvent represents mouse wheel movement.
+type ScrollEvent struct {
+	DX, DY int
+	Time   time.Time
+}
+
+func (ScrollEvent) isInput()               {}
+func (e ScrollEvent) Timestamp() time.Time { return e.Time }
 
 // KeyEvent represents a keyboard event.
 type KeyEvent struct {
 	Key  Key
 	Down bool
+	Time time.Time
 }
 
-func (KeyEvent) isInput() {}
+func (KeyEvent) isInput()               {}
+func (e KeyEvent) Timestamp() time.Time { return e.Time }
 
 // TextEvent represents text input.
 type TextEvent struct {
 	Rune rune
+	Time time.Time
+}
+
+func (TextEvent) isInput()               {}
+func (e TextEvent) Timestamp() time.Time { return e.Time }
+
+// NavEvent represents a directional navigation command.
+type NavEvent struct {
+	Nav  Nav
+	Time time.Time
 }
 
-func (TextEvent) isInput() {}
+func (NavEvent) isInput()               {}
+func (e NavEvent) Timestamp() time.Time { return e.Time }
+
+// The input methods below change the input state immediately, or with
+// Config.QueueInput queue an event for the next BeginFrame.
 
 // MouseMove updates the mouse position.
 func (u *UI) MouseMove(x, y int) {
-	u.mu.Lock()
-	u.input.MousePos = types.Vec2{X: x, Y: y}
-	u.mu.Unlock()
+	u.sendInput(MouseMoveEvent{X: x, Y: y})
 }
 
 // MouseDown handles a mouse button press.
 func (u *UI) MouseDown(x, y int, btn MouseButton) {
-	u.mu.Lock()
-	u.input.MousePos = types.Vec2{X: x, Y: y}
-	u.input.MouseDown[btn] = true
-	u.input.MousePressed[btn] = true
-	u.mu.Unlock()
+	u.sendInput(MouseEvent{X: x, Y: y, Btn: btn, Down: true})
 }
 
 // MouseUp handles a mouse button release.
 func (u *UI) MouseUp(x, y int, btn MouseButton) {
-	u.mu.Lock()
-	u.input.MousePos = types.Vec2{X: x, Y: y}
-	u.input.MouseDown[btn] = false
-	u.mu.Unlock()
+	u.sendInput(MouseEvent{X: x, Y: y, Btn: btn})
 }
 
 // Scroll handles mouse wheel scrolling.
 // dx, dy are scroll deltas (positive = scroll right/down).
 func (u *UI) Scroll(dx, dy int) {
-	u.mu.Lock()
-	u.input.ScrollDelta.X += dx
-	u.input.ScrollDelta.Y += dy
-	u.mu.Unlock()
+	u.sendInput(ScrollEvent{DX: dx, DY: dy})
+}
+
+// NavInput queues a directional navigation command for the next frame.
+// Directions move keyboard focus to the nearest control that way;
+// NavActivate presses the focused control.
+func (u *UI) NavInput(nav Nav) {
+	u.sendInput(NavEvent{Nav: nav})
 }
 
 // KeyDown handles a key press.
 func (u *UI) KeyDown(key Key) {
-	u.mu.Lock()
-	if !u.input.KeyDown[key] {
-		u.input.KeyPressed[key] = true // Only set on initial press
-	}
-	u.input.KeyDown[key] = true
-	u.mu.Unlock()
+	u.sendInput(KeyEvent{Key: key, Down: true})
 }
 
 // KeyUp handles a key release.
 func (u *UI) KeyUp(key Key) {
-	u.mu.Lock()
-	delete(u.input.KeyDown, key)
-	u.mu.Unlock()
+	u.sendInput(KeyEvent{Key: key})
+}
+
+// KeyMods returns the modifier keys held this frame.
+func (u *UI) KeyMods() KeyMod {
+	return u.input.Mods()
+}
+
+// Mods returns the modifier keys held.
+func (s *InputState) Mods() KeyMod {
+	var mods KeyMod
+	for _, m := range modKeys {
+		if s.KeyDown[m.key] {
+			mods |= m.mod
+		}
+	}
+	return mods
+}
+
+// shortcutDown reports whether the shortcut modifier, Ctrl or Meta, is held.
+func (u *UI) shortcutDown() bool {
+	return u.input.KeyDown[KeyCtrl] || u.input.KeyDown[KeyMeta]
 }
 
 // TextChar handles single character text input.
 func (u *UI) TextChar(r rune) {
+	u.sendInput(TextEvent{Rune: r})
+}
+
+// TextInput adds text input for the current frame. With
+// Config.QueueInput it is typed at the next BeginFrame instead.
+func (u *UI) TextInput(text string) {
+	if u.queueInput {
+		now := time.Now()
+		u.mu.Lock()
+		for _, r := range text {
+			u.inputQueue = append(u.inputQueue, TextEvent{Rune: r, Time: now})
+		}
+		u.mu.Unlock()
+		u.inputArrived()
+		return
+	}
+	u.checkGoroutine("TextInput")
 	u.mu.Lock()
-	u.input.TextInput += string(r)
+	for _, r := range text {
+		u.applyInput(TextEvent{Rune: r})
+	}
 	u.mu.Unlock()
+	u.inputArrived()
 }
 
-// TextInput adds text input for the current frame.
-func (u *UI) TextInput(text string) {
+// sendInput applies ev now, or queues it with Config.QueueInput.
+func (u *UI) sendInput(ev InputEvent) {
+	if u.queueInput {
+		u.PushInput(ev)
+		return
+	}
+	if u.checkGo {
+		u.checkGoroutine(inputMethod(ev))
+	}
 	u.mu.Lock()
-	u.input.TextInput += text
+	u.applyInput(ev)
 	u.mu.Unlock()
+	u.inputArrived()
+}
+
+// PushInput queues ev for the next BeginFrame, which applies queued and
+// InputChan events in timestamp order. An event without a timestamp is
+// stamped with the current time. It is safe to call from any goroutine,
+// e.g. to feed input received over the network.
+func (u *UI) PushInput(ev InputEvent) {
+	if ev.Timestamp().IsZero() {
+		ev = stamped(ev, time.Now())
+	}
+	u.mu.Lock()
+	u.inputQueue = append(u.inputQueue, ev)
+	u.mu.Unlock()
+	u.inputArrived()
 }
 
 // InputChan returns the channel for sending input events.
@@ -132,21 +301,261 @@ func (u *UI) InputChan() chan InputEvent {
 	return u.inputCh
 }
 
+// handleInput applies one event to the input state. The caller holds u.mu.
 func (u *UI) handleInput(ev InputEvent) {
 	switch e := ev.(type) {
 	case MouseEvent:
-		if e.Down {
-			u.MouseDown(e.X, e.Y, e.Btn)
-		} else {
-			u.MouseUp(e.X, e.Y, e.Btn)
-		}
+		u.mouseButton(e.X, e.Y, e.Btn, e.Down)
+	case MouseMoveEvent:
+		u.mouseMove(e.X, e.Y)
+	case ScrollEvent:
+		u.scroll(e.DX, e.DY)
 	case KeyEvent:
-		if e.Down {
-			u.KeyDown(e.Key)
-		} else {
-			u.KeyUp(e.Key)
+		u.key(e.Key, e.Down)
+	case TextEvent:
+		u.input.TextInput += string(e.Rune)
+		if u.repeatArmed && !u.repeatFired {
+			u.repeatText += string(e.Rune) // Typed by the key just pressed
+		}
+	case NavEvent:
+		u.input.NavPressed[e.Nav] = true
+	case TouchEvent:
+		u.touch(e)
+	}
+}
+
+func (u *UI) mouseMove(x, y int) {
+	u.input.MousePos = types.Vec2{X: x, Y: y}
+}
+
+func (u *UI) mouseButton(x, y int, btn MouseButton, down bool) {
+	u.input.MousePos = types.Vec2{X: x, Y: y}
+	u.input.MouseDown[btn] = down
+	if down {
+		u.input.MousePressed[btn] = true
+	}
+}
+
+func (u *UI) scroll(dx, dy int) {
+	u.input.ScrollDelta.X += dx
+	u.input.ScrollDelta.Y += dy
+}
+
+func (u *UI) key(key Key, down bool) {
+	if !down {
+		delete(u.input.KeyDown, key)
+		if key == u.repeatKey {
+			u.repeatArmed = false
+		}
+		return
+	}
+	if !u.input.KeyDown[key] {
+		u.input.KeyPressed[key] = true // Only set on initial press
+		if !isModifier(key) {
+			u.repeatKey, u.repeatArmed, u.repeatFired = key, true, false
+			u.repeatText, u.repeatNext = "", time.Time{}
+			if u.inFrame {
+				u.repeatNext = u.frameTime.Add(u.repeatDelay)
+			}
+		}
+	}
+	u.input.KeyDown[key] = true
+}
+
+// isModifier reports whether key is a modifier, which never repeats.
+func isModifier(key Key) bool {
+	for _, m := range modKeys {
+		if m.key == key {
+			return true
 		}
+	}
+	return false
+}
+
+// repeatKeys fires the held key again once it has been held for the
+// repeat delay, then every repeat interval: KeyPressed is set and the
+// text it typed is typed again. Called from BeginFrameAt after input.
+func (u *UI) repeatKeys() {
+	if !u.repeatArmed || u.repeatDelay < 0 {
+		return
+	}
+	now := u.frameTime
+	if u.repeatNext.IsZero() {
+		// Pressed between frames, so held from this one
+		u.repeatNext = now.Add(u.repeatDelay)
+		return
+	}
+	if now.Before(u.repeatNext) {
+		return
+	}
+	u.input.KeyPressed[u.repeatKey] = true
+	u.input.TextInput += u.repeatText
+	u.repeatFired = true
+	// At most one repeat per frame; a slow frame doesn't cause a burst
+	u.repeatNext = u.repeatNext.Add(u.repeatInterval)
+	if u.repeatNext.Before(now) {
+		u.repeatNext = now.Add(u.repeatInterval)
+	}
+}
+
+// processInput applies the queued and InputChan events, oldest first.
+// Events with equal timestamps keep the order they arrived in, queued
+// events before channel ones.
+func (u *UI) processInput() {
+	u.mu.Lock()
+	events := append(u.inputBatch[:0], u.inputQueue...)
+	clear(u.inputQueue)
+	u.inputQueue = u.inputQueue[:0]
+	u.mu.Unlock()
+
+	now := time.Now()
+drain:
+	for {
+		select {
+		case ev := <-u.inputCh:
+			if ev.Timestamp().IsZero() {
+				ev = stamped(ev, now)
+			}
+			events = append(events, ev)
+			u.settle = true
+		default:
+			break drain
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp().Before(events[j].Timestamp())
+	})
+	u.mu.Lock()
+	for _, ev := range events {
+		u.applyInput(ev)
+	}
+	if u.playback != nil {
+		u.playInput()
+	}
+	u.mu.Unlock()
+	clear(events)
+	u.inputBatch = events[:0]
+}
+
+// applyInput applies live input, recording it if a recording is running.
+// Live input is ignored while a trace plays. The caller holds u.mu.
+func (u *UI) applyInput(ev InputEvent) {
+	if u.playback != nil {
+		return
+	}
+	ev = u.transformInput(ev)
+	if u.recording != nil {
+		u.recordInput(ev)
+	}
+	u.handleInput(ev)
+}
+
+// stamped returns ev with its timestamp set to t.
+func stamped(ev InputEvent, t time.Time) InputEvent {
+	switch e := ev.(type) {
+	case MouseEvent:
+		e.Time = t
+		return e
+	case MouseMoveEvent:
+		e.Time = t
+		return e
+	case ScrollEvent:
+		e.Time = t
+		return e
+	case KeyEvent:
+		e.Time = t
+		return e
 	case TextEvent:
-		u.TextChar(e.Rune)
+		e.Time = t
+		return e
+	case NavEvent:
+		e.Time = t
+		return e
+	case TouchEvent:
+		e.Time = t
+		return e
+	}
+	return ev
+}
+
+// SetInputTransform maps mouse and touch positions from the screen to the
+// UI, for a UI drawn into an off-screen image at a logical resolution that
+// is then scaled up by scale and drawn at offset, e.g. letterboxed in the
+// middle of the window. Positions passed to MouseMove, MouseDown, MouseUp,
+// PushInput and InputChan are then in screen pixels and become
+// (pos - offset) / scale. SetInputTransform(1, types.Vec2{}) removes it.
+//
+// Recorded input traces hold the mapped positions, so they replay the same
+// under any transform. ToScreen maps positions the other way. Positions
+// passed through a Viewport are mapped too, so use one or the other.
+func (u *UI) SetInputTransform(scale float64, offset types.Vec2) {
+	if scale <= 0 {
+		scale = 1
+	}
+	u.mu.Lock()
+	u.inputScale, u.inputOffset = scale, offset
+	u.mu.Unlock()
+}
+
+// InputTransform returns the scale and offset set by SetInputTransform;
+// 1 and (0, 0) if none is set.
+func (u *UI) InputTransform() (scale float64, offset types.Vec2) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.inputScale == 0 {
+		return 1, types.Vec2{}
+	}
+	return u.inputScale, u.inputOffset
+}
+
+// FromScreen converts a position on the screen to the UI's coordinates
+// with the transform set by SetInputTransform.
+func (u *UI) FromScreen(p types.Vec2) types.Vec2 {
+	scale, offset := u.InputTransform()
+	return fromScreen(p, scale, offset)
+}
+
+// ToScreen converts a position in the UI's coordinates, such as the rect
+// of a control from GetItemRect, to the screen with the transform set by
+// SetInputTransform, e.g. to draw game content next to a control.
+func (u *UI) ToScreen(p types.Vec2) types.Vec2 {
+	scale, offset := u.InputTransform()
+	return types.Vec2{
+		X: int(math.Round(float64(p.X)*scale)) + offset.X,
+		Y: int(math.Round(float64(p.Y)*scale)) + offset.Y,
+	}
+}
+
+// fromScreen maps screen position p to the UI. Positions round down, so
+// every screen pixel of a scaled-up UI pixel maps to that pixel.
+func fromScreen(p types.Vec2, scale float64, offset types.Vec2) types.Vec2 {
+	return types.Vec2{
+		X: int(math.Floor(float64(p.X-offset.X) / scale)),
+		Y: int(math.Floor(float64(p.Y-offset.Y) / scale)),
+	}
+}
+
+// transformInput maps the position of a mouse or touch event from the
+// screen to the UI. The caller holds u.mu.
+func (u *UI) transformInput(ev InputEvent) InputEvent {
+	if u.inputScale == 0 {
+		return ev
+	}
+	mapXY := func(x, y int) (int, int) {
+		p := fromScreen(types.Vec2{X: x, Y: y}, u.inputScale, u.inputOffset)
+		return p.X, p.Y
+	}
+	switch e := ev.(type) {
+	case MouseEvent:
+		e.X, e.Y = mapXY(e.X, e.Y)
+		return e
+	case MouseMoveEvent:
+		e.X, e.Y = mapXY(e.X, e.Y)
+		return e
+	case TouchEvent:
+		e.X, e.Y = mapXY(e.X, e.Y)
+		return e
 	}
+	return ev
 }
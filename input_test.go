@@ -1,7 +1,12 @@
 package microui
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/user/microui-go/types"
 )
 
 func TestUI_MouseMove(t *testing.T) {
@@ -61,6 +66,22 @@ func TestUI_KeyUp(t *testing.T) {
 	}
 }
 
+func TestUI_KeyPressed(t *testing.T) {
+	ui := New(Config{})
+	ui.KeyDown(KeyUp)
+	ui.BeginFrame()
+	if !ui.KeyPressed(KeyUp) {
+		t.Error("KeyPressed(KeyUp) = false in the frame it was pressed")
+	}
+	ui.EndFrame()
+
+	ui.BeginFrame()
+	if ui.KeyPressed(KeyUp) {
+		t.Error("KeyPressed(KeyUp) = true a frame after the press")
+	}
+	ui.EndFrame()
+}
+
 func TestUI_InputChan(t *testing.T) {
 	ui := New(Config{})
 
@@ -97,3 +118,273 @@ func TestUI_MousePressedCleared(t *testing.T) {
 		t.Error("MousePressed should be cleared after EndFrame")
 	}
 }
+
+func TestQueueInput_AppliedAtBeginFrame(t *testing.T) {
+	ui := New(Config{QueueInput: true})
+
+	ui.MouseMove(42, 99)
+	ui.MouseDown(42, 99, MouseLeft)
+	ui.KeyDown(KeyEnter)
+	ui.TextInput("hi")
+	ui.Scroll(0, 3)
+	if ui.input.MousePos.X != 0 || ui.input.KeyDown[KeyEnter] {
+		t.Fatal("queued input changed the state before BeginFrame")
+	}
+
+	ui.BeginFrame()
+	if ui.input.MousePos != (types.Vec2{X: 42, Y: 99}) || !ui.input.MousePressed[MouseLeft] {
+		t.Errorf("mouse = %v pressed %v, want (42,99) pressed", ui.input.MousePos, ui.input.MousePressed[MouseLeft])
+	}
+	if !ui.input.KeyPressed[KeyEnter] || ui.input.TextInput != "hi" || ui.input.ScrollDelta.Y != 3 {
+		t.Errorf("keys/text/scroll not applied: %v %q %v", ui.input.KeyPressed, ui.input.TextInput, ui.input.ScrollDelta)
+	}
+	ui.EndFrame()
+}
+
+func TestQueueInput_TimestampOrder(t *testing.T) {
+	ui := New(Config{})
+	t0 := time.Unix(1000, 0)
+
+	// Pushed out of order, e.g. from two network connections
+	ui.PushInput(MouseMoveEvent{X: 30, Y: 30, Time: t0.Add(2 * time.Millisecond)})
+	ui.PushInput(MouseMoveEvent{X: 10, Y: 10, Time: t0})
+	ui.InputChan() <- MouseMoveEvent{X: 20, Y: 20, Time: t0.Add(time.Millisecond)}
+	ui.PushInput(TextEvent{Rune: 'b', Time: t0.Add(time.Millisecond)})
+	ui.PushInput(TextEvent{Rune: 'a', Time: t0})
+
+	ui.BeginFrame()
+	if ui.input.MousePos != (types.Vec2{X: 30, Y: 30}) {
+		t.Errorf("mouse = %v, want the latest event (30,30)", ui.input.MousePos)
+	}
+	if ui.input.TextInput != "ab" {
+		t.Errorf("text = %q, want %q", ui.input.TextInput, "ab")
+	}
+	ui.EndFrame()
+}
+
+func TestQueueInput_ClickWithinOneFrame(t *testing.T) {
+	ui := New(Config{QueueInput: true})
+	ui.MouseDown(5, 5, MouseLeft)
+	ui.MouseUp(5, 5, MouseLeft)
+	ui.BeginFrame()
+	if !ui.input.MousePressed[MouseLeft] || ui.input.MouseDown[MouseLeft] {
+		t.Error("a press and release in one frame should leave the button pressed this frame but up")
+	}
+	ui.EndFrame()
+}
+
+func TestQueueInput_MouseDeltaAndDrag(t *testing.T) {
+	ui := New(Config{QueueInput: true})
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Win", types.Rect{X: 10, Y: 10, W: 200, H: 100})
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	ui.MouseMove(50, 15) // On the title bar
+	frame()
+	ui.MouseDown(50, 15, MouseLeft)
+	frame()
+	ui.MouseMove(80, 35)
+	ui.BeginFrame()
+	if ui.input.MouseDelta != (types.Vec2{X: 30, Y: 20}) {
+		t.Errorf("MouseDelta = %v, want the queued move (30,20)", ui.input.MouseDelta)
+	}
+	ui.BeginWindow("Win", types.Rect{X: 10, Y: 10, W: 200, H: 100})
+	ui.EndWindow()
+	ui.EndFrame()
+	ui.MouseUp(80, 35, MouseLeft)
+	frame()
+	if r := ui.GetContainer("Win").Rect(); r.X != 40 || r.Y != 30 {
+		t.Errorf("window at (%d,%d) after dragging its title 30,20, want (40,30)", r.X, r.Y)
+	}
+}
+
+func TestQueueInput_ConcurrentSenders(t *testing.T) {
+	ui := New(Config{QueueInput: true})
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				ui.Scroll(0, 1)
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Frames run while the senders are busy; every event lands in one
+	total := 0
+	frame := func() {
+		ui.BeginFrame()
+		total += ui.input.ScrollDelta.Y
+		ui.EndFrame()
+	}
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		frame()
+	}
+	frame()
+	if total != 400 {
+		t.Errorf("scrolled %d, want the 400 sent", total)
+	}
+}
+
+// repeatFrames runs a frame at each offset from t0 and returns whether key
+// was pressed and the text typed in each.
+func repeatFrames(ui *UI, t0 time.Time, key Key, offsets ...time.Duration) (pressed []bool, text []string) {
+	for _, off := range offsets {
+		ui.BeginFrameAt(t0.Add(off))
+		pressed = append(pressed, ui.input.KeyPressed[key])
+		text = append(text, ui.input.TextInput)
+		ui.EndFrame()
+	}
+	return pressed, text
+}
+
+func TestKeyRepeat(t *testing.T) {
+	ui := New(Config{})
+	t0 := time.Now()
+	ms := time.Millisecond
+
+	ui.KeyDown(KeyBackspace)
+	pressed, _ := repeatFrames(ui, t0, KeyBackspace, 0, 100*ms, 399*ms, 400*ms, 420*ms, 450*ms, 900*ms, 910*ms)
+	want := []bool{true, false, false, true, false, true, true, false}
+	for i := range want {
+		if pressed[i] != want[i] {
+			t.Errorf("frame %d: pressed = %v, want %v", i, pressed[i], want[i])
+		}
+	}
+
+	ui.KeyUp(KeyBackspace)
+	if pressed, _ := repeatFrames(ui, t0, KeyBackspace, time.Second, 2*time.Second); pressed[0] || pressed[1] {
+		t.Error("a released key should not repeat")
+	}
+}
+
+func TestKeyRepeat_Text(t *testing.T) {
+	ui := New(Config{KeyRepeatDelay: 100 * time.Millisecond, KeyRepeatInterval: 10 * time.Millisecond})
+	t0 := time.Now()
+
+	// Typed after BeginFrame, as the ebiten backend does
+	ui.BeginFrameAt(t0)
+	ui.KeyDown(KeyChar)
+	ui.TextInput("1")
+	ui.EndFrame()
+
+	_, text := repeatFrames(ui, t0, KeyChar, 50*time.Millisecond, 100*time.Millisecond, 110*time.Millisecond)
+	if text[0] != "" || text[1] != "1" || text[2] != "1" {
+		t.Errorf("text per frame = %q, want [\"\" \"1\" \"1\"]", text)
+	}
+}
+
+func TestKeyRepeat_LastKeyAndModifiers(t *testing.T) {
+	ui := New(Config{})
+	t0 := time.Now()
+
+	// Holding Shift and then A repeats A; pressing B takes over
+	ui.KeyDown(KeyShift)
+	ui.KeyDown(KeyA)
+	repeatFrames(ui, t0, KeyA, 0)
+	ui.KeyDown(KeyB)
+	pressedB, _ := repeatFrames(ui, t0, KeyB, 100*time.Millisecond, 600*time.Millisecond)
+	pressedA, _ := repeatFrames(ui, t0, KeyA, 700*time.Millisecond)
+	if !pressedB[1] || pressedA[0] {
+		t.Errorf("B repeated %v, A repeated %v; want only the last key to repeat", pressedB[1], pressedA[0])
+	}
+	if ui.input.KeyPressed[KeyShift] {
+		t.Error("modifiers should not repeat")
+	}
+}
+
+func TestKeyRepeat_Disabled(t *testing.T) {
+	ui := New(Config{KeyRepeatDelay: -1})
+	t0 := time.Now()
+	ui.KeyDown(KeyLeft)
+	pressed, _ := repeatFrames(ui, t0, KeyLeft, 0, time.Second, 2*time.Second)
+	if pressed[1] || pressed[2] {
+		t.Error("negative KeyRepeatDelay should disable repeat")
+	}
+}
+
+func TestInputTransform_MapsMouse(t *testing.T) {
+	ui := New(Config{})
+	ui.SetInputTransform(4, types.Vec2{X: 80, Y: 0})
+	ui.BeginFrame()
+
+	ui.MouseMove(80+4*42+3, 4*99)
+	if got := ui.MousePos(); got != (types.Vec2{X: 42, Y: 99}) {
+		t.Errorf("MousePos = %v, want (42, 99)", got)
+	}
+	if got := ui.ToScreen(types.Vec2{X: 42, Y: 99}); got != (types.Vec2{X: 80 + 4*42, Y: 4 * 99}) {
+		t.Errorf("ToScreen = %v, want the screen pixel at the UI pixel's corner", got)
+	}
+	if got := ui.FromScreen(types.Vec2{X: 79, Y: 0}); got.X != -1 {
+		t.Errorf("FromScreen left of the offset = %v, want x=-1", got)
+	}
+
+	ui.SetInputTransform(1, types.Vec2{})
+	ui.MouseMove(42, 99)
+	if got := ui.MousePos(); got != (types.Vec2{X: 42, Y: 99}) {
+		t.Errorf("MousePos = %v without a transform, want (42, 99)", got)
+	}
+}
+
+func TestInputTransform_Click(t *testing.T) {
+	// The UI is drawn at half size into a 2x-scaled image, 100 pixels from
+	// the left of the window
+	for _, queue := range []bool{false, true} {
+		a := &traceApp{ui: New(Config{QueueInput: queue})}
+		a.ui.SetInputTransform(2, types.Vec2{X: 100})
+		x, y := 100+2*50, 2*40 // The Add button at UI (50, 40)
+		a.ui.MouseMove(x, y)
+		a.frame()
+		a.ui.MouseDown(x, y, MouseLeft)
+		a.frame()
+		a.ui.MouseUp(x, y, MouseLeft)
+		a.frame()
+		if a.clicks != 1 {
+			t.Errorf("QueueInput=%v: button clicked %d times through the transform, want 1", queue, a.clicks)
+		}
+	}
+}
+
+func TestInputTransform_RecordsMappedPositions(t *testing.T) {
+	ui := New(Config{})
+	ui.SetInputTransform(3, types.Vec2{X: 30, Y: 30})
+	ui.StartInputRecording()
+	ui.MouseMove(30+3*10, 30+3*20)
+	ui.BeginFrame()
+	ui.EndFrame()
+	trace := ui.StopInputRecording()
+	if len(trace.Events) != 1 || trace.Events[0].X != 10 || trace.Events[0].Y != 20 {
+		t.Errorf("recorded %+v, want a move to the UI position (10, 20)", trace.Events)
+	}
+}
+
+func TestKeyRepeat_FrameDelta(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrameDelta(0)
+	ui.EndFrame()
+	ui.KeyDown(KeyA)
+	var pressed []bool
+	for range 6 {
+		ui.BeginFrameDelta(100 * time.Millisecond)
+		pressed = append(pressed, ui.KeyPressed(KeyA))
+		ui.EndFrame()
+	}
+	// Pressed, then held for the 400ms delay, then repeating every 50ms
+	want := []bool{true, false, false, false, true, true}
+	if fmt.Sprint(pressed) != fmt.Sprint(want) {
+		t.Errorf("pressed at 100ms frames = %v, want %v", pressed, want)
+	}
+}
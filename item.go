@@ -0,0 +1,121 @@
+package microui
+
+import "github.com/user/microui-go/types"
+
+// itemState is what UpdateControlOpt saw of the last control, for the
+// IsItem queries.
+type itemState struct {
+	id        ID
+	rect      types.Rect
+	hovered   bool // Mouse over it, in the hover root and not held by another control
+	holdFocus bool // Registered with OptHoldFocus
+	res       int  // Res flags for ItemResult
+}
+
+// itemTrack follows controls across frames for the hover and release
+// flags of ItemResult.
+type itemTrack struct {
+	hoverPrev ID // Control hovered last frame
+	hoverCur  ID // Control hovered this frame
+	held      ID // Control the left button was pressed on, until released
+}
+
+// IsItemHovered reports whether the mouse is over the last control, e.g.
+// to show a tooltip for it. It is false while another control is held.
+//
+//	ui.Button("Save")
+//	if ui.IsItemHovered() {
+//		// draw a tooltip
+//	}
+func (u *UI) IsItemHovered() bool {
+	return u.item.id != 0 && u.item.hovered
+}
+
+// IsItemActive reports whether the last control has input capture: it is
+// held down with the mouse, or is being edited like a focused textbox.
+func (u *UI) IsItemActive() bool {
+	id := u.item.id
+	return id != 0 && u.input.Focus == id && (u.input.MouseDown[int(MouseLeft)] || u.item.holdFocus)
+}
+
+// IsItemFocused reports whether the last control has focus, from the
+// mouse or from keyboard navigation.
+func (u *UI) IsItemFocused() bool {
+	id := u.item.id
+	return id != 0 && (u.input.Focus == id || u.navFocus == id)
+}
+
+// IsItemClicked reports whether btn was pressed over the last control this
+// frame, e.g. MouseRight to open a context menu for it.
+func (u *UI) IsItemClicked(btn MouseButton) bool {
+	return u.IsItemHovered() && u.input.MousePressed[btn]
+}
+
+// ItemResult returns the Res flags of the last control: ResClick when it
+// was clicked, ResChange when its value changed, ResActive while it is
+// held or edited, ResRelease when the mouse is let go over it after
+// pressing it, and ResHoverEnter and ResHoverExit as the mouse moves onto
+// and off it. Button, ImageButton, Selectable, Checkbox, Radio, Slider,
+// Number and the textboxes set ResClick and ResChange; the other flags
+// come from any control.
+//
+//	if ui.Button("Fire"); ui.ItemResult()&microui.ResActive != 0 {
+//		// repeat while held
+//	}
+func (u *UI) ItemResult() int {
+	return u.item.res
+}
+
+// updateItemResult adds the hover, active and release flags to the
+// result of control id. Deferred by UpdateControlOpt.
+func (u *UI) updateItemResult(id ID) {
+	it, tr := &u.item, &u.itemTrack
+	if it.hovered {
+		tr.hoverCur = id
+		if tr.hoverPrev != id {
+			it.res |= ResHoverEnter
+		}
+	} else if tr.hoverPrev == id && tr.hoverCur != id {
+		it.res |= ResHoverExit
+	}
+	if u.input.Focus == id {
+		it.res |= ResActive
+	}
+	if down := u.input.MouseDown[int(MouseLeft)]; down && u.input.Focus == id {
+		tr.held = id
+	} else if !down && tr.held == id {
+		if it.hovered {
+			it.res |= ResRelease
+		}
+		tr.held = 0
+	}
+}
+
+// itemClicked adds ResClick to the last control's result if clicked, and
+// returns clicked.
+func (u *UI) itemClicked(clicked bool) bool {
+	if clicked {
+		u.item.res |= ResClick
+	}
+	return clicked
+}
+
+// itemChanged adds ResChange to the last control's result if changed, and
+// returns changed.
+func (u *UI) itemChanged(changed bool) bool {
+	if changed {
+		u.item.res |= ResChange
+	}
+	return changed
+}
+
+// GetItemID returns the ID of the last control, e.g. to FocusControl a
+// textbox whose ID comes from its buffer.
+func (u *UI) GetItemID() ID {
+	return u.item.id
+}
+
+// GetItemRect returns the rect of the last control.
+func (u *UI) GetItemRect() types.Rect {
+	return u.item.rect
+}
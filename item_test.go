@@ -0,0 +1,132 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// itemFrame draws two buttons and returns the status of the first as seen
+// right after it.
+func itemFrame(ui *UI) (hovered, active, focused, clicked bool, rect types.Rect) {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{100}, 0)
+	ui.Button("A")
+	hovered, active, focused = ui.IsItemHovered(), ui.IsItemActive(), ui.IsItemFocused()
+	clicked, rect = ui.IsItemClicked(MouseRight), ui.GetItemRect()
+	ui.Button("B")
+	ui.EndWindow()
+	ui.EndFrame()
+	return
+}
+
+func TestItemQueries(t *testing.T) {
+	ui := New(Config{})
+	ui.MouseMove(20, 35)
+	itemFrame(ui)
+	hovered, active, _, _, rect := itemFrame(ui)
+	if !hovered || active {
+		t.Errorf("hovered=%v active=%v, want hovered and not active", hovered, active)
+	}
+	if rect != (types.Rect{X: 5, Y: 29, W: 100, H: 20}) {
+		t.Errorf("item rect = %v, want the first button's", rect)
+	}
+
+	ui.MouseDown(20, 35, MouseLeft)
+	_, active, focused, _, _ := itemFrame(ui)
+	if !active || !focused {
+		t.Errorf("active=%v focused=%v while held, want both", active, focused)
+	}
+	ui.MouseUp(20, 35, MouseLeft)
+	itemFrame(ui)
+
+	ui.MouseDown(20, 35, MouseRight)
+	if _, _, _, clicked, _ := itemFrame(ui); !clicked {
+		t.Error("right press over the button should report IsItemClicked(MouseRight)")
+	}
+	ui.MouseUp(20, 35, MouseRight)
+
+	// Moving away clears hover even though the button was the last hovered
+	ui.MouseMove(300, 200)
+	if hovered, _, _, _, _ := itemFrame(ui); hovered {
+		t.Error("button reported hovered after the mouse left it")
+	}
+}
+
+func TestItemQueries_HeldTextboxIsActive(t *testing.T) {
+	ui := New(Config{})
+	buf := make([]byte, 0, 32)
+	var active bool
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.LayoutRow(1, []int{100}, 0)
+		ui.Textbox(&buf, 32)
+		active = ui.IsItemActive()
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	ui.MouseMove(20, 35)
+	frame()
+	ui.MouseDown(20, 35, MouseLeft)
+	frame()
+	ui.MouseUp(20, 35, MouseLeft)
+	frame()
+	if !active {
+		t.Error("a textbox being edited should stay active after the click")
+	}
+}
+
+func TestItemResult(t *testing.T) {
+	ui := New(Config{})
+	var checked bool
+	var button, box int
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.LayoutRow(1, []int{100}, 0)
+		ui.Button("A")
+		button = ui.ItemResult()
+		ui.Checkbox("B", &checked)
+		box = ui.ItemResult()
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	frame()
+	ui.MouseMove(20, 35)
+	frame()
+	if button != ResHoverEnter {
+		t.Errorf("button result = %b, want ResHoverEnter", button)
+	}
+	frame()
+	if button != 0 {
+		t.Errorf("button result = %b, want none while just hovered", button)
+	}
+	ui.MouseDown(20, 35, MouseLeft)
+	frame()
+	if button != ResClick|ResActive {
+		t.Errorf("button result = %b, want ResClick|ResActive on press", button)
+	}
+	frame()
+	if button != ResActive {
+		t.Errorf("button result = %b, want ResActive while held", button)
+	}
+	ui.MouseUp(20, 35, MouseLeft)
+	frame()
+	if button != ResRelease {
+		t.Errorf("button result = %b, want ResRelease", button)
+	}
+
+	// Moving to the checkbox leaves the button and enters the checkbox
+	ui.MouseMove(20, 59)
+	frame()
+	if button != ResHoverExit || box != ResHoverEnter {
+		t.Errorf("results = %b, %b, want ResHoverExit and ResHoverEnter", button, box)
+	}
+	ui.MouseDown(20, 59, MouseLeft)
+	frame()
+	if want := ResClick | ResChange | ResActive; box != want || !checked {
+		t.Errorf("checkbox result = %b, want %b", box, want)
+	}
+}
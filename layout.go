@@ -1,6 +1,11 @@
 package microui
 
-import "github.com/user/microui-go/types"
+import (
+	"math"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
 
 const (
 	nextTypeNone     = 0
@@ -8,6 +13,18 @@ const (
 	nextTypeRelative = 2
 )
 
+// Anchor is a corner of the container body an item can be pinned to with
+// LayoutAnchor.
+type Anchor int
+
+const (
+	AnchorNone Anchor = iota
+	AnchorTopLeft
+	AnchorTopRight
+	AnchorBottomLeft
+	AnchorBottomRight
+)
+
 // ColumnLayout stores state for a layout column.
 type ColumnLayout struct {
 	columnRect types.Rect // This column's bounds
@@ -27,6 +44,16 @@ type Layout struct {
 	indent    int        // Current indentation
 	next      types.Rect // Override rect for next LayoutNext call
 	nextType  int        // 0=none, 1=absolute, 2=relative (body-relative)
+	aligns    []int      // Text alignment per column (from LayoutAlign)
+	align     int        // Alignment of the cell LayoutNext returned last
+	sameLine  bool       // Place the next item on the current row (SameLine)
+	grid      bool       // Rows use gridSpacing (LayoutGrid)
+	gridSpace int        // Spacing between grid cells
+	anchor    Anchor     // Corner to pin the next item to (LayoutAnchor)
+	scroll    types.Vec2 // Scroll offset body was shifted by
+	fillW     bool       // An item's width was relative to the body's (negative)
+	fillH     bool       // An item's height was relative to the body's (negative)
+	columns   *columnSet // Set of Columns this is the layout of a column of
 
 	// Go extensions: explicit size overrides (cleared after each use)
 	sizeOverrideW int // Width override from LayoutWidth (0 = not set)
@@ -40,6 +67,12 @@ func (u *UI) LayoutRow(columns int, widths []int, height int) {
 	if widths != nil {
 		layout.widths = make([]int, columns)
 		copy(layout.widths, widths)
+		layout.aligns = nil
+		if layout.grid {
+			// Normal spacing below a grid
+			layout.nextRow += u.style.Spacing - layout.gridSpace
+			layout.grid = false
+		}
 	}
 	layout.items = columns
 	layout.position = types.Vec2{X: layout.indent, Y: layout.nextRow}
@@ -47,11 +80,116 @@ func (u *UI) LayoutRow(columns int, widths []int, height int) {
 	layout.itemIndex = 0
 }
 
+// LayoutRowWeights sets up a row whose columns share the width left after
+// spacing in proportion to weights, e.g. {1, 2, 1} gives the middle column
+// half of the row. The widths follow the container as it is resized.
+func (u *UI) LayoutRowWeights(weights []float64, height int) {
+	layout := u.getLayout()
+	avail := max(0, layout.body.W-layout.indent-u.style.Spacing*(len(weights)-1))
+	weight := func(i int) float64 { return max(weights[i], 0) }
+	total := 0.0
+	for i := range weights {
+		total += weight(i)
+	}
+	if total == 0 {
+		weight = func(int) float64 { return 1 } // Equal widths
+		total = float64(len(weights))
+	}
+
+	// Round the column edges rather than the widths so they add up
+	widths := make([]int, len(weights))
+	sum, edge := 0.0, 0
+	for i := range weights {
+		sum += weight(i)
+		next := int(math.Round(float64(avail) * sum / total))
+		widths[i] = next - edge
+		edge = next
+	}
+	u.LayoutRow(len(weights), widths, height)
+}
+
+// LayoutGrid lays out the following controls in a grid of cellW x cellH
+// cells, spacing pixels apart, wrapping to a new row every cols cells. With
+// cols <= 0 as many columns fit as the container is wide. The grid lasts
+// until the next LayoutRow, and its rows count towards the content size
+// like any other, so the container scrolls to show them.
+func (u *UI) LayoutGrid(cols, cellW, cellH, spacing int) {
+	layout := u.getLayout()
+	if cols <= 0 {
+		cols = max(1, (layout.body.W-layout.indent+spacing)/max(cellW+spacing, 1))
+	}
+	widths := make([]int, cols)
+	for i := range widths {
+		widths[i] = cellW
+	}
+	u.LayoutRow(cols, widths, cellH)
+	layout.grid = true
+	layout.gridSpace = spacing
+}
+
+// LayoutAlign sets the text alignment (0, OptAlignCenter or OptAlignRight)
+// of labels and selectables in each column of the current row, until the
+// next LayoutRow. An alignment passed to the control itself wins.
+func (u *UI) LayoutAlign(aligns ...int) {
+	u.getLayout().aligns = append([]int(nil), aligns...)
+}
+
+// SameLine places the next control on the current row even if the row is
+// full, to the right of the last one. It gets the default width unless
+// LayoutWidth sets one.
+func (u *UI) SameLine() {
+	u.getLayout().sameLine = true
+}
+
+// LayoutAnchor pins the next item to a corner of the visible container
+// body, e.g. a Close button at the bottom right however long the content
+// is. The item keeps its size but takes no room in the flow, so the items
+// after it are placed as if it wasn't there. Anchor a LayoutBeginColumn to
+// pin a group of controls. Content can scroll underneath an anchored item;
+// use BeginFooter to keep it clear.
+func (u *UI) LayoutAnchor(a Anchor) {
+	u.getLayout().anchor = a
+}
+
+// anchorRect moves r to corner a of the layout's body as it is on screen,
+// before scrolling.
+func (l *Layout) anchorRect(r types.Rect, a Anchor) types.Rect {
+	body := l.body
+	body.X += l.scroll.X
+	body.Y += l.scroll.Y
+	r.X, r.Y = body.X, body.Y
+	if a == AnchorTopRight || a == AnchorBottomRight {
+		r.X = body.X + body.W - r.W
+	}
+	if a == AnchorBottomLeft || a == AnchorBottomRight {
+		r.Y = body.Y + body.H - r.H
+	}
+	return r
+}
+
+// cellAlign adds the current cell's LayoutAlign alignment to opt unless
+// opt has an alignment of its own.
+func (u *UI) cellAlign(opt int) int {
+	if opt&(OptAlignCenter|OptAlignRight) == 0 {
+		opt |= u.getLayout().align
+	}
+	return opt
+}
+
 // LayoutNext returns the next layout rectangle and advances the layout.
 func (u *UI) LayoutNext() types.Rect {
+	if u.metrics != nil {
+		defer u.metrics.timeLayout(time.Now())
+	}
 	layout := u.getLayout()
 	style := &u.style
 	var res types.Rect
+	sameLine := layout.sameLine
+	layout.sameLine = false
+	layout.align = 0
+	anchor := layout.anchor
+	layout.anchor = AnchorNone
+	position, nextRow, itemIndex, maxExt := layout.position, layout.nextRow, layout.itemIndex, layout.max
 
 	if layout.nextType != nextTypeNone {
 		nextType := layout.nextType
@@ -62,9 +200,12 @@ func (u *UI) LayoutNext() types.Rect {
 			return res
 		}
 	} else {
-		if layout.itemIndex == layout.items {
+		if layout.itemIndex >= layout.items && !sameLine {
 			u.LayoutRow(layout.items, nil, layout.size.Y)
 		}
+		if layout.itemIndex < len(layout.aligns) {
+			layout.align = layout.aligns[layout.itemIndex]
+		}
 		res.X = layout.position.X
 		res.Y = layout.position.Y
 
@@ -92,15 +233,21 @@ func (u *UI) LayoutNext() types.Rect {
 		}
 		if res.W < 0 {
 			res.W += layout.body.W - res.X + 1
+			layout.fillW = layout.fillW || anchor == AnchorNone
 		}
 		if res.H < 0 {
 			res.H += layout.body.H - res.Y + 1
+			layout.fillH = layout.fillH || anchor == AnchorNone
 		}
 		layout.itemIndex++
 	}
 
-	layout.position.X += res.W + style.Spacing
-	newNextRow := res.Y + res.H + style.Spacing
+	spacing := style.Spacing
+	if layout.grid {
+		spacing = layout.gridSpace
+	}
+	layout.position.X += res.W + spacing
+	newNextRow := res.Y + res.H + spacing
 	if newNextRow > layout.nextRow {
 		layout.nextRow = newNextRow
 	}
@@ -115,6 +262,12 @@ func (u *UI) LayoutNext() types.Rect {
 		layout.max.Y = res.Y + res.H
 	}
 
+	if anchor != AnchorNone {
+		// Anchored items take no room in the flow
+		layout.position, layout.nextRow, layout.itemIndex, layout.max = position, nextRow, itemIndex, maxExt
+		res = layout.anchorRect(res, anchor)
+	}
+
 	u.lastRect = res
 	return res
 }
@@ -142,7 +295,8 @@ func (u *UI) pushLayout(body types.Rect, scroll types.Vec2) {
 			W: body.W,
 			H: body.H,
 		},
-		max: types.Vec2{X: -0x1000000, Y: -0x1000000},
+		max:    types.Vec2{X: -0x1000000, Y: -0x1000000},
+		scroll: scroll,
 	}
 	u.layoutStack.Push(layout)
 
@@ -156,6 +310,7 @@ func (u *UI) PushLayout(body types.Rect) {
 
 // PopLayout pops the current layout context.
 func (u *UI) PopLayout() {
+	u.endReveals(u.layoutStack.Len())
 	if u.layoutStack.Len() > 0 {
 		u.layoutStack.Pop()
 	}
@@ -183,6 +338,26 @@ func (u *UI) LayoutSetNext(rect types.Rect, relative bool) {
 	}
 }
 
+// LayoutFitContent makes the current window or panel fit its content from
+// the next frame, as OptFitContent does, for each frame it is called in.
+// Call it after deciding from GetContentSize whether the content fits.
+func (u *UI) LayoutFitContent() {
+	if cnt := u.GetCurrentContainer(); cnt != nil {
+		cnt.fitNext = true
+	}
+}
+
+// GetContentSize returns the size of the content laid out so far this
+// frame in the current window, panel or column. Container.ContentSize
+// returns the size measured when the container last ended.
+func (u *UI) GetContentSize() types.Vec2 {
+	layout := u.getLayout()
+	return types.Vec2{
+		X: max(0, layout.max.X-layout.body.X),
+		Y: max(0, layout.max.Y-layout.body.Y),
+	}
+}
+
 // LayoutBeginColumn starts a sub-layout column within the current row.
 func (u *UI) LayoutBeginColumn() {
 	columnRect := u.LayoutNext()
@@ -101,3 +101,143 @@ func TestLayoutFillWidth(t *testing.T) {
 	ui.EndWindow()
 	ui.EndFrame()
 }
+
+func TestLayoutRowWeights(t *testing.T) {
+	ui := New(Config{})
+	rowRects := func(w int) []types.Rect {
+		var rects []types.Rect
+		ui.GetContainer("Weights").SetRect(types.Rect{X: 0, Y: 0, W: w, H: 300})
+		ui.BeginFrame()
+		ui.BeginWindow("Weights", types.Rect{X: 0, Y: 0, W: w, H: 300})
+		ui.LayoutRowWeights([]float64{1, 2, 1}, 0)
+		for i := 0; i < 3; i++ {
+			rects = append(rects, ui.LayoutNext())
+		}
+		ui.EndWindow()
+		ui.EndFrame()
+		return rects
+	}
+
+	for _, w := range []int{400, 200} {
+		r := rowRects(w)
+		body := w - 10 // 5px padding each side
+		if end := r[2].X + r[2].W; end != 5+body {
+			t.Errorf("width %d: row ends at %d, want %d", w, end, 5+body)
+		}
+		if r[1].X != r[0].X+r[0].W+4 || r[2].X != r[1].X+r[1].W+4 {
+			t.Errorf("width %d: columns %v not spaced by 4", w, r)
+		}
+		if d := r[1].W - 2*r[0].W; d < -1 || d > 1 {
+			t.Errorf("width %d: middle column %d, want twice %d", w, r[1].W, r[0].W)
+		}
+	}
+}
+
+func TestSameLine(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{100}, 0)
+	first := ui.LayoutNext()
+	ui.SameLine()
+	ui.LayoutWidth(50)
+	same := ui.LayoutNext()
+	next := ui.LayoutNext()
+	ui.EndWindow()
+	ui.EndFrame()
+
+	if same != (types.Rect{X: first.X + 104, Y: first.Y, W: 50, H: first.H}) {
+		t.Errorf("SameLine rect = %v, want right of %v", same, first)
+	}
+	if next.X != first.X || next.Y != first.Y+first.H+4 || next.W != 100 {
+		t.Errorf("rect after SameLine = %v, want the next row", next)
+	}
+}
+
+func TestLayoutAlign(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(2, []int{100, 100}, 0)
+	ui.LayoutAlign(OptAlignRight)
+	ui.Label("ab")                    // Right: 5+100-16-5
+	ui.Label("cd")                    // No alignment for the second column
+	ui.LabelOpt("ef", OptAlignCenter) // Own alignment wins: 5+(100-16)/2
+	ui.LayoutRow(1, []int{100}, 0)    // A new row resets it
+	ui.Label("gh")
+	ui.EndWindow()
+	ui.EndFrame()
+
+	want := map[string]int{"ab": 84, "cd": 114, "ef": 47, "gh": 10}
+	for _, cmd := range ui.commands.cmds {
+		if x, ok := want[cmd.Text]; ok && cmd.Kind == CmdText && cmd.Pos.X != x {
+			t.Errorf("%q at x=%d, want %d", cmd.Text, cmd.Pos.X, x)
+		}
+	}
+}
+
+func TestLayoutGrid(t *testing.T) {
+	ui := New(Config{})
+	var cells []types.Rect
+	var after types.Rect
+	frame := func(cols int) {
+		cells = cells[:0]
+		ui.BeginFrame()
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.LayoutRow(1, []int{100}, 50)
+		ui.BeginPanelOpt("grid", OptNoFrame)
+		ui.LayoutGrid(cols, 20, 20, 2)
+		for i := 0; i < 10; i++ {
+			cells = append(cells, ui.LayoutNext())
+		}
+		ui.LayoutRow(1, []int{-1}, 0)
+		after = ui.LayoutNext()
+		ui.EndPanel()
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+
+	frame(4)
+	// The panel body starts at (10,34) inside its padding
+	for i, r := range cells {
+		want := types.Rect{X: 10 + i%4*22, Y: 34 + i/4*22, W: 20, H: 20}
+		if r != want {
+			t.Errorf("cell %d = %v, want %v", i, r, want)
+		}
+	}
+	if after.Y != 34+3*22-2+4 {
+		t.Errorf("row after the grid at y=%d, want it below the last grid row", after.Y)
+	}
+	if got := ui.GetContainer("grid").ContentSize().Y; got < 3*22-2 {
+		t.Errorf("content height = %d, want it to include all three grid rows", got)
+	}
+
+	// Without a column count the grid fills the width left by padding and
+	// the scrollbar: (100-10-12+2)/22 = 3
+	frame(0)
+	if cells[3].X != cells[0].X || cells[3].Y != cells[0].Y+22 {
+		t.Errorf("auto columns: cell 3 = %v, want the start of the second row", cells[3])
+	}
+}
+
+func TestLayoutAnchor(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{100}, 0)
+	first := ui.LayoutNext()
+	ui.LayoutAnchor(AnchorBottomRight)
+	pinned := ui.LayoutNext()
+	next := ui.LayoutNext()
+	body := ui.GetCurrentContainer().Body()
+	ui.EndWindow()
+	ui.EndFrame()
+
+	want := types.Rect{X: body.X + body.W - 5 - 100, Y: body.Y + body.H - 5 - 20, W: 100, H: 20}
+	if pinned != want {
+		t.Errorf("anchored rect = %v, want %v in the bottom right corner", pinned, want)
+	}
+	if next.Y != first.Y+24 {
+		t.Errorf("next item at y=%d, want %d as if the anchored one took no room", next.Y, first.Y+24)
+	}
+}
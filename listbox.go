@@ -0,0 +1,174 @@
+package microui
+
+// listBoxState persists keyboard cursor and range anchor for a list box.
+type listBoxState struct {
+	cursor int // Row with keyboard focus
+	anchor int // Start of shift-selection range
+	ensure int // Row to scroll into view on the next frame (-1 = none)
+}
+
+// ListBox adds a scrollable single-selection list filling the next layout cell.
+// Click or Up/Down/Home/End change the selection; Enter reports ResSubmit.
+// Returns ResChange when *selected changed and ResActive while focused.
+func (u *UI) ListBox(name string, items []string, selected *int) int {
+	return u.listBox(name, items, selected, nil)
+}
+
+// ListBoxMulti adds a scrollable multi-selection list. selected must have one
+// entry per item. Ctrl+click toggles a row, Shift+click or Shift+arrows select
+// a range from the last clicked row, and Ctrl+A selects everything.
+func (u *UI) ListBoxMulti(name string, items []string, selected []bool) int {
+	return u.listBox(name, items, nil, selected)
+}
+
+// ListBoxEnsureVisible scrolls the named list box so that row index is
+// visible the next time it is drawn.
+func (u *UI) ListBoxEnsureVisible(name string, index int) {
+	u.getListBoxState(u.getRawID(name)).ensure = index
+}
+
+func (u *UI) getListBoxState(id ID) *listBoxState {
+	st, ok := u.listBoxes[id]
+	if !ok {
+		st = &listBoxState{ensure: -1}
+		u.listBoxes[id] = st
+	}
+	return st
+}
+
+// listBox implements ListBox (single != nil) and ListBoxMulti (multi != nil).
+func (u *UI) listBox(name string, items []string, single *int, multi []bool) int {
+	id := u.GetID(name)
+	cnt := u.GetContainer(name)
+	st := u.getListBoxState(cnt.id)
+	rowH := u.style.Size.Y + u.style.Padding.Y*2
+	pitch := rowH + u.style.Spacing
+	res := 0
+	if multi == nil && *single >= 0 && *single < len(items) {
+		st.cursor = *single
+	}
+
+	isSelected := func(i int) bool {
+		if multi != nil {
+			return i < len(multi) && multi[i]
+		}
+		return *single == i
+	}
+	// selectRange selects [a, b] (inclusive, any order), optionally clearing others
+	selectRange := func(a, b int, keep bool) {
+		if a > b {
+			a, b = b, a
+		}
+		if multi == nil {
+			if *single != b {
+				*single = b
+				res |= ResChange
+			}
+			return
+		}
+		for i := range multi {
+			want := i >= a && i <= b
+			if keep {
+				want = want || multi[i]
+			}
+			if multi[i] != want {
+				multi[i] = want
+				res |= ResChange
+			}
+		}
+	}
+
+	// Keyboard navigation (uses the previous frame's focus)
+	if u.input.Focus == id && len(items) > 0 {
+		shift := u.input.KeyDown[KeyShift] && multi != nil
+		move := -1
+		if u.input.KeyPressed[KeyUp] {
+			move = max(st.cursor-1, 0)
+		}
+		if u.input.KeyPressed[KeyDown] {
+			move = min(st.cursor+1, len(items)-1)
+		}
+		if u.input.KeyPressed[KeyHome] {
+			move = 0
+		}
+		if u.input.KeyPressed[KeyEnd] {
+			move = len(items) - 1
+		}
+		if move >= 0 {
+			st.cursor = move
+			if shift {
+				selectRange(st.anchor, st.cursor, false)
+			} else {
+				st.anchor = st.cursor
+				selectRange(st.cursor, st.cursor, false)
+			}
+			st.ensure = st.cursor
+		}
+		if multi != nil && u.shortcutDown() && u.input.KeyPressed[KeyA] {
+			selectRange(0, len(items)-1, false)
+		}
+		if u.input.KeyPressed[KeyEnter] {
+			res |= ResSubmit
+		}
+	}
+
+	// Scroll a requested row into view using the previous frame's body
+	if st.ensure >= 0 && cnt.body.H > 0 {
+		top := st.ensure * pitch
+		viewH := cnt.body.H - u.style.Padding.Y*2
+		if top < cnt.scroll.Y {
+			cnt.scroll.Y = top
+		} else if top+rowH > cnt.scroll.Y+viewH {
+			cnt.scroll.Y = top + rowH - viewH
+		}
+		st.ensure = -1
+	}
+
+	// The whole list is one focusable control; rows are hit-tested below.
+	// Focus is resolved before BeginPanel so the panel's scrollbars, updated
+	// afterwards, still win clicks on their own area.
+	rect := u.LayoutNext()
+	u.UpdateControlOpt(id, rect, OptHoldFocus)
+	u.LayoutSetNext(rect, false)
+
+	u.BeginPanelOpt(name, 0)
+	u.accessAs(cnt, id, RoleListBox, name)
+	u.LayoutRow(1, []int{-1}, rowH)
+	for i, item := range items {
+		r := u.LayoutNext()
+		hovered := u.MouseOver(r) && u.input.Hover == id
+
+		if u.input.MousePressed[int(MouseLeft)] && u.input.Focus == id && u.MouseOver(r) {
+			switch {
+			case multi != nil && u.input.KeyDown[KeyShift]:
+				selectRange(st.anchor, i, u.shortcutDown())
+			case multi != nil && u.shortcutDown():
+				multi[i] = !multi[i]
+				st.anchor = i
+				res |= ResChange
+			default:
+				selectRange(i, i, false)
+				st.anchor = i
+			}
+			st.cursor = i
+		}
+
+		if isSelected(i) {
+			u.DrawFrame(r, ColorButtonFocus)
+		} else if hovered || (multi != nil && u.input.Focus == id && i == st.cursor) {
+			u.DrawFrame(r, ColorButtonHover)
+		}
+		u.DrawControlText(item, r, ColorText, 0)
+		var state AccessState
+		if isSelected(i) {
+			state = AccessSelected
+		}
+		u.accessControl(0, r, RoleOption, item, "", state)
+	}
+	u.EndPanel()
+
+	if u.input.Focus == id {
+		res |= ResActive
+	}
+	return res
+}
@@ -0,0 +1,129 @@
+package microui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func listItems(n int) []string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf("Item %d", i)
+	}
+	return items
+}
+
+// listFrame draws a 200x100 list box at (5,29). Row i starts at y=34+24*i (minus scroll).
+func listFrame(ui *UI, build func()) {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 100)
+	build()
+	ui.EndWindow()
+	ui.EndFrame()
+}
+
+func listClick(ui *UI, x, y int, build func()) {
+	ui.MouseMove(x, y)
+	listFrame(ui, build)
+	ui.MouseDown(x, y, MouseLeft)
+	listFrame(ui, build)
+	ui.MouseUp(x, y, MouseLeft)
+	listFrame(ui, build)
+}
+
+func TestListBox_ClickSelects(t *testing.T) {
+	ui := New(Config{})
+	items := listItems(3)
+	selected := -1
+	res := 0
+	build := func() { res |= ui.ListBox("list", items, &selected) }
+
+	listClick(ui, 50, 60, build) // row 1 spans y=58..78
+	if selected != 1 {
+		t.Errorf("selected = %d, want 1", selected)
+	}
+	if res&ResChange == 0 {
+		t.Error("expected ResChange")
+	}
+}
+
+func TestListBox_KeyboardNavigation(t *testing.T) {
+	ui := New(Config{})
+	items := listItems(10)
+	selected := 0
+	build := func() { ui.ListBox("list", items, &selected) }
+
+	listClick(ui, 50, 40, build)
+	for i := 0; i < 6; i++ {
+		pressKey(ui, KeyDown)
+		listFrame(ui, build)
+	}
+	if selected != 6 {
+		t.Fatalf("selected = %d, want 6", selected)
+	}
+	// Row 6 must have been scrolled into view
+	if scroll := ui.GetContainer("list").Scroll().Y; scroll == 0 {
+		t.Error("list should scroll to keep the cursor visible")
+	}
+
+	pressKey(ui, KeyHome)
+	listFrame(ui, build)
+	listFrame(ui, build)
+	if selected != 0 || ui.GetContainer("list").Scroll().Y != 0 {
+		t.Errorf("Home: selected = %d scroll = %d, want 0 and 0", selected, ui.GetContainer("list").Scroll().Y)
+	}
+}
+
+func TestListBoxMulti_Modifiers(t *testing.T) {
+	ui := New(Config{})
+	items := listItems(4)
+	selected := make([]bool, len(items))
+	build := func() { ui.ListBoxMulti("list", items, selected) }
+
+	listClick(ui, 50, 40, build) // row 0
+
+	ui.KeyDown(KeyShift)
+	listClick(ui, 50, 85, build) // row 2
+	ui.KeyUp(KeyShift)
+	if !selected[0] || !selected[1] || !selected[2] || selected[3] {
+		t.Fatalf("shift-click range = %v, want rows 0-2", selected)
+	}
+
+	ui.KeyDown(KeyCtrl)
+	listClick(ui, 50, 60, build) // toggle row 1 off
+	ui.KeyUp(KeyCtrl)
+	if selected[1] || !selected[0] || !selected[2] {
+		t.Fatalf("ctrl-click = %v, want row 1 toggled off", selected)
+	}
+
+	ui.KeyDown(KeyCtrl)
+	pressKey(ui, KeyA)
+	listFrame(ui, build)
+	ui.KeyUp(KeyCtrl)
+	for i, s := range selected {
+		if !s {
+			t.Errorf("Ctrl+A: row %d not selected", i)
+		}
+	}
+}
+
+func TestListBox_EnsureVisible(t *testing.T) {
+	ui := New(Config{})
+	items := listItems(20)
+	selected := 0
+	build := func() { ui.ListBox("list", items, &selected) }
+
+	listFrame(ui, build)
+	listFrame(ui, build)
+	ui.ListBoxEnsureVisible("list", 15)
+	listFrame(ui, build)
+
+	cnt := ui.GetContainer("list")
+	top := 15 * 24
+	if cnt.Scroll().Y > top || cnt.Scroll().Y+cnt.Body().H-10 < top+20 {
+		t.Errorf("row 15 (y=%d) not visible with scroll %d and body %v", top, cnt.Scroll().Y, cnt.Body())
+	}
+}
@@ -0,0 +1,39 @@
+package microui
+
+// MenuItem adds a row of a menu, usually in a popup: label at the left
+// and, if a shortcut's Label is label, the shortcut's keys at the right.
+// Returns true when it is clicked or activated with the keyboard, and
+// then closes the popup it is in.
+//
+//	save, _ := ui.RegisterShortcut("ctrl+s", saveFile)
+//	save.Label = "Save"
+//	...
+//	if ui.BeginPopup("file") {
+//		if ui.MenuItem("Save") { // Shows "Save  Ctrl+S"
+//			saveFile()
+//		}
+//		ui.EndPopup()
+//	}
+func (u *UI) MenuItem(label string) bool {
+	id := u.getID(label)
+	rect := u.LayoutNext()
+	u.UpdateControl(id, rect)
+	clicked := u.itemClicked(u.activated(id))
+
+	if u.input.Hover == id || u.input.Focus == id {
+		u.DrawControlFrame(id, rect, ColorButton, 0)
+	}
+	u.DrawControlText(label, rect, ColorText, 0)
+	keys := u.shortcutFor(label)
+	if keys != "" {
+		u.DrawControlText(keys, rect, ColorText, OptAlignRight)
+	}
+	u.accessControl(id, rect, RoleMenuItem, label, keys, 0)
+
+	if clicked {
+		if root := u.rootContainer(); root != nil && root.opt&OptPopup != 0 {
+			root.open = false
+		}
+	}
+	return clicked
+}
@@ -0,0 +1,216 @@
+package microui
+
+import (
+	"fmt"
+	"runtime/metrics"
+	"slices"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+// metricsWindowName is the container name of the window shown by
+// MetricsWindow.
+const metricsWindowName = "Metrics"
+
+// metricsFrames is how many frames of history MetricsWindow graphs.
+const metricsFrames = 120
+
+// Phases of a frame timed for MetricsWindow.
+const (
+	phaseUpdate = iota // BeginFrame to EndFrame: the application's UI code
+	phaseLayout        // Time in LayoutNext, part of phaseUpdate
+	phaseEnd           // EndFrame
+	phaseRender        // Render
+	phaseCount
+)
+
+var phaseNames = [phaseCount]string{"Update", "Layout", "End frame", "Render"}
+
+// allocSamples are the runtime metrics read each frame for the allocation
+// counters.
+var allocSamples = []string{"/gc/heap/allocs:objects", "/gc/heap/allocs:bytes", "/memory/classes/heap/objects:bytes"}
+
+// windowCommands is the number of commands a root window drew.
+type windowCommands struct {
+	name     string
+	commands int
+}
+
+// frameMetrics records the timings MetricsWindow shows. It is created by
+// the first MetricsWindow call, so a UI without one isn't timed.
+type frameMetrics struct {
+	frameMS  []float64                 // Time between frames, oldest first
+	phaseMS  [phaseCount][]float64     // Time in each phase, oldest first
+	allocs   []float64                 // Heap objects allocated per frame
+	bytes    []float64                 // Heap bytes allocated per frame
+	heap     uint64                    // Bytes in live heap objects
+	windows  []windowCommands          // Commands per window last frame
+	cur      [phaseCount]time.Duration // This frame's phase times so far
+	start    time.Time                 // When the current phase started
+	last     time.Time                 // When the last frame began
+	samples  []metrics.Sample
+	prevObjs uint64 // Allocation counters at the last frame's start
+	prevByte uint64
+}
+
+func newFrameMetrics() *frameMetrics {
+	m := &frameMetrics{samples: make([]metrics.Sample, len(allocSamples))}
+	for i, name := range allocSamples {
+		m.samples[i].Name = name
+	}
+	m.readAllocs()
+	return m
+}
+
+// readAllocs reads the allocation counters, returning how much was
+// allocated since the last read.
+func (m *frameMetrics) readAllocs() (objs, bytes uint64) {
+	metrics.Read(m.samples)
+	var v [3]uint64
+	for i, s := range m.samples {
+		if s.Value.Kind() == metrics.KindUint64 {
+			v[i] = s.Value.Uint64()
+		}
+	}
+	objs, bytes = v[0]-m.prevObjs, v[1]-m.prevByte
+	m.prevObjs, m.prevByte, m.heap = v[0], v[1], v[2]
+	return objs, bytes
+}
+
+// pushHistory appends v to the history h, dropping its oldest value
+// when full.
+func pushHistory(h []float64, v float64) []float64 {
+	if len(h) == metricsFrames {
+		copy(h, h[1:])
+		h = h[:len(h)-1]
+	}
+	return append(h, v)
+}
+
+// millis returns d in milliseconds.
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// beginFrame records the last frame, which began at m.last, and starts
+// timing the frame beginning at now.
+func (m *frameMetrics) beginFrame(now time.Time) {
+	if !m.last.IsZero() {
+		objs, bytes := m.readAllocs()
+		m.frameMS = pushHistory(m.frameMS, millis(now.Sub(m.last)))
+		m.allocs = pushHistory(m.allocs, float64(objs))
+		m.bytes = pushHistory(m.bytes, float64(bytes))
+		for i, d := range m.cur {
+			m.phaseMS[i] = pushHistory(m.phaseMS[i], millis(d))
+		}
+	}
+	m.last = now
+	m.cur = [phaseCount]time.Duration{}
+	m.start = time.Now()
+}
+
+// endPhase adds the time since the phase started to phase and starts the
+// next one.
+func (m *frameMetrics) endPhase(phase int) {
+	now := time.Now()
+	m.cur[phase] += now.Sub(m.start)
+	m.start = now
+}
+
+// timeLayout adds the time since start to the layout phase.
+func (m *frameMetrics) timeLayout(start time.Time) {
+	m.cur[phaseLayout] += time.Since(start)
+}
+
+// countWindows records the commands drawn by each window this frame.
+func (u *UI) countWindows() {
+	m := u.metrics
+	m.windows = m.windows[:0]
+	for _, cnt := range u.zOrder {
+		if u.begunThisFrame(cnt) {
+			m.windows = append(m.windows, windowCommands{cnt.name, cnt.tailIdx - cnt.headIdx})
+		}
+	}
+	slices.SortStableFunc(m.windows, func(a, b windowCommands) int { return b.commands - a.commands })
+}
+
+// MetricsWindow shows a window for finding the UI's hotspots: a graph of
+// frame times, how long each phase of the frame took, the commands each
+// window drew and the heap allocations per frame. It starts closed and
+// Ctrl+Shift+M toggles it; ShowMetrics opens it from code. The core is
+// timed from the first call on, so call it every frame, e.g. at the end
+// of the frame like DebugWindow.
+func (u *UI) MetricsWindow() {
+	if u.metrics == nil {
+		u.metrics = newFrameMetrics()
+	}
+	if u.shortcutDown() && u.input.KeyDown[KeyShift] && u.input.KeyPressed[KeyM] {
+		cnt := u.GetContainer(metricsWindowName)
+		cnt.open = !cnt.open
+	}
+	if !u.BeginWindowOpt(metricsWindowName, types.Rect{X: 10, Y: 10, W: 300, H: 420}, OptClosed) {
+		return
+	}
+	defer u.EndWindow()
+
+	m := u.metrics
+	row := func(label, format string, args ...any) {
+		u.LayoutRow(2, []int{110, -1}, 0)
+		u.Label(label)
+		u.Label(fmt.Sprintf(format, args...))
+	}
+
+	if u.HeaderEx("Frame", OptExpanded) {
+		mean, worst := meanMax(m.frameMS)
+		fps := 0.0
+		if mean > 0 {
+			fps = 1000 / mean
+		}
+		row("FPS", "%.0f", fps)
+		row("Frame time", "%.2f ms (max %.2f)", mean, worst)
+		u.LayoutRow(1, []int{-1}, 60)
+		u.PlotLinesRange("ms", m.frameMS, 0, max(33.3, worst), 0)
+	}
+
+	if u.HeaderEx("Phases", OptExpanded) {
+		for i, name := range phaseNames {
+			mean, _ := meanMax(m.phaseMS[i])
+			u.LayoutRow(3, []int{110, 80, -1}, 0)
+			u.Label(name)
+			u.Sparkline(m.phaseMS[i])
+			u.Label(fmt.Sprintf("%.3f ms", mean))
+		}
+	}
+
+	if u.HeaderEx("Windows", OptExpanded) {
+		for _, w := range m.windows {
+			row(w.name, "%d commands", w.commands)
+		}
+	}
+
+	if u.HeaderEx("Allocations", OptExpanded) {
+		allocs, _ := meanMax(m.allocs)
+		bytes, _ := meanMax(m.bytes)
+		row("Objects/frame", "%.0f", allocs)
+		row("Bytes/frame", "%.0f", bytes)
+		row("Live heap", "%.1f MB", float64(m.heap)/(1<<20))
+	}
+}
+
+// ShowMetrics opens or closes the window shown by MetricsWindow.
+func (u *UI) ShowMetrics(show bool) {
+	u.GetContainer(metricsWindowName).open = show
+}
+
+// meanMax returns the mean and largest of values, or zeros if empty.
+func meanMax(values []float64) (mean, largest float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+		largest = max(largest, v)
+	}
+	return mean / float64(len(values)), largest
+}
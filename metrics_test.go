@@ -0,0 +1,98 @@
+package microui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestMetricsWindow_Toggle(t *testing.T) {
+	ui := New(Config{})
+	frame := func() {
+		ui.BeginFrame()
+		ui.MetricsWindow()
+		ui.EndFrame()
+	}
+	frame()
+	if ui.GetContainer("Metrics").Open() {
+		t.Fatal("metrics window should start closed")
+	}
+
+	ui.KeyDown(KeyCtrl)
+	ui.KeyDown(KeyShift)
+	ui.KeyDown(KeyM)
+	frame()
+	if !ui.GetContainer("Metrics").Open() {
+		t.Fatal("Ctrl+Shift+M should open the metrics window")
+	}
+	ui.KeyUp(KeyM)
+	frame()
+	if !ui.GetContainer("Metrics").Open() {
+		t.Fatal("metrics window should stay open until toggled again")
+	}
+	ui.KeyDown(KeyM)
+	frame()
+	if ui.GetContainer("Metrics").Open() {
+		t.Error("pressing Ctrl+Shift+M again should close the metrics window")
+	}
+}
+
+func TestMetricsWindow_Records(t *testing.T) {
+	ui := New(Config{})
+	ui.ShowMetrics(true)
+	now := time.Unix(0, 0)
+	frame := func() []string {
+		ui.BeginFrameAt(now)
+		if ui.BeginWindow("Main", types.Rect{X: 400, Y: 0, W: 200, H: 100}) {
+			ui.Button("OK")
+			ui.EndWindow()
+		}
+		ui.MetricsWindow()
+		ui.EndFrame()
+		ui.Render(&recordRenderer{})
+		now = now.Add(20 * time.Millisecond)
+		var texts []string
+		for _, cmd := range ui.RecordFrame().Commands {
+			if cmd.Kind == CmdText {
+				texts = append(texts, cmd.Text)
+			}
+		}
+		return texts
+	}
+	for range 3 {
+		frame()
+	}
+	ui.GetContainer("Metrics").SetRect(types.Rect{X: 0, Y: 0, W: 300, H: 1000}) // Everything unscrolled
+	texts := frame()
+
+	for _, want := range []string{"FPS", "50", "Frame time", "Update", "Layout", "Render", "Main", "Objects/frame"} {
+		if !slices.Contains(texts, want) {
+			t.Errorf("metrics window does not show %q", want)
+		}
+	}
+
+	// Timing starts in the first frame's MetricsWindow call, so the
+	// second and third frames are recorded
+	m := ui.metrics
+	if len(m.frameMS) != 2 || m.frameMS[0] != 20 {
+		t.Errorf("frame times = %v, want two of 20ms", m.frameMS)
+	}
+	if len(m.phaseMS[phaseRender]) != 2 || m.phaseMS[phaseUpdate][1] <= 0 {
+		t.Errorf("phase times = %v, want two frames timed", m.phaseMS)
+	}
+	if i := slices.IndexFunc(m.windows, func(w windowCommands) bool { return w.name == "Main" }); i < 0 || m.windows[i].commands == 0 {
+		t.Errorf("window commands = %v, want Main's counted", m.windows)
+	}
+}
+
+func TestMetrics_HistoryLimit(t *testing.T) {
+	var h []float64
+	for i := range metricsFrames + 5 {
+		h = pushHistory(h, float64(i))
+	}
+	if len(h) != metricsFrames || h[0] != 5 || h[len(h)-1] != metricsFrames+4 {
+		t.Errorf("history = %d values from %v to %v, want the last %d", len(h), h[0], h[len(h)-1], metricsFrames)
+	}
+}
@@ -0,0 +1,70 @@
+package microui
+
+import (
+	"image/color"
+
+	"github.com/user/microui-go/types"
+)
+
+// OpenModal opens the named modal dialog. While it is open, windows beneath
+// it receive no mouse input and keyboard focus is cleared.
+func (u *UI) OpenModal(name string) {
+	cnt := u.GetContainer(name)
+	cnt.open = true
+	u.BringToFront(cnt)
+	u.modal = cnt
+	u.modalFrame = u.frame
+	u.hoverRoot = cnt
+	u.nextHoverRoot = cnt
+	u.SetFocus(0)
+}
+
+// CloseModal closes the named modal dialog.
+func (u *UI) CloseModal(name string) {
+	cnt := u.GetContainer(name)
+	cnt.open = false
+	if u.modal == cnt {
+		u.modal = nil
+	}
+}
+
+// BeginModal begins a modal dialog opened with OpenModal.
+// Returns false if the dialog is closed. Escape or the title bar close
+// button close it. Call EndModal only if BeginModal returned true.
+func (u *UI) BeginModal(name string, rect types.Rect) bool {
+	cnt := u.GetContainer(name)
+	if cnt.open && u.modal == cnt && u.input.KeyPressed[KeyEscape] {
+		u.CloseModal(name)
+	}
+	if !cnt.open {
+		return false
+	}
+	u.modal = cnt
+	u.modalFrame = u.frame
+	return u.BeginWindowOpt(name, rect, OptClosed)
+}
+
+// EndModal ends the current modal dialog.
+func (u *UI) EndModal() {
+	u.EndWindow()
+}
+
+// inputBlockedByModal reports whether an open modal sits above cnt.
+// Popups opened from inside the modal are brought to front and stay usable.
+func (u *UI) inputBlockedByModal(cnt *Container) bool {
+	return u.modal != nil && cnt != u.modal && u.inFront(u.modal, cnt)
+}
+
+// drawModalOverlay dims everything beneath the modal. It runs at the start
+// of the modal's root container so the overlay sorts just below it.
+func (u *UI) drawModalOverlay() {
+	u.clipStack.Push(unclippedRect)
+	u.commands.Push(Command{Kind: CmdClip, Rect: unclippedRect})
+	u.modalOverlay(u, unclippedRect)
+	u.PopClip()
+}
+
+// defaultModalOverlay fills rect with translucent black.
+func defaultModalOverlay(ui *UI, rect types.Rect) {
+	ui.DrawRect(rect, color.RGBA{0, 0, 0, 128})
+}
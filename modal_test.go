@@ -0,0 +1,86 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// modalFrame draws a background window with a button at (5,29)-(73,49)
+// and, when open, a modal dialog at (100,100).
+func modalFrame(ui *UI, clicked *bool, modalShown *bool) {
+	ui.BeginFrame()
+	ui.BeginWindow("Back", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	if ui.Button("Press") {
+		*clicked = true
+	}
+	ui.EndWindow()
+	*modalShown = false
+	if ui.BeginModal("Confirm", types.Rect{X: 100, Y: 100, W: 150, H: 80}) {
+		*modalShown = true
+		ui.Label("Are you sure?")
+		ui.EndModal()
+	}
+	ui.EndFrame()
+}
+
+func modalClick(ui *UI, x, y int, clicked, shown *bool) {
+	ui.MouseMove(x, y)
+	modalFrame(ui, clicked, shown)
+	ui.MouseDown(x, y, MouseLeft)
+	modalFrame(ui, clicked, shown)
+	ui.MouseUp(x, y, MouseLeft)
+	modalFrame(ui, clicked, shown)
+}
+
+func TestModal_BlocksInputBehind(t *testing.T) {
+	ui := New(Config{})
+	var clicked, shown bool
+	modalFrame(ui, &clicked, &shown)
+	ui.OpenModal("Confirm")
+	modalFrame(ui, &clicked, &shown)
+	if !shown {
+		t.Fatal("modal should be shown after OpenModal")
+	}
+
+	modalClick(ui, 20, 35, &clicked, &shown)
+	if clicked {
+		t.Error("button behind the modal should not receive clicks")
+	}
+	if !shown {
+		t.Error("clicking outside a modal should not close it")
+	}
+}
+
+func TestModal_EscapeCloses(t *testing.T) {
+	ui := New(Config{})
+	var clicked, shown bool
+	ui.OpenModal("Confirm")
+	modalFrame(ui, &clicked, &shown)
+
+	pressKey(ui, KeyEscape)
+	modalFrame(ui, &clicked, &shown)
+	if shown {
+		t.Fatal("Escape should close the modal")
+	}
+
+	modalClick(ui, 20, 35, &clicked, &shown)
+	if !clicked {
+		t.Error("input behind should work again once the modal is closed")
+	}
+}
+
+func TestModal_OverlayCallback(t *testing.T) {
+	calls := 0
+	ui := New(Config{ModalOverlay: func(ui *UI, rect types.Rect) { calls++ }})
+	var clicked, shown bool
+	modalFrame(ui, &clicked, &shown)
+	if calls != 0 {
+		t.Fatalf("overlay drawn with no modal open")
+	}
+	ui.OpenModal("Confirm")
+	modalFrame(ui, &clicked, &shown)
+	if calls != 1 {
+		t.Errorf("overlay calls = %d, want 1", calls)
+	}
+}
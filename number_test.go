@@ -149,3 +149,108 @@ func TestNumberOpt_Drag(t *testing.T) {
 		t.Errorf("NumberOpt drag should work, got value=%v changed=%v", value, changed)
 	}
 }
+
+// spinnerFrame runs a frame with a 100px wide spinner number.
+func spinnerFrame(ui *UI, value *float64, low, high float64) bool {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{100}, 0)
+	changed := ui.NumberRange(value, low, high, 1, "%.0f", OptSpinner)
+	ui.EndWindow()
+	ui.EndFrame()
+	return changed
+}
+
+func TestNumber_SpinnerButtons(t *testing.T) {
+	ui := New(Config{})
+	value := 5.0
+	const clickY = 35
+
+	click := func(x int) bool {
+		ui.MouseMove(x, clickY)
+		spinnerFrame(ui, &value, 0, 6)
+		ui.MouseDown(x, clickY, MouseLeft)
+		changed := spinnerFrame(ui, &value, 0, 6)
+		ui.MouseUp(x, clickY, MouseLeft)
+		spinnerFrame(ui, &value, 0, 6)
+		return changed
+	}
+
+	// The control spans x 5..105; + is at the right end
+	if !click(100) || value != 6 {
+		t.Errorf("+ button: value = %v, want 6", value)
+	}
+	click(100)
+	if value != 6 {
+		t.Errorf("+ button past high: value = %v, want 6", value)
+	}
+	click(10)
+	if value != 5 {
+		t.Errorf("- button: value = %v, want 5", value)
+	}
+}
+
+func TestNumber_SpinnerWheel(t *testing.T) {
+	ui := New(Config{})
+	value := 5.0
+
+	ui.MouseMove(50, 35)
+	spinnerFrame(ui, &value, 0, 10)
+	ui.Scroll(0, -30)
+	if !spinnerFrame(ui, &value, 0, 10) || value != 6 {
+		t.Errorf("wheel up: value = %v, want 6", value)
+	}
+	ui.Scroll(0, 30)
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{100}, 0)
+	ui.NumberRange(&value, 0, 10, 1, "%.0f", OptSpinner)
+	if d := ui.ScrollDelta(); d.Y != 0 {
+		t.Errorf("spinner left scroll delta %d for the window", d.Y)
+	}
+	ui.EndWindow()
+	ui.EndFrame()
+	if value != 5 {
+		t.Errorf("wheel down: value = %v, want 5", value)
+	}
+
+	// Without OptSpinner the wheel leaves the value alone
+	ui.Scroll(0, -30)
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{100}, 0)
+	ui.NumberOpt(&value, 1, "%.0f", 0)
+	ui.EndWindow()
+	ui.EndFrame()
+	if value != 5 {
+		t.Errorf("wheel changed a plain number to %v", value)
+	}
+}
+
+func TestNumberRange_ClampsDrag(t *testing.T) {
+	ui := New(Config{})
+	value := 5.0
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.LayoutRow(1, []int{100}, 0)
+		ui.NumberRange(&value, 0, 10, 1, "%.0f", 0)
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+
+	ui.MouseMove(50, 35)
+	frame()
+	ui.MouseDown(50, 35, MouseLeft)
+	frame()
+	ui.MouseMove(150, 35)
+	frame()
+	if value != 10 {
+		t.Errorf("drag past high: value = %v, want 10", value)
+	}
+	ui.MouseMove(0, 35)
+	frame()
+	if value != 0 {
+		t.Errorf("drag past low: value = %v, want 0", value)
+	}
+}
@@ -2,26 +2,52 @@ package microui
 
 // Option flags for controls
 const (
-	OptAlignCenter = 1 << iota // Center text alignment
-	OptAlignRight              // Right text alignment
-	OptNoInteract              // Non-interactive (display only)
-	OptNoFrame                 // Don't draw control frame
-	OptNoResize                // Window: disable resize
-	OptNoScroll                // Panel: disable scrollbars
-	OptNoClose                 // Window: no close button
-	OptNoTitle                 // Window: no title bar
-	OptHoldFocus               // Keep focus after interaction
-	OptAutoSize                // Container: auto-size to content
-	OptPopup                   // Popup behavior
-	OptClosed                  // Start closed/collapsed
-	OptExpanded                // Start expanded (default for headers)
+	OptAlignCenter      = 1 << iota // Center text alignment
+	OptAlignRight                   // Right text alignment
+	OptNoInteract                   // Non-interactive (display only)
+	OptNoFrame                      // Don't draw control frame
+	OptNoResize                     // Window: disable resize
+	OptNoScroll                     // Panel: disable scrollbars
+	OptNoClose                      // Window: no close button
+	OptNoTitle                      // Window: no title bar
+	OptHoldFocus                    // Keep focus after interaction
+	OptAutoSize                     // Container: auto-size to content
+	OptPopup                        // Popup behavior
+	OptClosed                       // Start closed/collapsed
+	OptExpanded                     // Start expanded (default for headers)
+	OptIndeterminate                // Progress bar: animate a busy state, ignore value
+	OptNoNav                        // Exclude from Tab/Shift-Tab focus order
+	OptCollapsible                  // Window: title-bar button collapses it to the title bar
+	OptMaximizable                  // Window: title-bar button maximizes it to the screen
+	OptAutoScroll                   // Window/panel: stay scrolled to the bottom as content grows
+	OptReadOnly                     // Textbox: text can be selected and copied but not edited
+	OptSpinner                      // Number: -/+ buttons and the mouse wheel step the value
+	OptNumeric                      // Textbox: accept only digits, a sign and a decimal point
+	OptPassword                     // Textbox: show every character as '*' and disable copy
+	OptFitContent                   // Window/panel: size to the content measured last frame
+	OptRepeat                       // Button: click repeatedly while held (Config.ButtonRepeatDelay)
+	OptDefault                      // Button: Enter clicks it while its window is in front
+	OptAlwaysOnTop                  // Window: stay above windows without it (see Container.SetPinned)
+	OptPinnable                     // Window: title-bar pin button toggles OptAlwaysOnTop
+	OptNoBackground                 // Window/panel: draw no background or shadow, keep the border and title bar
+	OptRTL                          // Text: lay out right-to-left, overriding UI.SetTextDirection
+	OptLTR                          // Text: lay out left-to-right, overriding UI.SetTextDirection
+	OptInputPassthrough             // Window: take no input; the mouse reaches what is beneath it
 )
 
 // Response flags returned by controls
 const (
-	ResChange = 1 << iota // Value changed
-	ResSubmit             // Enter pressed / submitted
-	ResActive             // Control is active (has focus)
+	ResChange     = 1 << iota // Value changed
+	ResSubmit                 // Enter pressed / submitted
+	ResActive                 // Control is active (has focus)
+	ResSelection              // Textbox has a non-empty text selection
+	ResInvalid                // Textbox text failed its Validate rule
+	ResUndo                   // Textbox edit undone (Ctrl+Z)
+	ResRedo                   // Textbox edit redone (Ctrl+Y or Ctrl+Shift+Z)
+	ResClick                  // Button, checkbox, radio or selectable clicked / activated
+	ResRelease                // Mouse released over the control after pressing it
+	ResHoverEnter             // Mouse moved onto the control this frame
+	ResHoverExit              // Mouse moved off the control this frame
 )
 
 // Clip result constants
@@ -47,4 +73,11 @@ const (
 	ColorBaseFocus
 	ColorScrollBase
 	ColorScrollThumb
+	ColorProgressBase // Progress bar / spinner track
+	ColorProgressFill // Progress bar fill / active spinner segment
+	ColorRadio        // Radio button indicator (draw round/diamond in custom frames)
+	ColorRadioHover
+	ColorRadioFocus
+	ColorGroup     // Group box border, drawn over the group's contents (draw an outline)
+	ColorStatusBar // Status bar background (BeginStatusBar)
 )
@@ -0,0 +1,58 @@
+package microui
+
+import (
+	"image/color"
+
+	"github.com/user/microui-go/types"
+)
+
+// OverlayCommand adds cmd to the overlay: commands drawn after every window
+// and popup, whichever container is current, for drag previews, guides,
+// measurements and debug annotations. The overlay is unclipped unless it
+// adds its own CmdClip, and is emptied by BeginFrame.
+func (u *UI) OverlayCommand(cmd Command) {
+	u.overlay = append(u.overlay, cmd)
+}
+
+// OverlayDrawRect draws a filled rectangle above every window.
+func (u *UI) OverlayDrawRect(rect types.Rect, c color.Color) {
+	u.OverlayCommand(Command{
+		Kind:  CmdRect,
+		Rect:  rect,
+		Pos:   types.Vec2{X: rect.X, Y: rect.Y},
+		Size:  types.Vec2{X: rect.W, Y: rect.H},
+		Color: c,
+	})
+}
+
+// OverlayDrawBox draws an outline rectangle above every window.
+func (u *UI) OverlayDrawBox(rect types.Rect, c color.Color) {
+	u.OverlayCommand(Command{
+		Kind:  CmdBox,
+		Rect:  rect,
+		Pos:   types.Vec2{X: rect.X, Y: rect.Y},
+		Size:  types.Vec2{X: rect.W, Y: rect.H},
+		Color: c,
+	})
+}
+
+// OverlayDrawText draws text above every window. A nil font uses the
+// style's font.
+func (u *UI) OverlayDrawText(text string, pos types.Vec2, font types.Font, c color.Color) {
+	if font == nil {
+		font = u.style.Font
+	}
+	u.OverlayCommand(Command{Kind: CmdText, Text: text, Pos: pos, Color: c, Font: font})
+}
+
+// eachOverlay calls renderCmd for the overlay's commands, clearing the
+// clip the last window left first.
+func (u *UI) eachOverlay(renderCmd func(Command)) {
+	if len(u.overlay) == 0 {
+		return
+	}
+	renderCmd(Command{Kind: CmdClip, Rect: unclippedRect})
+	for _, cmd := range u.overlay {
+		renderCmd(cmd)
+	}
+}
@@ -0,0 +1,39 @@
+package microui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestOverlay(t *testing.T) {
+	ui := New(Config{})
+	guide := color.RGBA{R: 255, A: 255}
+	frame := func(overlay bool) *recordRenderer {
+		ui.BeginFrame()
+		if ui.BeginWindow("Back", types.Rect{X: 0, Y: 0, W: 200, H: 100}) {
+			// Added from inside the back window, drawn above the front one
+			if overlay {
+				ui.OverlayDrawRect(types.Rect{X: 50, Y: 50, W: 300, H: 1}, guide)
+			}
+			ui.EndWindow()
+		}
+		if ui.BeginWindow("Front", types.Rect{X: 100, Y: 0, W: 200, H: 100}) {
+			ui.EndWindow()
+		}
+		ui.EndFrame()
+		r := &recordRenderer{}
+		ui.Render(r)
+		return r
+	}
+
+	r := frame(true)
+	if n := len(r.rects); n == 0 || r.rects[n-1] != guide {
+		t.Errorf("last rect drawn = %v, want the overlay guide", r.rects)
+	}
+	r = frame(false)
+	if n := len(r.rects); n > 0 && r.rects[n-1] == guide {
+		t.Error("the overlay should be emptied by the next frame")
+	}
+}
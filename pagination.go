@@ -0,0 +1,156 @@
+package microui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/user/microui-go/types"
+)
+
+// pageSegment is a button of a pagination control.
+type pageSegment struct {
+	rect    types.Rect
+	text    string
+	target  int  // Page it goes to; -1 for "..." and disabled arrows
+	current bool // The current page
+}
+
+// Pagination adds a compact row of page buttons for moving through total
+// pages, e.g. of a data table: arrows to the previous and next page, the
+// first and last page and the pages around *page, with "..." for the ones
+// that don't fit. *page counts from 0 and is kept within the pages; the
+// buttons number them from 1.
+//
+// With focus, Left and Right (or PageUp and PageDown) step the page, and
+// Home and End go to the first and last one. Returns true if *page
+// changed. Its ID comes from the address of page, like Slider; see
+// PaginationID.
+func (u *UI) Pagination(page *int, total int) bool {
+	return u.pagination(u.getIDFromPtr(page), page, total)
+}
+
+// PaginationID is Pagination with an ID from id (scoped by PushID) instead
+// of the address of page.
+func (u *UI) PaginationID(id string, page *int, total int) bool {
+	return u.pagination(u.getID(id), page, total)
+}
+
+func (u *UI) pagination(id ID, page *int, total int) bool {
+	rect := u.LayoutNext()
+	if total <= 0 {
+		return false
+	}
+	prev := *page
+	*page = max(0, min(*page, total-1))
+	u.UpdateControlOpt(id, rect, OptHoldFocus)
+
+	// Keyboard and clicks use the buttons laid out for the previous page
+	if u.input.Focus == id {
+		switch {
+		case u.input.KeyPressed[KeyLeft] || u.input.KeyPressed[KeyPageUp]:
+			*page = max(*page-1, 0)
+		case u.input.KeyPressed[KeyRight] || u.input.KeyPressed[KeyPageDown]:
+			*page = min(*page+1, total-1)
+		case u.input.KeyPressed[KeyHome]:
+			*page = 0
+		case u.input.KeyPressed[KeyEnd]:
+			*page = total - 1
+		}
+		if u.input.MousePressed[int(MouseLeft)] {
+			for _, s := range u.pageSegments(rect, *page, total) {
+				if s.target >= 0 && u.MouseOver(s.rect) {
+					*page = s.target
+				}
+			}
+		}
+	}
+
+	segments := u.pageSegments(rect, *page, total)
+	for _, s := range segments {
+		switch {
+		case s.current:
+			u.DrawFrame(s.rect, ColorButtonFocus)
+		case s.target < 0:
+		case u.input.Hover == id && u.MouseOver(s.rect):
+			u.DrawFrame(s.rect, ColorButtonHover)
+		default:
+			u.DrawFrame(s.rect, ColorButton)
+		}
+		u.DrawControlText(s.text, s.rect, ColorText, OptAlignCenter)
+	}
+	if u.navFocus == id {
+		end := segments[len(segments)-1].rect
+		u.drawFocusRing(types.Rect{X: rect.X, Y: rect.Y, W: end.X + end.W - rect.X, H: rect.H})
+	}
+	u.accessControl(id, rect, RoleSpinButton, "Page", fmt.Sprintf("%d of %d", *page+1, total), 0)
+	return u.itemChanged(*page != prev)
+}
+
+// pageSegments lays out the buttons of a pagination control in rect: as
+// many as fit, all as wide as the widest page number.
+func (u *UI) pageSegments(rect types.Rect, page, total int) []pageSegment {
+	sp := u.style.Spacing
+	w := max(u.style.Font.Width(strconv.Itoa(total))+u.style.Padding.X*2, rect.H)
+	slots := max((rect.W+sp)/(w+sp), 3)
+	pages := pageItems(page, total, slots-2)
+
+	segments := make([]pageSegment, 0, len(pages)+2)
+	add := func(text string, target int) {
+		r := types.Rect{X: rect.X + len(segments)*(w+sp), Y: rect.Y, W: w, H: rect.H}
+		segments = append(segments, pageSegment{rect: r, text: text, target: target, current: target == page})
+	}
+	arrow := func(text string, target int) {
+		if target < 0 || target >= total {
+			target = -1
+		}
+		add(text, target)
+		segments[len(segments)-1].current = false
+	}
+	arrow("<", page-1)
+	for _, p := range pages {
+		if p < 0 {
+			add("...", -1)
+		} else {
+			add(strconv.Itoa(p+1), p)
+		}
+	}
+	arrow(">", page+1)
+	return segments
+}
+
+// pageItems returns the pages to show in n buttons, -1 standing for the
+// pages left out: the first and last page, the pages around page and the
+// gaps between them.
+func pageItems(page, total, n int) []int {
+	var items []int
+	switch {
+	case total <= n:
+		for p := range total {
+			items = append(items, p)
+		}
+	case n < 5:
+		// No room for the first and last page and a gap around page
+		lo := max(0, min(page-(n-1)/2, total-n))
+		for p := lo; p < lo+n; p++ {
+			items = append(items, p)
+		}
+	default:
+		// A gap standing for a single page shows that page instead
+		w := n - 4
+		lo := max(2, min(page-(w-1)/2, total-2-w))
+		items = append(items, 0, -1)
+		if lo == 2 {
+			items[1] = 1
+		}
+		for p := lo; p < lo+w; p++ {
+			items = append(items, p)
+		}
+		if lo+w == total-2 {
+			items = append(items, total-2)
+		} else {
+			items = append(items, -1)
+		}
+		items = append(items, total-1)
+	}
+	return items
+}
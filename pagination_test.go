@@ -0,0 +1,107 @@
+package microui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// paginationFrame draws a pagination control over total pages and returns
+// whether it changed page.
+func paginationFrame(ui *UI, page *int, total int) bool {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{-1}, 0)
+	changed := ui.Pagination(page, total)
+	ui.EndWindow()
+	ui.EndFrame()
+	return changed
+}
+
+func TestPageItems(t *testing.T) {
+	tests := []struct {
+		page, total, n int
+		want           []int
+	}{
+		{0, 3, 7, []int{0, 1, 2}},
+		{0, 20, 7, []int{0, 1, 2, 3, 4, -1, 19}},
+		{10, 20, 7, []int{0, -1, 9, 10, 11, -1, 19}},
+		{19, 20, 7, []int{0, -1, 15, 16, 17, 18, 19}},
+		{3, 8, 7, []int{0, 1, 2, 3, 4, -1, 7}},
+		{10, 20, 3, []int{9, 10, 11}},
+		{19, 20, 1, []int{19}},
+	}
+	for _, tt := range tests {
+		if got := pageItems(tt.page, tt.total, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("pageItems(%d, %d, %d) = %v, want %v", tt.page, tt.total, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPagination_Click(t *testing.T) {
+	ui := New(Config{})
+	page := 0
+	paginationFrame(ui, &page, 20)
+	pos := drawnTexts(ui)["20"]
+
+	ui.MouseMove(pos.X+2, pos.Y+2)
+	paginationFrame(ui, &page, 20)
+	ui.MouseDown(pos.X+2, pos.Y+2, MouseLeft)
+	if !paginationFrame(ui, &page, 20) || page != 19 {
+		t.Errorf("clicking the last page: page = %d, want 19", page)
+	}
+	ui.MouseUp(pos.X+2, pos.Y+2, MouseLeft)
+	paginationFrame(ui, &page, 20)
+
+	// The next arrow is disabled on the last page
+	pos = drawnTexts(ui)[">"]
+	ui.MouseMove(pos.X+2, pos.Y+2)
+	ui.MouseDown(pos.X+2, pos.Y+2, MouseLeft)
+	if paginationFrame(ui, &page, 20) || page != 19 {
+		t.Errorf("next arrow on the last page: page = %d, want 19", page)
+	}
+	ui.MouseUp(pos.X+2, pos.Y+2, MouseLeft)
+	paginationFrame(ui, &page, 20)
+
+	pos = drawnTexts(ui)["<"]
+	ui.MouseMove(pos.X+2, pos.Y+2)
+	ui.MouseDown(pos.X+2, pos.Y+2, MouseLeft)
+	if !paginationFrame(ui, &page, 20) || page != 18 {
+		t.Errorf("previous arrow: page = %d, want 18", page)
+	}
+}
+
+func TestPagination_Keyboard(t *testing.T) {
+	ui := New(Config{})
+	page := 0
+	paginationFrame(ui, &page, 20)
+	pressKey(ui, KeyTab)
+	paginationFrame(ui, &page, 20)
+
+	steps := []struct {
+		key  Key
+		want int
+	}{
+		{KeyRight, 1}, {KeyPageDown, 2}, {KeyLeft, 1}, {KeyEnd, 19}, {KeyRight, 19}, {KeyHome, 0}, {KeyPageUp, 0},
+	}
+	for _, s := range steps {
+		pressKey(ui, s.key)
+		paginationFrame(ui, &page, 20)
+		if page != s.want {
+			t.Fatalf("after key %d page = %d, want %d", s.key, page, s.want)
+		}
+	}
+}
+
+func TestPagination_Clamp(t *testing.T) {
+	ui := New(Config{})
+	page := 30
+	if !paginationFrame(ui, &page, 5) || page != 4 {
+		t.Errorf("page past the end: page = %d, want 4", page)
+	}
+	page = -2
+	if !paginationFrame(ui, &page, 5) || page != 0 {
+		t.Errorf("negative page: page = %d, want 0", page)
+	}
+}
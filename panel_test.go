@@ -195,3 +195,83 @@ func TestPanel_ScrollPersists(t *testing.T) {
 		t.Errorf("Scroll.Y = %d, want 50 (should persist)", cnt.Scroll().Y)
 	}
 }
+
+// nestedPanelApp is a 100px tall panel holding a 150px tall one, whose
+// button "A" is at the top and button "B" below the outer panel's edge.
+type nestedPanelApp struct {
+	ui       *UI
+	outerOpt int
+	a, b     int
+}
+
+func (p *nestedPanelApp) frame() {
+	ui := p.ui
+	ui.BeginFrame()
+	if ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+		ui.LayoutRow(1, []int{-1}, 100)
+		ui.BeginPanelOpt("outer", p.outerOpt)
+		ui.LayoutRow(1, []int{-1}, 150)
+		ui.BeginPanel("inner")
+		ui.LayoutRow(1, []int{100}, 0)
+		if ui.Button("A") {
+			p.a++
+		}
+		for i := 0; i < 3; i++ {
+			ui.Label("filler")
+		}
+		if ui.Button("B") { // y 135..155, the outer panel ends at 129
+			p.b++
+		}
+		ui.EndPanel()
+		ui.EndPanel()
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+func (p *nestedPanelApp) click(x, y int) {
+	p.ui.MouseMove(x, y)
+	p.frame()
+	p.ui.MouseDown(x, y, MouseLeft)
+	p.frame()
+	p.ui.MouseUp(x, y, MouseLeft)
+	p.frame()
+}
+
+func TestPanel_NestedParent(t *testing.T) {
+	p := &nestedPanelApp{ui: New(Config{})}
+	p.frame()
+	inner, outer := p.ui.GetContainer("inner"), p.ui.GetContainer("outer")
+	if inner.Parent() != outer || outer.Parent() != p.ui.GetContainer("W") || outer.Parent().Parent() != nil {
+		t.Error("panels should be nested inside the panel or window they were begun in")
+	}
+}
+
+func TestPanel_NestedClipBlocksInput(t *testing.T) {
+	p := &nestedPanelApp{ui: New(Config{})}
+	p.click(50, 140)
+	if p.b != 0 {
+		t.Error("a button clipped away by the outer panel should not be clickable")
+	}
+	p.click(50, 45)
+	if p.a != 1 {
+		t.Errorf("button A clicked %d times, want 1", p.a)
+	}
+}
+
+func TestPanel_DisabledInherited(t *testing.T) {
+	p := &nestedPanelApp{ui: New(Config{}), outerOpt: OptNoInteract}
+	p.click(50, 45)
+	if p.a != 0 {
+		t.Error("a control in a panel nested in a disabled panel should ignore input")
+	}
+	if !p.ui.GetContainer("inner").disabled {
+		t.Error("the inner panel should inherit the disabled state")
+	}
+
+	p.outerOpt = 0
+	p.click(50, 45)
+	if p.a != 1 {
+		t.Errorf("button A clicked %d times once enabled, want 1", p.a)
+	}
+}
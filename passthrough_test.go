@@ -0,0 +1,109 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// passthroughApp draws a window with a button under a HUD window with
+// OptInputPassthrough that covers it.
+type passthroughApp struct {
+	ui       *UI
+	back     int // Clicks of the button in the window beneath
+	hud      int // Clicks of the button in the HUD
+	scrolled types.Vec2
+}
+
+func (p *passthroughApp) frame() {
+	ui := p.ui
+	ui.BeginFrame()
+	if ui.BeginWindow("Back", types.Rect{X: 0, Y: 0, W: 200, H: 200}) {
+		ui.LayoutRow(1, []int{-1}, 0)
+		if ui.Button("Back") {
+			p.back++
+		}
+		ui.LayoutRow(1, []int{-1}, 400)
+		ui.Label("tall")
+		p.scrolled = ui.GetCurrentContainer().Scroll()
+		ui.EndWindow()
+	}
+	if ui.BeginWindowOpt("HUD", types.Rect{X: 0, Y: 0, W: 300, H: 300}, OptInputPassthrough|OptNoTitle|OptNoBackground) {
+		ui.LayoutRow(1, []int{-1}, 0)
+		if ui.Button("HUD") {
+			p.hud++
+		}
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+func (p *passthroughApp) click(x, y int) {
+	p.ui.MouseMove(x, y)
+	p.frame()
+	p.ui.MouseDown(x, y, MouseLeft)
+	p.frame()
+	p.ui.MouseUp(x, y, MouseLeft)
+	p.frame()
+}
+
+func TestInputPassthrough_ClickReachesWindowBeneath(t *testing.T) {
+	p := &passthroughApp{ui: New(Config{})}
+	p.frame()
+
+	p.click(50, 40) // The back window's button, beneath the HUD's
+	if p.back != 1 {
+		t.Errorf("button beneath the HUD clicked %d times, want 1", p.back)
+	}
+	if p.hud != 0 {
+		t.Errorf("HUD button clicked %d times, want 0", p.hud)
+	}
+	if got := p.ui.HoveredWindow(); got == nil || got.Name() != "Back" {
+		t.Errorf("HoveredWindow = %v, want Back", got)
+	}
+}
+
+func TestInputPassthrough_NothingBeneath(t *testing.T) {
+	p := &passthroughApp{ui: New(Config{})}
+	p.frame()
+
+	p.click(250, 20) // Only the HUD is here
+	if p.hud != 0 {
+		t.Errorf("HUD button clicked %d times, want 0", p.hud)
+	}
+	if got := p.ui.HoveredWindow(); got != nil {
+		t.Errorf("HoveredWindow = %q over only a passthrough window, want nil", got.Name())
+	}
+}
+
+func TestInputPassthrough_ScrollReachesWindowBeneath(t *testing.T) {
+	p := &passthroughApp{ui: New(Config{})}
+	p.ui.MouseMove(100, 100)
+	p.frame()
+	p.frame()
+
+	p.ui.Scroll(0, 40)
+	p.frame()
+	p.frame()
+	if p.scrolled.Y == 0 {
+		t.Error("scrolling over the HUD should scroll the window beneath")
+	}
+}
+
+func TestInputPassthrough_NotFrontWindow(t *testing.T) {
+	ui := New(Config{})
+	fired := 0
+	if _, err := ui.RegisterWindowShortcut("Back", "ctrl+k", func() { fired++ }); err != nil {
+		t.Fatal(err)
+	}
+	p := &passthroughApp{ui: ui}
+	p.frame()
+	p.frame()
+
+	ui.KeyDown(KeyCtrl)
+	ui.KeyDown(KeyK)
+	p.frame()
+	if fired != 1 {
+		t.Errorf("window shortcut fired %d times under a passthrough HUD, want 1", fired)
+	}
+}
@@ -0,0 +1,121 @@
+package microui
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/user/microui-go/types"
+)
+
+// windowState is the persisted state of one container.
+// Content is the last measured content size; without it the first frame
+// after loading would clamp the restored scroll back to zero.
+type windowState struct {
+	Rect      types.Rect       `json:"rect"`
+	Scroll    types.Vec2       `json:"scroll"`
+	Content   types.Vec2       `json:"content"`
+	Open      bool             `json:"open"`
+	ZIndex    int              `json:"z"`
+	Collapsed bool             `json:"collapsed,omitempty"`
+	Restore   *types.Rect      `json:"restore,omitempty"` // Un-maximized rect, set while maximized
+	Split     float64          `json:"split,omitempty"`   // Splitter ratio
+	Columns   map[ID][]float64 `json:"columns,omitempty"` // Column offsets of Columns, by ID
+	Pinned    bool             `json:"pinned,omitempty"`
+}
+
+// layoutState is the document written by SaveLayout.
+type layoutState struct {
+	Windows   map[string]windowState `json:"windows"`
+	TreeNodes map[ID]bool            `json:"tree_nodes,omitempty"`
+	Docks     map[string]*dockNode   `json:"docks,omitempty"`
+}
+
+// SaveLayout serializes window arrangement to JSON: container rects,
+// scroll positions (windows and panels), open, collapsed and maximized
+// state, z-order, pinning, splitter ratios, column widths, header/tree-node
+// expansion and dock spaces. Popups and internal containers are not saved.
+func (u *UI) SaveLayout() ([]byte, error) {
+	st := layoutState{
+		Windows:   make(map[string]windowState, len(u.containers)),
+		TreeNodes: make(map[ID]bool, len(u.treeNodeState)),
+		Docks:     u.dockRoots(),
+	}
+	for id, n := range u.treeNodeState {
+		st.TreeNodes[id] = n.expanded
+	}
+	for _, cnt := range u.containers {
+		if cnt.opt&OptPopup != 0 || strings.HasPrefix(cnt.name, "!") {
+			continue
+		}
+		ws := windowState{
+			Rect:      cnt.rect,
+			Scroll:    cnt.scroll,
+			Content:   cnt.contentSize,
+			Open:      cnt.open,
+			ZIndex:    cnt.zindex,
+			Collapsed: cnt.collapsed,
+			Split:     cnt.split,
+			Pinned:    cnt.pinned,
+		}
+		if cnt.maximized {
+			restore := cnt.restoreRect
+			ws.Restore = &restore
+		}
+		for id, cs := range cnt.columns {
+			if ws.Columns == nil {
+				ws.Columns = make(map[ID][]float64, len(cnt.columns))
+			}
+			ws.Columns[id] = cs.offsets
+		}
+		st.Windows[cnt.name] = ws
+	}
+	return json.Marshal(st)
+}
+
+// LoadLayout restores state saved by SaveLayout. Call it before the first
+// frame; restored windows keep their saved rect instead of the one passed
+// to BeginWindow. Malformed data is an error and restores nothing.
+func (u *UI) LoadLayout(data []byte) error {
+	var st layoutState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	if err := checkDockRoots(st.Docks); err != nil {
+		return err
+	}
+	for name, ws := range st.Windows {
+		cnt := u.GetContainer(name)
+		cnt.rect = ws.Rect
+		cnt.scroll = ws.Scroll
+		cnt.contentSize = ws.Content
+		cnt.open = ws.Open
+		cnt.zindex = ws.ZIndex
+		if cnt.zpos > 0 {
+			u.restack(cnt)
+		}
+		cnt.collapsed = ws.Collapsed
+		cnt.split = ws.Split
+		cnt.columns = nil
+		for id, offsets := range ws.Columns {
+			if cnt.columns == nil {
+				cnt.columns = make(map[ID]*columnsState, len(ws.Columns))
+			}
+			cnt.columns[id] = &columnsState{offsets: clampColumnOffsets(offsets)}
+		}
+		cnt.pinned = ws.Pinned
+		cnt.maximized = ws.Restore != nil
+		if ws.Restore != nil {
+			cnt.restoreRect = *ws.Restore
+		}
+		u.lastZIndex = max(u.lastZIndex, ws.ZIndex)
+	}
+	for id, expanded := range st.TreeNodes {
+		n := u.treeNodeState[id]
+		n.expanded, n.frame = expanded, u.frame
+		u.treeNodeState[id] = n
+	}
+	if st.Docks != nil {
+		u.setDockRoots(st.Docks)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func persistFrame(ui *UI) (expanded bool) {
+	ui.BeginFrame()
+	if ui.BeginWindow("Main", types.Rect{X: 10, Y: 10, W: 200, H: 150}) {
+		expanded = ui.Header("Section")
+		for i := 0; i < 20; i++ {
+			ui.Label("row")
+		}
+		ui.EndWindow()
+	}
+	if ui.BeginWindow("Other", types.Rect{X: 50, Y: 50, W: 200, H: 150}) {
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+	return expanded
+}
+
+func TestLayout_SaveLoadRoundTrip(t *testing.T) {
+	ui := New(Config{})
+	persistFrame(ui)
+
+	main := ui.GetContainer("Main")
+	main.rect = types.Rect{X: 120, Y: 80, W: 300, H: 220}
+	main.scroll = types.Vec2{X: 0, Y: 40}
+	ui.BringToFront(main)
+	ui.PushID("Main")
+	ui.setTreeNode(ui.GetID("Section"), "Section", false)
+	ui.PopID()
+
+	data, err := ui.SaveLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ui2 := New(Config{})
+	if err := ui2.LoadLayout(data); err != nil {
+		t.Fatal(err)
+	}
+	if persistFrame(ui2) {
+		t.Error("header expansion should be restored as collapsed")
+	}
+
+	main2 := ui2.GetContainer("Main")
+	if main2.rect != main.rect {
+		t.Errorf("rect = %v, want %v", main2.rect, main.rect)
+	}
+	if main2.scroll != main.scroll {
+		t.Errorf("scroll = %v, want %v", main2.scroll, main.scroll)
+	}
+	if main2.zindex <= ui2.GetContainer("Other").zindex {
+		t.Error("z-order not restored: Main should be above Other")
+	}
+
+	// New windows still open above restored ones
+	ui2.BeginFrame()
+	ui2.BeginWindow("New", types.Rect{X: 0, Y: 0, W: 100, H: 100})
+	ui2.EndWindow()
+	ui2.EndFrame()
+	if ui2.GetContainer("New").zindex <= main2.zindex {
+		t.Error("new window should get a z-index above restored windows")
+	}
+}
+
+func TestLayout_SkipsPopups(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("Main", types.Rect{X: 10, Y: 10, W: 200, H: 150})
+	ui.OpenPopup("menu")
+	ui.EndWindow()
+	if ui.BeginPopup("menu") {
+		ui.EndPopup()
+	}
+	ui.EndFrame()
+
+	data, err := ui.SaveLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui2 := New(Config{})
+	if err := ui2.LoadLayout(data); err != nil {
+		t.Fatal(err)
+	}
+	if ui2.GetContainer("menu").open {
+		t.Error("popups should not be restored open")
+	}
+}
+
+func TestLayout_LoadInvalid(t *testing.T) {
+	ui := New(Config{})
+	if err := ui.LoadLayout([]byte("{")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestLayout_LoadMalformedDocks(t *testing.T) {
+	ui := New(Config{})
+	data := `{"windows": {"A": {"rect": {"X": 5, "Y": 5, "W": 50, "H": 50}, "open": true}},
+		"docks": {"main": {"split": 1, "children": [{"windows": ["A"]}]}}}`
+	if err := ui.LoadLayout([]byte(data)); err == nil {
+		t.Fatal("LoadLayout = nil for a split with one child, want an error")
+	}
+	dockFrame(ui, "A") // Must not panic
+	if got := ui.GetContainer("A").rect; got == (types.Rect{X: 5, Y: 5, W: 50, H: 50}) {
+		t.Error("a rejected layout restored a window rect")
+	}
+}
@@ -0,0 +1,113 @@
+package microui
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/user/microui-go/types"
+)
+
+// PlotLines adds a line graph of values, scaled to fit between their
+// smallest and largest value. Hovering it shows the value under the mouse.
+// label is drawn in its top-left corner; opt accepts OptNoFrame.
+func (u *UI) PlotLines(label string, values []float64, opt int) {
+	low, high := valueRange(values)
+	u.plot(types.PlotLines, label, values, low, high, opt)
+}
+
+// PlotLinesRange is PlotLines with a fixed scale from low to high, e.g.
+// 0 to 33ms for a frame time graph.
+func (u *UI) PlotLinesRange(label string, values []float64, low, high float64, opt int) {
+	u.plot(types.PlotLines, label, values, low, high, opt)
+}
+
+// PlotHistogram adds a bar chart with one bar per value, scaled from zero
+// (or the smallest value, if negative) to the largest value.
+func (u *UI) PlotHistogram(label string, values []float64, opt int) {
+	low, high := valueRange(values)
+	u.plot(types.PlotHistogram, label, values, min(low, 0), high, opt)
+}
+
+// PlotHistogramRange is PlotHistogram with a fixed scale from low to high.
+func (u *UI) PlotHistogramRange(label string, values []float64, low, high float64, opt int) {
+	u.plot(types.PlotHistogram, label, values, low, high, opt)
+}
+
+// Sparkline adds a small line graph without a frame or label, for a trend
+// next to a number in a row of controls.
+func (u *UI) Sparkline(values []float64) {
+	low, high := valueRange(values)
+	u.plot(types.PlotLines, "", values, low, high, OptNoFrame)
+}
+
+// valueRange returns the smallest and largest of values, ignoring NaNs.
+func valueRange(values []float64) (low, high float64) {
+	low, high = math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			low, high = math.Min(low, v), math.Max(high, v)
+		}
+	}
+	if low > high {
+		return 0, 0
+	}
+	return low, high
+}
+
+// plot draws values as kind, scaled from low to high.
+func (u *UI) plot(kind types.PlotKind, label string, values []float64, low, high float64, opt int) {
+	rect := u.LayoutNext()
+	if opt&OptNoFrame == 0 {
+		u.DrawFrame(rect, ColorBase)
+	}
+	// The command keeps its own copy, as the caller may reuse values
+	// before the frame is rendered
+	p := &types.Plot{Kind: kind, Values: slices.Clone(values), Min: low, Max: high}
+	u.PushCommand(Command{Kind: CmdPlot, Rect: rect, Color: u.GetColorByID(ColorProgressFill), Plot: p})
+	if label != "" {
+		pos := types.Vec2{X: rect.X + u.style.Padding.X, Y: rect.Y + u.style.Padding.Y}
+		u.DrawText(label, pos, nil, u.style.Colors.Text)
+	}
+	u.accessControl(0, rect, RoleImage, label, "", 0)
+
+	if u.MouseOver(rect) {
+		if i := plotIndex(p, u.input.MousePos.X-rect.X, rect.W); i >= 0 {
+			u.plotTooltip(fmt.Sprintf("%d: %.4g", i, values[i]))
+		}
+	}
+}
+
+// plotIndex returns the index of the value under column x of plot p, w
+// columns wide.
+func plotIndex(p *types.Plot, x, w int) int {
+	n := len(p.Values)
+	switch {
+	case n == 0 || w <= 0:
+		return -1
+	case p.Kind == types.PlotHistogram:
+		return max(0, min(x*n/w, n-1))
+	case w == 1:
+		return 0
+	}
+	return max(0, min(int(math.Round(float64(x)*float64(n-1)/float64(w-1))), n-1))
+}
+
+// plotTooltip shows text in a box next to the mouse, above every window.
+func (u *UI) plotTooltip(text string) {
+	font := u.style.Font
+	pad := u.style.Padding
+	r := types.Rect{
+		X: u.input.MousePos.X + pad.X,
+		Y: u.input.MousePos.Y + pad.Y,
+		W: font.Width(text) + pad.X*2,
+		H: font.Height() + pad.Y*2,
+	}
+	if s := u.screen; !s.Empty() {
+		r.X = max(s.X, min(r.X, s.X+s.W-r.W))
+		r.Y = max(s.Y, min(r.Y, s.Y+s.H-r.H))
+	}
+	u.OverlayDrawRect(r, u.style.Colors.WindowBg)
+	u.OverlayDrawBox(r, u.GetColorByID(ColorGroup))
+	u.OverlayDrawText(text, types.Vec2{X: r.X + pad.X, Y: r.Y + pad.Y}, font, u.style.Colors.Text)
+}
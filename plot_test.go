@@ -0,0 +1,144 @@
+package microui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// plotFrame draws a frame with build in a window, its control cell 200x60
+// at (5,29), and returns the plot commands it pushed.
+func plotFrame(ui *UI, build func(ui *UI)) []Command {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 60)
+	build(ui)
+	ui.EndWindow()
+	ui.EndFrame()
+	var plots []Command
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdPlot {
+			plots = append(plots, cmd)
+		}
+	})
+	return plots
+}
+
+func TestPlot_Command(t *testing.T) {
+	ui := New(Config{})
+	values := []float64{3, 1, 4, 1, 5}
+	plots := plotFrame(ui, func(ui *UI) {
+		ui.PlotLines("Lines", values, 0)
+		ui.PlotHistogram("Bars", values, 0)
+		ui.PlotLinesRange("Range", values, -10, 10, 0)
+	})
+	if len(plots) != 3 {
+		t.Fatalf("got %d plot commands, want 3", len(plots))
+	}
+	for i, want := range []types.Plot{
+		{Kind: types.PlotLines, Min: 1, Max: 5},
+		{Kind: types.PlotHistogram, Min: 0, Max: 5},
+		{Kind: types.PlotLines, Min: -10, Max: 10},
+	} {
+		p := plots[i].Plot
+		if p.Kind != want.Kind || p.Min != want.Min || p.Max != want.Max {
+			t.Errorf("plot %d = kind %d range %v..%v, want kind %d range %v..%v",
+				i, p.Kind, p.Min, p.Max, want.Kind, want.Min, want.Max)
+		}
+	}
+
+	values[0] = 99
+	if plots[0].Plot.Values[0] != 3 {
+		t.Error("the plot command should keep its own copy of the values")
+	}
+}
+
+func TestSparkline_NoFrameOrLabel(t *testing.T) {
+	ui := New(Config{})
+	framed := 0
+	ui.drawFrame = func(ui *UI, rect types.Rect, colorID int) {
+		if colorID == ColorBase {
+			framed++
+		}
+	}
+	plotFrame(ui, func(ui *UI) { ui.Sparkline([]float64{1, 2, 3}) })
+	if framed != 0 {
+		t.Errorf("sparkline drew %d frames, want none", framed)
+	}
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdText && cmd.Text != "Test" {
+			t.Errorf("sparkline drew text %q", cmd.Text)
+		}
+	})
+}
+
+// plotRenderer records the plots it is given.
+type plotRenderer struct {
+	recordRenderer
+	plots []*types.Plot
+}
+
+func (r *plotRenderer) DrawPlot(rect types.Rect, plot *types.Plot, c color.Color) {
+	r.plots = append(r.plots, plot)
+}
+
+func TestPlot_Render(t *testing.T) {
+	ui := New(Config{})
+	build := func(ui *UI) { ui.PlotHistogram("", []float64{1, 2}, OptNoFrame) }
+
+	plotFrame(ui, build)
+	r := &recordRenderer{}
+	ui.Render(r)
+	withoutPlot := len(r.rects)
+	plotFrame(ui, func(ui *UI) {})
+	r = &recordRenderer{}
+	ui.Render(r)
+	if bars := withoutPlot - len(r.rects); bars != 2 {
+		t.Errorf("renderer without DrawPlot got %d bar rects, want 2", bars)
+	}
+
+	plotFrame(ui, build)
+	pr := &plotRenderer{}
+	ui.Render(pr)
+	if len(pr.plots) != 1 || len(pr.rects) != len(r.rects) {
+		t.Errorf("PlotRenderer got %d plots and %d extra rects, want 1 and 0",
+			len(pr.plots), len(pr.rects)-len(r.rects))
+	}
+}
+
+func TestPlot_HoverTooltip(t *testing.T) {
+	ui := New(Config{})
+	build := func(ui *UI) { ui.PlotHistogram("", []float64{10, 20, 30, 40}, 0) }
+	plotFrame(ui, build)
+	// Third bar of four across x 5..205
+	ui.MouseMove(5+125, 50)
+	plotFrame(ui, build)
+
+	var texts []string
+	for _, cmd := range ui.overlay {
+		if cmd.Kind == CmdText {
+			texts = append(texts, cmd.Text)
+		}
+	}
+	if len(texts) != 1 || texts[0] != "2: 30" {
+		t.Errorf("tooltip = %q, want %q", texts, "2: 30")
+	}
+}
+
+func TestPlot_FrameChanged(t *testing.T) {
+	ui := New(Config{})
+	values := []float64{1, 2, 3}
+	frame := func() bool {
+		plotFrame(ui, func(ui *UI) { ui.PlotLines("", values, 0) })
+		return ui.FrameChanged()
+	}
+	frame()
+	if frame() {
+		t.Error("unchanged values should report no change")
+	}
+	values[1] = 5
+	if !frame() {
+		t.Error("changed values should report a change")
+	}
+}
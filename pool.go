@@ -49,9 +49,8 @@ func (p *growPool[T]) Alloc() *T {
 	}
 
 	// Fall back to growth with warning
-	slot := poolSlot[T]{used: atomic.Bool{}}
-	slot.used.Store(true)
-	p.grow = append(p.grow, slot)
+	p.grow = append(p.grow, poolSlot[T]{})
+	p.grow[len(p.grow)-1].used.Store(true)
 	if len(p.grow) == 1 {
 		log.Printf("warning: microui pool started growing beyond fixed size")
 	}
@@ -0,0 +1,90 @@
+package microui
+
+import "github.com/user/microui-go/types"
+
+// Placement is the side of its anchor a popup opens on. A popup that would
+// overflow the screen there flips to the opposite side when that has more
+// room, and is then shifted to stay on screen.
+type Placement int
+
+const (
+	PlaceBelow Placement = iota // Below the anchor, left edges aligned
+	PlaceAbove                  // Above the anchor, left edges aligned
+	PlaceRight                  // Right of the anchor, top edges aligned
+	PlaceLeft                   // Left of the anchor, top edges aligned
+)
+
+// OpenPopupAt opens a popup below anchor, typically the rect of the control
+// that opens it, flipping above it near the bottom of the screen.
+func (u *UI) OpenPopupAt(name string, anchor types.Rect) {
+	u.OpenPopupAtOpt(name, anchor, PlaceBelow)
+}
+
+// OpenPopupAtOpt opens a popup on the given side of anchor. The popup stays
+// attached to anchor while open; move it with SetPopupAnchor if the control
+// scrolls.
+func (u *UI) OpenPopupAtOpt(name string, anchor types.Rect, place Placement) {
+	cnt := u.GetContainer(name)
+	u.hoverRoot = cnt
+	u.nextHoverRoot = cnt
+	cnt.rect = types.Rect{X: anchor.X, Y: anchor.Y, W: 1, H: 1}
+	cnt.anchored = true
+	cnt.popupAnchor, cnt.popupPlace = anchor, place
+	if root := u.rootContainer(); root != nil {
+		cnt.viewport = root.viewport
+	}
+	cnt.open = true
+	u.BringToFront(cnt)
+	u.emit(UIEventPopupOpen, cnt.id, name, 0, anchor)
+}
+
+// SetPopupAnchor moves the anchor of a popup opened with OpenPopupAt, e.g.
+// each frame to follow a control that scrolls.
+func (u *UI) SetPopupAnchor(name string, anchor types.Rect) {
+	u.GetContainer(name).popupAnchor = anchor
+}
+
+// placePopup positions an anchored popup next to its anchor at its current
+// size, flipping and shifting it to fit the screen.
+func (u *UI) placePopup(cnt *Container) {
+	if !cnt.anchored {
+		return
+	}
+	a, r := cnt.popupAnchor, cnt.rect
+	screen := u.screen
+	if vp := cnt.viewport; vp != nil {
+		screen = vp.rect
+	}
+	switch cnt.popupPlace {
+	case PlaceAbove, PlaceBelow:
+		r.X = a.X
+		r.Y = flipPlace(a.Y, a.H, r.H, screen.Y, screen.H, cnt.popupPlace == PlaceAbove)
+	case PlaceLeft, PlaceRight:
+		r.Y = a.Y
+		r.X = flipPlace(a.X, a.W, r.W, screen.X, screen.W, cnt.popupPlace == PlaceLeft)
+	}
+	if !screen.Empty() {
+		r.X = max(screen.X, min(r.X, screen.X+screen.W-r.W))
+		r.Y = max(screen.Y, min(r.Y, screen.Y+screen.H-r.H))
+	}
+	cnt.rect = r
+}
+
+// flipPlace returns where along one axis a popup of length size goes next
+// to an anchor at pos with length span: after it, or before it if before
+// is set, switching sides when the preferred one overflows the screen
+// from lo to lo+length and the other has more room.
+func flipPlace(pos, span, size, lo, length int, before bool) int {
+	room := [2]int{lo + length - pos - span, pos - lo} // After, before
+	side := 0
+	if before {
+		side = 1
+	}
+	if length > 0 && room[side] < size && room[1-side] > room[side] {
+		side = 1 - side
+	}
+	if side == 1 {
+		return pos - size
+	}
+	return pos + span
+}
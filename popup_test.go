@@ -71,3 +71,94 @@ func TestPopup_OpenClose(t *testing.T) {
 		t.Error("Popup should be open after OpenPopup")
 	}
 }
+
+// placedPopup opens popup "p" next to anchor on a 320x240 screen and
+// returns its rect once its size has settled.
+func placedPopup(anchor types.Rect, place Placement) types.Rect {
+	ui := New(Config{})
+	ui.SetScreenSize(320, 240)
+	ui.OpenPopupAtOpt("p", anchor, place)
+	for range 3 {
+		ui.BeginFrame()
+		if ui.BeginPopup("p") {
+			ui.LayoutRow(1, []int{100}, 60)
+			ui.Label("content")
+			ui.EndPopup()
+		}
+		ui.EndFrame()
+	}
+	return ui.GetContainer("p").Rect()
+}
+
+func TestOpenPopupAt_Placement(t *testing.T) {
+	anchor := types.Rect{X: 20, Y: 20, W: 80, H: 20}
+	tests := []struct {
+		name   string
+		anchor types.Rect
+		place  Placement
+		check  func(r types.Rect) bool
+	}{
+		{"below", anchor, PlaceBelow, func(r types.Rect) bool { return r.X == 20 && r.Y == 40 }},
+		{"right", anchor, PlaceRight, func(r types.Rect) bool { return r.X == 100 && r.Y == 20 }},
+		{"above flips below", anchor, PlaceAbove, func(r types.Rect) bool { return r.Y == 40 }},
+		{"left flips right", anchor, PlaceLeft, func(r types.Rect) bool { return r.X == 100 }},
+		{"below flips above", types.Rect{X: 20, Y: 200, W: 80, H: 20}, PlaceBelow, func(r types.Rect) bool { return r.Y+r.H == 200 }},
+		{"right flips left", types.Rect{X: 240, Y: 20, W: 60, H: 20}, PlaceRight, func(r types.Rect) bool { return r.X+r.W == 240 }},
+		{"clamped to the right edge", types.Rect{X: 300, Y: 20, W: 10, H: 20}, PlaceBelow, func(r types.Rect) bool { return r.X+r.W == 320 && r.Y == 40 }},
+	}
+	for _, tt := range tests {
+		r := placedPopup(tt.anchor, tt.place)
+		if r.W <= 100 || !tt.check(r) {
+			t.Errorf("%s: popup at %v next to %v", tt.name, r, tt.anchor)
+		}
+	}
+}
+
+func TestOpenPopup_FlipsAtScreenEdge(t *testing.T) {
+	ui := New(Config{})
+	ui.SetScreenSize(320, 240)
+	ui.MouseMove(310, 230)
+	ui.OpenPopup("p")
+	for range 3 {
+		ui.BeginFrame()
+		if ui.BeginPopup("p") {
+			ui.Label("content")
+			ui.EndPopup()
+		}
+		ui.EndFrame()
+	}
+	r := ui.GetContainer("p").Rect()
+	if r.Y+r.H != 230 || r.X+r.W > 320 {
+		t.Errorf("popup at %v, want it above the mouse and on screen", r)
+	}
+}
+
+func TestCombo_OpensAboveNearScreenBottom(t *testing.T) {
+	ui := New(Config{})
+	ui.SetScreenSize(400, 300)
+	selected := 0
+	control := types.Rect{X: 5, Y: 229, W: 200, H: 20} // As in comboFrame, 200px lower
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 200, W: 400, H: 100})
+		ui.LayoutRow(1, []int{200}, 0)
+		ui.Combo("fruit", comboItems, &selected)
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	frame()
+	x, y := control.X+10, control.Y+5
+	ui.MouseMove(x, y)
+	frame()
+	ui.MouseDown(x, y, MouseLeft)
+	frame()
+	ui.MouseUp(x, y, MouseLeft)
+	frame()
+	popup := comboPopup(ui)
+	if !popup.Open() {
+		t.Fatal("combo list should be open")
+	}
+	if r := popup.Rect(); r.Y+r.H != control.Y {
+		t.Errorf("list at %v, want it above the control at %v", r, control)
+	}
+}
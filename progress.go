@@ -0,0 +1,74 @@
+package microui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+// Animation timing for busy indicators, by the frame clock so they move
+// at the same speed whatever the frame rate.
+const (
+	progressPeriod = 1500 * time.Millisecond // Indeterminate block: one sweep there and back
+	spinnerStep    = 133 * time.Millisecond  // Time per spinner segment
+	spinnerDots    = 3                       // Spinner segment count
+)
+
+// ProgressBar adds a progress bar showing value (0..1) with a percentage label.
+// With OptIndeterminate, value is ignored and a block sweeps back and forth.
+func (u *UI) ProgressBar(value float64, opt int) {
+	u.ProgressBarFormat(value, "%.0f%%", opt)
+}
+
+// ProgressBarFormat adds a progress bar with a custom label. format receives
+// the value as a percentage (0..100); an empty format hides the label.
+func (u *UI) ProgressBarFormat(value float64, format string, opt int) {
+	rect := u.LayoutNext()
+	u.DrawFrame(rect, ColorProgressBase)
+
+	if opt&OptIndeterminate != 0 {
+		u.busy = true
+		blockW := max(rect.W/4, 1)
+		travel := rect.W - blockW
+		// Ping-pong position over the period
+		t := time.Duration(u.frameTime.UnixNano()) % progressPeriod
+		half := progressPeriod / 2
+		if t > half {
+			t = progressPeriod - t
+		}
+		x := rect.X + int(time.Duration(travel)*t/half)
+		u.DrawFrame(types.Rect{X: x, Y: rect.Y, W: blockW, H: rect.H}, ColorProgressFill)
+		u.accessControl(0, rect, RoleProgress, "", "", 0)
+		return
+	}
+
+	value = min(max(value, 0), 1)
+	if fillW := int(value * float64(rect.W)); fillW > 0 {
+		u.DrawFrame(types.Rect{X: rect.X, Y: rect.Y, W: fillW, H: rect.H}, ColorProgressFill)
+	}
+	text := fmt.Sprintf("%.0f%%", value*100)
+	if format != "" {
+		text = fmt.Sprintf(format, value*100)
+		u.DrawControlText(text, rect, ColorText, opt|OptAlignCenter)
+	}
+	u.accessControl(0, rect, RoleProgress, "", text, 0)
+}
+
+// Spinner adds a busy indicator: a row of square segments with one
+// highlighted segment that advances over time, by the frame clock.
+func (u *UI) Spinner() {
+	u.busy = true
+	rect := u.LayoutNext()
+	size := rect.H
+	gap := size / 2
+	active := int(time.Duration(u.frameTime.UnixNano()) / spinnerStep % spinnerDots)
+	for i := 0; i < spinnerDots; i++ {
+		r := types.Rect{X: rect.X + i*(size+gap), Y: rect.Y, W: size, H: size}
+		colorID := ColorProgressBase
+		if i == active {
+			colorID = ColorProgressFill
+		}
+		u.DrawFrame(r, colorID)
+	}
+}
@@ -0,0 +1,123 @@
+package microui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+// progressFrames draws one frame and returns the rects passed to DrawFrame
+// with ColorProgressFill. The control cell is 200 wide at (5,29).
+func progressFrames(t *testing.T, build func(ui *UI)) []types.Rect {
+	t.Helper()
+	var fills []types.Rect
+	ui := New(Config{DrawFrame: func(ui *UI, rect types.Rect, colorID int) {
+		if colorID == ColorProgressFill {
+			fills = append(fills, rect)
+		}
+	}})
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 0)
+	build(ui)
+	ui.EndWindow()
+	ui.EndFrame()
+	return fills
+}
+
+func TestProgressBar_FillWidth(t *testing.T) {
+	fills := progressFrames(t, func(ui *UI) { ui.ProgressBar(0.25, 0) })
+	if len(fills) != 1 || fills[0].W != 50 {
+		t.Fatalf("fills = %v, want one 50px fill", fills)
+	}
+
+	fills = progressFrames(t, func(ui *UI) { ui.ProgressBar(-1, 0) })
+	if len(fills) != 0 {
+		t.Errorf("negative value should draw no fill, got %v", fills)
+	}
+
+	fills = progressFrames(t, func(ui *UI) { ui.ProgressBar(2, 0) })
+	if len(fills) != 1 || fills[0].W != 200 {
+		t.Errorf("value > 1 should clamp to full width, got %v", fills)
+	}
+}
+
+func TestProgressBar_Label(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.ProgressBar(0.5, 0)
+	ui.ProgressBarFormat(0.5, "", 0)
+	ui.EndWindow()
+	ui.EndFrame()
+
+	var texts []string
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdText && cmd.Text != "Test" {
+			texts = append(texts, cmd.Text)
+		}
+	})
+	if len(texts) != 1 || texts[0] != "50%" {
+		t.Errorf("texts = %q, want only %q", texts, "50%")
+	}
+}
+
+func TestProgressBar_IndeterminateMoves(t *testing.T) {
+	ui := New(Config{})
+	var fills []types.Rect
+	ui.drawFrame = func(ui *UI, rect types.Rect, colorID int) {
+		if colorID == ColorProgressFill {
+			fills = append(fills, rect)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		ui.BeginFrameDelta(time.Second / 60)
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.ProgressBar(0, OptIndeterminate)
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	if len(fills) != 10 {
+		t.Fatalf("got %d fills, want one per frame", len(fills))
+	}
+	if fills[0].X == fills[9].X {
+		t.Error("indeterminate block should move between frames")
+	}
+}
+
+func TestBusyIndicators_FrameRateIndependent(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	// at draws both indicators for frames at fps over 400ms and returns
+	// where the block and the active spinner segment ended up.
+	at := func(fps int) []types.Rect {
+		var fills []types.Rect
+		ui := New(Config{DrawFrame: func(ui *UI, rect types.Rect, colorID int) {
+			if colorID == ColorProgressFill {
+				fills = append(fills, rect)
+			}
+		}})
+		for i := 0; i <= fps*4/10; i++ {
+			fills = fills[:0]
+			ui.BeginFrameAt(t0.Add(time.Duration(i) * time.Second / time.Duration(fps)))
+			ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+			ui.LayoutRow(1, []int{200}, 0)
+			ui.ProgressBar(0, OptIndeterminate)
+			ui.Spinner()
+			ui.EndWindow()
+			ui.EndFrame()
+		}
+		return fills
+	}
+	slow, fast := at(30), at(120)
+	if len(slow) != 2 || len(fast) != 2 || slow[0] != fast[0] || slow[1] != fast[1] {
+		t.Errorf("after 400ms at 30 FPS drew %v, at 120 FPS %v; want the same", slow, fast)
+	}
+}
+
+func TestSpinner_OneActiveSegment(t *testing.T) {
+	fills := progressFrames(t, func(ui *UI) { ui.Spinner() })
+	if len(fills) != 1 {
+		t.Errorf("spinner drew %d active segments, want 1", len(fills))
+	}
+}
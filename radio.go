@@ -0,0 +1,62 @@
+package microui
+
+import (
+	"fmt"
+
+	"github.com/user/microui-go/types"
+)
+
+// Radio adds a radio button that selects option for *value.
+// It is checked while *value == option. Radios sharing the same value
+// form a mutually exclusive group. Its ID comes from label and option
+// (scoped by PushID), so value may move between frames; see RadioGroupID
+// for groups with the same labels.
+// Returns true if clicking it changed *value this frame.
+func (u *UI) Radio(label string, value *int, option int) bool {
+	id := u.GetID(fmt.Sprintf("!radio:%s:%d", label, option))
+	rect := u.LayoutNext()
+	box := types.Rect{X: rect.X, Y: rect.Y, W: rect.H, H: rect.H}
+	u.UpdateControl(id, rect)
+
+	changed := false
+	if u.itemClicked(u.activated(id)) && *value != option {
+		*value = option
+		changed = u.itemChanged(true)
+		u.emit(UIEventToggle, id, label, 1, rect)
+	}
+
+	u.DrawControlFrame(id, box, ColorRadio, 0)
+	if *value == option {
+		u.DrawIcon(IconRadio, box, u.style.Colors.Text)
+	}
+	u.DrawControlText(label, types.Rect{X: rect.X + box.W, Y: rect.Y, W: rect.W - box.W, H: rect.H}, ColorText, 0)
+	var state AccessState
+	if *value == option {
+		state = AccessChecked
+	}
+	u.accessControl(id, rect, RoleRadio, label, "", state)
+	return changed
+}
+
+// RadioGroup adds one radio button per label, selecting the label's index
+// into *value. Buttons follow the current layout row, so the group can be
+// laid out vertically or horizontally.
+// Returns true if the selection changed this frame.
+func (u *UI) RadioGroup(value *int, labels []string) bool {
+	changed := false
+	for i, label := range labels {
+		if u.Radio(label, value, i) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RadioGroupID is RadioGroup with its IDs scoped by id (within PushID),
+// for more than one group with the same labels in a container, such as
+// several Yes/No questions.
+func (u *UI) RadioGroupID(id string, value *int, labels []string) bool {
+	u.PushID(id)
+	defer u.PopID()
+	return u.RadioGroup(value, labels)
+}
@@ -0,0 +1,130 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// radioFrame draws a vertical radio group; row i spans y=29+24*i.
+func radioFrame(ui *UI, value *int) bool {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 0)
+	changed := ui.RadioGroup(value, []string{"A", "B", "C"})
+	ui.EndWindow()
+	ui.EndFrame()
+	return changed
+}
+
+func TestRadio_ClickSelects(t *testing.T) {
+	ui := New(Config{})
+	value := 0
+	changed := false
+
+	ui.MouseMove(50, 65) // row 1
+	changed = radioFrame(ui, &value) || changed
+	ui.MouseDown(50, 65, MouseLeft)
+	changed = radioFrame(ui, &value) || changed
+	ui.MouseUp(50, 65, MouseLeft)
+	changed = radioFrame(ui, &value) || changed
+
+	if value != 1 {
+		t.Errorf("value = %d, want 1", value)
+	}
+	if !changed {
+		t.Error("RadioGroup should report the change")
+	}
+}
+
+func TestRadio_ClickingSelectedIsNoChange(t *testing.T) {
+	ui := New(Config{})
+	value := 0
+	ui.MouseMove(50, 35)
+	radioFrame(ui, &value)
+	ui.MouseDown(50, 35, MouseLeft)
+	if radioFrame(ui, &value) {
+		t.Error("clicking the selected radio should not report a change")
+	}
+	ui.MouseUp(50, 35, MouseLeft)
+	radioFrame(ui, &value)
+}
+
+func TestRadio_ValueMovesBetweenFrames(t *testing.T) {
+	ui := New(Config{})
+	v := 0
+	frame := func() {
+		copied := v // A copied struct puts value somewhere new each frame
+		radioFrame(ui, &copied)
+		v = copied
+	}
+	frame()
+	for _, key := range []Key{KeyTab, KeyTab, KeySpace} {
+		pressKey(ui, key)
+		frame()
+	}
+	if v != 1 {
+		t.Errorf("value = %d after tabbing to row 1 and pressing space, want 1", v)
+	}
+}
+
+func TestRadioGroupID_SameLabels(t *testing.T) {
+	ui := New(Config{})
+	var first, second int
+	var focused []ID
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.LayoutRow(1, []int{200}, 0)
+		ui.RadioGroupID("first", &first, []string{"Yes", "No"})
+		ui.RadioGroupID("second", &second, []string{"Yes", "No"})
+		ui.Button("OK")
+		ui.EndWindow()
+		ui.EndFrame()
+		focused = append(focused, ui.input.Focus)
+	}
+	frame()
+	for range 4 { // To the second group's No
+		pressKey(ui, KeyTab)
+		frame()
+	}
+	pressKey(ui, KeySpace)
+	frame()
+	if first != 0 || second != 1 {
+		t.Errorf("values = %d, %d after choosing the second group's No, want 0, 1", first, second)
+	}
+	pressKey(ui, KeyTab) // On to the button
+	frame()
+
+	seen := map[ID]bool{}
+	for _, id := range focused[1:] {
+		seen[id] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("Tab focused %d different controls, want both groups' radios and the button", len(seen))
+	}
+}
+
+func TestRadio_DrawsIndicator(t *testing.T) {
+	radioFrames := 0
+	ui := New(Config{DrawFrame: func(ui *UI, rect types.Rect, colorID int) {
+		if colorID >= ColorRadio && colorID <= ColorRadioFocus {
+			radioFrames++
+		}
+	}})
+	value := 2
+	radioFrame(ui, &value)
+	if radioFrames != 3 {
+		t.Errorf("radio frames = %d, want 3", radioFrames)
+	}
+
+	dots := 0
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdIcon && cmd.Icon == IconRadio {
+			dots++
+		}
+	})
+	if dots != 1 {
+		t.Errorf("radio dots = %d, want 1", dots)
+	}
+}
@@ -0,0 +1,164 @@
+package microui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/user/microui-go/types"
+)
+
+// FrameRecord is a snapshot of one frame's draw commands, in the order
+// Render draws them. It encodes to JSON, prints as one line per command
+// for diffing, and can be replayed to any renderer.
+type FrameRecord struct {
+	Frame    int               `json:"frame"`
+	Scale    float64           `json:"scale"`
+	Commands []RecordedCommand `json:"commands"`
+}
+
+// RecordedCommand is a Command in a serializable form. Fonts, image
+// handles and custom payloads are kept for replay in the same process but
+// aren't encoded, so a decoded record replays text with a nil font and
+// skips images and custom commands.
+type RecordedCommand struct {
+	Kind  CommandKind  `json:"kind"`
+	Rect  types.Rect   `json:"rect,omitzero"`
+	Pos   types.Vec2   `json:"pos,omitzero"`
+	Size  types.Vec2   `json:"size,omitzero"`
+	Text  string       `json:"text,omitempty"`
+	Color *color.NRGBA `json:"color,omitempty"` // Nil for commands without a color
+	Icon  int          `json:"icon,omitempty"`
+	Src   types.Rect   `json:"src,omitzero"`
+	Slice types.Insets `json:"slice,omitzero"`
+	Plot  *types.Plot  `json:"plot,omitempty"`
+
+	Font  types.Font `json:"-"`
+	Image any        `json:"-"`
+	Data  any        `json:"-"`
+}
+
+// RecordFrame returns a snapshot of the commands built since BeginFrame.
+// Call it after EndFrame, where Render would be called.
+func (u *UI) RecordFrame() *FrameRecord {
+	rec := &FrameRecord{Frame: u.frame, Scale: u.scale}
+	u.eachRendered(func(cmd Command) {
+		rec.Commands = append(rec.Commands, recordCommand(cmd))
+	}, nil)
+	return rec
+}
+
+// Replay draws the recorded commands with renderer, as Render would have.
+func (f *FrameRecord) Replay(renderer interface{}) {
+	renderCmd := commandRenderer(renderer, f.Scale)
+	if renderCmd == nil {
+		return
+	}
+	for _, rc := range f.Commands {
+		if (rc.Kind == CmdImage || rc.Kind == CmdNineSlice) && rc.Image == nil {
+			continue // Image handle lost in encoding
+		}
+		if rc.Kind == CmdCustom && rc.Data == nil {
+			continue // Payload lost in encoding
+		}
+		renderCmd(rc.command())
+	}
+}
+
+// String lists the commands one per line, so two records can be compared
+// with a text diff.
+func (f *FrameRecord) String() string {
+	var sb strings.Builder
+	for _, rc := range f.Commands {
+		sb.WriteString(rc.String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func recordCommand(cmd Command) RecordedCommand {
+	rc := RecordedCommand{
+		Kind:  cmd.Kind,
+		Rect:  cmd.Rect,
+		Pos:   cmd.Pos,
+		Size:  cmd.Size,
+		Text:  cmd.Text,
+		Icon:  cmd.Icon,
+		Src:   cmd.Src,
+		Slice: cmd.Slice,
+		Plot:  cmd.Plot,
+		Font:  cmd.Font,
+		Image: cmd.Image,
+		Data:  cmd.Data,
+	}
+	if cmd.Color != nil {
+		c := color.NRGBAModel.Convert(cmd.Color).(color.NRGBA)
+		rc.Color = &c
+	}
+	return rc
+}
+
+func (rc RecordedCommand) command() Command {
+	cmd := Command{
+		Kind:  rc.Kind,
+		Rect:  rc.Rect,
+		Pos:   rc.Pos,
+		Size:  rc.Size,
+		Text:  rc.Text,
+		Icon:  rc.Icon,
+		Src:   rc.Src,
+		Slice: rc.Slice,
+		Plot:  rc.Plot,
+		Font:  rc.Font,
+		Image: rc.Image,
+		Data:  rc.Data,
+	}
+	if rc.Color != nil {
+		cmd.Color = *rc.Color
+	}
+	return cmd
+}
+
+// String formats the command as a single line, e.g.
+// "rect 10,20 30x40 #ff0000ff".
+func (rc RecordedCommand) String() string {
+	rect := func(r types.Rect) string { return fmt.Sprintf("%d,%d %dx%d", r.X, r.Y, r.W, r.H) }
+	var s string
+	switch rc.Kind {
+	case CmdRect:
+		s = fmt.Sprintf("rect %d,%d %dx%d", rc.Pos.X, rc.Pos.Y, rc.Size.X, rc.Size.Y)
+	case CmdText:
+		s = fmt.Sprintf("text %d,%d %q", rc.Pos.X, rc.Pos.Y, rc.Text)
+	case CmdClip:
+		s = "clip " + rect(rc.Rect)
+	case CmdIcon:
+		s = fmt.Sprintf("icon %d %s %s", rc.Icon, rc.Text, rect(rc.Rect))
+	case CmdBox:
+		s = "box " + rect(rc.Rect)
+	case CmdScrollTrack:
+		s = "scrolltrack " + rect(rc.Rect)
+	case CmdScrollThumb:
+		s = "scrollthumb " + rect(rc.Rect)
+	case CmdImage:
+		s = fmt.Sprintf("image %s src %s", rect(rc.Rect), rect(rc.Src))
+	case CmdNineSlice:
+		s = fmt.Sprintf("nineslice %s src %s border %d,%d,%d,%d", rect(rc.Rect), rect(rc.Src),
+			rc.Slice.Left, rc.Slice.Top, rc.Slice.Right, rc.Slice.Bottom)
+	case CmdCustom:
+		s = fmt.Sprintf("custom %s %T", rect(rc.Rect), rc.Data)
+	case CmdShadow:
+		s = fmt.Sprintf("shadow %s offset %d,%d", rect(rc.Rect), rc.Pos.X, rc.Pos.Y)
+	case CmdPlot:
+		s = "plot " + rect(rc.Rect)
+		if p := rc.Plot; p != nil {
+			s += fmt.Sprintf(" kind %d values %v range %g..%g", p.Kind, p.Values, p.Min, p.Max)
+		}
+	default:
+		s = fmt.Sprintf("cmd%d %s", rc.Kind, rect(rc.Rect))
+	}
+	if rc.Color != nil {
+		c := rc.Color
+		s += fmt.Sprintf(" #%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+	}
+	return s
+}
@@ -0,0 +1,142 @@
+package microui
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// logRenderer logs every call, to compare Render with Replay.
+type logRenderer struct {
+	log []string
+}
+
+func (r *logRenderer) add(format string, args ...any) {
+	r.log = append(r.log, fmt.Sprintf(format, args...))
+}
+
+func (r *logRenderer) DrawRect(pos, size types.Vec2, c color.Color) {
+	r.add("rect %v %v %v", pos, size, types.RGBAFromColor(c))
+}
+func (r *logRenderer) DrawText(text string, pos types.Vec2, _ types.Font, c color.Color) {
+	r.add("text %q %v %v", text, pos, types.RGBAFromColor(c))
+}
+func (r *logRenderer) SetClip(rect types.Rect) { r.add("clip %v", rect) }
+func (r *logRenderer) DrawIcon(id int, rect types.Rect, c color.Color) {
+	r.add("icon %d %v %v", id, rect, types.RGBAFromColor(c))
+}
+func (r *logRenderer) DrawBox(rect types.Rect, c color.Color) {
+	r.add("box %v %v", rect, types.RGBAFromColor(c))
+}
+
+func recordTestFrame(ui *UI) {
+	ui.BeginFrame()
+	if ui.BeginWindow("Back", types.Rect{X: 0, Y: 0, W: 200, H: 150}) {
+		ui.Label("Behind")
+		ui.EndWindow()
+	}
+	if ui.BeginWindow("Front", types.Rect{X: 50, Y: 40, W: 200, H: 150}) {
+		ui.Button("OK")
+		checked := true
+		ui.Checkbox("Check", &checked)
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+func TestRecordFrame_ReplayMatchesRender(t *testing.T) {
+	ui := New(Config{})
+	recordTestFrame(ui)
+	ui.BringToFront(ui.GetContainer("Back"))
+	recordTestFrame(ui)
+
+	want := &logRenderer{}
+	ui.Render(want)
+	rec := ui.RecordFrame()
+	if rec.Frame != ui.Frame() || len(rec.Commands) == 0 {
+		t.Fatalf("record frame %d with %d commands", rec.Frame, len(rec.Commands))
+	}
+
+	got := &logRenderer{}
+	rec.Replay(got)
+	if strings.Join(got.log, "\n") != strings.Join(want.log, "\n") {
+		t.Errorf("replay differs from render:\ngot:\n%s\nwant:\n%s", strings.Join(got.log, "\n"), strings.Join(want.log, "\n"))
+	}
+
+	// Back was raised, so its label is the last text drawn
+	var last string
+	for _, cmd := range rec.Commands {
+		if cmd.Kind == CmdText {
+			last = cmd.Text
+		}
+	}
+	if last != "Behind" {
+		t.Errorf("last text = %q, want the Back window's label", last)
+	}
+}
+
+func TestRecordFrame_JSONRoundTrip(t *testing.T) {
+	ui := New(Config{})
+	recordTestFrame(ui)
+	rec := ui.RecordFrame()
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded FrameRecord
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() != rec.String() {
+		t.Errorf("decoded record differs:\n%s\nwant:\n%s", decoded.String(), rec.String())
+	}
+
+	want, got := &logRenderer{}, &logRenderer{}
+	rec.Replay(want)
+	decoded.Replay(got)
+	if strings.Join(got.log, "\n") != strings.Join(want.log, "\n") {
+		t.Error("decoded record replays differently")
+	}
+}
+
+func TestRecordedCommand_String(t *testing.T) {
+	tests := []struct {
+		cmd  Command
+		want string
+	}{
+		{Command{Kind: CmdRect, Pos: types.Vec2{X: 1, Y: 2}, Size: types.Vec2{X: 3, Y: 4}, Color: color.RGBA{R: 255, A: 255}},
+			"rect 1,2 3x4 #ff0000ff"},
+		{Command{Kind: CmdText, Pos: types.Vec2{X: 5, Y: 6}, Text: "Hi", Color: color.White},
+			`text 5,6 "Hi" #ffffffff`},
+		{Command{Kind: CmdClip, Rect: types.Rect{X: 0, Y: 0, W: 10, H: 20}},
+			"clip 0,0 10x20"},
+		{Command{Kind: CmdIcon, Icon: IconClose, Text: "close", Rect: types.Rect{X: 1, Y: 1, W: 8, H: 8}, Color: color.Black},
+			"icon 1 close 1,1 8x8 #000000ff"},
+	}
+	for _, tt := range tests {
+		if got := recordCommand(tt.cmd).String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestRecordFrame_Diff(t *testing.T) {
+	ui := New(Config{})
+	recordTestFrame(ui)
+	a := ui.RecordFrame().String()
+	recordTestFrame(ui)
+	if b := ui.RecordFrame().String(); a != b {
+		t.Errorf("identical frames record differently:\n%s\n---\n%s", a, b)
+	}
+
+	ui.GetContainer("Front").SetRect(types.Rect{X: 60, Y: 40, W: 200, H: 150})
+	recordTestFrame(ui)
+	if ui.RecordFrame().String() == a {
+		t.Error("moving a window did not change the record")
+	}
+}
@@ -0,0 +1,86 @@
+package microui
+
+// NeedsRedraw reports whether the UI needs another frame: input arrived
+// since the last BeginFrame, the last frame had input whose effects show
+// in the next one, an animation, smooth scroll, window fade, Spinner or
+// indeterminate ProgressBar is running, a key, button or finger is held
+// and may repeat or long-press, an input trace is playing, the screen size
+// or scale changed, or Invalidate was called. Event-driven hosts such as
+// terminal UIs can build a frame only while it reports true instead of
+// ticking at a fixed rate:
+//
+//	ui.EndFrame()
+//	ui.Render(renderer)
+//	if ui.NeedsRedraw() {
+//		return tickAgain()
+//	}
+//
+// Before the first frame it reports true. It is safe to call from any
+// goroutine.
+func (u *UI) NeedsRedraw() bool {
+	return u.redraw.Load() || len(u.inputCh) > 0
+}
+
+// Invalidate asks for another frame, e.g. when data the UI shows or the
+// style changed outside of a frame. NeedsRedraw reports true until the
+// next BeginFrame, and Config.OnInvalidate is called if no frame was asked
+// for yet. It is safe to call from any goroutine; called while a frame is
+// being built it asks for the one after.
+func (u *UI) Invalidate() {
+	if !u.redraw.Swap(true) && u.onInvalidate != nil {
+		u.onInvalidate()
+	}
+}
+
+// inputArrived asks for a frame for new input, and for one more after it
+// to show its effects.
+func (u *UI) inputArrived() {
+	u.inputSeen.Store(true)
+	u.Invalidate()
+}
+
+// beginRedraw clears the frame request at the start of a frame. Input
+// arriving from here on asks for the next one.
+func (u *UI) beginRedraw() {
+	u.redraw.Store(false)
+	u.settle = u.inputSeen.Swap(false)
+	u.busy = false
+}
+
+// endRedraw asks for another frame if this one leaves something in
+// motion. Called from EndFrame once the animations are pruned.
+func (u *UI) endRedraw() {
+	if u.settle || u.animating() {
+		u.Invalidate()
+	}
+}
+
+// animating reports whether anything changes in the next frame without
+// new input.
+func (u *UI) animating() bool {
+	if u.busy {
+		return true
+	}
+	for _, st := range u.anims {
+		if st.moving {
+			return true
+		}
+	}
+	for _, cnt := range u.containers {
+		if cnt.scrollAnim || cnt.fadeCmds != nil {
+			return true
+		}
+		if u.style.ScrollbarAutoHide && cnt.barAlpha > 0 && !cnt.barHot && cnt.lastUsed == u.frame {
+			return true // Scrollbars waiting to fade out
+		}
+	}
+	if u.repeatArmed && u.repeatDelay >= 0 || u.buttonRepeat != 0 {
+		return true
+	}
+	if len(u.touches.points) > 0 || u.touches.fling != [2]float64{} {
+		return true
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.playback != nil
+}
@@ -0,0 +1,132 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// redrawApp counts OnInvalidate calls and draws one window.
+type redrawApp struct {
+	ui          *UI
+	invalidated int
+}
+
+func newRedrawApp(cfg Config) *redrawApp {
+	p := &redrawApp{}
+	cfg.OnInvalidate = func() { p.invalidated++ }
+	p.ui = New(cfg)
+	return p
+}
+
+func (p *redrawApp) frame() {
+	ui := p.ui
+	ui.BeginFrame()
+	if ui.BeginWindow("Main", types.Rect{X: 0, Y: 0, W: 200, H: 100}) {
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Button("OK")
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+// framesUntilIdle runs frames while the UI needs them, up to limit.
+func (p *redrawApp) framesUntilIdle(limit int) int {
+	n := 0
+	for ; p.ui.NeedsRedraw() && n < limit; n++ {
+		p.frame()
+	}
+	return n
+}
+
+func TestNeedsRedraw_Input(t *testing.T) {
+	p := newRedrawApp(Config{})
+	if !p.ui.NeedsRedraw() {
+		t.Fatal("a new UI should need its first frame")
+	}
+	if n := p.framesUntilIdle(10); n != 1 {
+		t.Fatalf("idle after %d frames, want 1", n)
+	}
+
+	p.ui.MouseMove(20, 40)
+	p.ui.MouseMove(30, 40)
+	if !p.ui.NeedsRedraw() {
+		t.Fatal("input should ask for a frame")
+	}
+	if p.invalidated != 1 {
+		t.Errorf("OnInvalidate called %d times for two events, want 1", p.invalidated)
+	}
+	if n := p.framesUntilIdle(10); n != 2 {
+		t.Errorf("idle %d frames after input, want 2: one for the input, one to show its effects", n)
+	}
+}
+
+func TestNeedsRedraw_Animations(t *testing.T) {
+	p := newRedrawApp(Config{Animations: true})
+	p.framesUntilIdle(100)
+	p.ui.MouseMove(20, 40) // Onto the button, which fades to its hover color
+	if n := p.framesUntilIdle(100); n < 4 || n == 100 {
+		t.Errorf("idle after %d frames of a hover fade, want it to run and then stop", n)
+	}
+}
+
+func TestNeedsRedraw_HeldKey(t *testing.T) {
+	p := newRedrawApp(Config{})
+	p.framesUntilIdle(10)
+	p.ui.KeyDown(KeyA)
+	if n := p.framesUntilIdle(10); n != 10 {
+		t.Errorf("idle after %d frames with a key held, want frames while it may repeat", n)
+	}
+	p.ui.KeyUp(KeyA)
+	if n := p.framesUntilIdle(10); n == 10 {
+		t.Error("still busy after the key was released")
+	}
+}
+
+func TestNeedsRedraw_BusyIndicators(t *testing.T) {
+	for name, draw := range map[string]func(ui *UI){
+		"spinner":              func(ui *UI) { ui.Spinner() },
+		"indeterminate":        func(ui *UI) { ui.ProgressBar(0, OptIndeterminate) },
+		"determinate progress": func(ui *UI) { ui.ProgressBar(0.5, 0) },
+	} {
+		ui := New(Config{})
+		frame := func() {
+			ui.BeginFrame()
+			if ui.BeginWindow("Main", types.Rect{X: 0, Y: 0, W: 200, H: 100}) {
+				ui.LayoutRow(1, []int{-1}, 0)
+				draw(ui)
+				ui.EndWindow()
+			}
+			ui.EndFrame()
+		}
+		n := 0
+		for ; ui.NeedsRedraw() && n < 10; n++ {
+			frame()
+		}
+		if busy := name != "determinate progress"; (n == 10) != busy {
+			t.Errorf("%s: idle after %d frames, want frames while it moves: %v", name, n, busy)
+		}
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	p := newRedrawApp(Config{})
+	p.framesUntilIdle(10)
+	done := make(chan struct{})
+	go func() {
+		p.ui.Invalidate()
+		close(done)
+	}()
+	<-done
+	if !p.ui.NeedsRedraw() || p.invalidated != 1 {
+		t.Errorf("NeedsRedraw = %v, OnInvalidate called %d times after Invalidate, want true, 1",
+			p.ui.NeedsRedraw(), p.invalidated)
+	}
+	p.ui.SetScreenSize(640, 480)
+	if p.invalidated != 1 {
+		t.Error("OnInvalidate called again while a frame was already asked for")
+	}
+	if n := p.framesUntilIdle(10); n != 1 {
+		t.Errorf("idle after %d frames, want 1", n)
+	}
+}
@@ -0,0 +1,61 @@
+// Package atlas holds the bitmap atlas from the original microui: a
+// 128x128 grayscale texture with a proportional 17px font and the
+// close, check and arrow icons. It has no dependencies, so software and
+// GPU renderers can share it.
+package atlas
+
+import (
+	"image"
+	"image/color"
+)
+
+// Image returns the atlas as white pixels with the grayscale values as
+// alpha, ready to upload as a texture and tint per draw.
+func Image() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, AtlasWidth, AtlasHeight))
+	for y := 0; y < AtlasHeight; y++ {
+		for x := 0; x < AtlasWidth; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: AtlasTexture[y*AtlasWidth+x]})
+		}
+	}
+	return img
+}
+
+// Glyph returns the atlas rect of a character.
+func Glyph(ch rune) (Rect, bool) {
+	if ch < 0 || ch > 127 {
+		return Rect{}, false
+	}
+	rect, ok := AtlasRects[AtlasFont+int(ch)]
+	return rect, ok
+}
+
+// Font measures text set in the atlas font; it implements types.Font.
+type Font struct{}
+
+// FontHeight is the height of the atlas font in pixels.
+const FontHeight = 17
+
+// UnknownWidth is the advance of characters missing from the atlas.
+const UnknownWidth = 6
+
+// Width returns the pixel width of the given text.
+func (Font) Width(text string) int {
+	width := 0
+	for _, ch := range text {
+		if ch == '\n' {
+			continue
+		}
+		if rect, ok := Glyph(ch); ok {
+			width += rect.W
+		} else {
+			width += UnknownWidth
+		}
+	}
+	return width
+}
+
+// Height returns the font height in pixels.
+func (Font) Height() int {
+	return FontHeight
+}
@@ -0,0 +1,303 @@
+package batch
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+
+	"github.com/user/microui-go/render/atlas"
+	"github.com/user/microui-go/types"
+)
+
+// Vertex is one corner of a textured, colored triangle.
+type Vertex struct {
+	X, Y  float32  // Position in pixels
+	U, V  float32  // Texture coordinates, 0-1 across the texture
+	Color [4]uint8 // RGBA, straight alpha; multiplies the texture
+}
+
+// DrawCall is a run of Indices drawn with one texture.
+type DrawCall struct {
+	Texture any // nil = the atlas, otherwise the handle passed to UI.Image
+	Offset  int // First index
+	Count   int // Number of indices
+}
+
+// Icon IDs (must match microui constants)
+const (
+	iconClose      = 1
+	iconCheck      = 2
+	iconCollapsed  = 3
+	iconExpanded   = 4
+	iconResize     = 5
+	iconRadio      = 6
+	iconMaximize   = 7
+	iconRestore    = 8
+	iconPin        = 9
+	iconArrowUp    = 10
+	iconArrowDown  = 11
+	iconArrowLeft  = 12
+	iconArrowRight = 13
+)
+
+// noClip is the clip rect before the first SetClip.
+var noClip = types.Rect{X: -1 << 20, Y: -1 << 20, W: 1 << 21, H: 1 << 21}
+
+// Renderer implements microui.Renderer by collecting vertices and
+// indices; it draws nothing itself. Call Reset before each frame.
+type Renderer struct {
+	Vertices []Vertex
+	Indices  []uint32
+	Draws    []DrawCall
+
+	// Scrollbar colors (scroll commands carry none)
+	ScrollTrackColor color.Color
+	ScrollThumbColor color.Color
+
+	clip  types.Rect
+	scale float32
+}
+
+// NewRenderer creates a new batch renderer.
+func NewRenderer() *Renderer {
+	theme := types.DarkTheme()
+	return &Renderer{
+		ScrollTrackColor: theme.ScrollBase,
+		ScrollThumbColor: theme.ScrollThumb,
+		clip:             noClip,
+		scale:            1,
+	}
+}
+
+// Reset clears the buffers for a new frame, keeping their memory.
+func (r *Renderer) Reset() {
+	r.Vertices = r.Vertices[:0]
+	r.Indices = r.Indices[:0]
+	r.Draws = r.Draws[:0]
+	r.clip = noClip
+}
+
+// SetScale is called by microui.UI.Render with the UI scale; text and
+// icons are magnified by it.
+func (r *Renderer) SetScale(scale float64) {
+	if scale <= 0 {
+		scale = 1
+	}
+	r.scale = float32(scale)
+}
+
+// SetClip sets the clipping rectangle.
+func (r *Renderer) SetClip(rect types.Rect) {
+	r.clip = rect
+}
+
+// DrawRect fills a rectangle with the given color.
+func (r *Renderer) DrawRect(pos, size types.Vec2, c color.Color) {
+	r.fill(float32(pos.X), float32(pos.Y), float32(size.X), float32(size.Y), c)
+}
+
+// DrawBox draws an unfilled rectangle outline (border only).
+func (r *Renderer) DrawBox(rect types.Rect, c color.Color) {
+	x, y, w, h := float32(rect.X), float32(rect.Y), float32(rect.W), float32(rect.H)
+	r.fill(x+1, y, w-2, 1, c)
+	r.fill(x+1, y+h-1, w-2, 1, c)
+	r.fill(x, y, 1, h, c)
+	r.fill(x+w-1, y, 1, h, c)
+}
+
+// DrawText draws text with the atlas font; the layout font should be
+// atlas.Font so measurements match. font is ignored.
+func (r *Renderer) DrawText(text string, pos types.Vec2, font types.Font, c color.Color) {
+	x, y := float32(pos.X), float32(pos.Y)
+	for _, ch := range text {
+		if ch == '\n' {
+			x = float32(pos.X)
+			y += atlas.FontHeight * r.scale
+			continue
+		}
+		g, ok := atlas.Glyph(ch)
+		if !ok {
+			x += atlas.UnknownWidth * r.scale
+			continue
+		}
+		w, h := float32(g.W)*r.scale, float32(g.H)*r.scale
+		r.atlasQuad(x, y, w, h, g, c)
+		x += w
+	}
+}
+
+// DrawIcon draws an icon centered in rect: atlas icons where the atlas
+// has them, simple shapes otherwise.
+func (r *Renderer) DrawIcon(id int, rect types.Rect, c color.Color) {
+	if g, ok := atlas.AtlasRects[id]; ok && id < atlas.AtlasFont {
+		w, h := float32(g.W)*r.scale, float32(g.H)*r.scale
+		x := float32(rect.X) + (float32(rect.W)-w)/2
+		y := float32(rect.Y) + (float32(rect.H)-h)/2
+		r.atlasQuad(x, y, w, h, g, c)
+		return
+	}
+
+	cx := float32(rect.X) + float32(rect.W)/2
+	cy := float32(rect.Y) + float32(rect.H)/2
+	size := float32(min(rect.W, rect.H)) * 0.6
+	switch id {
+	case iconResize:
+		// No visual for the resize gripper - the area still works for dragging
+
+	case iconRadio: // Filled square dot
+		half := size * 0.35
+		r.fill(cx-half, cy-half, half*2, half*2, c)
+
+	case iconMaximize: // Single window outline
+		r.outline(cx-size/2, cy-size/2, size, size, c)
+
+	case iconRestore: // Two overlapping window outlines
+		s := size * 0.7
+		off := size - s
+		r.outline(cx-size/2+off, cy-size/2, s, s, c)
+		r.outline(cx-size/2, cy-size/2+off, s, s, c)
+
+	case iconPin: // Pushpin: head over a needle
+		r.fill(cx-size/3, cy-size/2, size*2/3, size/2, c)
+		r.fill(cx-0.5, cy, 1, size/2, c)
+
+	case iconArrowUp, iconArrowDown, iconArrowLeft, iconArrowRight: // Filled triangle
+		r.arrow(id, cx, cy, size/2, c)
+	}
+}
+
+// arrow fills a triangle pointing the way of arrow icon id, centered on
+// cx, cy, as one-pixel strips widening by two from the tip.
+func (r *Renderer) arrow(id int, cx, cy, length float32, c color.Color) {
+	tip := length / 2
+	for i := float32(0); i < length; i++ {
+		switch id {
+		case iconArrowUp:
+			r.fill(cx-i-0.5, cy-tip+i, i*2+1, 1, c)
+		case iconArrowDown:
+			r.fill(cx-i-0.5, cy+tip-i-1, i*2+1, 1, c)
+		case iconArrowLeft:
+			r.fill(cx-tip+i, cy-i-0.5, 1, i*2+1, c)
+		case iconArrowRight:
+			r.fill(cx+tip-i-1, cy-i-0.5, 1, i*2+1, c)
+		}
+	}
+}
+
+// DrawScrollTrack draws a scrollbar track (background).
+func (r *Renderer) DrawScrollTrack(rect types.Rect) {
+	r.fill(float32(rect.X), float32(rect.Y), float32(rect.W), float32(rect.H), r.ScrollTrackColor)
+}
+
+// DrawScrollThumb draws a scrollbar thumb (draggable part).
+func (r *Renderer) DrawScrollThumb(rect types.Rect) {
+	r.fill(float32(rect.X), float32(rect.Y), float32(rect.W), float32(rect.H), r.ScrollThumbColor)
+}
+
+// DrawWindowShadow darkens the strips right of and below rect where its
+// drop shadow falls, blending c over them.
+func (r *Renderer) DrawWindowShadow(rect types.Rect, offset types.Vec2, c color.Color) {
+	for _, s := range rect.ShadowStrips(offset) {
+		r.DrawRect(types.Vec2{X: s.X, Y: s.Y}, types.Vec2{X: s.W, Y: s.H}, c)
+	}
+}
+
+// DrawImage adds a quad textured with img, starting a new draw call when
+// the texture changes. src selects a region of img in pixels when img has
+// a Bounds method (image.Image, *ebiten.Image, ...); otherwise, or with an
+// empty src, the whole texture is used.
+func (r *Renderer) DrawImage(img any, rect, src types.Rect, tint color.Color) {
+	if img == nil {
+		return
+	}
+	u0, v0, u1, v1 := float32(0), float32(0), float32(1), float32(1)
+	if b, ok := img.(interface{ Bounds() image.Rectangle }); ok && !src.Empty() {
+		bounds := b.Bounds()
+		if bounds.Empty() {
+			return
+		}
+		bw, bh := float32(bounds.Dx()), float32(bounds.Dy())
+		u0 = float32(src.X-bounds.Min.X) / bw
+		v0 = float32(src.Y-bounds.Min.Y) / bh
+		u1 = float32(src.X+src.W-bounds.Min.X) / bw
+		v1 = float32(src.Y+src.H-bounds.Min.Y) / bh
+	}
+	r.quad(img, float32(rect.X), float32(rect.Y), float32(rect.W), float32(rect.H), u0, v0, u1, v1, tint)
+}
+
+// fill adds a solid rectangle drawn from the atlas's white patch.
+func (r *Renderer) fill(x, y, w, h float32, c color.Color) {
+	white := atlas.WhiteRect
+	u := (float32(white.X) + float32(white.W)/2) / atlas.AtlasWidth
+	v := (float32(white.Y) + float32(white.H)/2) / atlas.AtlasHeight
+	r.quad(nil, x, y, w, h, u, v, u, v, c)
+}
+
+// outline adds a 1px rectangle outline.
+func (r *Renderer) outline(x, y, w, h float32, c color.Color) {
+	r.fill(x, y, w, 1, c)
+	r.fill(x, y+h-1, w, 1, c)
+	r.fill(x, y+1, 1, h-2, c)
+	r.fill(x+w-1, y+1, 1, h-2, c)
+}
+
+// atlasQuad adds a quad showing the atlas rect g.
+func (r *Renderer) atlasQuad(x, y, w, h float32, g atlas.Rect, c color.Color) {
+	r.quad(nil, x, y, w, h,
+		float32(g.X)/atlas.AtlasWidth, float32(g.Y)/atlas.AtlasHeight,
+		float32(g.X+g.W)/atlas.AtlasWidth, float32(g.Y+g.H)/atlas.AtlasHeight, c)
+}
+
+// quad adds a textured rectangle clipped to the clip rect, interpolating
+// the texture coordinates at the clipped edges.
+func (r *Renderer) quad(tex any, x, y, w, h, u0, v0, u1, v1 float32, c color.Color) {
+	if c == nil || w <= 0 || h <= 0 {
+		return
+	}
+	x0 := max(x, float32(r.clip.X))
+	y0 := max(y, float32(r.clip.Y))
+	x1 := min(x+w, float32(r.clip.X+r.clip.W))
+	y1 := min(y+h, float32(r.clip.Y+r.clip.H))
+	if x0 >= x1 || y0 >= y1 {
+		return
+	}
+	du, dv := (u1-u0)/w, (v1-v0)/h
+	u0, u1 = u0+(x0-x)*du, u0+(x1-x)*du
+	v0, v1 = v0+(y0-y)*dv, v0+(y1-y)*dv
+
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	col := [4]uint8{nc.R, nc.G, nc.B, nc.A}
+
+	r.use(tex)
+	base := uint32(len(r.Vertices))
+	r.Vertices = append(r.Vertices,
+		Vertex{X: x0, Y: y0, U: u0, V: v0, Color: col},
+		Vertex{X: x1, Y: y0, U: u1, V: v0, Color: col},
+		Vertex{X: x1, Y: y1, U: u1, V: v1, Color: col},
+		Vertex{X: x0, Y: y1, U: u0, V: v1, Color: col},
+	)
+	r.Indices = append(r.Indices, base, base+1, base+2, base+2, base+3, base)
+	r.Draws[len(r.Draws)-1].Count += 6
+}
+
+// use makes tex the texture of the last draw call, starting a new one if
+// it differs.
+func (r *Renderer) use(tex any) {
+	if n := len(r.Draws); n > 0 && sameTexture(r.Draws[n-1].Texture, tex) {
+		return
+	}
+	r.Draws = append(r.Draws, DrawCall{Texture: tex, Offset: len(r.Indices)})
+}
+
+// sameTexture compares texture handles without panicking on
+// uncomparable ones, including comparable structs holding an uncomparable
+// value in an interface field.
+func sameTexture(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	return reflect.ValueOf(a).Comparable() && reflect.ValueOf(b).Comparable() && a == b
+}
@@ -0,0 +1,118 @@
+package batch
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/render/atlas"
+	"github.com/user/microui-go/types"
+)
+
+func TestRenderer_QuadClipping(t *testing.T) {
+	r := NewRenderer()
+	r.SetClip(types.Rect{X: 10, Y: 0, W: 10, H: 100})
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 40))
+	r.DrawImage(img, types.Rect{X: 0, Y: 0, W: 20, H: 20}, types.Rect{}, color.White)
+
+	if len(r.Vertices) != 4 || len(r.Indices) != 6 {
+		t.Fatalf("got %d vertices, %d indices, want one quad", len(r.Vertices), len(r.Indices))
+	}
+	// The left half is clipped away, and the texture with it
+	v := r.Vertices[0]
+	if v.X != 10 || v.U != 0.5 {
+		t.Errorf("top-left vertex at x=%v u=%v, want x=10 u=0.5", v.X, v.U)
+	}
+	if v := r.Vertices[2]; v.X != 20 || v.Y != 20 || v.U != 1 || v.V != 1 {
+		t.Errorf("bottom-right vertex = %+v, want (20,20) uv (1,1)", v)
+	}
+
+	r.SetClip(types.Rect{X: 50, Y: 50, W: 10, H: 10})
+	r.DrawRect(types.Vec2{}, types.Vec2{X: 20, Y: 20}, color.White)
+	if len(r.Vertices) != 4 {
+		t.Error("a rect outside the clip should add no vertices")
+	}
+}
+
+func TestRenderer_ImageSource(t *testing.T) {
+	r := NewRenderer()
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 32))
+	r.DrawImage(img, types.Rect{W: 16, H: 16}, types.Rect{X: 16, Y: 0, W: 16, H: 16}, nil)
+	if len(r.Vertices) != 0 {
+		t.Error("a nil tint should draw nothing")
+	}
+	r.DrawImage(img, types.Rect{W: 16, H: 16}, types.Rect{X: 16, Y: 0, W: 16, H: 16}, color.White)
+	v0, v2 := r.Vertices[0], r.Vertices[2]
+	if v0.U != 0.25 || v0.V != 0 || v2.U != 0.5 || v2.V != 0.5 {
+		t.Errorf("uv = (%v,%v)-(%v,%v), want (0.25,0)-(0.5,0.5)", v0.U, v0.V, v2.U, v2.V)
+	}
+}
+
+func TestRenderer_DrawCalls(t *testing.T) {
+	r := NewRenderer()
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	rect := types.Rect{W: 8, H: 8}
+	r.DrawRect(types.Vec2{}, types.Vec2{X: 8, Y: 8}, color.White)
+	r.DrawText("ab", types.Vec2{}, nil, color.White)
+	r.DrawImage(img, rect, types.Rect{}, color.White)
+	r.DrawImage(img, rect, types.Rect{}, color.White)
+	r.DrawIcon(microui.IconCheck, rect, color.White)
+
+	want := []DrawCall{
+		{Texture: nil, Offset: 0, Count: 18},
+		{Texture: img, Offset: 18, Count: 12},
+		{Texture: nil, Offset: 30, Count: 6},
+	}
+	if len(r.Draws) != len(want) {
+		t.Fatalf("draws = %+v, want %+v", r.Draws, want)
+	}
+	for i := range want {
+		if r.Draws[i] != want[i] {
+			t.Errorf("draw %d = %+v, want %+v", i, r.Draws[i], want[i])
+		}
+	}
+
+	r.Reset()
+	if len(r.Vertices) != 0 || len(r.Indices) != 0 || len(r.Draws) != 0 {
+		t.Error("Reset should clear the buffers")
+	}
+}
+
+func TestRenderer_UncomparableTexture(t *testing.T) {
+	// A comparable struct type holding a slice: == on it panics
+	type handle struct{ v any }
+	r := NewRenderer()
+	rect := types.Rect{W: 8, H: 8}
+	r.DrawImage(handle{v: []int{1}}, rect, rect, color.White)
+	r.DrawImage(handle{v: []int{1}}, rect, rect, color.White)
+	if len(r.Draws) != 2 {
+		t.Errorf("%d draw calls, want textures that can't be compared kept apart", len(r.Draws))
+	}
+}
+
+func TestRenderer_UIFrameIsOneDrawCall(t *testing.T) {
+	style := microui.GUIStyle()
+	style.Font = atlas.Font{}
+	ui := microui.New(microui.Config{Style: style})
+	ui.BeginFrame()
+	if ui.BeginWindow("Batch", types.Rect{X: 10, Y: 10, W: 200, H: 150}) {
+		ui.Label("Hello")
+		ui.Button("OK")
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+
+	r := NewRenderer()
+	ui.Render(r)
+	if len(r.Draws) != 1 || r.Draws[0].Count != len(r.Indices) {
+		t.Fatalf("draws = %+v for %d indices, want a single draw call", r.Draws, len(r.Indices))
+	}
+	// GUI borders are drawn one pixel outside the window
+	for _, i := range r.Indices {
+		v := r.Vertices[i]
+		if v.X < 9 || v.Y < 9 || v.X > 211 || v.Y > 161 {
+			t.Fatalf("vertex %+v outside the window and its border", v)
+		}
+	}
+}
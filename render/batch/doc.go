@@ -0,0 +1,30 @@
+// Package batch turns microui-go draw commands into triangles for custom
+// engines and graphics APIs (OpenGL, Vulkan, Metal, WebGPU, ...).
+//
+// Text, icons and solid rectangles are all drawn from the microui bitmap
+// atlas (see render/atlas), and clipping is done on the CPU, so a frame
+// without application images is a single draw call with one texture.
+//
+// # Usage
+//
+//	import (
+//		"github.com/user/microui-go/render/atlas"
+//		"github.com/user/microui-go/render/batch"
+//	)
+//
+//	// Once: upload atlas.Image() as a texture, and measure with the atlas font
+//	ui := microui.New(microui.Config{Style: style}) // style.Font = atlas.Font{}
+//	r := batch.NewRenderer()
+//
+//	// Each frame
+//	r.Reset()
+//	ui.Render(r)
+//	upload(r.Vertices, r.Indices)
+//	for _, d := range r.Draws {
+//		bindTexture(d.Texture) // nil = the atlas
+//		drawTriangles(d.Offset, d.Count)
+//	}
+//
+// Vertices use pixel positions with the origin at the top left and
+// straight (non-premultiplied) alpha.
+package batch
@@ -0,0 +1,45 @@
+package bubbletea
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"sync"
+)
+
+// Clipboard implements microui.Clipboard for terminal applications.
+//
+// Set writes an OSC 52 escape sequence so terminals that support it copy
+// the text to the system clipboard. Terminals rarely allow reading the
+// clipboard back, so Get returns the last text set through this provider.
+type Clipboard struct {
+	mu   sync.Mutex
+	text string
+	out  io.Writer
+}
+
+// NewClipboard creates a clipboard that writes OSC 52 sequences to out.
+// If out is nil, os.Stdout is used.
+func NewClipboard(out io.Writer) *Clipboard {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &Clipboard{out: out}
+}
+
+// Get returns the most recently copied text.
+func (c *Clipboard) Get() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.text
+}
+
+// Set stores text and sends it to the terminal clipboard via OSC 52.
+func (c *Clipboard) Set(text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.text = text
+	if c.out != nil {
+		io.WriteString(c.out, "\x1b]52;c;"+base64.StdEncoding.EncodeToString([]byte(text))+"\a")
+	}
+}
@@ -34,25 +34,7 @@ func TUITheme() types.ThemeColors {
 // Classic blue/cyan color scheme with high contrast.
 // Note: Use with custom DrawFrame (tuiDrawFrame) to draw borders only on windows.
 func BorlandTheme() types.ThemeColors {
-	return types.ThemeColors{
-		Text:         color.RGBA{R: 255, G: 255, B: 255, A: 255}, // Bright white
-		Border:       color.RGBA{R: 0, G: 0, B: 0, A: 255},       // Black (used by custom DrawFrame for windows only)
-		WindowBg:     color.RGBA{R: 0, G: 170, B: 170, A: 255},   // Cyan window background
-		WindowTitle:  color.RGBA{R: 0, G: 0, B: 170, A: 255},     // Blue title bar
-		WindowBorder: color.RGBA{R: 0, G: 0, B: 0, A: 255},       // Black window border
-		TitleText:    color.RGBA{R: 255, G: 255, B: 255, A: 255}, // White title text
-		PanelBg:      color.RGBA{R: 0, G: 170, B: 170, A: 255},   // Cyan panel
-		Button:       color.RGBA{R: 0, G: 170, B: 0, A: 255},     // Green buttons
-		ButtonHover:  color.RGBA{R: 0, G: 255, B: 0, A: 255},     // Bright green hover
-		ButtonActive: color.RGBA{R: 255, G: 255, B: 255, A: 255}, // White when pressed
-		Base:         color.RGBA{R: 0, G: 0, B: 170, A: 255},     // Blue input bg
-		BaseHover:    color.RGBA{R: 0, G: 0, B: 200, A: 255},     // Lighter blue hover
-		BaseFocus:    color.RGBA{R: 0, G: 0, B: 255, A: 255},     // Bright blue focus
-		CheckBg:      color.RGBA{R: 0, G: 170, B: 170, A: 255},   // Cyan checkbox bg
-		CheckActive:  color.RGBA{R: 255, G: 255, B: 0, A: 255},   // Yellow checkmark
-		ScrollBase:   color.RGBA{R: 0, G: 85, B: 85, A: 255},     // Dark cyan track
-		ScrollThumb:  color.RGBA{R: 0, G: 255, B: 255, A: 255},   // Bright cyan thumb
-	}
+	return types.BorlandTheme()
 }
 
 // DesktopBlue is the classic Borland desktop background color.
@@ -1,16 +1,24 @@
 package bubbletea
 
-import "unicode/utf8"
+import "github.com/user/microui-go/types"
 
 // MonospaceFont implements types.Font for terminal text rendering.
-// Each character occupies exactly 1 cell width and 1 cell height.
+// Most characters take 1 cell; wide CJK characters and emoji take 2, and
+// combining marks none (see types.RuneCells).
 type MonospaceFont struct{}
 
 // Width returns the width of text in terminal cells.
-// For a monospace terminal, each rune is 1 cell wide.
-// Note: CJK wide characters would need special handling for proper display.
 func (f *MonospaceFont) Width(text string) int {
-	return utf8.RuneCountInString(text)
+	return types.StringCells(text)
+}
+
+// WidthRunes returns the width of runes in terminal cells.
+func (f *MonospaceFont) WidthRunes(runes []rune) int {
+	n := 0
+	for _, r := range runes {
+		n += types.RuneCells(r)
+	}
+	return n
 }
 
 // Height returns the font height in terminal rows (always 1).
@@ -2,22 +2,38 @@ package bubbletea
 
 // Icon IDs (must match microui.IconClose, IconCheck, etc.)
 const (
-	iconClose     = 1
-	iconCheck     = 2
-	iconCollapsed = 3
-	iconExpanded  = 4
-	iconResize    = 5
+	iconClose      = 1
+	iconCheck      = 2
+	iconCollapsed  = 3
+	iconExpanded   = 4
+	iconResize     = 5
+	iconRadio      = 6
+	iconMaximize   = 7
+	iconRestore    = 8
+	iconPin        = 9
+	iconArrowUp    = 10
+	iconArrowDown  = 11
+	iconArrowLeft  = 12
+	iconArrowRight = 13
 )
 
 // Icon rune mappings for terminal display.
 // Classic Turbo Vision style characters.
 const (
-	IconRuneClose     = '\u25A0' // ■ (black square - classic TV close button)
-	IconRuneCheck     = '\u2713' // ✓ (check mark)
-	IconRuneCollapsed = '\u25BA' // ► (black right-pointing pointer)
-	IconRuneExpanded  = '\u25BC' // ▼ (black down-pointing triangle)
-	IconRuneFallback  = '\u25A1' // □ (white square, fallback)
-	IconRuneResize    = '\u2518' // ┘ (box drawings light up and left - resize gripper)
+	IconRuneClose      = '\u25A0' // ■ (black square - classic TV close button)
+	IconRuneCheck      = '\u2713' // ✓ (check mark)
+	IconRuneCollapsed  = '\u25BA' // ► (black right-pointing pointer)
+	IconRuneExpanded   = '\u25BC' // ▼ (black down-pointing triangle)
+	IconRuneFallback   = '\u25A1' // □ (white square, fallback)
+	IconRuneResize     = '\u2518' // ┘ (box drawings light up and left - resize gripper)
+	IconRuneRadio      = '\u2022' // • (bullet - selected radio button)
+	IconRuneMaximize   = '\u2191' // ↑ (upwards arrow - classic TV zoom button)
+	IconRuneRestore    = '\u2195' // ↕ (up down arrow - classic TV unzoom button)
+	IconRunePin        = '\u2020' // † (dagger - a pin stuck in)
+	IconRuneArrowUp    = '\u25B2' // ▲ (scrollbar arrows, as in Turbo Vision)
+	IconRuneArrowDown  = '\u25BC' // ▼
+	IconRuneArrowLeft  = '\u25C4' // ◄
+	IconRuneArrowRight = '\u25BA' // ►
 )
 
 // IconToRune converts a microui icon ID to a Unicode rune for terminal display.
@@ -33,7 +49,34 @@ func IconToRune(id int) rune {
 		return IconRuneExpanded
 	case iconResize:
 		return IconRuneResize
+	case iconRadio:
+		return IconRuneRadio
+	case iconMaximize:
+		return IconRuneMaximize
+	case iconRestore:
+		return IconRuneRestore
+	case iconPin:
+		return IconRunePin
+	case iconArrowUp:
+		return IconRuneArrowUp
+	case iconArrowDown:
+		return IconRuneArrowDown
+	case iconArrowLeft:
+		return IconRuneArrowLeft
+	case iconArrowRight:
+		return IconRuneArrowRight
 	default:
 		return IconRuneFallback
 	}
 }
+
+// IconFunc resolves an icon to a rune, e.g. for application icons
+// registered with microui.UI.RegisterIcon. name is the icon's registered
+// or built-in name. Return false to use IconToRune.
+type IconFunc func(id int, name string) (rune, bool)
+
+// SetIconFunc sets the callback used to resolve icons before the built-in
+// runes. Pass nil to use the built-in runes only.
+func (r *Renderer) SetIconFunc(fn IconFunc) {
+	r.iconFunc = fn
+}
@@ -0,0 +1,67 @@
+package bubbletea
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/user/microui-go/types"
+)
+
+// HalfBlockUpper is drawn in image cells: the foreground colors the upper
+// half of the cell and the background the lower half.
+const HalfBlockUpper = '\u2580' // ▀ (upper half block)
+
+// DrawImage draws img (an image.Image; other handles are ignored) into
+// rect with half-block characters, so each cell shows two vertically
+// stacked pixels sampled from src (the whole image if empty). tint
+// multiplies the colors; mostly transparent pixels keep the cell's
+// existing background.
+func (r *Renderer) DrawImage(img any, rect, src types.Rect, tint color.Color) {
+	im, ok := img.(image.Image)
+	if !ok || rect.W <= 0 || rect.H <= 0 {
+		return
+	}
+	b := im.Bounds()
+	if !src.Empty() {
+		b = image.Rect(src.X, src.Y, src.X+src.W, src.Y+src.H).Intersect(b)
+	}
+	if b.Empty() {
+		return
+	}
+
+	for cy := 0; cy < rect.H; cy++ {
+		y := rect.Y + cy
+		for cx := 0; cx < rect.W; cx++ {
+			x := rect.X + cx
+			if !r.inClip(x, y) || !r.inBounds(x, y) {
+				continue
+			}
+			bg := r.back[y][x].Bg
+			r.back[y][x] = Cell{
+				Char: HalfBlockUpper,
+				Fg:   samplePixel(im, b, cx, cy*2, rect.W, rect.H*2, tint, bg),
+				Bg:   samplePixel(im, b, cx, cy*2+1, rect.W, rect.H*2, tint, bg),
+			}
+		}
+	}
+}
+
+// samplePixel returns the pixel of region b under cell (x, y) of a w×h grid
+// laid over it, multiplied by tint. Mostly transparent pixels give
+// fallback instead.
+func samplePixel(im image.Image, b image.Rectangle, x, y, w, h int, tint, fallback color.Color) color.Color {
+	px := b.Min.X + (2*x+1)*b.Dx()/(2*w)
+	py := b.Min.Y + (2*y+1)*b.Dy()/(2*h)
+	c := color.NRGBAModel.Convert(im.At(px, py)).(color.NRGBA)
+	if c.A < 0x80 {
+		return fallback
+	}
+	if tint != nil {
+		t := color.NRGBAModel.Convert(tint).(color.NRGBA)
+		c.R = uint8(uint16(c.R) * uint16(t.R) / 0xff)
+		c.G = uint8(uint16(c.G) * uint16(t.G) / 0xff)
+		c.B = uint8(uint16(c.B) * uint16(t.B) / 0xff)
+	}
+	c.A = 0xff
+	return c
+}
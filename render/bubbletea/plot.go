@@ -0,0 +1,77 @@
+package bubbletea
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/user/microui-go/types"
+)
+
+// brailleDots are the bits of a braille character's dots, by row and
+// column of its 2x4 grid.
+var brailleDots = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// eighthBlocks are the lower block characters one to seven eighths high.
+var eighthBlocks = []rune("▁▂▃▄▅▆▇")
+
+// DrawPlot draws a graph in cells: lines with braille characters, two dots
+// across and four down per cell, and histograms with bars rising in
+// eighths of a cell.
+func (r *Renderer) DrawPlot(rect types.Rect, plot *types.Plot, c color.Color) {
+	if rect.W <= 0 || rect.H <= 0 {
+		return
+	}
+	if plot.Kind == types.PlotHistogram {
+		r.drawHistogram(rect, plot, c)
+		return
+	}
+
+	w, h := rect.W*2, rect.H*4
+	cells := make([]rune, rect.W*rect.H)
+	prev := -1
+	for x := range w {
+		v := plot.At(x, w)
+		if math.IsNaN(v) {
+			prev = -1
+			continue
+		}
+		y := h - 1 - int(math.Round(plot.Scale(v)*float64(h-1)))
+		if prev < 0 {
+			prev = y
+		}
+		for dy := min(prev, y); dy <= max(prev, y); dy++ {
+			cells[dy/4*rect.W+x/2] |= brailleDots[dy%4][x%2]
+		}
+		prev = y
+	}
+	for i, dots := range cells {
+		if dots != 0 {
+			r.setCell(rect.X+i%rect.W, rect.Y+i/rect.W, 0x2800|dots, c)
+		}
+	}
+}
+
+// drawHistogram draws one bar per value, leaving a column between bars
+// that are three or more cells wide.
+func (r *Renderer) drawHistogram(rect types.Rect, plot *types.Plot, c color.Color) {
+	n := len(plot.Values)
+	for x := range rect.W {
+		bar := x * n / rect.W
+		if rect.W >= n*3 && (x+1)*n/rect.W != bar {
+			continue // Gap before the next bar
+		}
+		v := plot.At(x, rect.W)
+		if math.IsNaN(v) {
+			continue
+		}
+		eighths := int(math.Round(plot.Scale(v) * float64(rect.H*8)))
+		for y := rect.Y + rect.H - 1; eighths > 0; y-- {
+			ch := '█'
+			if eighths < 8 {
+				ch = eighthBlocks[eighths-1]
+			}
+			r.setCell(rect.X+x, y, ch, c)
+			eighths -= 8
+		}
+	}
+}
@@ -21,11 +21,15 @@ const (
 
 // Cell represents a single terminal cell with character and colors.
 type Cell struct {
-	Char rune        // Character to display (0 = empty/space)
+	Char rune        // Character to display (0 = empty/space, WideTail = covered)
 	Fg   color.Color // Foreground color
 	Bg   color.Color // Background color
 }
 
+// WideTail is the Char of the cell covered by the right half of a wide
+// character in the cell to its left.
+const WideTail rune = -1
+
 // Renderer implements render.Renderer for terminal output.
 // It maintains a double-buffered cell buffer for thread-safe rendering.
 // The back buffer is updated by Draw operations, then swapped to front
@@ -38,6 +42,7 @@ type Renderer struct {
 	height    int        // Terminal height in cells
 	clipRect  types.Rect // Current clipping rectangle
 	colorMode ColorMode  // Terminal color depth for shadow style
+	iconFunc  IconFunc   // Resolves application icons (nil = built-in runes only)
 }
 
 // NewRenderer creates a new TUI renderer with the given dimensions.
@@ -307,6 +312,17 @@ func (r *Renderer) DrawShadow(rect types.Rect, factor float64) {
 	}
 }
 
+// DrawWindowShadow draws the drop shadow of the window rect with
+// DrawShadow, darkening to 1 minus c's alpha, e.g. 40% brightness for the
+// default black at 60%. Set Style.WindowShadow to an offset of 2, 1 for
+// the classic Turbo Vision look.
+func (r *Renderer) DrawWindowShadow(rect types.Rect, offset types.Vec2, c color.Color) {
+	factor := 1 - float64(types.RGBAFromColor(c).A)/255
+	for _, s := range rect.ShadowStrips(offset) {
+		r.DrawShadow(s, factor)
+	}
+}
+
 // Box-drawing characters for TUI borders
 const (
 	boxTopLeft     = '┌'
@@ -428,25 +444,65 @@ func (r *Renderer) DrawText(text string, pos types.Vec2, font types.Font, c colo
 	}
 
 	for _, ch := range text {
+		w := types.RuneCells(ch)
+		if w == 0 {
+			continue // Combining marks and the like have no cell of their own
+		}
+		// A wide character cut by the clip or the edge shows as a space
+		if w == 2 && !(r.inClip(x+1, y) && r.inBounds(x+1, y)) {
+			ch = ' '
+		}
 		// Skip if outside clip rect horizontally
-		if x >= r.clipRect.X && x < r.clipRect.X+r.clipRect.W {
-			if r.inBounds(x, y) {
-				// Preserve existing background color
-				bg := r.back[y][x].Bg
-				r.back[y][x] = Cell{
-					Char: ch,
-					Fg:   c,
-					Bg:   bg,
-				}
+		if r.inClip(x, y) && r.inBounds(x, y) {
+			// Preserve existing background color
+			bg := r.back[y][x].Bg
+			r.back[y][x] = Cell{
+				Char: ch,
+				Fg:   c,
+				Bg:   bg,
+			}
+			if ch != ' ' && w == 2 {
+				r.back[y][x+1] = Cell{Char: WideTail, Fg: c, Bg: r.back[y][x+1].Bg}
 			}
 		}
-		x++
+		x += w
+	}
+}
+
+// cellChar returns the rune to show for row[x]: a space for empty cells
+// and for halves of wide characters split by later drawing, and 0 for the
+// right half of an intact wide character, which takes no output.
+func cellChar(row []Cell, x int) rune {
+	switch ch := row[x].Char; {
+	case ch == 0:
+		return ' '
+	case ch == WideTail:
+		if x > 0 && types.RuneCells(row[x-1].Char) == 2 {
+			return 0
+		}
+		return ' '
+	case types.RuneCells(ch) == 2 && (x+1 >= len(row) || row[x+1].Char != WideTail):
+		return ' '
+	default:
+		return ch
 	}
 }
 
 // DrawIcon renders an icon using Unicode symbols.
 func (r *Renderer) DrawIcon(id int, rect types.Rect, c color.Color) {
-	icon := IconToRune(id)
+	r.DrawNamedIcon(id, "", rect, c)
+}
+
+// DrawNamedIcon renders an icon, asking the IconFunc first so registered
+// application icons can have their own runes.
+func (r *Renderer) DrawNamedIcon(id int, name string, rect types.Rect, c color.Color) {
+	icon, ok := rune(0), false
+	if r.iconFunc != nil {
+		icon, ok = r.iconFunc(id, name)
+	}
+	if !ok {
+		icon = IconToRune(id)
+	}
 
 	// Center the icon in the rect (for single-char icons)
 	x := rect.X + rect.W/2
@@ -473,11 +529,9 @@ func (r *Renderer) RenderToString() string {
 	var sb strings.Builder
 	for y := 0; y < r.height; y++ {
 		for x := 0; x < r.width; x++ {
-			ch := r.back[y][x].Char
-			if ch == 0 {
-				ch = ' '
+			if ch := cellChar(r.back[y], x); ch != 0 {
+				sb.WriteRune(ch)
 			}
-			sb.WriteRune(ch)
 		}
 		if y < r.height-1 {
 			sb.WriteRune('\n')
@@ -508,10 +562,7 @@ func (r *Renderer) RenderToANSI() string {
 	for y := 0; y < r.height; y++ {
 		for x := 0; x < r.width; x++ {
 			cell := r.back[y][x]
-			ch := cell.Char
-			if ch == 0 {
-				ch = ' '
-			}
+			ch := cellChar(r.back[y], x)
 
 			// Get color keys for this cell
 			newFg := colorKey(cell.Fg)
@@ -571,7 +622,9 @@ func (r *Renderer) RenderToANSI() string {
 				curBg = newBg
 			}
 
-			sb.WriteRune(ch)
+			if ch != 0 {
+				sb.WriteRune(ch)
+			}
 		}
 
 		// Reset colors at end of line for cleaner output
@@ -651,9 +704,9 @@ func (r *Renderer) Draw(s uv.Screen, rect uv.Rectangle) {
 				continue
 			}
 
-			ch := cell.Char
+			ch := cellChar(r.front[y], x)
 			if ch == 0 {
-				ch = ' '
+				continue // Covered by the wide character to the left
 			}
 
 			s.SetCell(x, y, &uv.Cell{
@@ -662,7 +715,7 @@ func (r *Renderer) Draw(s uv.Screen, rect uv.Rectangle) {
 					Fg: cell.Fg,
 					Bg: cell.Bg,
 				},
-				Width: 1,
+				Width: types.RuneCells(ch),
 			})
 		}
 	}
@@ -5,35 +5,39 @@ import (
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	base "github.com/user/microui-go/render/atlas"
 )
 
 // Font renders text using the microui bitmap atlas
 type Font struct {
 	atlas *ebiten.Image
+	scale float64 // Glyph scale for high-DPI displays (0 = 1)
 }
 
 // NewFont creates a new atlas-based font
 func NewFont() *Font {
-	// Create NRGBA image with white color and grayscale as alpha
-	// This matches C microui's GL_ALPHA texture approach
-	// The grayscale value becomes the alpha, color is applied when drawing
-	img := image.NewNRGBA(image.Rect(0, 0, AtlasWidth, AtlasHeight))
-	for y := 0; y < AtlasHeight; y++ {
-		for x := 0; x < AtlasWidth; x++ {
-			alpha := AtlasTexture[y*AtlasWidth+x]
-			// White color with variable alpha - color tinting happens via ColorScale
-			img.SetNRGBA(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: alpha})
-		}
-	}
-
-	// Convert to Ebiten image
-	ebitenImg := ebiten.NewImageFromImage(img)
+	// White color with the grayscale as alpha, matching C microui's
+	// GL_ALPHA texture approach; color is applied when drawing
+	ebitenImg := ebiten.NewImageFromImage(base.Image())
 
 	return &Font{
 		atlas: ebitenImg,
 	}
 }
 
+// SetScale sets the glyph scale. Text and icons are magnified with
+// nearest-neighbor filtering, and Width/Height report scaled metrics.
+func (f *Font) SetScale(scale float64) {
+	f.scale = scale
+}
+
+func (f *Font) getScale() float64 {
+	if f.scale <= 0 {
+		return 1
+	}
+	return f.scale
+}
+
 // Draw renders text at the specified position with the given color
 func (f *Font) Draw(target *ebiten.Image, text string, x, y int, c color.Color) {
 	if f.atlas == nil {
@@ -46,11 +50,12 @@ func (f *Font) Draw(target *ebiten.Image, text string, x, y int, c color.Color)
 	cb := float64(b) / 0xffff
 	ca := float64(a) / 0xffff
 
-	curX := x
+	s := f.getScale()
+	curX := float64(x)
 	for _, ch := range text {
 		if ch == '\n' {
-			curX = x
-			y += 17 // Font height
+			curX = float64(x)
+			y += f.Height()
 			continue
 		}
 
@@ -58,7 +63,7 @@ func (f *Font) Draw(target *ebiten.Image, text string, x, y int, c color.Color)
 		rect, ok := AtlasRects[charIdx]
 		if !ok {
 			// Unknown character, use space width
-			curX += 6
+			curX += 6 * s
 			continue
 		}
 
@@ -68,12 +73,13 @@ func (f *Font) Draw(target *ebiten.Image, text string, x, y int, c color.Color)
 
 		// Draw with color (nearest-neighbor for pixel-perfect text)
 		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(float64(curX), float64(y))
+		op.GeoM.Scale(s, s)
+		op.GeoM.Translate(curX, float64(y))
 		op.ColorScale.Scale(float32(cr), float32(cg), float32(cb), float32(ca))
 		op.Filter = ebiten.FilterNearest
 		target.DrawImage(charImg, op)
 
-		curX += rect.W
+		curX += float64(rect.W) * s
 	}
 }
 
@@ -92,12 +98,12 @@ func (f *Font) Width(text string) int {
 		}
 		width += rect.W
 	}
-	return width
+	return int(float64(width)*f.getScale() + 0.5)
 }
 
 // Height returns the font height in pixels
 func (f *Font) Height() int {
-	return 17
+	return int(17*f.getScale() + 0.5)
 }
 
 // GetIconRect returns the atlas rect for an icon
@@ -139,13 +145,15 @@ func (f *Font) DrawIcon(target *ebiten.Image, iconID int, destRect image.Rectang
 	iconImg := f.atlas.SubImage(srcRect).(*ebiten.Image)
 
 	// Center icon within destination rect
+	s := f.getScale()
 	destW := destRect.Dx()
 	destH := destRect.Dy()
-	offsetX := (destW - atlasRect.W) / 2
-	offsetY := (destH - atlasRect.H) / 2
+	offsetX := (destW - int(float64(atlasRect.W)*s)) / 2
+	offsetY := (destH - int(float64(atlasRect.H)*s)) / 2
 
 	// Draw with color (nearest-neighbor for pixel-perfect icons)
 	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(s, s)
 	op.GeoM.Translate(float64(destRect.Min.X+offsetX), float64(destRect.Min.Y+offsetY))
 	op.ColorScale.Scale(float32(cr), float32(cg), float32(cb), float32(ca))
 	op.Filter = ebiten.FilterNearest
@@ -0,0 +1,33 @@
+package ebiten
+
+import "sync"
+
+// Clipboard implements microui.Clipboard for Ebiten applications.
+//
+// Ebiten has no system clipboard API, so text is kept in-process. On
+// js/wasm builds Set additionally forwards the text to the browser via
+// navigator.clipboard so copied text is available outside the page.
+type Clipboard struct {
+	mu   sync.Mutex
+	text string
+}
+
+// NewClipboard creates a clipboard provider for use with microui.Config.
+func NewClipboard() *Clipboard {
+	return &Clipboard{}
+}
+
+// Get returns the most recently copied text.
+func (c *Clipboard) Get() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.text
+}
+
+// Set stores text and publishes it to the platform clipboard where supported.
+func (c *Clipboard) Set(text string) {
+	c.mu.Lock()
+	c.text = text
+	c.mu.Unlock()
+	writeSystemClipboard(text)
+}
@@ -0,0 +1,15 @@
+//go:build js && wasm
+
+package ebiten
+
+import "syscall/js"
+
+// writeSystemClipboard forwards text to the browser clipboard.
+// The returned promise is ignored; browsers may reject it without a user gesture.
+func writeSystemClipboard(text string) {
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if clipboard.IsUndefined() {
+		return
+	}
+	clipboard.Call("writeText", text)
+}
@@ -0,0 +1,6 @@
+//go:build !(js && wasm)
+
+package ebiten
+
+// writeSystemClipboard is a no-op on platforms without clipboard access.
+func writeSystemClipboard(text string) {}
@@ -24,11 +24,22 @@ type IconProvider interface {
 	HasIcon(iconID int) bool
 }
 
+// IconFunc draws an icon into target, e.g. an application icon registered
+// with microui.UI.RegisterIcon; name is the icon's registered or built-in
+// name. Return false to fall back to the IconProvider and built-in shapes.
+type IconFunc func(target *ebiten.Image, id int, name string, rect image.Rectangle, c color.Color) bool
+
+// CustomFunc draws a command added with microui.UI.DrawCustom into
+// target, which is clipped to the command's clip rect.
+type CustomFunc func(target *ebiten.Image, rect types.Rect, data any)
+
 // Renderer implements microui.Renderer using Ebiten v2.
 type Renderer struct {
 	target       *ebiten.Image
 	font         Font
 	iconProvider IconProvider
+	iconFunc     IconFunc
+	customFunc   CustomFunc
 	clipRect     types.Rect
 	mu           sync.Mutex
 }
@@ -61,6 +72,35 @@ func (r *Renderer) SetIconProvider(provider IconProvider) {
 	r.mu.Unlock()
 }
 
+// SetIconFunc sets a callback asked to draw each icon before the
+// IconProvider and built-in shapes. Pass nil to remove it.
+func (r *Renderer) SetIconFunc(fn IconFunc) {
+	r.mu.Lock()
+	r.iconFunc = fn
+	r.mu.Unlock()
+}
+
+// SetCustomFunc sets the callback drawing the application's custom
+// commands. Pass nil to skip them.
+func (r *Renderer) SetCustomFunc(fn CustomFunc) {
+	r.mu.Lock()
+	r.customFunc = fn
+	r.mu.Unlock()
+}
+
+// SetScale is called by microui.UI.Render with the UI scale. It is passed
+// on to the font and icon provider when they support scaling.
+func (r *Renderer) SetScale(scale float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.font.(interface{ SetScale(float64) }); ok {
+		s.SetScale(scale)
+	}
+	if s, ok := r.iconProvider.(interface{ SetScale(float64) }); ok {
+		s.SetScale(scale)
+	}
+}
+
 // SetTarget sets the render target.
 func (r *Renderer) SetTarget(target *ebiten.Image) {
 	r.mu.Lock()
@@ -193,16 +233,30 @@ func (r *Renderer) DrawText(text string, pos types.Vec2, font types.Font, c colo
 
 // Icon IDs (must match microui constants)
 const (
-	iconClose     = 1
-	iconCheck     = 2
-	iconCollapsed = 3
-	iconExpanded  = 4
-	iconResize    = 5
+	iconClose      = 1
+	iconCheck      = 2
+	iconCollapsed  = 3
+	iconExpanded   = 4
+	iconResize     = 5
+	iconRadio      = 6
+	iconMaximize   = 7
+	iconRestore    = 8
+	iconPin        = 9
+	iconArrowUp    = 10
+	iconArrowDown  = 11
+	iconArrowLeft  = 12
+	iconArrowRight = 13
 )
 
 // DrawIcon renders an icon with proper clipping.
 // Uses atlas icons if an IconProvider is set, otherwise falls back to geometric shapes.
 func (r *Renderer) DrawIcon(id int, rect types.Rect, c color.Color) {
+	r.DrawNamedIcon(id, "", rect, c)
+}
+
+// DrawNamedIcon renders an icon, asking the IconFunc first so registered
+// application icons can be drawn, then falling back like DrawIcon.
+func (r *Renderer) DrawNamedIcon(id int, name string, rect types.Rect, c color.Color) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -210,6 +264,15 @@ func (r *Renderer) DrawIcon(id int, rect types.Rect, c color.Color) {
 		return
 	}
 
+	if r.iconFunc != nil {
+		if sub := r.clipTarget(); sub != nil {
+			iconRect := image.Rect(rect.X, rect.Y, rect.X+rect.W, rect.Y+rect.H)
+			if r.iconFunc(sub, id, name, iconRect, c) {
+				return
+			}
+		}
+	}
+
 	// Try atlas-based icon first
 	if r.iconProvider != nil && r.iconProvider.HasIcon(id) {
 		// Get clipped subimage
@@ -305,51 +368,133 @@ func (r *Renderer) DrawIcon(id int, rect types.Rect, c color.Color) {
 		vector.StrokeLine(subImg, cx-size*0.3, cy-size*0.05, cx-size*0.05, cy+size*0.2, 1.5, rgba, false)
 		vector.StrokeLine(subImg, cx-size*0.05, cy+size*0.2, cx+size*0.35, cy-size*0.3, 1.5, rgba, false)
 
-	case iconCollapsed: // Right-pointing triangle (>) - filled
-		x1, y1 := cx-size*0.2, cy-size*0.35
-		x2, y2 := cx+size*0.3, cy
-		x3, y3 := cx-size*0.2, cy+size*0.35
-		// Use Path for filled triangle
-		var path vector.Path
-		path.MoveTo(x1, y1)
-		path.LineTo(x2, y2)
-		path.LineTo(x3, y3)
-		path.Close()
-		vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
-		for i := range vs {
-			vs[i].SrcX = 1
-			vs[i].SrcY = 1
-			vs[i].ColorR = float32(rgba.R) / 255
-			vs[i].ColorG = float32(rgba.G) / 255
-			vs[i].ColorB = float32(rgba.B) / 255
-			vs[i].ColorA = float32(rgba.A) / 255
-		}
-		subImg.DrawTriangles(vs, is, emptyImage, nil)
-
-	case iconExpanded: // Down-pointing triangle (v) - filled
-		x1, y1 := cx-size*0.35, cy-size*0.2
-		x2, y2 := cx+size*0.35, cy-size*0.2
-		x3, y3 := cx, cy+size*0.3
-		// Use Path for filled triangle
-		var path vector.Path
-		path.MoveTo(x1, y1)
-		path.LineTo(x2, y2)
-		path.LineTo(x3, y3)
-		path.Close()
-		vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
-		for i := range vs {
-			vs[i].SrcX = 1
-			vs[i].SrcY = 1
-			vs[i].ColorR = float32(rgba.R) / 255
-			vs[i].ColorG = float32(rgba.G) / 255
-			vs[i].ColorB = float32(rgba.B) / 255
-			vs[i].ColorA = float32(rgba.A) / 255
-		}
-		subImg.DrawTriangles(vs, is, emptyImage, nil)
+	case iconCollapsed, iconArrowRight: // Right-pointing triangle (>) - filled
+		fillTriangle(subImg, cx-size*0.2, cy-size*0.35, cx+size*0.3, cy, cx-size*0.2, cy+size*0.35, rgba)
+
+	case iconExpanded, iconArrowDown: // Down-pointing triangle (v) - filled
+		fillTriangle(subImg, cx-size*0.35, cy-size*0.2, cx+size*0.35, cy-size*0.2, cx, cy+size*0.3, rgba)
+
+	case iconArrowUp: // Up-pointing triangle - filled
+		fillTriangle(subImg, cx-size*0.35, cy+size*0.2, cx+size*0.35, cy+size*0.2, cx, cy-size*0.3, rgba)
+
+	case iconArrowLeft: // Left-pointing triangle (<) - filled
+		fillTriangle(subImg, cx+size*0.2, cy-size*0.35, cx-size*0.3, cy, cx+size*0.2, cy+size*0.35, rgba)
 
 	case iconResize:
 		// GUI: no visual for resize gripper - the area still works for dragging
+
+	case iconRadio: // Filled dot
+		vector.DrawFilledCircle(subImg, cx, cy, size*0.35, rgba, true)
+
+	case iconMaximize: // Single window outline
+		half := size / 2
+		vector.StrokeRect(subImg, cx-half, cy-half, size, size, 1.5, rgba, false)
+
+	case iconRestore: // Two overlapping window outlines
+		s := size * 0.7
+		off := size - s
+		vector.StrokeRect(subImg, cx-size/2+off, cy-size/2, s, s, 1, rgba, false)
+		vector.StrokeRect(subImg, cx-size/2, cy-size/2+off, s, s, 1.5, rgba, false)
+
+	case iconPin: // Pushpin: head over a needle
+		vector.DrawFilledRect(subImg, cx-size/3, cy-size/2, size*2/3, size/2, rgba, false)
+		vector.StrokeLine(subImg, cx, cy, cx, cy+size/2, 1.5, rgba, false)
+	}
+}
+
+// fillTriangle fills the triangle with the given corners on dst.
+func fillTriangle(dst *ebiten.Image, x1, y1, x2, y2, x3, y3 float32, c color.NRGBA) {
+	var path vector.Path
+	path.MoveTo(x1, y1)
+	path.LineTo(x2, y2)
+	path.LineTo(x3, y3)
+	path.Close()
+	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	for i := range vs {
+		vs[i].SrcX = 1
+		vs[i].SrcY = 1
+		vs[i].ColorR = float32(c.R) / 255
+		vs[i].ColorG = float32(c.G) / 255
+		vs[i].ColorB = float32(c.B) / 255
+		vs[i].ColorA = float32(c.A) / 255
 	}
+	dst.DrawTriangles(vs, is, emptyImage, nil)
+}
+
+// DrawImage draws img (an *ebiten.Image; other handles are ignored) scaled
+// into rect. src selects a region of img, the whole image if empty, and
+// tint multiplies its colors.
+func (r *Renderer) DrawImage(img any, rect, src types.Rect, tint color.Color) {
+	eimg, ok := img.(*ebiten.Image)
+	if !ok || rect.W <= 0 || rect.H <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	target := r.clipTarget()
+	if target == nil {
+		return
+	}
+	if !src.Empty() {
+		eimg = eimg.SubImage(image.Rect(src.X, src.Y, src.X+src.W, src.Y+src.H)).(*ebiten.Image)
+	}
+	b := eimg.Bounds()
+	if b.Empty() {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(rect.W)/float64(b.Dx()), float64(rect.H)/float64(b.Dy()))
+	op.GeoM.Translate(float64(rect.X), float64(rect.Y))
+	if tint != nil {
+		op.ColorScale.ScaleWithColor(tint)
+	}
+	op.Filter = ebiten.FilterNearest
+	target.DrawImage(eimg, op)
+}
+
+// DrawCustom passes a custom command to the CustomFunc.
+func (r *Renderer) DrawCustom(rect types.Rect, data any) {
+	r.mu.Lock()
+	fn, target := r.customFunc, r.clipTarget()
+	r.mu.Unlock()
+	if fn != nil && target != nil {
+		fn(target, rect, data)
+	}
+}
+
+// DrawNineSlice draws img (an *ebiten.Image) as a nine-patch: the border
+// of src keeps its size while the edges and center stretch over rect.
+func (r *Renderer) DrawNineSlice(img any, rect, src types.Rect, border types.Insets, tint color.Color) {
+	eimg, ok := img.(*ebiten.Image)
+	if !ok {
+		return
+	}
+	if src.Empty() {
+		b := eimg.Bounds()
+		src = types.Rect{X: b.Min.X, Y: b.Min.Y, W: b.Dx(), H: b.Dy()}
+	}
+	dst, srcs := rect.Slice9(border), src.Slice9(border)
+	for i := range dst {
+		if !srcs[i].Empty() {
+			r.DrawImage(img, dst[i], srcs[i], tint)
+		}
+	}
+}
+
+// clipTarget returns the part of the target inside the clip rect, or nil
+// if nothing is visible. Callers hold r.mu.
+func (r *Renderer) clipTarget() *ebiten.Image {
+	if r.target == nil {
+		return nil
+	}
+	clip := image.Rect(r.clipRect.X, r.clipRect.Y, r.clipRect.X+r.clipRect.W, r.clipRect.Y+r.clipRect.H)
+	clip = clip.Intersect(r.target.Bounds())
+	if clip.Empty() {
+		return nil
+	}
+	return r.target.SubImage(clip).(*ebiten.Image)
 }
 
 // SetClip sets the clipping rectangle.
@@ -375,6 +520,14 @@ func (r *Renderer) DrawScrollThumb(rect types.Rect) {
 	r.DrawRect(types.Vec2{X: rect.X, Y: rect.Y}, types.Vec2{X: rect.W, Y: rect.H}, scrollThumbColor)
 }
 
+// DrawWindowShadow darkens the strips right of and below rect where its
+// drop shadow falls, blending c over them.
+func (r *Renderer) DrawWindowShadow(rect types.Rect, offset types.Vec2, c color.Color) {
+	for _, s := range rect.ShadowStrips(offset) {
+		r.DrawRect(types.Vec2{X: s.X, Y: s.Y}, types.Vec2{X: s.W, Y: s.H}, c)
+	}
+}
+
 func (r *Renderer) applyClip(x, y, w, h int) (int, int, int, int) {
 	// Simple rectangle intersection
 	if x < r.clipRect.X {
@@ -0,0 +1,29 @@
+// Package image provides a software renderer for microui-go that draws
+// into an image.RGBA, with no GPU or terminal.
+//
+// Text uses the bitmap font from the original microui atlas (see
+// render/atlas), so it looks the same as the Ebiten renderer's default.
+// Use it to render UIs server-side, for golden-image tests, or to export
+// screenshots.
+//
+// # Usage
+//
+//	import (
+//		"image"
+//		"image/color"
+//		"image/png"
+//
+//		"github.com/user/microui-go/render/atlas"
+//		imgrender "github.com/user/microui-go/render/image"
+//	)
+//
+//	style := microui.GUIStyle()
+//	style.Font = atlas.Font{} // measure with the font the renderer draws
+//	ui := microui.New(microui.Config{Style: style})
+//	// ... build a frame ...
+//
+//	r := imgrender.NewRenderer(image.NewRGBA(image.Rect(0, 0, 640, 480)))
+//	r.Clear(color.Black)
+//	ui.Render(r)
+//	png.Encode(f, r.Target())
+package image
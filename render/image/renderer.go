@@ -0,0 +1,322 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/user/microui-go/render/atlas"
+	"github.com/user/microui-go/types"
+)
+
+// Icon IDs (must match microui constants)
+const (
+	iconClose      = 1
+	iconCheck      = 2
+	iconCollapsed  = 3
+	iconExpanded   = 4
+	iconResize     = 5
+	iconRadio      = 6
+	iconMaximize   = 7
+	iconRestore    = 8
+	iconPin        = 9
+	iconArrowUp    = 10
+	iconArrowDown  = 11
+	iconArrowLeft  = 12
+	iconArrowRight = 13
+)
+
+// Renderer implements microui.Renderer by drawing into an *image.RGBA.
+type Renderer struct {
+	target  *image.RGBA
+	clip    image.Rectangle // Clip rect, already limited to the target
+	scale   float64
+	limit   image.Rectangle // Area SetDirtyRegions allows drawing in
+	limited bool            // SetDirtyRegions was called
+
+	// Background is what the changed area is cleared to before a frame is
+	// drawn with dirty regions (nil = left as it is)
+	Background color.Color
+
+	// Scrollbar colors (scroll commands carry none)
+	ScrollTrackColor color.Color
+	ScrollThumbColor color.Color
+}
+
+// NewRenderer creates a renderer drawing into target.
+func NewRenderer(target *image.RGBA) *Renderer {
+	theme := types.DarkTheme()
+	r := &Renderer{
+		scale:            1,
+		ScrollTrackColor: theme.ScrollBase,
+		ScrollThumbColor: theme.ScrollThumb,
+	}
+	r.SetTarget(target)
+	return r
+}
+
+// SetTarget sets the image to draw into and resets the clip rect.
+func (r *Renderer) SetTarget(target *image.RGBA) {
+	r.target = target
+	if target != nil {
+		r.clip = target.Bounds()
+	}
+}
+
+// Target returns the image being drawn into.
+func (r *Renderer) Target() *image.RGBA {
+	return r.target
+}
+
+// Clear fills the whole target with c, e.g. before rendering a frame.
+func (r *Renderer) Clear(c color.Color) {
+	if r.target != nil {
+		draw.Draw(r.target, r.target.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+}
+
+// SetScale is called by microui.UI.Render with the UI scale; text and
+// icons are magnified by it.
+func (r *Renderer) SetScale(scale float64) {
+	if scale <= 0 {
+		scale = 1
+	}
+	r.scale = scale
+}
+
+// SetClip sets the clipping rectangle.
+func (r *Renderer) SetClip(rect types.Rect) {
+	if r.target == nil {
+		return
+	}
+	r.clip = image.Rect(rect.X, rect.Y, rect.X+rect.W, rect.Y+rect.H).Intersect(r.area())
+}
+
+// SetDirtyRegions is called by microui.UI.Render with Config.DirtyRegions
+// set. Drawing is limited to the bounding box of regions, cleared to
+// Background first, so the rest of the target keeps the previous frame.
+// With no regions nothing is drawn.
+func (r *Renderer) SetDirtyRegions(regions []types.Rect) {
+	r.limit, r.limited = image.Rectangle{}, true
+	for _, d := range regions {
+		r.limit = r.limit.Union(image.Rect(d.X, d.Y, d.X+d.W, d.Y+d.H))
+	}
+	if r.target == nil {
+		return
+	}
+	r.limit = r.limit.Intersect(r.target.Bounds())
+	r.clip = r.limit
+	if r.Background != nil && !r.limit.Empty() {
+		draw.Draw(r.target, r.limit, image.NewUniform(r.Background), image.Point{}, draw.Src)
+	}
+}
+
+// area returns the part of the target drawing may touch.
+func (r *Renderer) area() image.Rectangle {
+	if r.limited {
+		return r.limit
+	}
+	return r.target.Bounds()
+}
+
+// DrawRect fills a rectangle with the given color.
+func (r *Renderer) DrawRect(pos, size types.Vec2, c color.Color) {
+	r.fill(image.Rect(pos.X, pos.Y, pos.X+size.X, pos.Y+size.Y), c)
+}
+
+// DrawBox draws an unfilled rectangle outline (border only).
+func (r *Renderer) DrawBox(rect types.Rect, c color.Color) {
+	x0, y0, x1, y1 := rect.X, rect.Y, rect.X+rect.W, rect.Y+rect.H
+	r.fill(image.Rect(x0+1, y0, x1-1, y0+1), c)
+	r.fill(image.Rect(x0+1, y1-1, x1-1, y1), c)
+	r.fill(image.Rect(x0, y0, x0+1, y1), c)
+	r.fill(image.Rect(x1-1, y0, x1, y1), c)
+}
+
+// DrawText draws text with the atlas font; the layout font should be
+// atlas.Font so measurements match. font is ignored.
+func (r *Renderer) DrawText(text string, pos types.Vec2, font types.Font, c color.Color) {
+	x, y := float64(pos.X), float64(pos.Y)
+	for _, ch := range text {
+		if ch == '\n' {
+			x = float64(pos.X)
+			y += atlas.FontHeight * r.scale
+			continue
+		}
+		g, ok := atlas.Glyph(ch)
+		if !ok {
+			x += atlas.UnknownWidth * r.scale
+			continue
+		}
+		w := float64(g.W) * r.scale
+		r.drawAtlas(image.Rect(round(x), round(y), round(x+w), round(y+float64(g.H)*r.scale)), g, c)
+		x += w
+	}
+}
+
+// DrawIcon draws an icon centered in rect: atlas icons where the atlas
+// has them, simple shapes otherwise.
+func (r *Renderer) DrawIcon(id int, rect types.Rect, c color.Color) {
+	if g, ok := atlas.AtlasRects[id]; ok && id < atlas.AtlasFont {
+		w, h := round(float64(g.W)*r.scale), round(float64(g.H)*r.scale)
+		x := rect.X + (rect.W-w)/2
+		y := rect.Y + (rect.H-h)/2
+		r.drawAtlas(image.Rect(x, y, x+w, y+h), g, c)
+		return
+	}
+
+	cx, cy := rect.X+rect.W/2, rect.Y+rect.H/2
+	size := min(rect.W, rect.H) * 6 / 10
+	switch id {
+	case iconResize:
+		// No visual for the resize gripper - the area still works for dragging
+
+	case iconRadio: // Filled square dot
+		half := max(size*35/100, 1)
+		r.fill(image.Rect(cx-half, cy-half, cx+half, cy+half), c)
+
+	case iconMaximize: // Single window outline
+		r.outline(image.Rect(cx-size/2, cy-size/2, cx-size/2+size, cy-size/2+size), c)
+
+	case iconRestore: // Two overlapping window outlines
+		s := size * 7 / 10
+		off := size - s
+		x, y := cx-size/2, cy-size/2
+		r.outline(image.Rect(x+off, y, x+off+s, y+s), c)
+		r.outline(image.Rect(x, y+off, x+s, y+off+s), c)
+
+	case iconPin: // Pushpin: head over a needle
+		x, y := cx-size/3, cy-size/2
+		r.fill(image.Rect(x, y, x+size*2/3, y+size/2), c)
+		r.fill(image.Rect(cx, y+size/2, cx+1, y+size), c)
+
+	case iconArrowUp, iconArrowDown, iconArrowLeft, iconArrowRight: // Filled triangle
+		r.arrow(id, cx, cy, max(size/2, 1), c)
+	}
+}
+
+// arrow fills a triangle pointing the way of arrow icon id, centered on
+// cx, cy: a line of pixels widening by two from the tip for each of its
+// length rows.
+func (r *Renderer) arrow(id, cx, cy, length int, c color.Color) {
+	tip := length / 2
+	for i := 0; i < length; i++ {
+		switch id {
+		case iconArrowUp:
+			r.fill(image.Rect(cx-i, cy-tip+i, cx+i+1, cy-tip+i+1), c)
+		case iconArrowDown:
+			r.fill(image.Rect(cx-i, cy+tip-i, cx+i+1, cy+tip-i+1), c)
+		case iconArrowLeft:
+			r.fill(image.Rect(cx-tip+i, cy-i, cx-tip+i+1, cy+i+1), c)
+		case iconArrowRight:
+			r.fill(image.Rect(cx+tip-i, cy-i, cx+tip-i+1, cy+i+1), c)
+		}
+	}
+}
+
+// DrawScrollTrack draws a scrollbar track (background).
+func (r *Renderer) DrawScrollTrack(rect types.Rect) {
+	r.DrawRect(types.Vec2{X: rect.X, Y: rect.Y}, types.Vec2{X: rect.W, Y: rect.H}, r.ScrollTrackColor)
+}
+
+// DrawScrollThumb draws a scrollbar thumb (draggable part).
+func (r *Renderer) DrawScrollThumb(rect types.Rect) {
+	r.DrawRect(types.Vec2{X: rect.X, Y: rect.Y}, types.Vec2{X: rect.W, Y: rect.H}, r.ScrollThumbColor)
+}
+
+// DrawWindowShadow darkens the strips right of and below rect where its
+// drop shadow falls, blending c over them.
+func (r *Renderer) DrawWindowShadow(rect types.Rect, offset types.Vec2, c color.Color) {
+	for _, s := range rect.ShadowStrips(offset) {
+		r.DrawRect(types.Vec2{X: s.X, Y: s.Y}, types.Vec2{X: s.W, Y: s.H}, c)
+	}
+}
+
+// DrawImage draws img (an image.Image; other handles are ignored) scaled
+// into rect with nearest-neighbor sampling. src selects a region of img,
+// the whole image if empty, and tint multiplies its colors.
+func (r *Renderer) DrawImage(img any, rect, src types.Rect, tint color.Color) {
+	im, ok := img.(image.Image)
+	if !ok || r.target == nil || rect.W <= 0 || rect.H <= 0 {
+		return
+	}
+	b := im.Bounds()
+	if !src.Empty() {
+		b = image.Rect(src.X, src.Y, src.X+src.W, src.Y+src.H).Intersect(b)
+	}
+	if b.Empty() {
+		return
+	}
+	tr, tg, tb, ta := uint32(0xffff), uint32(0xffff), uint32(0xffff), uint32(0xffff)
+	if tint != nil {
+		tr, tg, tb, ta = tint.RGBA()
+	}
+
+	dst := image.Rect(rect.X, rect.Y, rect.X+rect.W, rect.Y+rect.H)
+	vis := dst.Intersect(r.clip)
+	for y := vis.Min.Y; y < vis.Max.Y; y++ {
+		sy := b.Min.Y + (y-dst.Min.Y)*b.Dy()/rect.H
+		for x := vis.Min.X; x < vis.Max.X; x++ {
+			sx := b.Min.X + (x-dst.Min.X)*b.Dx()/rect.W
+			cr, cg, cb, ca := im.At(sx, sy).RGBA()
+			r.blend(x, y, cr*tr/0xffff, cg*tg/0xffff, cb*tb/0xffff, ca*ta/0xffff)
+		}
+	}
+}
+
+// fill fills rect, clipped, with c.
+func (r *Renderer) fill(rect image.Rectangle, c color.Color) {
+	if r.target == nil || c == nil {
+		return
+	}
+	draw.Draw(r.target, rect.Intersect(r.clip), image.NewUniform(c), image.Point{}, draw.Over)
+}
+
+// outline draws a 1px outline just inside rect.
+func (r *Renderer) outline(rect image.Rectangle, c color.Color) {
+	r.fill(image.Rect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y+1), c)
+	r.fill(image.Rect(rect.Min.X, rect.Max.Y-1, rect.Max.X, rect.Max.Y), c)
+	r.fill(image.Rect(rect.Min.X, rect.Min.Y+1, rect.Min.X+1, rect.Max.Y-1), c)
+	r.fill(image.Rect(rect.Max.X-1, rect.Min.Y+1, rect.Max.X, rect.Max.Y-1), c)
+}
+
+// drawAtlas draws the atlas rect g scaled into dst, using the atlas
+// grayscale as coverage for c.
+func (r *Renderer) drawAtlas(dst image.Rectangle, g atlas.Rect, c color.Color) {
+	if r.target == nil || c == nil || dst.Empty() {
+		return
+	}
+	cr, cg, cb, ca := c.RGBA()
+	vis := dst.Intersect(r.clip)
+	for y := vis.Min.Y; y < vis.Max.Y; y++ {
+		ay := g.Y + (y-dst.Min.Y)*g.H/dst.Dy()
+		for x := vis.Min.X; x < vis.Max.X; x++ {
+			ax := g.X + (x-dst.Min.X)*g.W/dst.Dx()
+			cov := uint32(atlas.AtlasTexture[ay*atlas.AtlasWidth+ax])
+			if cov == 0 {
+				continue
+			}
+			cov *= 0x101
+			r.blend(x, y, cr*cov/0xffff, cg*cov/0xffff, cb*cov/0xffff, ca*cov/0xffff)
+		}
+	}
+}
+
+// blend composites a premultiplied 16-bit color over the target pixel.
+func (r *Renderer) blend(x, y int, sr, sg, sb, sa uint32) {
+	if sa == 0 {
+		return
+	}
+	i := r.target.PixOffset(x, y)
+	p := r.target.Pix[i : i+4 : i+4]
+	inv := 0xffff - sa
+	p[0] = uint8((uint32(p[0])*0x101*inv/0xffff + sr) >> 8)
+	p[1] = uint8((uint32(p[1])*0x101*inv/0xffff + sg) >> 8)
+	p[2] = uint8((uint32(p[2])*0x101*inv/0xffff + sb) >> 8)
+	p[3] = uint8((uint32(p[3])*0x101*inv/0xffff + sa) >> 8)
+}
+
+func round(f float64) int {
+	return int(math.Round(f))
+}
@@ -0,0 +1,172 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/render/atlas"
+	"github.com/user/microui-go/render/renderertest"
+	"github.com/user/microui-go/types"
+)
+
+var (
+	black = color.RGBA{A: 255}
+	red   = color.RGBA{R: 255, A: 255}
+)
+
+func newTarget() *Renderer {
+	r := NewRenderer(image.NewRGBA(image.Rect(0, 0, 64, 32)))
+	r.Clear(black)
+	return r
+}
+
+func TestRenderer_DrawRectClipped(t *testing.T) {
+	r := newTarget()
+	r.SetClip(types.Rect{X: 10, Y: 0, W: 10, H: 32})
+	r.DrawRect(types.Vec2{X: 0, Y: 0}, types.Vec2{X: 40, Y: 10}, red)
+
+	img := r.Target()
+	if got := img.RGBAAt(15, 5); got != red {
+		t.Errorf("inside clip = %v, want red", got)
+	}
+	if got := img.RGBAAt(5, 5); got != black {
+		t.Errorf("left of clip = %v, want black", got)
+	}
+	if got := img.RGBAAt(25, 5); got != black {
+		t.Errorf("right of clip = %v, want black", got)
+	}
+}
+
+func TestRenderer_TranslucentRect(t *testing.T) {
+	r := newTarget()
+	r.DrawRect(types.Vec2{}, types.Vec2{X: 4, Y: 4}, color.NRGBA{R: 255, A: 128})
+	if got := r.Target().RGBAAt(1, 1); got.R != 128 || got.G != 0 || got.A != 255 {
+		t.Errorf("half red over black = %v, want R=128", got)
+	}
+}
+
+func TestRenderer_DrawText(t *testing.T) {
+	r := newTarget()
+	r.DrawText("H", types.Vec2{X: 2, Y: 2}, nil, red)
+
+	g, _ := atlas.Glyph('H')
+	lit := 0
+	img := r.Target()
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 64; x++ {
+			if img.RGBAAt(x, y) == black {
+				continue
+			}
+			lit++
+			if x < 2 || x >= 2+g.W || y < 2 || y >= 2+g.H {
+				t.Fatalf("pixel (%d,%d) drawn outside the glyph box", x, y)
+			}
+		}
+	}
+	if lit == 0 {
+		t.Error("text drew no pixels")
+	}
+}
+
+func TestRenderer_DrawImageScaled(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, red)
+	src.SetRGBA(1, 0, color.RGBA{G: 255, A: 255})
+
+	r := newTarget()
+	r.DrawImage(src, types.Rect{X: 0, Y: 0, W: 8, H: 4}, types.Rect{}, nil)
+	img := r.Target()
+	if got := img.RGBAAt(3, 3); got != red {
+		t.Errorf("left half = %v, want red", got)
+	}
+	if got := img.RGBAAt(4, 0); got.G != 255 || got.R != 0 {
+		t.Errorf("right half = %v, want green", got)
+	}
+
+	// A region of the image, tinted
+	r.DrawImage(src, types.Rect{X: 10, Y: 0, W: 4, H: 4}, types.Rect{X: 1, Y: 0, W: 1, H: 1}, color.RGBA{G: 128, A: 255})
+	if got := img.RGBAAt(11, 1); got.G != 128 || got.R != 0 {
+		t.Errorf("tinted region = %v, want half green", got)
+	}
+}
+
+func TestRenderer_UIFrame(t *testing.T) {
+	style := microui.GUIStyle()
+	style.Font = atlas.Font{}
+	ui := microui.New(microui.Config{Style: style})
+	ui.BeginFrame()
+	if ui.BeginWindow("Shot", types.Rect{X: 10, Y: 10, W: 200, H: 120}) {
+		ui.Label("Hello")
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+
+	r := NewRenderer(image.NewRGBA(image.Rect(0, 0, 240, 160)))
+	r.Clear(black)
+	ui.Render(r)
+
+	theme := types.DarkTheme()
+	img := r.Target()
+	want := func(x, y int, c color.Color, what string) {
+		t.Helper()
+		if got, w := img.At(x, y), color.RGBAModel.Convert(c); got != w {
+			t.Errorf("%s at (%d,%d) = %v, want %v", what, x, y, got, w)
+		}
+	}
+	want(200, 120, theme.WindowBg, "window background")
+	want(200, 15, theme.WindowTitle, "title bar")
+	want(5, 5, black, "outside the window")
+}
+
+func TestRenderer_Conformance(t *testing.T) {
+	renderertest.Run(t, renderertest.Config{
+		New: func(w, h int) renderertest.Target {
+			r := NewRenderer(image.NewRGBA(image.Rect(0, 0, w, h)))
+			r.Clear(black)
+			return readback{r}
+		},
+		Font:  atlas.Font{},
+		Image: func(img image.Image) any { return img },
+	})
+}
+
+// readback exposes the target image to renderertest.
+type readback struct{ *Renderer }
+
+func (r readback) Image() image.Image { return r.Target() }
+
+func TestRenderer_DrawWindowShadow(t *testing.T) {
+	r := newTarget()
+	r.Clear(color.RGBA{R: 200, G: 200, B: 200, A: 255})
+	r.DrawWindowShadow(types.Rect{X: 0, Y: 0, W: 20, H: 10}, types.Vec2{X: 4, Y: 4}, color.NRGBA{A: 128})
+	img := r.Target()
+	if got := img.RGBAAt(22, 6); got.R >= 200 {
+		t.Errorf("right of the window = %v, want darkened", got)
+	}
+	if got := img.RGBAAt(6, 12); got.R >= 200 {
+		t.Errorf("below the window = %v, want darkened", got)
+	}
+	if got := img.RGBAAt(22, 1); got.R != 200 {
+		t.Errorf("above the shadow's offset = %v, want untouched", got)
+	}
+}
+
+func TestRenderer_SetDirtyRegions(t *testing.T) {
+	r := newTarget()
+	r.Background = red
+	r.SetDirtyRegions([]types.Rect{{X: 4, Y: 4, W: 8, H: 8}})
+	r.SetClip(types.Rect{W: 64, H: 32})
+	r.DrawRect(types.Vec2{X: 0, Y: 0}, types.Vec2{X: 10, Y: 10}, color.RGBA{G: 255, A: 255})
+	img := r.Target()
+	if got := img.RGBAAt(6, 6); got.G != 255 {
+		t.Errorf("inside the region = %v, want drawn", got)
+	}
+	if got := img.RGBAAt(11, 11); got != red {
+		t.Errorf("cleared part of the region = %v, want the background", got)
+	}
+	if got := img.RGBAAt(2, 2); got != black {
+		t.Errorf("outside the region = %v, want the previous frame kept", got)
+	}
+}
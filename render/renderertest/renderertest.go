@@ -0,0 +1,397 @@
+// Package renderertest checks that a microui-go renderer draws the command
+// stream the way the reference renderers do.
+//
+// Run feeds a fixed set of scenes through UI.Render and compares what the
+// renderer drew, read back as an image, with the expected result: exact
+// coverage for rectangles, clipping and z-order, and bounds and placement
+// for text and icons, whose glyphs differ between renderers. Cell-based
+// renderers report one pixel per cell.
+//
+//	func TestConformance(t *testing.T) {
+//		renderertest.Run(t, renderertest.Config{
+//			New: func(w, h int) renderertest.Target {
+//				return newReadbackRenderer(w, h)
+//			},
+//			Font: myFont,
+//		})
+//	}
+package renderertest
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/types"
+)
+
+// Scene size in pixels (or cells)
+const (
+	Width  = 64
+	Height = 48
+)
+
+// Target is a renderer under test that can read back what it drew.
+type Target interface {
+	microui.BaseRenderer
+	// Image returns the output so far, one pixel per pixel or per cell.
+	// For cells, use the foreground color where a character is drawn and
+	// the background color elsewhere.
+	Image() image.Image
+}
+
+// Config describes the renderer under test.
+type Config struct {
+	// New returns a renderer with a w x h output filled with one
+	// background color, not red, green, blue or white.
+	New func(w, h int) Target
+
+	// Font measures text as the renderer draws it (nil = types.MockFont).
+	Font types.Font
+
+	// Image converts a test image to the handle the renderer's DrawImage
+	// expects. Nil skips the image checks.
+	Image func(img image.Image) any
+
+	// Tolerance lets text and icons spill this many pixels past their
+	// rects, e.g. for antialiasing or font overhang. Clipping is always
+	// checked exactly.
+	Tolerance int
+}
+
+// Scene colors; the renderer's output is matched against them.
+var (
+	Red   = color.RGBA{R: 255, A: 255}
+	Green = color.RGBA{G: 255, A: 255}
+	Blue  = color.RGBA{B: 255, A: 255}
+	White = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// Palette indices of a classified pixel
+const (
+	bg = iota
+	red
+	green
+	blue
+	white
+)
+
+var paletteChars = [...]byte{'.', 'R', 'G', 'B', 'W'}
+
+// Run checks the renderer built by cfg.New against every scene.
+func Run(t *testing.T, cfg Config) {
+	t.Helper()
+	if cfg.New == nil {
+		t.Fatal("renderertest: Config.New is required")
+	}
+	if cfg.Font == nil {
+		cfg.Font = &types.MockFont{}
+	}
+	s := &suite{cfg: cfg}
+	t.Run("Rect", s.testRect)
+	t.Run("ZOrder", s.testZOrder)
+	t.Run("Clip", s.testClip)
+	t.Run("Box", s.testBox)
+	t.Run("Text", s.testText)
+	t.Run("TextClip", s.testTextClip)
+	t.Run("Icons", s.testIcons)
+	t.Run("Image", s.testImage)
+}
+
+type suite struct {
+	cfg Config
+}
+
+// output is a rendered scene classified into palette indices.
+type output struct {
+	px [Height][Width]int
+}
+
+// render draws one scene through a fresh UI and classifies the result.
+func (s *suite) render(t *testing.T, scene func(ui *microui.UI)) (*output, Target) {
+	t.Helper()
+	style := microui.GUIStyle()
+	style.Font = s.cfg.Font
+	ui := microui.New(microui.Config{Style: style})
+	ui.BeginFrame()
+	scene(ui)
+	ui.EndFrame()
+
+	target := s.cfg.New(Width, Height)
+	background := target.Image().At(0, 0)
+	ui.Render(target)
+	return classify(target.Image(), background), target
+}
+
+// classify maps every pixel to the nearest palette color.
+func classify(img image.Image, background color.Color) *output {
+	palette := []color.Color{background, Red, Green, Blue, White}
+	out := &output{}
+	b := img.Bounds()
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			out.px[y][x] = nearest(img.At(b.Min.X+x, b.Min.Y+y), palette)
+		}
+	}
+	return out
+}
+
+func nearest(c color.Color, palette []color.Color) int {
+	best, bestDist := 0, -1
+	r, g, b, _ := c.RGBA()
+	for i, p := range palette {
+		pr, pg, pb, _ := p.RGBA()
+		dr, dg, db := int64(r>>8)-int64(pr>>8), int64(g>>8)-int64(pg>>8), int64(b>>8)-int64(pb>>8)
+		if d := int(dr*dr + dg*dg + db*db); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// expect compares out with the snapshot given by want, printing both as
+// text on a mismatch.
+func expect(t *testing.T, out *output, want func(x, y int) int) {
+	t.Helper()
+	var exp output
+	bad := 0
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			exp.px[y][x] = want(x, y)
+			if exp.px[y][x] != out.px[y][x] {
+				bad++
+			}
+		}
+	}
+	if bad > 0 {
+		t.Errorf("%d pixels differ\ngot:\n%s\nwant:\n%s", bad, out, &exp)
+	}
+}
+
+// within checks that every non-background pixel lies inside rect grown by
+// the tolerance and returns how many there are of color c.
+func (s *suite) within(t *testing.T, out *output, rect types.Rect, c int, what string) int {
+	t.Helper()
+	tol := s.cfg.Tolerance
+	n := 0
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			if out.px[y][x] == bg {
+				continue
+			}
+			if x < rect.X-tol || x >= rect.X+rect.W+tol || y < rect.Y-tol || y >= rect.Y+rect.H+tol {
+				t.Errorf("%s drew at (%d,%d), outside %v\n%s", what, x, y, rect, out)
+				return n
+			}
+			if out.px[y][x] == c {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func (o *output) String() string {
+	var sb strings.Builder
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			sb.WriteByte(paletteChars[o.px[y][x]])
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func inRect(r types.Rect, x, y int) bool {
+	return r.Contains(types.Vec2{X: x, Y: y})
+}
+
+var (
+	rectA = types.Rect{X: 8, Y: 6, W: 20, H: 10}
+	rectB = types.Rect{X: 16, Y: 10, W: 20, H: 10}
+)
+
+func (s *suite) testRect(t *testing.T) {
+	out, _ := s.render(t, func(ui *microui.UI) {
+		ui.DrawRect(rectA, Red)
+	})
+	expect(t, out, func(x, y int) int {
+		if inRect(rectA, x, y) {
+			return red
+		}
+		return bg
+	})
+}
+
+func (s *suite) testZOrder(t *testing.T) {
+	out, _ := s.render(t, func(ui *microui.UI) {
+		ui.DrawRect(rectA, Red)
+		ui.DrawRect(rectB, Blue)
+	})
+	expect(t, out, func(x, y int) int {
+		switch {
+		case inRect(rectB, x, y):
+			return blue
+		case inRect(rectA, x, y):
+			return red
+		}
+		return bg
+	})
+}
+
+func (s *suite) testClip(t *testing.T) {
+	clip := types.Rect{X: 12, Y: 8, W: 10, H: 6}
+	after := types.Rect{X: 40, Y: 30, W: 4, H: 4}
+	out, _ := s.render(t, func(ui *microui.UI) {
+		ui.PushClip(clip)
+		ui.DrawRect(rectA, Red)
+		ui.PopClip()
+		ui.DrawRect(after, Green)
+	})
+	expect(t, out, func(x, y int) int {
+		switch {
+		case inRect(clip, x, y) && inRect(rectA, x, y):
+			return red
+		case inRect(after, x, y):
+			return green
+		}
+		return bg
+	})
+}
+
+func (s *suite) testBox(t *testing.T) {
+	out, target := s.render(t, func(ui *microui.UI) {
+		ui.DrawBox(rectA, Blue)
+	})
+	if _, ok := target.(microui.BoxRenderer); !ok {
+		t.Skip("renderer does not implement BoxRenderer")
+	}
+	expect(t, out, func(x, y int) int {
+		inner := types.Rect{X: rectA.X + 1, Y: rectA.Y + 1, W: rectA.W - 2, H: rectA.H - 2}
+		if inRect(rectA, x, y) && !inRect(inner, x, y) {
+			return blue
+		}
+		return bg
+	})
+}
+
+// textBox is the rect text at pos should be drawn in.
+func (s *suite) textBox(text string, pos types.Vec2) types.Rect {
+	return types.Rect{X: pos.X, Y: pos.Y, W: s.cfg.Font.Width(text), H: s.cfg.Font.Height()}
+}
+
+func (s *suite) testText(t *testing.T) {
+	const text = "Hello"
+	pos := types.Vec2{X: 6, Y: 4}
+	out, _ := s.render(t, func(ui *microui.UI) {
+		ui.DrawText(text, pos, s.cfg.Font, Green)
+	})
+	box := s.textBox(text, pos)
+	if s.within(t, out, box, green, "text") == 0 {
+		t.Errorf("text drew nothing in %v\n%s", box, out)
+	}
+}
+
+func (s *suite) testTextClip(t *testing.T) {
+	const text = "Hello"
+	pos := types.Vec2{X: 6, Y: 4}
+	box := s.textBox(text, pos)
+	clip := types.Rect{X: 0, Y: 0, W: pos.X + box.W/2, H: Height}
+	out, _ := s.render(t, func(ui *microui.UI) {
+		ui.PushClip(clip)
+		ui.DrawText(text, pos, s.cfg.Font, Green)
+		ui.PopClip()
+	})
+	for y := 0; y < Height; y++ {
+		for x := clip.W; x < Width; x++ {
+			if out.px[y][x] != bg {
+				t.Fatalf("text drew at (%d,%d), right of the clip edge at x=%d\n%s", x, y, clip.W, out)
+			}
+		}
+	}
+	if s.within(t, out, box, green, "clipped text") == 0 {
+		t.Errorf("clipped text drew nothing\n%s", out)
+	}
+}
+
+func (s *suite) testIcons(t *testing.T) {
+	rect := types.Rect{X: 16, Y: 12, W: 16, H: 16}
+	icons := []struct {
+		id       int
+		name     string
+		required bool // Every renderer draws something
+	}{
+		{microui.IconClose, "close", true},
+		{microui.IconCheck, "check", true},
+		{microui.IconCollapsed, "collapsed", true},
+		{microui.IconExpanded, "expanded", true},
+		{microui.IconResize, "resize", false},
+		{microui.IconRadio, "radio", false},
+		{microui.IconMaximize, "maximize", false},
+		{microui.IconRestore, "restore", false},
+		{microui.IconPin, "pin", false},
+		{microui.IconArrowUp, "arrow-up", false},
+		{microui.IconArrowDown, "arrow-down", false},
+		{microui.IconArrowLeft, "arrow-left", false},
+		{microui.IconArrowRight, "arrow-right", false},
+	}
+	for _, icon := range icons {
+		t.Run(icon.name, func(t *testing.T) {
+			out, target := s.render(t, func(ui *microui.UI) {
+				ui.DrawIcon(icon.id, rect, White)
+			})
+			if _, ok := target.(microui.IconRenderer); !ok {
+				t.Skip("renderer does not implement IconRenderer")
+			}
+			n := s.within(t, out, rect, white, "icon")
+			if n == 0 {
+				if icon.required {
+					t.Errorf("icon drew nothing in %v\n%s", rect, out)
+				}
+				return
+			}
+			// Icons are centered in their rect
+			var sx, sy int
+			for y := 0; y < Height; y++ {
+				for x := 0; x < Width; x++ {
+					if out.px[y][x] == white {
+						sx, sy = sx+x, sy+y
+					}
+				}
+			}
+			cx, cy := rect.X+rect.W/2, rect.Y+rect.H/2
+			if dx, dy := sx/n-cx, sy/n-cy; dx < -rect.W/4 || dx > rect.W/4 || dy < -rect.H/4 || dy > rect.H/4 {
+				t.Errorf("icon centered at (%d,%d), want near (%d,%d)\n%s", sx/n, sy/n, cx, cy, out)
+			}
+		})
+	}
+}
+
+func (s *suite) testImage(t *testing.T) {
+	if s.cfg.Image == nil {
+		t.Skip("Config.Image not set")
+	}
+	// A 2x2 image scaled to 16x16: one color per quadrant
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, Red)
+	img.Set(1, 0, Green)
+	img.Set(0, 1, Blue)
+	img.Set(1, 1, White)
+	handle := s.cfg.Image(img)
+	rect := types.Rect{X: 8, Y: 8, W: 16, H: 16}
+	out, target := s.render(t, func(ui *microui.UI) {
+		ui.Image(handle, rect, types.Rect{}, nil)
+	})
+	if _, ok := target.(microui.ImageRenderer); !ok {
+		t.Skip("renderer does not implement ImageRenderer")
+	}
+	expect(t, out, func(x, y int) int {
+		if !inRect(rect, x, y) {
+			return bg
+		}
+		qx, qy := (x-rect.X)/(rect.W/2), (y-rect.Y)/(rect.H/2)
+		return []int{red, green, blue, white}[qy*2+qx]
+	})
+}
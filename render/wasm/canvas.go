@@ -0,0 +1,302 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"syscall/js"
+
+	"github.com/user/microui-go/types"
+)
+
+// Icon IDs (must match microui constants)
+const (
+	iconClose      = 1
+	iconCheck      = 2
+	iconCollapsed  = 3
+	iconExpanded   = 4
+	iconResize     = 5
+	iconRadio      = 6
+	iconMaximize   = 7
+	iconRestore    = 8
+	iconPin        = 9
+	iconArrowUp    = 10
+	iconArrowDown  = 11
+	iconArrowLeft  = 12
+	iconArrowRight = 13
+)
+
+// Renderer implements microui.Renderer by drawing on an HTML canvas.
+type Renderer struct {
+	canvas  js.Value
+	ctx     js.Value // CanvasRenderingContext2D
+	font    string   // CSS font for text
+	clipped bool     // A clip is pushed on the context state stack
+	fill    string   // Current fillStyle, to skip redundant JS calls
+	stroke  string   // Current strokeStyle
+
+	// Scrollbar colors (scroll commands carry none)
+	ScrollTrackColor color.Color
+	ScrollThumbColor color.Color
+}
+
+// NewRenderer creates a renderer drawing on canvas, an HTMLCanvasElement.
+func NewRenderer(canvas js.Value) *Renderer {
+	theme := types.DarkTheme()
+	r := &Renderer{
+		canvas:           canvas,
+		ctx:              canvas.Call("getContext", "2d"),
+		font:             "13px sans-serif",
+		ScrollTrackColor: theme.ScrollBase,
+		ScrollThumbColor: theme.ScrollThumb,
+	}
+	r.ctx.Set("textBaseline", "top")
+	r.ctx.Set("font", r.font)
+	return r
+}
+
+// Context returns the canvas 2D context, for drawing outside microui.
+func (r *Renderer) Context() js.Value {
+	return r.ctx
+}
+
+// Clear removes the clip and fills the whole canvas with c. Call it
+// before each Render.
+func (r *Renderer) Clear(c color.Color) {
+	if r.clipped {
+		r.ctx.Call("restore")
+		r.clipped = false
+		r.fill, r.stroke = "", ""
+	}
+	r.setFill(c)
+	r.ctx.Call("fillRect", 0, 0, r.canvas.Get("width").Int(), r.canvas.Get("height").Int())
+}
+
+// SetClip sets the clipping rectangle.
+func (r *Renderer) SetClip(rect types.Rect) {
+	if r.clipped {
+		r.ctx.Call("restore")
+		r.fill, r.stroke = "", "" // restore resets the styles
+	}
+	r.ctx.Call("save")
+	r.ctx.Call("beginPath")
+	r.ctx.Call("rect", rect.X, rect.Y, rect.W, rect.H)
+	r.ctx.Call("clip")
+	r.clipped = true
+}
+
+// DrawRect fills a rectangle with the given color.
+func (r *Renderer) DrawRect(pos, size types.Vec2, c color.Color) {
+	if c == nil || size.X <= 0 || size.Y <= 0 {
+		return
+	}
+	r.setFill(c)
+	r.ctx.Call("fillRect", pos.X, pos.Y, size.X, size.Y)
+}
+
+// DrawBox draws an unfilled rectangle outline (border only).
+func (r *Renderer) DrawBox(rect types.Rect, c color.Color) {
+	if c == nil {
+		return
+	}
+	r.setStroke(c)
+	r.ctx.Set("lineWidth", 1)
+	r.ctx.Call("strokeRect", float64(rect.X)+0.5, float64(rect.Y)+0.5, rect.W-1, rect.H-1)
+}
+
+// DrawText draws text with the renderer's CSS font. Use a Font from
+// NewFont for layout so measurements match.
+func (r *Renderer) DrawText(text string, pos types.Vec2, font types.Font, c color.Color) {
+	if c == nil || text == "" {
+		return
+	}
+	if f, ok := font.(*Font); ok && f.css != r.font {
+		r.font = f.css
+		r.ctx.Set("font", r.font)
+	}
+	r.setFill(c)
+	r.ctx.Call("fillText", visualOverride(text), pos.X, pos.Y)
+}
+
+// visualOverride wraps text that microui has already put in display order
+// in a left-to-right override, so the canvas doesn't reorder
+// right-to-left runs a second time.
+func visualOverride(text string) string {
+	for _, ch := range text {
+		if ch >= 0x590 && ch <= 0x8ff || ch >= 0xfb1d && ch <= 0xfefe {
+			return "\u202d" + text + "\u202c"
+		}
+	}
+	return text
+}
+
+// DrawIcon draws an icon as a path centered in rect.
+func (r *Renderer) DrawIcon(id int, rect types.Rect, c color.Color) {
+	if c == nil {
+		return
+	}
+	cx := float64(rect.X) + float64(rect.W)/2
+	cy := float64(rect.Y) + float64(rect.H)/2
+	size := float64(min(rect.W, rect.H)) * 0.6 // Icon is 60% of rect size
+
+	ctx := r.ctx
+	r.setFill(c)
+	r.setStroke(c)
+	ctx.Call("beginPath")
+	switch id {
+	case iconClose: // X shape
+		half := size / 2
+		ctx.Set("lineWidth", 2)
+		ctx.Call("moveTo", cx-half, cy-half)
+		ctx.Call("lineTo", cx+half, cy+half)
+		ctx.Call("moveTo", cx+half, cy-half)
+		ctx.Call("lineTo", cx-half, cy+half)
+		ctx.Call("stroke")
+
+	case iconCheck: // Short leg down-left, long leg up-right
+		ctx.Set("lineWidth", 1.5)
+		ctx.Call("moveTo", cx-size*0.3, cy-size*0.05)
+		ctx.Call("lineTo", cx-size*0.05, cy+size*0.2)
+		ctx.Call("lineTo", cx+size*0.35, cy-size*0.3)
+		ctx.Call("stroke")
+
+	case iconCollapsed: // Right-pointing triangle
+		ctx.Call("moveTo", cx-size*0.2, cy-size*0.35)
+		ctx.Call("lineTo", cx+size*0.3, cy)
+		ctx.Call("lineTo", cx-size*0.2, cy+size*0.35)
+		ctx.Call("fill")
+
+	case iconExpanded: // Down-pointing triangle
+		ctx.Call("moveTo", cx-size*0.35, cy-size*0.2)
+		ctx.Call("lineTo", cx+size*0.35, cy-size*0.2)
+		ctx.Call("lineTo", cx, cy+size*0.3)
+		ctx.Call("fill")
+
+	case iconResize:
+		// No visual for the resize gripper - the area still works for dragging
+
+	case iconRadio: // Filled dot
+		ctx.Call("arc", cx, cy, size*0.35, 0, 2*math.Pi)
+		ctx.Call("fill")
+
+	case iconMaximize: // Single window outline
+		ctx.Set("lineWidth", 1.5)
+		ctx.Call("strokeRect", cx-size/2, cy-size/2, size, size)
+
+	case iconRestore: // Two overlapping window outlines
+		s := size * 0.7
+		off := size - s
+		ctx.Set("lineWidth", 1)
+		ctx.Call("strokeRect", cx-size/2+off, cy-size/2, s, s)
+		ctx.Set("lineWidth", 1.5)
+		ctx.Call("strokeRect", cx-size/2, cy-size/2+off, s, s)
+
+	case iconPin: // Pushpin: head over a needle
+		ctx.Call("fillRect", cx-size/3, cy-size/2, size*2/3, size/2)
+		ctx.Set("lineWidth", 1.5)
+		ctx.Call("moveTo", cx, cy)
+		ctx.Call("lineTo", cx, cy+size/2)
+		ctx.Call("stroke")
+
+	case iconArrowUp:
+		ctx.Call("moveTo", cx-size*0.35, cy+size*0.2)
+		ctx.Call("lineTo", cx+size*0.35, cy+size*0.2)
+		ctx.Call("lineTo", cx, cy-size*0.3)
+		ctx.Call("fill")
+
+	case iconArrowDown:
+		ctx.Call("moveTo", cx-size*0.35, cy-size*0.2)
+		ctx.Call("lineTo", cx+size*0.35, cy-size*0.2)
+		ctx.Call("lineTo", cx, cy+size*0.3)
+		ctx.Call("fill")
+
+	case iconArrowLeft:
+		ctx.Call("moveTo", cx+size*0.2, cy-size*0.35)
+		ctx.Call("lineTo", cx-size*0.3, cy)
+		ctx.Call("lineTo", cx+size*0.2, cy+size*0.35)
+		ctx.Call("fill")
+
+	case iconArrowRight:
+		ctx.Call("moveTo", cx-size*0.2, cy-size*0.35)
+		ctx.Call("lineTo", cx+size*0.3, cy)
+		ctx.Call("lineTo", cx-size*0.2, cy+size*0.35)
+		ctx.Call("fill")
+	}
+}
+
+// DrawScrollTrack draws a scrollbar track (background).
+func (r *Renderer) DrawScrollTrack(rect types.Rect) {
+	r.DrawRect(types.Vec2{X: rect.X, Y: rect.Y}, types.Vec2{X: rect.W, Y: rect.H}, r.ScrollTrackColor)
+}
+
+// DrawScrollThumb draws a scrollbar thumb (draggable part).
+func (r *Renderer) DrawScrollThumb(rect types.Rect) {
+	r.DrawRect(types.Vec2{X: rect.X, Y: rect.Y}, types.Vec2{X: rect.W, Y: rect.H}, r.ScrollThumbColor)
+}
+
+// DrawWindowShadow darkens the strips right of and below rect where its
+// drop shadow falls, blending c over them.
+func (r *Renderer) DrawWindowShadow(rect types.Rect, offset types.Vec2, c color.Color) {
+	for _, s := range rect.ShadowStrips(offset) {
+		r.DrawRect(types.Vec2{X: s.X, Y: s.Y}, types.Vec2{X: s.W, Y: s.H}, c)
+	}
+}
+
+// DrawImage draws img, a js.Value holding anything canvas drawImage
+// accepts (HTMLImageElement, ImageBitmap, HTMLCanvasElement, ...), scaled
+// into rect. src selects a region of it, the whole image if empty. The
+// 2D canvas can't tint, so only the tint's alpha is applied.
+func (r *Renderer) DrawImage(img any, rect, src types.Rect, tint color.Color) {
+	v, ok := img.(js.Value)
+	if !ok || v.IsUndefined() || v.IsNull() || rect.W <= 0 || rect.H <= 0 {
+		return
+	}
+	alpha := 1.0
+	if tint != nil {
+		_, _, _, a := tint.RGBA()
+		alpha = float64(a) / 0xffff
+	}
+	if alpha != 1 {
+		r.ctx.Set("globalAlpha", alpha)
+	}
+	if src.Empty() {
+		r.ctx.Call("drawImage", v, rect.X, rect.Y, rect.W, rect.H)
+	} else {
+		r.ctx.Call("drawImage", v, src.X, src.Y, src.W, src.H, rect.X, rect.Y, rect.W, rect.H)
+	}
+	if alpha != 1 {
+		r.ctx.Set("globalAlpha", 1)
+	}
+}
+
+func (r *Renderer) setFill(c color.Color) {
+	if css := cssColor(c); css != r.fill {
+		r.fill = css
+		r.ctx.Set("fillStyle", css)
+	}
+}
+
+func (r *Renderer) setStroke(c color.Color) {
+	if css := cssColor(c); css != r.stroke {
+		r.stroke = css
+		r.ctx.Set("strokeStyle", css)
+	}
+}
+
+// cssColor formats c as a CSS rgba() color.
+func cssColor(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	b := make([]byte, 0, 32)
+	b = append(b, "rgba("...)
+	b = strconv.AppendInt(b, int64(n.R), 10)
+	b = append(b, ',')
+	b = strconv.AppendInt(b, int64(n.G), 10)
+	b = append(b, ',')
+	b = strconv.AppendInt(b, int64(n.B), 10)
+	b = append(b, ',')
+	b = strconv.AppendFloat(b, float64(n.A)/255, 'f', 3, 64)
+	b = append(b, ')')
+	return string(b)
+}
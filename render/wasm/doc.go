@@ -0,0 +1,33 @@
+// Package wasm provides an HTML5 canvas renderer and DOM input bridge for
+// microui-go in the browser, without Ebiten.
+//
+// Drawing commands become CanvasRenderingContext2D calls through
+// syscall/js, and BindInput feeds mouse, wheel and keyboard events from
+// the page into a UI. The package only builds for GOOS=js GOARCH=wasm.
+//
+// # Usage
+//
+//	import "github.com/user/microui-go/render/wasm"
+//
+//	canvas := js.Global().Get("document").Call("getElementById", "ui")
+//	r := wasm.NewRenderer(canvas)
+//	font := wasm.NewFont(r, "13px sans-serif", 13)
+//	style := microui.GUIStyle()
+//	style.Font = font
+//	ui := microui.New(microui.Config{Style: style})
+//	release := wasm.BindInput(ui, canvas)
+//	defer release()
+//
+//	var frame js.Func
+//	frame = js.FuncOf(func(js.Value, []js.Value) any {
+//		ui.BeginFrame()
+//		// ... windows and controls ...
+//		ui.EndFrame()
+//		r.Clear(color.RGBA{30, 30, 30, 255})
+//		ui.Render(r)
+//		js.Global().Call("requestAnimationFrame", frame)
+//		return nil
+//	})
+//	js.Global().Call("requestAnimationFrame", frame)
+//	select {} // keep the program alive
+package wasm
@@ -0,0 +1,50 @@
+//go:build js && wasm
+
+package wasm
+
+import "syscall/js"
+
+// maxCachedWidths bounds the width cache; it is cleared when full.
+const maxCachedWidths = 4096
+
+// Font measures text with the canvas, so layout matches what DrawText
+// draws. It implements types.Font.
+type Font struct {
+	ctx    js.Value
+	css    string
+	height int
+	widths map[string]int
+}
+
+// NewFont creates a font from a CSS font string such as "13px sans-serif"
+// and its line height in pixels. Widths come from the canvas measureText
+// and are cached.
+func NewFont(r *Renderer, css string, height int) *Font {
+	return &Font{ctx: r.ctx, css: css, height: height, widths: make(map[string]int)}
+}
+
+// Width returns the pixel width of the given text.
+func (f *Font) Width(text string) int {
+	if text == "" {
+		return 0
+	}
+	if w, ok := f.widths[text]; ok {
+		return w
+	}
+	// Measure with this font, then put the current one back
+	prev := f.ctx.Get("font")
+	f.ctx.Set("font", f.css)
+	w := int(f.ctx.Call("measureText", text).Get("width").Float() + 0.5)
+	f.ctx.Set("font", prev)
+
+	if len(f.widths) >= maxCachedWidths {
+		clear(f.widths)
+	}
+	f.widths[text] = w
+	return w
+}
+
+// Height returns the font height in pixels.
+func (f *Font) Height() int {
+	return f.height
+}
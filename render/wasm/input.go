@@ -0,0 +1,180 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"strings"
+	"syscall/js"
+	"unicode/utf8"
+
+	microui "github.com/user/microui-go"
+)
+
+// linePixels is the scroll distance of one wheel line (deltaMode 1).
+const linePixels = 30
+
+// domKeys maps KeyboardEvent.key values to microui keys.
+var domKeys = map[string]microui.Key{
+	"Shift":      microui.KeyShift,
+	"Control":    microui.KeyCtrl,
+	"Meta":       microui.KeyMeta,
+	"Alt":        microui.KeyAlt,
+	"Enter":      microui.KeyEnter,
+	"Backspace":  microui.KeyBackspace,
+	"Delete":     microui.KeyDelete,
+	"Escape":     microui.KeyEscape,
+	"ArrowLeft":  microui.KeyLeft,
+	"ArrowRight": microui.KeyRight,
+	"ArrowUp":    microui.KeyUp,
+	"ArrowDown":  microui.KeyDown,
+	"Home":       microui.KeyHome,
+	"End":        microui.KeyEnd,
+	"PageUp":     microui.KeyPageUp,
+	"PageDown":   microui.KeyPageDown,
+	"Tab":        microui.KeyTab,
+	" ":          microui.KeySpace,
+}
+
+// BindInput forwards DOM mouse, wheel, touch and keyboard events on canvas
+// to ui. Mouse positions are converted to canvas pixels, so a canvas scaled
+// with CSS still lines up. Keyboard events are taken from the canvas,
+// which is made focusable and focused on click. Call the returned
+// function to remove the listeners.
+func BindInput(ui *microui.UI, canvas js.Value) (release func()) {
+	var funcs []js.Func
+	var removers []func()
+	listen := func(target js.Value, event string, fn func(e js.Value)) {
+		f := js.FuncOf(func(_ js.Value, args []js.Value) any {
+			fn(args[0])
+			return nil
+		})
+		funcs = append(funcs, f)
+		target.Call("addEventListener", event, f)
+		removers = append(removers, func() { target.Call("removeEventListener", event, f) })
+	}
+
+	pos := func(e js.Value) (int, int) {
+		rect := canvas.Call("getBoundingClientRect")
+		sx := canvas.Get("width").Float() / max(rect.Get("width").Float(), 1)
+		sy := canvas.Get("height").Float() / max(rect.Get("height").Float(), 1)
+		x := (e.Get("clientX").Float() - rect.Get("left").Float()) * sx
+		y := (e.Get("clientY").Float() - rect.Get("top").Float()) * sy
+		return int(x), int(y)
+	}
+	button := func(e js.Value) (microui.MouseButton, bool) {
+		switch e.Get("button").Int() {
+		case 0:
+			return microui.MouseLeft, true
+		case 1:
+			return microui.MouseMiddle, true
+		case 2:
+			return microui.MouseRight, true
+		}
+		return 0, false
+	}
+
+	if canvas.Get("tabIndex").Int() < 0 {
+		canvas.Set("tabIndex", 0)
+	}
+	window := js.Global()
+
+	listen(window, "mousemove", func(e js.Value) {
+		ui.MouseMove(pos(e))
+	})
+	listen(canvas, "mousedown", func(e js.Value) {
+		canvas.Call("focus")
+		if btn, ok := button(e); ok {
+			x, y := pos(e)
+			ui.MouseDown(x, y, btn)
+			e.Call("preventDefault")
+		}
+	})
+	// Released on the window so drags ending outside the canvas finish
+	listen(window, "mouseup", func(e js.Value) {
+		if btn, ok := button(e); ok {
+			x, y := pos(e)
+			ui.MouseUp(x, y, btn)
+		}
+	})
+	listen(canvas, "contextmenu", func(e js.Value) {
+		e.Call("preventDefault")
+	})
+	listen(canvas, "wheel", func(e js.Value) {
+		dx, dy := e.Get("deltaX").Float(), e.Get("deltaY").Float()
+		if e.Get("deltaMode").Int() == 1 {
+			dx, dy = dx*linePixels, dy*linePixels
+		}
+		ui.Scroll(int(dx), int(dy))
+		e.Call("preventDefault")
+	})
+	// Touches are reported as such; preventDefault stops the browser from
+	// also sending emulated mouse events and scrolling the page
+	touches := func(e js.Value, report func(id, x, y int)) {
+		changed := e.Get("changedTouches")
+		for i := 0; i < changed.Length(); i++ {
+			t := changed.Index(i)
+			x, y := pos(t)
+			report(t.Get("identifier").Int(), x, y)
+		}
+		e.Call("preventDefault")
+	}
+	listen(canvas, "touchstart", func(e js.Value) {
+		canvas.Call("focus")
+		touches(e, ui.TouchBegin)
+	})
+	listen(canvas, "touchmove", func(e js.Value) { touches(e, ui.TouchMove) })
+	listen(canvas, "touchend", func(e js.Value) { touches(e, ui.TouchEnd) })
+	listen(canvas, "touchcancel", func(e js.Value) { touches(e, ui.TouchEnd) })
+	listen(canvas, "keydown", func(e js.Value) {
+		key := e.Get("key").String()
+		ctrl := e.Get("ctrlKey").Bool() || e.Get("metaKey").Bool()
+		// Keep Tab, Space, arrows and shortcuts from scrolling or leaving the page
+		if key == "Tab" || key == " " || strings.HasPrefix(key, "Arrow") || ctrl {
+			e.Call("preventDefault")
+		}
+		if e.Get("repeat").Bool() {
+			return // microui repeats held keys itself
+		}
+		if k, ok := domKey(key); ok {
+			ui.KeyDown(k)
+		}
+		if utf8.RuneCountInString(key) == 1 && !ctrl {
+			// Queued rather than TextInput, which BeginFrame clears; events
+			// arrive between frames
+			r, _ := utf8.DecodeRuneInString(key)
+			ui.PushInput(microui.TextEvent{Rune: r})
+		}
+	})
+	listen(canvas, "keyup", func(e js.Value) {
+		if k, ok := domKey(e.Get("key").String()); ok {
+			ui.KeyUp(k)
+		}
+	})
+
+	return func() {
+		for _, remove := range removers {
+			remove()
+		}
+		for _, f := range funcs {
+			f.Release()
+		}
+	}
+}
+
+// domKey maps a KeyboardEvent.key value to a microui key, including the
+// letter keys used for shortcuts and KeyChar for other characters.
+func domKey(key string) (microui.Key, bool) {
+	if k, ok := domKeys[key]; ok {
+		return k, true
+	}
+	if len(key) == 1 {
+		c := key[0] | 0x20 // Lower case
+		if c >= 'a' && c <= 'z' {
+			return microui.KeyA + microui.Key(c-'a'), true
+		}
+	}
+	if utf8.RuneCountInString(key) == 1 {
+		return microui.KeyChar, true
+	}
+	return 0, false
+}
@@ -0,0 +1,48 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"image/color"
+	"testing"
+
+	microui "github.com/user/microui-go"
+)
+
+func TestCSSColor(t *testing.T) {
+	tests := []struct {
+		c    color.Color
+		want string
+	}{
+		{color.RGBA{R: 255, G: 128, B: 0, A: 255}, "rgba(255,128,0,1.000)"},
+		{color.NRGBA{R: 10, G: 20, B: 30, A: 51}, "rgba(10,20,30,0.200)"},
+	}
+	for _, tt := range tests {
+		if got := cssColor(tt.c); got != tt.want {
+			t.Errorf("cssColor(%v) = %q, want %q", tt.c, got, tt.want)
+		}
+	}
+}
+
+func TestDOMKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want microui.Key
+		ok   bool
+	}{
+		{"Enter", microui.KeyEnter, true},
+		{"ArrowLeft", microui.KeyLeft, true},
+		{"c", microui.KeyC, true},
+		{"V", microui.KeyV, true},
+		{" ", microui.KeySpace, true},
+		{"1", microui.KeyChar, true},
+		{"é", microui.KeyChar, true},
+		{"Meta", microui.KeyMeta, true},
+		{"F1", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := domKey(tt.key); got != tt.want || ok != tt.ok {
+			t.Errorf("domKey(%q) = %v, %v, want %v, %v", tt.key, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package microui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestButtonRepeat(t *testing.T) {
+	ui := New(Config{ButtonRepeatDelay: 300 * time.Millisecond, ButtonRepeatInterval: 100 * time.Millisecond})
+	now := time.Unix(0, 0)
+	clicks := 0
+	frame := func(dt time.Duration) {
+		now = now.Add(dt)
+		ui.BeginFrameAt(now)
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.LayoutRow(1, []int{100}, 0)
+		if ui.ButtonOpt("+", 0, OptRepeat) {
+			clicks++
+		}
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	ui.MouseMove(20, 35)
+	frame(0)
+	ui.MouseDown(20, 35, MouseLeft)
+	frame(16 * time.Millisecond)
+	if clicks != 1 {
+		t.Fatalf("clicks = %d after the press, want 1", clicks)
+	}
+	frame(200 * time.Millisecond)
+	if clicks != 1 {
+		t.Fatalf("clicks = %d before the repeat delay, want 1", clicks)
+	}
+	frame(100 * time.Millisecond) // 316ms held
+	frame(100 * time.Millisecond)
+	frame(100 * time.Millisecond)
+	if clicks != 4 {
+		t.Errorf("clicks = %d after 516ms held, want 4", clicks)
+	}
+
+	// Off the button the repeat pauses; releasing stops it
+	ui.MouseMove(300, 200)
+	frame(100 * time.Millisecond)
+	frame(100 * time.Millisecond)
+	ui.MouseUp(300, 200, MouseLeft)
+	frame(100 * time.Millisecond)
+	frame(100 * time.Millisecond)
+	if clicks != 4 {
+		t.Errorf("clicks = %d, want no repeats off the button or after release", clicks)
+	}
+}
@@ -0,0 +1,375 @@
+package microui
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/user/microui-go/types"
+)
+
+// TextSegment is a run of text drawn in one color by TextSegments.
+type TextSegment struct {
+	Text  string
+	Color color.Color // nil = style text color
+}
+
+// textPiece is the part of a word that lies in one segment.
+type textPiece struct {
+	text  string
+	color color.Color
+}
+
+// textWord is a wrappable word; it can span several colored pieces.
+type textWord []textPiece
+
+func (w textWord) String() string {
+	if len(w) == 1 {
+		return w[0].text
+	}
+	var sb strings.Builder
+	for _, p := range w {
+		sb.WriteString(p.text)
+	}
+	return sb.String()
+}
+
+// TextSegments draws word-wrapped text mixing colors, e.g. a log line with
+// a red "error" in it. Wrapping treats the segments as one string, so a
+// word may change color partway through; each run of same-colored text on
+// a line becomes one text command.
+func (u *UI) TextSegments(segs ...TextSegment) {
+	layout := u.getLayout()
+	font := u.style.Font
+	if font == nil {
+		font = &types.MockFont{}
+	}
+
+	availWidth := layout.body.W - layout.indent - u.style.Padding.X*2
+	startX := layout.body.X + layout.indent + u.style.Padding.X
+
+	relY := layout.position.Y
+	startY := relY
+	for _, line := range u.wrapText(segs, font, availWidth) {
+		for _, run := range line {
+			// PushCommand would measure the run again to cull it
+			pos := types.Vec2{X: startX + run.x, Y: layout.body.Y + relY}
+			if u.CheckClip(types.Rect{X: pos.X, Y: pos.Y, W: run.w, H: font.Height()}) == ClipAll {
+				u.culled++
+				continue
+			}
+			u.commands.Push(Command{Kind: CmdText, Text: run.text, Pos: pos, Color: run.color, Font: font})
+		}
+		relY += font.Height()
+	}
+
+	absY := layout.body.Y + relY
+	if u.accessOn {
+		var b strings.Builder
+		for _, s := range segs {
+			b.WriteString(s.Text)
+		}
+		u.accessStatic(RoleText, b.String(), types.Rect{X: startX, Y: layout.body.Y + startY, W: availWidth, H: relY - startY})
+	}
+	if startX+availWidth > layout.max.X {
+		layout.max.X = startX + availWidth
+	}
+	layout.fillW = true
+	if absY > layout.max.Y {
+		layout.max.Y = absY
+	}
+
+	layout.nextRow = relY + u.style.Spacing
+	layout.position.Y = layout.nextRow
+}
+
+// wrapSegments splits segments into lines no wider than availWidth, as
+// color runs ready to draw in direction dir. A blank line in the text
+// gives an empty line.
+func wrapSegments(segs []TextSegment, def color.Color, font types.Font, availWidth int, dir TextDirection) [][]textRun {
+	var lines [][]textRun
+	for _, para := range splitSegments(segs, def) {
+		if para == nil {
+			lines = append(lines, nil)
+			continue
+		}
+
+		// Every line of a paragraph takes its direction from the first
+		// strong character of the paragraph
+		pdir := dir
+		if pdir == DirAuto {
+			pdir = paraDirection(para)
+		}
+		var line []textWord
+		lineText := ""
+		newLine := func(word textWord) {
+			lines = append(lines, lineRuns(line, font, availWidth, pdir))
+			line = []textWord{word}
+			lineText = word.String()
+		}
+		for _, word := range para {
+			// A word wider than the line, such as a run of CJK text
+			// without spaces, is broken between runes
+			if availWidth > 0 && font.Width(word.String()) > availWidth {
+				for i, part := range breakWord(word, font, availWidth) {
+					if i > 0 || len(line) > 0 {
+						newLine(part)
+					} else {
+						line = append(line, part)
+						lineText = part.String()
+					}
+				}
+				continue
+			}
+
+			testLine := lineText
+			if len(testLine) > 0 {
+				testLine += " "
+			}
+			testLine += word.String()
+
+			if font.Width(testLine) > availWidth && len(line) > 0 {
+				newLine(word)
+			} else {
+				line = append(line, word)
+				lineText = testLine
+			}
+		}
+
+		if len(line) > 0 {
+			lines = append(lines, lineRuns(line, font, availWidth, pdir))
+		}
+	}
+	return lines
+}
+
+// paraDirection returns the direction of a paragraph's first strong
+// character, or DirAuto if it has none.
+func paraDirection(para []textWord) TextDirection {
+	for _, word := range para {
+		for _, p := range word {
+			for _, r := range p.text {
+				switch bidiClassOf(r) {
+				case bidiL:
+					return DirLTR
+				case bidiR:
+					return DirRTL
+				}
+			}
+		}
+	}
+	return DirAuto
+}
+
+// breakWord splits a word into parts no wider than width, between runes.
+// Each part has at least one rune, even if that alone is wider.
+func breakWord(word textWord, font types.Font, width int) []textWord {
+	var parts []textWord
+	var part textWord
+	var runes []rune // Runes of part, for measuring
+	for _, p := range word {
+		start := 0
+		for i, r := range p.text {
+			if len(runes) > 0 && types.MeasureRunes(font, append(runes, r)) > width {
+				if i > start {
+					part = append(part, textPiece{text: p.text[start:i], color: p.color})
+				}
+				parts = append(parts, part)
+				part, runes, start = nil, runes[:0], i
+			}
+			runes = append(runes, r)
+		}
+		if start < len(p.text) {
+			part = append(part, textPiece{text: p.text[start:], color: p.color})
+		}
+	}
+	if len(part) > 0 {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// TextMarkup draws word-wrapped text with inline colors, e.g.
+// "[#ff0000]error[/] file not found". See ParseMarkup for the syntax.
+func (u *UI) TextMarkup(markup string) {
+	u.TextSegments(ParseMarkup(markup)...)
+}
+
+// textRun is a run of same-colored text on a wrapped line, x pixels from
+// its start and w pixels wide.
+type textRun struct {
+	text  string
+	color color.Color
+	x, w  int
+}
+
+// lineRuns turns a wrapped line into one run per color. Right-to-left
+// text is reordered for display, and a right-to-left line is aligned to
+// the right of width.
+func lineRuns(line []textWord, font types.Font, width int, dir TextDirection) []textRun {
+	var runes []rune
+	var colors []color.Color // Color of each rune
+	var c color.Color
+	for i, word := range line {
+		if i > 0 {
+			runes, colors = append(runes, ' '), append(colors, c)
+		}
+		for _, p := range word {
+			c = p.color
+			for _, r := range p.text {
+				runes, colors = append(runes, r), append(colors, c)
+			}
+		}
+	}
+	rtl := false
+	if dir == DirRTL || hasRTL(string(runes)) {
+		b := newBidiLine(runes, dir)
+		visual := make([]color.Color, len(colors))
+		for l, v := range b.pos {
+			visual[v] = colors[l]
+		}
+		runes, colors, rtl = b.visual, visual, b.rtl
+	}
+
+	var runs []textRun
+	x := 0
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && colors[end] == colors[start] {
+			end++
+		}
+		text := string(runes[start:end])
+		w := font.Width(text)
+		runs = append(runs, textRun{text: text, color: colors[start], x: x, w: w})
+		x += w
+		start = end
+	}
+	if rtl && x < width {
+		for i := range runs {
+			runs[i].x += width - x
+		}
+	}
+	return runs
+}
+
+// splitSegments splits segments into paragraphs of words. An empty line
+// gives a nil paragraph, which still takes up a line; nil colors are
+// replaced by def.
+func splitSegments(segs []TextSegment, def color.Color) [][]textWord {
+	var paras [][]textWord
+	var para []textWord
+	var word textWord
+	empty := true // No text at all since the last newline
+
+	endWord := func() {
+		if len(word) > 0 {
+			para = append(para, word)
+			word = nil
+		}
+	}
+	endPara := func() {
+		if !empty && para == nil {
+			para = []textWord{} // Only whitespace: no line, but not a blank line either
+		}
+		paras = append(paras, para)
+		para, empty = nil, true
+	}
+
+	for _, seg := range segs {
+		c := seg.Color
+		if c == nil {
+			c = def
+		}
+		text := seg.Text
+		for len(text) > 0 {
+			i := strings.IndexAny(text, " \t\n")
+			if i < 0 {
+				i = len(text)
+			}
+			if i > 0 {
+				word = append(word, textPiece{text: text[:i], color: c})
+				empty = false
+			}
+			if i == len(text) {
+				break
+			}
+			endWord()
+			if text[i] == '\n' {
+				endPara()
+			} else {
+				empty = false
+			}
+			text = text[i+1:]
+		}
+	}
+	endWord()
+	endPara()
+	return paras
+}
+
+// ParseMarkup converts inline color markup to text segments.
+// [#rrggbb] or [#rrggbbaa] starts a color, [/] returns to the previous
+// one, and [[ is a literal "[". Anything else is kept as text.
+func ParseMarkup(markup string) []TextSegment {
+	var segs []TextSegment
+	var stack []color.Color
+	var sb strings.Builder
+	current := func() color.Color {
+		if len(stack) == 0 {
+			return nil
+		}
+		return stack[len(stack)-1]
+	}
+	flush := func() {
+		if sb.Len() > 0 {
+			segs = append(segs, TextSegment{Text: sb.String(), Color: current()})
+			sb.Reset()
+		}
+	}
+
+	for i := 0; i < len(markup); i++ {
+		if markup[i] != '[' {
+			sb.WriteByte(markup[i])
+			continue
+		}
+		rest := markup[i:]
+		switch {
+		case strings.HasPrefix(rest, "[["):
+			sb.WriteByte('[')
+			i++
+		case strings.HasPrefix(rest, "[/]"):
+			flush()
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			i += 2
+		default:
+			end := strings.IndexByte(rest, ']')
+			c, ok := parseHexColor(rest[1:max(end, 1)])
+			if end < 0 || !ok {
+				sb.WriteByte('[')
+				continue
+			}
+			flush()
+			stack = append(stack, c)
+			i += end
+		}
+	}
+	flush()
+	return segs
+}
+
+// parseHexColor parses "#rrggbb" or "#rrggbbaa".
+func parseHexColor(s string) (color.Color, bool) {
+	if len(s) != 7 && len(s) != 9 || s[0] != '#' {
+		return nil, false
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return nil, false
+	}
+	if len(s) == 7 {
+		v = v<<8 | 0xff
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, true
+}
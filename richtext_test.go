@@ -0,0 +1,135 @@
+package microui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+var red = color.NRGBA{R: 0xff, A: 0xff}
+
+func TestParseMarkup(t *testing.T) {
+	segs := ParseMarkup("[#ff0000]error[/] file [[not] [b]found")
+	want := []TextSegment{
+		{Text: "error", Color: red},
+		{Text: " file [not] [b]found"},
+	}
+	if len(segs) != len(want) {
+		t.Fatalf("segments = %+v, want %+v", segs, want)
+	}
+	for i := range want {
+		if segs[i] != want[i] {
+			t.Errorf("segment %d = %+v, want %+v", i, segs[i], want[i])
+		}
+	}
+}
+
+func TestParseMarkup_Nested(t *testing.T) {
+	segs := ParseMarkup("[#ff0000]a[#00ff0080]b[/]c[/]d")
+	wantColors := []color.Color{red, color.NRGBA{G: 0xff, A: 0x80}, red, nil}
+	if len(segs) != 4 {
+		t.Fatalf("got %d segments, want 4: %+v", len(segs), segs)
+	}
+	for i, c := range wantColors {
+		if segs[i].Color != c {
+			t.Errorf("segment %q color = %v, want %v", segs[i].Text, segs[i].Color, c)
+		}
+	}
+}
+
+// textCommands draws one text control in a window of the given width and
+// returns its text commands.
+func textCommands(width int, draw func(ui *UI)) []Command {
+	ui := New(Config{})
+	ui.BeginFrame()
+	ui.BeginWindow("T", types.Rect{X: 0, Y: 0, W: width, H: 300})
+	ui.LayoutRow(1, []int{-1}, 0)
+	draw(ui)
+	ui.EndWindow()
+	ui.EndFrame()
+
+	var cmds []Command
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdText && cmd.Text != "T" {
+			cmds = append(cmds, cmd)
+		}
+	})
+	return cmds
+}
+
+func TestTextMarkup_Runs(t *testing.T) {
+	cmds := textCommands(400, func(ui *UI) { ui.TextMarkup("[#ff0000]err[/] ok") })
+	if len(cmds) != 2 {
+		t.Fatalf("got %d text commands, want 2", len(cmds))
+	}
+	// The space between words goes with the run before it
+	if cmds[0].Text != "err " || cmds[0].Color != red {
+		t.Errorf("first run = %q %v, want red \"err \"", cmds[0].Text, cmds[0].Color)
+	}
+	if cmds[1].Text != "ok" || cmds[1].Color != DefaultStyle().Colors.Text {
+		t.Errorf("second run = %q %v, want \"ok\" in the text color", cmds[1].Text, cmds[1].Color)
+	}
+	if cmds[1].Pos.X != cmds[0].Pos.X+32 || cmds[1].Pos.Y != cmds[0].Pos.Y {
+		t.Errorf("second run at %v, want right after the first at %v", cmds[1].Pos, cmds[0].Pos)
+	}
+}
+
+func TestTextSegments_WrapsLikeText(t *testing.T) {
+	const text = "one two three four five six seven eight nine ten"
+	plain := textCommands(120, func(ui *UI) { ui.Text(text) })
+	styled := textCommands(120, func(ui *UI) {
+		ui.TextSegments(
+			TextSegment{Text: "one two thr"},
+			TextSegment{Text: "ee four five", Color: red},
+			TextSegment{Text: " six seven eight nine ten"},
+		)
+	})
+
+	lines := map[int]string{}
+	for _, cmd := range styled {
+		lines[cmd.Pos.Y] += cmd.Text
+	}
+	if len(lines) != len(plain) {
+		t.Fatalf("styled text has %d lines, plain text %d", len(lines), len(plain))
+	}
+	for _, cmd := range plain {
+		if lines[cmd.Pos.Y] != cmd.Text {
+			t.Errorf("line at y=%d = %q, want %q", cmd.Pos.Y, lines[cmd.Pos.Y], cmd.Text)
+		}
+	}
+}
+
+func TestTextSegments_ColorChangeInsideWord(t *testing.T) {
+	cmds := textCommands(400, func(ui *UI) { ui.TextMarkup("foo[#ff0000]bar[/]") })
+	if len(cmds) != 2 || cmds[0].Text != "foo" || cmds[1].Text != "bar" {
+		t.Fatalf("commands = %+v, want \"foo\" then \"bar\"", cmds)
+	}
+	if cmds[1].Pos.X != cmds[0].Pos.X+24 {
+		t.Errorf("\"bar\" at x=%d, want %d", cmds[1].Pos.X, cmds[0].Pos.X+24)
+	}
+}
+
+func TestTextSegments_BreaksLongWord(t *testing.T) {
+	const word = "abcdefghijklmnopqrstuvwxyz"
+	cmds := textCommands(80, func(ui *UI) { ui.TextMarkup("hi [#ff0000]" + word + "[/]") })
+	if len(cmds) < 3 {
+		t.Fatalf("got %d text commands, want the word split over several lines", len(cmds))
+	}
+	if cmds[0].Text != "hi" {
+		t.Errorf("first line = %q, want \"hi\"", cmds[0].Text)
+	}
+	got := ""
+	for _, cmd := range cmds[1:] {
+		if cmd.Color != red {
+			t.Errorf("piece %q lost its color", cmd.Text)
+		}
+		if w := len(cmd.Text) * 8; w > 80-2*DefaultStyle().Padding.X {
+			t.Errorf("piece %q is %dpx wide, wider than the window body", cmd.Text, w)
+		}
+		got += cmd.Text
+	}
+	if got != word {
+		t.Errorf("pieces join to %q, want %q", got, word)
+	}
+}
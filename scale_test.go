@@ -0,0 +1,73 @@
+package microui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestScale_MultipliesMetrics(t *testing.T) {
+	ui := New(Config{})
+	base := ui.Style()
+	ui.SetScale(2)
+
+	if ui.Scale() != 2 {
+		t.Errorf("Scale() = %v, want 2", ui.Scale())
+	}
+	if got := ui.Style(); got.TitleHeight != base.TitleHeight {
+		t.Errorf("Style() should stay unscaled, TitleHeight = %d", got.TitleHeight)
+	}
+	s := ui.style
+	if s.TitleHeight != base.TitleHeight*2 || s.Padding.X != base.Padding.X*2 ||
+		s.Spacing != base.Spacing*2 || s.ScrollbarSize != base.ScrollbarSize*2 {
+		t.Errorf("metrics not scaled: %+v", s)
+	}
+
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{100}, 0)
+	r := ui.LayoutNext()
+	ui.EndWindow()
+	ui.EndFrame()
+	if want := base.TitleHeight*2 + base.Padding.Y*2; r.Y != want {
+		t.Errorf("first row Y = %d, want %d", r.Y, want)
+	}
+	if want := (base.Size.Y + base.Padding.Y*2) * 2; r.H != want {
+		t.Errorf("row height = %d, want %d", r.H, want)
+	}
+}
+
+func TestScale_SurvivesSetStyle(t *testing.T) {
+	ui := New(Config{})
+	ui.SetScale(1.5)
+	ui.SetStyle(GUIStyle())
+	if want := 36; ui.style.TitleHeight != want {
+		t.Errorf("TitleHeight = %d, want %d after SetStyle", ui.style.TitleHeight, want)
+	}
+	ui.SetScale(1)
+	if ui.style.TitleHeight != GUIStyle().TitleHeight {
+		t.Errorf("SetScale(1) should restore unscaled metrics")
+	}
+}
+
+type scaleRenderer struct {
+	scale float64
+}
+
+func (r *scaleRenderer) DrawRect(types.Vec2, types.Vec2, color.Color)         {}
+func (r *scaleRenderer) DrawText(string, types.Vec2, types.Font, color.Color) {}
+func (r *scaleRenderer) SetClip(types.Rect)                                   {}
+func (r *scaleRenderer) SetScale(scale float64)                               { r.scale = scale }
+
+func TestScale_PassedToRenderer(t *testing.T) {
+	ui := New(Config{})
+	ui.SetScale(1.25)
+	ui.BeginFrame()
+	ui.EndFrame()
+	r := &scaleRenderer{}
+	ui.Render(r)
+	if r.scale != 1.25 {
+		t.Errorf("renderer scale = %v, want 1.25", r.scale)
+	}
+}
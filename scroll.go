@@ -0,0 +1,235 @@
+package microui
+
+import (
+	"math"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+const (
+	scrollSpeed    = 0.35 // Fraction of the remaining distance an animated scroll covers per frame
+	scrollFriction = 0.92 // Fraction of a touch fling's speed kept per frame
+
+	// scrollbarLinger is how long Style.ScrollbarAutoHide scrollbars stay
+	// after the last scroll or hover before fading out
+	scrollbarLinger = time.Second
+)
+
+// ScrollTo sets the scroll offset of the named window or panel. The offset
+// is clamped to the content measured last frame.
+func (u *UI) ScrollTo(name string, pos types.Vec2) {
+	cnt := u.GetContainer(name)
+	maxScroll := u.maxScroll(cnt)
+	cnt.scroll.X = max(0, min(pos.X, maxScroll.X))
+	cnt.scroll.Y = max(0, min(pos.Y, maxScroll.Y))
+	cnt.toBottom = false
+	cnt.scrollAnim = false
+}
+
+// ScrollToBottom scrolls the named window or panel to its last line. It
+// takes effect when the container ends, so content added this frame is
+// included; call it before or after the container in the same frame.
+func (u *UI) ScrollToBottom(name string) {
+	u.GetContainer(name).toBottom = true
+}
+
+// EnsureVisible scrolls the current window or panel, and those around it,
+// so that rect, a control laid out this frame, is in view from the next
+// frame. With Config.SmoothScroll it scrolls there gradually. A control
+// focused with Tab, directional navigation or FocusControl is brought into
+// view this way.
+func (u *UI) EnsureVisible(rect types.Rect) {
+	for cnt := u.GetCurrentContainer(); cnt != nil && !rect.Empty(); cnt = cnt.parent {
+		if cnt.opt&OptNoScroll != 0 {
+			rect = intersectRect(rect, cnt.body)
+			continue
+		}
+		d := types.Vec2{
+			X: revealDelta(rect.X, rect.W, cnt.body.X, cnt.body.W),
+			Y: revealDelta(rect.Y, rect.H, cnt.body.Y, cnt.body.H),
+		}
+		if d != (types.Vec2{}) {
+			goal := u.clampScroll(cnt, cnt.scroll.Add(d))
+			d = goal.Sub(cnt.scroll)
+			if u.smoothScroll {
+				cnt.SetScrollAnimated(goal)
+			} else {
+				cnt.SetScroll(goal)
+			}
+			cnt.toBottom = false
+		}
+		// Reveal where it will be in the container around this one
+		rect.X, rect.Y = rect.X-d.X, rect.Y-d.Y
+		rect = intersectRect(rect, cnt.body)
+	}
+}
+
+// revealDelta returns how far to scroll along one axis to bring the span
+// at pos into the view from lo to lo+length: its start when it doesn't
+// fit, else whichever edge is out of view.
+func revealDelta(pos, size, lo, length int) int {
+	switch {
+	case pos < lo || size > length:
+		return pos - lo
+	case pos+size > lo+length:
+		return pos + size - lo - length
+	}
+	return 0
+}
+
+// maxScroll returns how far a container's content can scroll.
+func (u *UI) maxScroll(cnt *Container) types.Vec2 {
+	return types.Vec2{
+		X: max(0, cnt.contentSize.X+u.style.Padding.X*2-cnt.body.W),
+		Y: max(0, cnt.contentSize.Y+u.style.Padding.Y*2-cnt.body.H),
+	}
+}
+
+// clampScroll limits a scroll offset to a container's content.
+func (u *UI) clampScroll(cnt *Container, pos types.Vec2) types.Vec2 {
+	maxScroll := u.maxScroll(cnt)
+	return types.Vec2{
+		X: max(0, min(pos.X, maxScroll.X)),
+		Y: max(0, min(pos.Y, maxScroll.Y)),
+	}
+}
+
+// routeScroll applies this frame's scroll delta at the end of the frame.
+// Each axis goes to the innermost panel under the mouse that can scroll
+// along it, or else to the window. Shift turns the vertical wheel into
+// horizontal scrolling.
+func (u *UI) routeScroll() {
+	delta := u.input.ScrollDelta
+	if delta == (types.Vec2{}) {
+		return
+	}
+	if u.input.KeyDown[KeyShift] && delta.X == 0 {
+		delta = types.Vec2{X: delta.Y}
+	}
+	x, y := u.scrollContainer(true), u.scrollContainer(false)
+	if x == y {
+		if x != nil {
+			u.applyScroll(x, delta)
+		}
+		return
+	}
+	if x != nil && delta.X != 0 {
+		u.applyScroll(x, types.Vec2{X: delta.X})
+	}
+	if y != nil && delta.Y != 0 {
+		u.applyScroll(y, types.Vec2{Y: delta.Y})
+	}
+}
+
+// scrollContainer returns the container that scrolls along an axis: the
+// innermost panel under the mouse with content overflowing that way,
+// walking out through its parents, or else the root container under the
+// mouse.
+func (u *UI) scrollContainer(horizontal bool) *Container {
+	root := u.scrollPanel
+	for root != nil && root.parent != nil {
+		root = root.parent
+	}
+	if root != u.scrollTarget {
+		return u.scrollTarget // The panel is in a window that lost the mouse
+	}
+	for cnt := u.scrollPanel; cnt != nil && cnt.parent != nil; cnt = cnt.parent {
+		maxScroll := u.maxScroll(cnt)
+		if cnt.opt&OptNoScroll == 0 && (horizontal && maxScroll.X > 0 || !horizontal && maxScroll.Y > 0) {
+			return cnt
+		}
+	}
+	return u.scrollTarget
+}
+
+// applyScroll scrolls cnt by the wheel or touch delta. With
+// Config.SmoothScroll the wheel eases there over the next frames, while
+// touch drags still follow the fingers.
+func (u *UI) applyScroll(cnt *Container, delta types.Vec2) {
+	if u.smoothScroll && u.input.TouchPan == (types.Vec2{}) {
+		if !cnt.scrollAnim {
+			cnt.scrollGoal = cnt.scroll
+		}
+		cnt.scrollGoal = u.clampScroll(cnt, cnt.scrollGoal.Add(delta))
+		cnt.scrollAnim = true
+		return
+	}
+	cnt.scroll = u.clampScroll(cnt, cnt.scroll.Add(delta))
+	cnt.scrollAnim = false
+}
+
+// animateScroll moves an animated scroll a step towards its goal. Called
+// once per frame as the container begins.
+func (u *UI) animateScroll(cnt *Container) {
+	if !cnt.scrollAnim {
+		return
+	}
+	goal := u.clampScroll(cnt, cnt.scrollGoal)
+	step := func(from, to int) int {
+		d := int(math.Round(float64(to-from) * u.frameFraction(scrollSpeed)))
+		if d == 0 && from != to {
+			d = max(-1, min(to-from, 1))
+		}
+		return from + d
+	}
+	cnt.scroll = types.Vec2{X: step(cnt.scroll.X, goal.X), Y: step(cnt.scroll.Y, goal.Y)}
+	cnt.scrollAnim = cnt.scroll != goal
+}
+
+// scrollbarAlpha returns the opacity of cnt's auto-hiding scrollbars,
+// shown whether any are, and hot whether one is under the mouse or being
+// dragged. They appear while the container scrolls or a bar is hot and
+// fade out once it has been idle for scrollbarLinger.
+func (u *UI) scrollbarAlpha(cnt *Container, shown, hot bool) float64 {
+	cnt.barHot, cnt.barAlpha = hot, 0
+	if !shown {
+		return 0
+	}
+	if !u.style.ScrollbarAutoHide {
+		cnt.barAlpha = 1
+		return 1
+	}
+	if hot || cnt.scroll != cnt.barScroll {
+		cnt.barShown = u.frameTime
+	}
+	cnt.barScroll = cnt.scroll
+	target := 0.0
+	if !cnt.barShown.IsZero() && u.frameTime.Sub(cnt.barShown) < scrollbarLinger {
+		target = 1
+	}
+	cnt.barAlpha = target
+	if u.animations {
+		cnt.barAlpha = u.animate(animKey{id: cnt.id, slot: animScrollbar}, target, animSpeed)
+	}
+	return cnt.barAlpha
+}
+
+// endScroll measures a container's content at its end, applies
+// OptAutoScroll and ScrollToBottom, and clamps the scroll offset.
+func (u *UI) endScroll(cnt *Container) {
+	// With OptAutoScroll, a container showing its last line keeps showing
+	// it as content is appended
+	atBottom := cnt.scroll.Y >= u.maxScroll(cnt).Y
+
+	layout := u.getLayout()
+	grew := layout.max.Y-layout.body.Y > cnt.contentSize.Y
+	cnt.contentSize.X = layout.max.X - layout.body.X
+	cnt.contentSize.Y = layout.max.Y - layout.body.Y
+	cnt.measured = true
+	// Content reaching the body's edge with an item sized relative to it
+	// fills the body, and follows it when the body is resized
+	cnt.fills = [2]bool{
+		layout.fillW && cnt.contentSize.X == layout.body.W,
+		layout.fillH && cnt.contentSize.Y == layout.body.H,
+	}
+
+	maxScroll := u.maxScroll(cnt)
+	if cnt.toBottom || (cnt.opt&OptAutoScroll != 0 && atBottom && grew) {
+		cnt.scroll.Y = maxScroll.Y
+		cnt.toBottom = false
+		cnt.scrollAnim = false
+	}
+	cnt.scroll.X = min(cnt.scroll.X, maxScroll.X)
+	cnt.scroll.Y = min(cnt.scroll.Y, maxScroll.Y)
+}
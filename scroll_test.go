@@ -1,6 +1,11 @@
 package microui
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
 
 func TestScroll_Accumulates(t *testing.T) {
 	ui := New(Config{})
@@ -29,3 +34,304 @@ func TestScroll_Accumulates(t *testing.T) {
 
 	ui.EndFrame()
 }
+
+// logFrame draws a window with a 100px tall panel of n lines.
+func logFrame(ui *UI, n, opt int) {
+	ui.BeginFrame()
+	if ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+		ui.LayoutRow(1, []int{-1}, 100)
+		ui.BeginPanelOpt("log", opt)
+		ui.LayoutRow(1, []int{-1}, 0)
+		for i := 0; i < n; i++ {
+			ui.Label(fmt.Sprintf("line %d", i))
+		}
+		ui.EndPanel()
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+func TestScrollTo(t *testing.T) {
+	ui := New(Config{})
+	logFrame(ui, 20, 0)
+	cnt := ui.GetContainer("log")
+
+	ui.ScrollTo("log", types.Vec2{Y: 50})
+	logFrame(ui, 20, 0)
+	if cnt.Scroll().Y != 50 {
+		t.Errorf("scroll = %d, want 50", cnt.Scroll().Y)
+	}
+
+	// Out of range offsets are clamped
+	ui.ScrollTo("log", types.Vec2{X: -5, Y: 100000})
+	if want := ui.maxScroll(cnt).Y; cnt.Scroll().Y != want || cnt.Scroll().X != 0 {
+		t.Errorf("scroll = %v, want (0,%d)", cnt.Scroll(), want)
+	}
+}
+
+func TestScrollToBottom(t *testing.T) {
+	ui := New(Config{})
+	logFrame(ui, 20, 0)
+	cnt := ui.GetContainer("log")
+
+	// Requested before lines are appended: the new lines are included
+	ui.ScrollToBottom("log")
+	logFrame(ui, 30, 0)
+	bottom := ui.maxScroll(cnt).Y
+	if bottom == 0 || cnt.Scroll().Y != bottom {
+		t.Errorf("scroll = %d, want bottom %d", cnt.Scroll().Y, bottom)
+	}
+
+	// It only applies once
+	logFrame(ui, 40, 0)
+	if cnt.Scroll().Y != bottom {
+		t.Errorf("scroll = %d, want it to stay at %d", cnt.Scroll().Y, bottom)
+	}
+}
+
+func TestAutoScroll(t *testing.T) {
+	ui := New(Config{})
+	n := 3
+	for ; n < 20; n++ {
+		logFrame(ui, n, OptAutoScroll)
+	}
+	cnt := ui.GetContainer("log")
+	if bottom := ui.maxScroll(cnt).Y; bottom == 0 || cnt.Scroll().Y != bottom {
+		t.Fatalf("scroll = %d, want to follow the last line at %d", cnt.Scroll().Y, bottom)
+	}
+
+	// Scrolling up stops following until the bottom is reached again
+	ui.ScrollTo("log", types.Vec2{Y: 10})
+	logFrame(ui, n+1, OptAutoScroll)
+	if cnt.Scroll().Y != 10 {
+		t.Errorf("scroll = %d, want 10 after scrolling up", cnt.Scroll().Y)
+	}
+	ui.ScrollToBottom("log")
+	logFrame(ui, n+2, OptAutoScroll)
+	logFrame(ui, n+3, OptAutoScroll)
+	if bottom := ui.maxScroll(cnt).Y; cnt.Scroll().Y != bottom {
+		t.Errorf("scroll = %d, want to follow again at %d", cnt.Scroll().Y, bottom)
+	}
+}
+
+func TestSmoothScroll_WheelEases(t *testing.T) {
+	ui := New(Config{SmoothScroll: true})
+	ui.MouseMove(50, 60) // Over the panel
+	logFrame(ui, 20, 0)
+	logFrame(ui, 20, 0)
+	cnt := ui.GetContainer("log")
+
+	ui.Scroll(0, 60)
+	logFrame(ui, 20, 0)
+	logFrame(ui, 20, 0)
+	if cnt.scroll.Y <= 0 || cnt.scroll.Y >= 60 {
+		t.Fatalf("scroll = %d one frame after the wheel, want between 0 and 60", cnt.scroll.Y)
+	}
+	// A second notch while animating adds to the goal
+	ui.Scroll(0, 60)
+	for i := 0; i < 30; i++ {
+		logFrame(ui, 20, 0)
+	}
+	if cnt.scroll.Y != 120 || cnt.scrollAnim {
+		t.Errorf("scroll = %d (animating %v), want 120 once settled", cnt.scroll.Y, cnt.scrollAnim)
+	}
+}
+
+func TestSetScrollAnimated(t *testing.T) {
+	ui := New(Config{})
+	logFrame(ui, 20, 0)
+	cnt := ui.GetContainer("log")
+
+	cnt.SetScrollAnimated(types.Vec2{Y: 1000}) // Clamped to the content
+	logFrame(ui, 20, 0)
+	if cnt.scroll.Y <= 0 || cnt.scroll.Y >= ui.maxScroll(cnt).Y {
+		t.Fatalf("scroll = %d after one frame, want part of the way", cnt.scroll.Y)
+	}
+	for i := 0; i < 30; i++ {
+		logFrame(ui, 20, 0)
+	}
+	if want := ui.maxScroll(cnt).Y; cnt.scroll.Y != want {
+		t.Errorf("scroll = %d, want %d at the end", cnt.scroll.Y, want)
+	}
+
+	// Scrolling from code stops the animation
+	cnt.SetScrollAnimated(types.Vec2{})
+	logFrame(ui, 20, 0)
+	ui.ScrollTo("log", types.Vec2{Y: 50})
+	logFrame(ui, 20, 0)
+	if cnt.scroll.Y != 50 {
+		t.Errorf("scroll = %d, want 50 after ScrollTo", cnt.scroll.Y)
+	}
+}
+
+func TestSmoothScroll_TouchFling(t *testing.T) {
+	ui := New(Config{SmoothScroll: true})
+	logFrame(ui, 20, 0)
+	cnt := ui.GetContainer("log")
+
+	ui.TouchBegin(1, 100, 80)
+	ui.TouchBegin(2, 140, 80)
+	logFrame(ui, 20, 0)
+	for y := 60; y >= 40; y -= 20 {
+		ui.TouchMove(1, 100, y)
+		ui.TouchMove(2, 140, y)
+		logFrame(ui, 20, 0)
+	}
+	if cnt.scroll.Y != 40 {
+		t.Fatalf("scroll = %d while dragging, want 40", cnt.scroll.Y)
+	}
+	ui.TouchEnd(1, 100, 40)
+	ui.TouchEnd(2, 140, 40)
+	for i := 0; i < 100; i++ {
+		logFrame(ui, 20, 0)
+	}
+	coasted := cnt.scroll.Y
+	if coasted <= 60 || coasted >= ui.maxScroll(cnt).Y {
+		t.Fatalf("scroll = %d after lifting, want it to coast on and stop", coasted)
+	}
+	logFrame(ui, 20, 0)
+	if cnt.scroll.Y != coasted {
+		t.Error("the fling should have stopped")
+	}
+}
+
+// nestedFrame draws a 150px tall panel holding a 60px tall panel at its top
+// and enough lines below it to overflow. The inner panel overflows
+// vertically, or with wide set only horizontally.
+func nestedFrame(ui *UI, wide bool) {
+	ui.BeginFrame()
+	if ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+		ui.LayoutRow(1, []int{-1}, 150)
+		ui.BeginPanel("outer")
+		ui.LayoutRow(1, []int{-1}, 60)
+		ui.BeginPanel("inner")
+		if wide {
+			ui.LayoutRow(1, []int{600}, 0)
+			ui.Label("a very wide line")
+		} else {
+			for i := 0; i < 10; i++ {
+				ui.Label(fmt.Sprintf("inner %d", i))
+			}
+		}
+		ui.EndPanel()
+		for i := 0; i < 10; i++ {
+			ui.Label(fmt.Sprintf("outer %d", i))
+		}
+		ui.EndPanel()
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+func TestScroll_InnermostPanelWins(t *testing.T) {
+	ui := New(Config{})
+	ui.MouseMove(50, 50) // Over the inner panel
+	nestedFrame(ui, false)
+	nestedFrame(ui, false)
+
+	ui.Scroll(0, 30)
+	nestedFrame(ui, false)
+	if got := ui.GetContainer("inner").scroll.Y; got != 30 {
+		t.Errorf("inner scroll = %d, want 30", got)
+	}
+	if got := ui.GetContainer("outer").scroll.Y; got != 0 {
+		t.Errorf("outer scroll = %d, want 0", got)
+	}
+}
+
+func TestScroll_RoutesByAxis(t *testing.T) {
+	ui := New(Config{})
+	ui.MouseMove(50, 50)
+	nestedFrame(ui, true)
+	nestedFrame(ui, true)
+	inner, outer := ui.GetContainer("inner"), ui.GetContainer("outer")
+
+	// The inner panel can't scroll vertically, so the outer one does
+	ui.Scroll(0, 30)
+	nestedFrame(ui, true)
+	if inner.scroll.Y != 0 || outer.scroll.Y != 30 {
+		t.Errorf("scroll inner=%d outer=%d, want the outer panel scrolled by 30", inner.scroll.Y, outer.scroll.Y)
+	}
+	ui.Scroll(0, -30)
+	nestedFrame(ui, true)
+
+	// Shift+wheel scrolls the inner panel sideways
+	ui.KeyDown(KeyShift)
+	ui.Scroll(0, 40)
+	nestedFrame(ui, true)
+	ui.KeyUp(KeyShift)
+	if inner.scroll.X != 40 || outer.scroll.Y != 0 {
+		t.Errorf("inner scroll X = %d, outer Y = %d, want 40 and 0 after Shift+wheel", inner.scroll.X, outer.scroll.Y)
+	}
+}
+
+// buttonsFrame draws n buttons in a 100px tall panel and returns the rect
+// and ID of button reveal, after calling EnsureVisible on it if ensure is
+// set.
+func buttonsFrame(ui *UI, n, reveal int, ensure bool) (r types.Rect, id ID) {
+	ui.BeginFrame()
+	if ui.BeginWindow("W", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+		ui.LayoutRow(1, []int{-1}, 100)
+		ui.BeginPanel("list")
+		ui.LayoutRow(1, []int{-1}, 0)
+		for i := 0; i < n; i++ {
+			ui.Button(fmt.Sprintf("button %d", i))
+			if i == reveal {
+				r, id = ui.GetItemRect(), ui.GetItemID()
+				if ensure {
+					ui.EnsureVisible(r)
+				}
+			}
+		}
+		ui.EndPanel()
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+	return r, id
+}
+
+func TestEnsureVisible(t *testing.T) {
+	ui := New(Config{})
+	buttonsFrame(ui, 20, -1, false)
+	body := ui.GetContainer("list").Body()
+
+	buttonsFrame(ui, 20, 15, true)
+	r, _ := buttonsFrame(ui, 20, 15, false)
+	if r.Y < body.Y || r.Y+r.H > body.Y+body.H {
+		t.Fatalf("button at %v after EnsureVisible, want inside %v", r, body)
+	}
+	if r.Y+r.H != body.Y+body.H {
+		t.Errorf("button at %v, want it scrolled just into view at the bottom of %v", r, body)
+	}
+
+	// Scrolling back up aligns the top
+	buttonsFrame(ui, 20, 2, true)
+	if r, _ := buttonsFrame(ui, 20, 2, false); r.Y != body.Y {
+		t.Errorf("button at %v, want at the top of %v", r, body)
+	}
+
+	// Nothing moves for a control already in view
+	scroll := ui.GetContainer("list").Scroll()
+	buttonsFrame(ui, 20, 3, true)
+	if got := ui.GetContainer("list").Scroll(); got != scroll {
+		t.Errorf("scroll = %v, want %v unchanged", got, scroll)
+	}
+}
+
+func TestEnsureVisible_FocusNav(t *testing.T) {
+	ui := New(Config{})
+	buttonsFrame(ui, 20, -1, false)
+	body := ui.GetContainer("list").Body()
+	for i := 0; i <= 10; i++ {
+		pressKey(ui, KeyTab)
+		buttonsFrame(ui, 20, -1, false)
+	}
+	buttonsFrame(ui, 20, -1, false) // Scrolls to the newly focused button
+	r, id := buttonsFrame(ui, 20, 10, false)
+	if ui.KeyboardFocus() != id {
+		t.Fatal("eleven Tabs should focus button 10")
+	}
+	if r.Y < body.Y || r.Y+r.H > body.Y+body.H {
+		t.Errorf("tabbed-to button at %v, want it scrolled into %v", r, body)
+	}
+}
@@ -2,6 +2,7 @@ package microui
 
 import (
 	"testing"
+	"time"
 
 	"github.com/user/microui-go/types"
 )
@@ -440,21 +441,10 @@ func TestTUIScrollbar_AppearsWhenWindowShrinks(t *testing.T) {
 	}
 	ui.EndFrame()
 
-	// Frame 3: C microui uses previous frame's body, so scrollbar appears one frame after resize
-	ui.BeginFrame()
-	if ui.BeginWindowOpt("Test", types.Rect{X: 0, Y: 0, W: windowW, H: smallWindowH}, OptNoResize) {
-		for i := 0; i < contentRows; i++ {
-			ui.LayoutRow(1, []int{-1}, 1)
-			ui.Label("X")
-		}
-		ui.EndWindow()
-	}
-	ui.EndFrame()
-
-	// Scrollbar should now be present (after frame stabilization)
+	// Scrollbar should be present in the frame the window shrank
 	expectedBodyW := bodyW - style.ScrollbarSize
 	if cnt.Body().W != expectedBodyW {
-		t.Errorf("Frame 3 (after stabilization): Body.W = %d, want %d (scrollbar should appear)",
+		t.Errorf("Frame 2: Body.W = %d, want %d (scrollbar should appear)",
 			cnt.Body().W, expectedBodyW)
 	}
 }
@@ -797,8 +787,6 @@ func TestTUIScrollbar_BottomContentVisible(t *testing.T) {
 // TestTUIScrollbar_MutualDependencyCorrect tests that horizontal scrollbar
 // correctly appears only when content doesn't fit even after accounting for
 // vertical scrollbar space.
-// Note: C microui uses previous frame's body for scrollbar decisions, so mutual
-// dependency takes multiple frames to stabilize.
 func TestTUIScrollbar_MutualDependencyCorrect(t *testing.T) {
 	style := TUIStyle()
 	ui := New(Config{Style: style})
@@ -828,9 +816,8 @@ func TestTUIScrollbar_MutualDependencyCorrect(t *testing.T) {
 		ui.EndFrame()
 	}
 
-	// Run multiple frames for scrollbar state to stabilize
-	// C microui uses previous frame's body, so mutual dependency takes 3+ frames
-	for i := 0; i < 4; i++ {
+	// Content is measured in the first frame and both bars resolve in the second
+	for i := 0; i < 2; i++ {
 		drawFrame()
 	}
 
@@ -971,3 +958,228 @@ func TestTUIScrollbar_BoxTestScenario(t *testing.T) {
 			contentBottomY, clipEndY)
 	}
 }
+
+// scrollbarApp draws a 200x100 window with 20 rows of content, so the
+// vertical scrollbar pages and drags, at frame times it advances itself.
+type scrollbarApp struct {
+	ui  *UI
+	now time.Time
+}
+
+func newScrollbarApp(style Style) *scrollbarApp {
+	return &scrollbarApp{ui: New(Config{Style: style}), now: time.Unix(1000, 0)}
+}
+
+func (p *scrollbarApp) frame() {
+	p.now = p.now.Add(20 * time.Millisecond)
+	ui := p.ui
+	ui.BeginFrameAt(p.now)
+	if ui.BeginWindow("Scroll", types.Rect{X: 0, Y: 0, W: 200, H: 100}) {
+		for i := 0; i < 20; i++ {
+			ui.LayoutRow(1, []int{-1}, 0)
+			ui.Label("Content")
+		}
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+// drawn returns the rect of the first command of kind drawn in the last
+// frame, and for icons the first icon of that kind.
+func (p *scrollbarApp) drawn(kind CommandKind, icon int) types.Rect {
+	for _, rc := range p.ui.RecordFrame().Commands {
+		if rc.Kind == kind && (kind != CmdIcon || rc.Icon == icon) {
+			return rc.Rect
+		}
+	}
+	return types.Rect{}
+}
+
+// thumb returns the vertical scrollbar's thumb, the first one drawn.
+func (p *scrollbarApp) thumb() types.Rect {
+	return p.drawn(CmdScrollThumb, 0)
+}
+
+func (p *scrollbarApp) press(x, y int) {
+	p.ui.MouseMove(x, y)
+	p.frame()
+	p.ui.MouseDown(x, y, MouseLeft)
+	p.frame()
+}
+
+func (p *scrollbarApp) scroll() int {
+	return p.ui.GetContainer("Scroll").Scroll().Y
+}
+
+func TestScrollbar_TrackClickPages(t *testing.T) {
+	p := newScrollbarApp(GUIStyle())
+	p.frame()
+	p.frame()
+	thumb := p.thumb()
+	body := p.ui.GetContainer("Scroll").Body()
+
+	p.press(thumb.X+thumb.W/2, thumb.Y+thumb.H+5) // Just below the thumb
+	if got := p.scroll(); got != body.H {
+		t.Fatalf("scroll = %d after clicking the track, want a page of %d", got, body.H)
+	}
+	p.ui.MouseUp(thumb.X, thumb.Y, MouseLeft)
+	p.frame()
+
+	// Held near the bottom, it keeps paging until the thumb gets there
+	bottom := body.Y + body.H - 2*thumb.W
+	p.press(thumb.X+thumb.W/2, bottom)
+	for range 30 {
+		p.frame()
+	}
+	if th := p.thumb(); bottom < th.Y || bottom >= th.Y+th.H {
+		t.Errorf("thumb at %v after holding the track at y=%d, want it under the mouse", th, bottom)
+	}
+}
+
+func TestScrollbar_ThumbDragKeepsGrabOffset(t *testing.T) {
+	p := newScrollbarApp(GUIStyle())
+	p.frame()
+	p.frame()
+	thumb := p.thumb()
+	x, y := thumb.X+thumb.W/2, thumb.Y+thumb.H-1 // Near the thumb's bottom end
+	p.press(x, y)
+	if got := p.scroll(); got != 0 {
+		t.Fatalf("scroll = %d after pressing the thumb, want it unchanged", got)
+	}
+
+	p.ui.MouseMove(x, y+20)
+	p.frame()
+	p.frame()
+	if got := p.thumb(); got.Y != thumb.Y+20 {
+		t.Errorf("thumb at y=%d after dragging it 20 down from y=%d, want %d", got.Y, thumb.Y, thumb.Y+20)
+	}
+}
+
+func TestScrollbar_Arrows(t *testing.T) {
+	style := GUIStyle()
+	style.ScrollbarArrows = true
+	p := newScrollbarApp(style)
+	p.frame()
+	p.frame()
+	up, down := p.drawn(CmdIcon, IconArrowUp), p.drawn(CmdIcon, IconArrowDown)
+	if resize := p.drawn(CmdIcon, IconResize); intersectRect(down, resize) != (types.Rect{}) {
+		t.Fatalf("down arrow %v under the resize gripper %v", down, resize)
+	}
+
+	p.press(down.X+down.W/2, down.Y+down.H/2)
+	if got := p.scroll(); got != style.Size.Y {
+		t.Fatalf("scroll = %d after clicking the down arrow, want a line of %d", got, style.Size.Y)
+	}
+	for range 30 { // 600ms: past the repeat delay
+		p.frame()
+	}
+	if got := p.scroll(); got <= 2*style.Size.Y {
+		t.Errorf("scroll = %d after holding the down arrow, want it to repeat", got)
+	}
+	if th := p.thumb(); th.Y < up.Y+up.H || th.Y+th.H > down.Y {
+		t.Errorf("thumb %v overlaps the arrow buttons", th)
+	}
+}
+
+func TestScrollbar_OverlayKeepsBodyWidth(t *testing.T) {
+	style := GUIStyle()
+	style.ScrollbarOverlay = true
+	p := newScrollbarApp(style)
+	p.frame()
+	p.frame()
+	cnt := p.ui.GetContainer("Scroll")
+	if got, want := cnt.Body().W, cnt.Rect().W; got != want {
+		t.Errorf("body width = %d with an overlay scrollbar, want the window's %d", got, want)
+	}
+
+	// Drawn flat over the content, last in the window
+	cmds := p.ui.RecordFrame().Commands
+	var thumb types.Rect
+	for i := len(cmds) - 1; i >= 0; i-- {
+		if cmds[i].Kind == CmdRect {
+			thumb = cmds[i].Rect
+			break
+		}
+	}
+	body := cnt.Body()
+	if thumb.X+thumb.W != body.X+body.W || thumb.Y != body.Y {
+		t.Fatalf("last rect drawn %v, want the thumb at the top of the body's right edge %v", thumb, body)
+	}
+
+	x, y := thumb.X+thumb.W/2, thumb.Y+2
+	p.press(x, y)
+	p.ui.MouseMove(x, y+20)
+	p.frame()
+	if p.scroll() == 0 {
+		t.Error("dragging the overlay thumb didn't scroll")
+	}
+}
+
+func TestScrollbar_AutoHide(t *testing.T) {
+	style := GUIStyle()
+	style.ScrollbarOverlay = true
+	style.ScrollbarAutoHide = true
+	p := newScrollbarApp(style)
+	shown := func() bool {
+		for _, rc := range p.ui.RecordFrame().Commands {
+			if rc.Kind == CmdRect && rc.Rect.W == style.ScrollbarSize {
+				return true
+			}
+		}
+		return false
+	}
+	p.frame()
+	p.frame()
+	if shown() {
+		t.Error("scrollbar shown before the window scrolled")
+	}
+
+	p.ui.MouseMove(50, 50)
+	p.ui.Scroll(0, 30)
+	p.frame() // Scrolls at the end of the frame
+	p.frame()
+	if !shown() {
+		t.Fatal("scrollbar hidden while the window scrolls")
+	}
+	if !p.ui.NeedsRedraw() {
+		t.Error("NeedsRedraw = false while the scrollbar waits to hide")
+	}
+	for range 40 { // 800ms
+		p.frame()
+	}
+	if !shown() {
+		t.Error("scrollbar hidden before it was idle for a second")
+	}
+	for range 20 {
+		p.frame()
+	}
+	if shown() {
+		t.Error("scrollbar still shown after a second idle")
+	}
+	if p.ui.NeedsRedraw() {
+		t.Error("NeedsRedraw = true with the scrollbar hidden")
+	}
+}
+
+func TestScrollbar_ResizeResolvesInOneFrame(t *testing.T) {
+	p := newScrollbarApp(GUIStyle())
+	p.frame()
+	cnt := p.ui.GetContainer("Scroll")
+	cnt.SetRect(types.Rect{W: 200, H: 600})
+	p.frame()
+	full := cnt.Body()
+
+	// Content filling the width gets a vertical bar, and no horizontal
+	// one, in the frame the window shrinks
+	cnt.SetRect(types.Rect{W: 200, H: 100})
+	p.frame()
+	if got, want := cnt.Body(), (types.Rect{X: full.X, Y: full.Y, W: full.W - 12, H: 100 - full.Y}); got != want {
+		t.Errorf("body = %v in the frame the window shrank, want %v", got, want)
+	}
+
+	cnt.SetRect(types.Rect{W: 200, H: 600})
+	p.frame()
+	if got := cnt.Body(); got != full {
+		t.Errorf("body = %v in the frame the window grew back, want %v", got, full)
+	}
+}
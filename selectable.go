@@ -0,0 +1,37 @@
+package microui
+
+// Selectable adds a label that highlights while hovered or when selected,
+// for lists and inspectors built from plain rows. Returns true
+// when it is clicked (or activated with the keyboard); the caller owns the
+// selected state. opt accepts the text alignment options.
+func (u *UI) Selectable(label string, selected bool, opt int) bool {
+	id := u.getID(label)
+	rect := u.LayoutNext()
+	u.UpdateControlOpt(id, rect, opt)
+	clicked := u.itemClicked(u.activated(id))
+
+	if selected {
+		u.DrawFrame(rect, ColorButtonFocus)
+	} else if u.input.Hover == id {
+		u.DrawFrame(rect, ColorButtonHover)
+	}
+	u.DrawControlText(label, rect, ColorText, u.cellAlign(opt))
+	var state AccessState
+	if selected {
+		state = AccessSelected
+	}
+	u.accessControl(id, rect, RoleOption, label, "", state)
+	return clicked
+}
+
+// SelectableText shows a single line of text like a label, but it can be
+// selected with the mouse or Shift+arrows and copied with Ctrl+C through
+// the clipboard. The selection belongs to the text, so changing it resets
+// the selection.
+func (u *UI) SelectableText(text string) {
+	buf := []byte(text)
+	rect := u.LayoutNext()
+	id := u.getID(text)
+	u.textboxRaw(&buf, len(buf), id, rect, OptReadOnly|OptNoFrame, nil)
+	u.accessControl(id, rect, RoleLabel, text, "", AccessReadOnly)
+}
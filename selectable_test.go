@@ -0,0 +1,99 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// selectableFrame draws three selectable rows (row 1 selected) and returns
+// the index of the row clicked this frame, or -1.
+func selectableFrame(ui *UI) int {
+	clicked := -1
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{-1}, 0)
+	for i, label := range []string{"zero", "one", "two"} {
+		if ui.Selectable(label, i == 1, 0) {
+			clicked = i
+		}
+	}
+	ui.EndWindow()
+	ui.EndFrame()
+	return clicked
+}
+
+func TestSelectable_Click(t *testing.T) {
+	ui := New(Config{})
+	ui.MouseMove(50, 35) // Row 0
+	selectableFrame(ui)
+	ui.MouseDown(50, 35, MouseLeft)
+	if got := selectableFrame(ui); got != 0 {
+		t.Errorf("clicked row = %d, want 0", got)
+	}
+	ui.MouseUp(50, 35, MouseLeft)
+	if got := selectableFrame(ui); got != -1 {
+		t.Errorf("clicked row after release = %d, want none", got)
+	}
+}
+
+func TestSelectable_Highlight(t *testing.T) {
+	ui := New(Config{})
+	ui.MouseMove(50, 83) // Row 2
+	selectableFrame(ui)
+	selectableFrame(ui)
+
+	// Row 1 is selected and row 2 hovered; row 0 has no frame
+	var rows []int
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdRect && cmd.Rect.H == 20 {
+			rows = append(rows, cmd.Rect.Y)
+		}
+	})
+	if len(rows) != 2 || rows[0] != 53 || rows[1] != 77 {
+		t.Errorf("highlighted rows at y=%v, want [53 77]", rows)
+	}
+}
+
+func selectableTextFrame(ui *UI, input string) {
+	ui.BeginFrame()
+	if input != "" {
+		ui.TextInput(input)
+	}
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{-1}, 0)
+	ui.SelectableText("hello world")
+	ui.EndWindow()
+	ui.EndFrame()
+}
+
+func TestSelectableText_Copy(t *testing.T) {
+	ui := New(Config{})
+
+	// Drag from the start of the text past its end
+	ui.MouseMove(10, 39)
+	selectableTextFrame(ui, "")
+	ui.MouseDown(10, 39, MouseLeft)
+	selectableTextFrame(ui, "")
+	ui.MouseMove(300, 39)
+	selectableTextFrame(ui, "")
+	ui.MouseUp(300, 39, MouseLeft)
+	selectableTextFrame(ui, "")
+
+	ui.KeyDown(KeyCtrl)
+	pressKey(ui, KeyC)
+	selectableTextFrame(ui, "")
+	ui.KeyUp(KeyCtrl)
+	if got := ui.Clipboard().Get(); got != "hello world" {
+		t.Errorf("clipboard = %q, want %q", got, "hello world")
+	}
+
+	// Typing and deleting don't edit the text
+	pressKey(ui, KeyBackspace)
+	selectableTextFrame(ui, "x")
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdText && cmd.Text != "Test" && cmd.Text != "hello world" {
+			t.Errorf("text changed to %q", cmd.Text)
+		}
+	})
+}
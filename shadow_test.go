@@ -0,0 +1,76 @@
+package microui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// shadowRenderer records the shadows drawn and the rects around them.
+type shadowRenderer struct {
+	recordRenderer
+	shadows []types.Rect
+	offset  types.Vec2
+	color   color.Color
+	order   []string
+}
+
+func (r *shadowRenderer) DrawRect(pos, size types.Vec2, c color.Color) {
+	r.order = append(r.order, "rect")
+}
+
+func (r *shadowRenderer) DrawWindowShadow(rect types.Rect, offset types.Vec2, c color.Color) {
+	r.shadows = append(r.shadows, rect)
+	r.offset, r.color = offset, c
+	r.order = append(r.order, "shadow")
+}
+
+func TestWindowShadow(t *testing.T) {
+	style := GUIStyle()
+	style.WindowShadow = Shadow{Offset: types.Vec2{X: 4, Y: 6}}
+	ui := New(Config{Style: style})
+	frame := func() *shadowRenderer {
+		ui.BeginFrame()
+		if ui.BeginWindow("A", types.Rect{X: 10, Y: 10, W: 100, H: 80}) {
+			ui.EndWindow()
+		}
+		if ui.BeginWindowOpt("HUD", types.Rect{X: 200, Y: 10, W: 50, H: 50}, OptNoFrame) {
+			ui.EndWindow()
+		}
+		ui.EndFrame()
+		r := &shadowRenderer{}
+		ui.Render(r)
+		return r
+	}
+
+	r := frame()
+	if len(r.shadows) != 1 || r.shadows[0] != (types.Rect{X: 10, Y: 10, W: 100, H: 80}) {
+		t.Fatalf("shadows = %v, want one for window A and none for the frameless HUD", r.shadows)
+	}
+	if r.offset != style.WindowShadow.Offset || r.color != defaultShadowColor {
+		t.Errorf("shadow offset %v color %v, want the style offset and default color", r.offset, r.color)
+	}
+	if r.order[0] != "shadow" {
+		t.Errorf("draw order %v, want the shadow before the window's frame", r.order)
+	}
+
+	ui.SetScale(2)
+	if r := frame(); r.offset != (types.Vec2{X: 8, Y: 12}) {
+		t.Errorf("scaled offset = %v, want doubled", r.offset)
+	}
+}
+
+func TestWindowShadow_Off(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	if ui.BeginWindow("A", types.Rect{X: 10, Y: 10, W: 100, H: 80}) {
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdShadow {
+			t.Error("no shadow should be drawn without Style.WindowShadow")
+		}
+	})
+}
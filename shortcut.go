@@ -0,0 +1,225 @@
+package microui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrShortcutConflict is returned, wrapped, when keys are registered twice
+// in the same scope.
+var ErrShortcutConflict = errors.New("microui: shortcut already registered")
+
+// Shortcut is a key combination bound to an action with RegisterShortcut
+// or RegisterWindowShortcut.
+type Shortcut struct {
+	Keys   string // The keys as shown in menus, e.g. "Ctrl+Shift+S"
+	Window string // Window the shortcut belongs to; "" for a global one
+
+	// Label is the MenuItem that shows Keys beside its label.
+	Label string
+
+	// InTextbox lets the shortcut fire while a textbox has focus.
+	// Registration turns it on for keys with Ctrl, Alt or Meta, which
+	// don't type text, and off for the others, so that "delete" or "f"
+	// reach the textbox instead. A shortcut that fires takes the key
+	// press, so a focused textbox doesn't act on it too.
+	InTextbox bool
+
+	// Disabled stops the shortcut firing until it is cleared.
+	Disabled bool
+
+	mods   KeyMod
+	key    Key
+	action func()
+}
+
+// shortcutMods are the modifier names a shortcut can use.
+var shortcutMods = map[string]KeyMod{
+	"shift": ModShift, "ctrl": ModCtrl, "control": ModCtrl, "alt": ModAlt, "option": ModAlt,
+	"meta": ModMeta, "cmd": ModMeta, "command": ModMeta, "super": ModMeta,
+}
+
+// shortcutAliases are other names for keys, besides their names in
+// keyNames.
+var shortcutAliases = map[string]Key{
+	"esc": KeyEscape, "return": KeyEnter, "del": KeyDelete, "pgup": KeyPageUp, "pgdn": KeyPageDown,
+}
+
+// parseShortcut parses keys such as "ctrl+shift+s": any modifiers, then a
+// letter or named key, joined by "+" and in any case.
+func parseShortcut(keys string) (KeyMod, Key, error) {
+	var mods KeyMod
+	key := Key(-1)
+	for part := range strings.SplitSeq(keys, "+") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if m, ok := shortcutMods[name]; ok {
+			mods |= m
+			continue
+		}
+		k, ok := shortcutKey(name)
+		if !ok || key >= 0 {
+			return 0, 0, fmt.Errorf("microui: invalid shortcut %q, want modifiers and one key, e.g. \"ctrl+s\"", keys)
+		}
+		key = k
+	}
+	if key < 0 {
+		return 0, 0, fmt.Errorf("microui: invalid shortcut %q: no key", keys)
+	}
+	return mods, key, nil
+}
+
+// shortcutKey returns the key named name in a shortcut.
+func shortcutKey(name string) (Key, bool) {
+	if len(name) == 1 && name[0] >= 'a' && name[0] <= 'z' {
+		return KeyA + Key(name[0]-'a'), true
+	}
+	if k, ok := shortcutAliases[name]; ok {
+		return k, true
+	}
+	for k, n := range keyNames {
+		if n != "" && strings.ToLower(n) == name && !isModifier(Key(k)) {
+			return Key(k), true
+		}
+	}
+	return 0, false
+}
+
+// shortcutText returns the keys as shown in menus, e.g. "Ctrl+Shift+S".
+func shortcutText(mods KeyMod, key Key) string {
+	var b strings.Builder
+	for _, m := range [...]struct {
+		mod  KeyMod
+		name string
+	}{{ModCtrl, "Ctrl"}, {ModAlt, "Alt"}, {ModShift, "Shift"}, {ModMeta, "Meta"}} {
+		if mods&m.mod != 0 {
+			b.WriteString(m.name + "+")
+		}
+	}
+	b.WriteString(keyName(key))
+	return b.String()
+}
+
+// RegisterShortcut binds keys, e.g. "ctrl+s" or "ctrl+shift+z", to action
+// for the whole UI. Keys are modifiers (ctrl, shift, alt, meta or cmd)
+// and one letter or named key (enter, escape, delete, tab, space, up,
+// pageup, home, ...), in any case. Ctrl also matches Meta (Cmd on macOS),
+// as for the built-in editing shortcuts, unless "meta+..." is bound too.
+//
+// action runs in BeginFrame when the keys are pressed, and again as they
+// repeat while held. It returns an error wrapping ErrShortcutConflict if
+// the keys are already bound for the whole UI; a window shortcut with the
+// same keys takes precedence in its window.
+func (u *UI) RegisterShortcut(keys string, action func()) (*Shortcut, error) {
+	return u.RegisterWindowShortcut("", keys, action)
+}
+
+// RegisterWindowShortcut is RegisterShortcut for the window named window
+// only: the shortcut fires while that window is in front of the other
+// windows, so the one last clicked or opened, and takes precedence over a
+// global one with the same keys.
+func (u *UI) RegisterWindowShortcut(window, keys string, action func()) (*Shortcut, error) {
+	mods, key, err := parseShortcut(keys)
+	if err != nil {
+		return nil, err
+	}
+	s := &Shortcut{
+		Keys:      shortcutText(mods, key),
+		Window:    window,
+		InTextbox: mods&(ModCtrl|ModAlt|ModMeta) != 0,
+		mods:      mods,
+		key:       key,
+		action:    action,
+	}
+	for _, other := range u.shortcuts {
+		if other.Window == window && other.mods == mods && other.key == key {
+			scope := "globally"
+			if window != "" {
+				scope = "in window " + window
+			}
+			return nil, fmt.Errorf("%w: %s %s", ErrShortcutConflict, s.Keys, scope)
+		}
+	}
+	u.shortcuts = append(u.shortcuts, s)
+	return s, nil
+}
+
+// UnregisterShortcut removes a shortcut, so its keys can be bound again.
+func (u *UI) UnregisterShortcut(s *Shortcut) {
+	u.shortcuts = slices.DeleteFunc(u.shortcuts, func(other *Shortcut) bool { return other == s })
+}
+
+// Shortcuts returns the registered shortcuts, in the order they were
+// registered.
+func (u *UI) Shortcuts() []*Shortcut {
+	return slices.Clone(u.shortcuts)
+}
+
+// SetShortcutsEnabled turns every shortcut on or off, e.g. while a dialog
+// waits for the keys to bind.
+func (u *UI) SetShortcutsEnabled(enabled bool) {
+	u.shortcutsOff = !enabled
+}
+
+// runShortcuts runs the action of the shortcut pressed this frame, if any.
+// Called from BeginFrame once the input has been processed.
+func (u *UI) runShortcuts() {
+	if u.shortcutsOff || len(u.shortcuts) == 0 {
+		return
+	}
+	typing := u.input.Focus != 0 && u.input.Focus == u.lastTextboxID
+	front := u.frontWindow()
+
+	held := u.input.Mods()
+	tries := []KeyMod{held}
+	if held&ModMeta != 0 && held&ModCtrl == 0 {
+		tries = append(tries, held&^ModMeta|ModCtrl)
+	}
+	for _, mods := range tries {
+		// Window shortcuts first, then global ones
+		for _, global := range [...]bool{false, true} {
+			for _, s := range u.shortcuts {
+				if s.Disabled || s.mods != mods || !u.input.KeyPressed[s.key] || typing && !s.InTextbox {
+					continue
+				}
+				if global && s.Window == "" || !global && s.Window != "" && s.Window == front {
+					if s.action != nil {
+						s.action()
+					}
+					u.input.KeyPressed[s.key] = false
+					return
+				}
+			}
+		}
+	}
+}
+
+// frontWindow returns the name of the window in front of the others at
+// the end of the last frame, or "" if none is open. Popups are skipped,
+// so a window's menus don't turn its shortcuts off.
+func (u *UI) frontWindow() string {
+	var front *Container
+	for _, cnt := range u.zOrder {
+		if !cnt.open || cnt.rootFrame < u.frame-1 || cnt.opt&OptPopup != 0 || passthrough(cnt) || strings.HasPrefix(cnt.name, "!") {
+			continue
+		}
+		if front == nil || u.inFront(cnt, front) {
+			front = cnt
+		}
+	}
+	if front == nil {
+		return ""
+	}
+	return front.name
+}
+
+// shortcutFor returns the keys of the shortcut labelled label, or "".
+func (u *UI) shortcutFor(label string) string {
+	for _, s := range u.shortcuts {
+		if s.Label == label {
+			return s.Keys
+		}
+	}
+	return ""
+}
@@ -0,0 +1,241 @@
+package microui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestParseShortcut(t *testing.T) {
+	tests := []struct {
+		keys, want string
+	}{
+		{"ctrl+s", "Ctrl+S"},
+		{"Shift + Ctrl + Z", "Ctrl+Shift+Z"},
+		{"cmd+alt+p", "Alt+Meta+P"},
+		{"esc", "Escape"},
+		{"pagedown", "PageDown"},
+	}
+	for _, tt := range tests {
+		mods, key, err := parseShortcut(tt.keys)
+		if err != nil {
+			t.Errorf("parseShortcut(%q): %v", tt.keys, err)
+			continue
+		}
+		if got := shortcutText(mods, key); got != tt.want {
+			t.Errorf("parseShortcut(%q) = %s, want %s", tt.keys, got, tt.want)
+		}
+	}
+	for _, keys := range []string{"", "ctrl", "ctrl+", "ctrl+s+t", "ctrl+f13", "shift+ctrl+alt"} {
+		if _, _, err := parseShortcut(keys); err == nil {
+			t.Errorf("parseShortcut(%q) should fail", keys)
+		}
+	}
+}
+
+func TestRegisterShortcut_Conflict(t *testing.T) {
+	ui := New(Config{})
+	s, err := ui.RegisterShortcut("ctrl+s", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.RegisterShortcut("Ctrl+S", nil); !errors.Is(err, ErrShortcutConflict) {
+		t.Errorf("binding ctrl+s twice: err = %v, want ErrShortcutConflict", err)
+	}
+	if _, err := ui.RegisterWindowShortcut("Editor", "ctrl+s", nil); err != nil {
+		t.Errorf("a window shortcut may shadow a global one: %v", err)
+	}
+	ui.UnregisterShortcut(s)
+	if _, err := ui.RegisterShortcut("ctrl+s", nil); err != nil {
+		t.Errorf("binding ctrl+s after unregistering it: %v", err)
+	}
+	if n := len(ui.Shortcuts()); n != 2 {
+		t.Errorf("%d shortcuts, want 2", n)
+	}
+}
+
+// shortcutFrame draws windows with a textbox in the first, bringing front
+// to the front.
+func shortcutFrame(ui *UI, buf *[]byte, front string, windows ...string) {
+	ui.BeginFrame()
+	for i, name := range windows {
+		if ui.BeginWindow(name, types.Rect{X: i * 200, Y: 0, W: 180, H: 100}) {
+			if name == front {
+				ui.BringToFront(ui.GetCurrentContainer())
+			}
+			if i == 0 {
+				ui.LayoutRow(1, []int{-1}, 0)
+				ui.Textbox(buf, 64)
+			}
+			ui.EndWindow()
+		}
+	}
+	ui.EndFrame()
+}
+
+// pressShortcut holds mod while pressing key and runs a frame.
+func pressShortcut(ui *UI, buf *[]byte, mod, key Key) {
+	ui.KeyDown(mod)
+	pressKey(ui, key)
+	shortcutFrame(ui, buf, "", "A", "B")
+	ui.KeyUp(mod)
+}
+
+func TestShortcut_Fires(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	saves := 0
+	s, _ := ui.RegisterShortcut("ctrl+s", func() { saves++ })
+	shortcutFrame(ui, &buf, "", "A", "B")
+
+	pressKey(ui, KeyS)
+	shortcutFrame(ui, &buf, "", "A", "B")
+	if saves != 0 {
+		t.Fatalf("S alone ran ctrl+s")
+	}
+	pressShortcut(ui, &buf, KeyCtrl, KeyS)
+	if saves != 1 {
+		t.Fatalf("ctrl+s ran %d times, want 1", saves)
+	}
+	pressShortcut(ui, &buf, KeyMeta, KeyS)
+	if saves != 2 {
+		t.Errorf("meta+s should run ctrl+s")
+	}
+
+	s.Disabled = true
+	pressShortcut(ui, &buf, KeyCtrl, KeyS)
+	s.Disabled = false
+	ui.SetShortcutsEnabled(false)
+	pressShortcut(ui, &buf, KeyCtrl, KeyS)
+	if saves != 2 {
+		t.Errorf("disabled shortcut ran")
+	}
+}
+
+func TestShortcut_WindowScope(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	var ran []string
+	for _, scope := range []string{"", "A", "B"} {
+		ui.RegisterWindowShortcut(scope, "ctrl+d", func() { ran = append(ran, scope) })
+	}
+	shortcutFrame(ui, &buf, "", "A", "B")
+
+	shortcutFrame(ui, &buf, "A", "A", "B")
+	pressShortcut(ui, &buf, KeyCtrl, KeyD)
+	shortcutFrame(ui, &buf, "B", "A", "B")
+	pressShortcut(ui, &buf, KeyCtrl, KeyD)
+	shortcutFrame(ui, &buf, "", "C")
+	pressShortcut(ui, &buf, KeyCtrl, KeyD)
+	if len(ran) != 3 || ran[0] != "A" || ran[1] != "B" || ran[2] != "" {
+		t.Errorf("ran %q, want the front window's shortcut, then the global one", ran)
+	}
+}
+
+func TestShortcut_PinnedWindowInFront(t *testing.T) {
+	ui := New(Config{})
+	frame := func() {
+		ui.BeginFrame()
+		for _, name := range []string{"A", "B"} {
+			if ui.BeginWindow(name, types.Rect{X: 0, Y: 0, W: 100, H: 100}) {
+				ui.EndWindow()
+			}
+		}
+		ui.EndFrame()
+	}
+	frame()
+	ui.GetContainer("A").SetPinned(true)
+	ui.BringToFront(ui.GetContainer("B"))
+	frame()
+	if front := ui.frontWindow(); front != "A" {
+		t.Errorf("front window = %q, want the pinned A above the raised B", front)
+	}
+}
+
+func TestShortcut_Textbox(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("hello")
+	var ran []string
+	ui.RegisterShortcut("delete", func() { ran = append(ran, "delete") })
+	ui.RegisterShortcut("ctrl+s", func() { ran = append(ran, "save") })
+	ui.RegisterShortcut("ctrl+a", func() { ran = append(ran, "all") })
+	f, _ := ui.RegisterShortcut("f", func() { ran = append(ran, "f") })
+	f.InTextbox = true
+
+	// Focus the textbox
+	shortcutFrame(ui, &buf, "", "A", "B")
+	ui.MouseMove(50, 35)
+	ui.MouseDown(50, 35, MouseLeft)
+	shortcutFrame(ui, &buf, "", "A", "B")
+	ui.MouseUp(50, 35, MouseLeft)
+	shortcutFrame(ui, &buf, "", "A", "B")
+
+	pressKey(ui, KeyDelete)
+	shortcutFrame(ui, &buf, "", "A", "B")
+	pressKey(ui, KeyF)
+	shortcutFrame(ui, &buf, "", "A", "B")
+	pressShortcut(ui, &buf, KeyCtrl, KeyS)
+	if len(ran) != 2 || ran[0] != "f" || ran[1] != "save" {
+		t.Errorf("while typing ran %q, want [f save]", ran)
+	}
+
+	// The shortcut takes the key, so the textbox doesn't select all too
+	pressShortcut(ui, &buf, KeyCtrl, KeyA)
+	if len(ran) != 3 || ran[2] != "all" {
+		t.Errorf("ctrl+a ran %q, want it to run the shortcut", ran)
+	}
+	if ui.textboxHasSelection() || string(buf) != "hello" {
+		start, end := ui.textboxSelRange()
+		t.Errorf("textbox %q selected %d..%d after ctrl+a ran a shortcut, want it untouched", buf, start, end)
+	}
+}
+
+func TestMenuItem(t *testing.T) {
+	ui := New(Config{})
+	save, _ := ui.RegisterShortcut("ctrl+s", nil)
+	save.Label = "Save"
+
+	clicked := false
+	frame := func() {
+		ui.BeginFrame()
+		if ui.BeginPopup("file") {
+			ui.LayoutRow(1, []int{150}, 0)
+			clicked = ui.MenuItem("Save") || clicked
+			ui.MenuItem("Quit")
+			ui.EndPopup()
+		}
+		ui.EndFrame()
+	}
+	ui.MouseMove(20, 20)
+	ui.BeginFrame()
+	ui.OpenPopup("file")
+	ui.EndFrame()
+	for range 3 { // Until auto-sized to the row
+		frame()
+	}
+
+	var keys []string
+	for _, cmd := range ui.RecordFrame().Commands {
+		if cmd.Kind == CmdText && cmd.Text == "Ctrl+S" {
+			keys = append(keys, cmd.Text)
+		}
+	}
+	if len(keys) != 1 {
+		t.Errorf("menu shows the shortcut %d times, want once beside Save", len(keys))
+	}
+
+	pos := drawnTexts(ui)["Save"]
+	ui.MouseMove(pos.X+2, pos.Y+2)
+	frame()
+	ui.MouseDown(pos.X+2, pos.Y+2, MouseLeft)
+	frame()
+	ui.MouseUp(pos.X+2, pos.Y+2, MouseLeft)
+	frame()
+	if !clicked {
+		t.Error("clicking Save should return true")
+	}
+	if ui.GetContainer("file").Open() {
+		t.Error("choosing a menu item should close its popup")
+	}
+}
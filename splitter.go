@@ -0,0 +1,75 @@
+package microui
+
+import "github.com/user/microui-go/types"
+
+// Splitter lays out two panes in the next layout cell with a divider
+// between them that can be dragged to resize them, e.g. a sidebar next to
+// the main content. With vertical set the divider is vertical and the panes
+// sit side by side; otherwise they are stacked. first and second fill the
+// panes, each of which is a panel named name+"/1" or name+"/2" that scrolls
+// on its own.
+//
+// ratio is the first pane's share of the space, clamped to 0.1..0.9 and
+// updated while the divider is dragged; 0 splits in half. Pass nil to keep
+// the ratio in the splitter's container instead, where SaveLayout and
+// LoadLayout persist it.
+func (u *UI) Splitter(name string, first, second func(), vertical bool, ratio *float64) {
+	cnt := u.GetContainer(name)
+	if ratio == nil {
+		ratio = &cnt.split
+	}
+	if *ratio == 0 {
+		*ratio = 0.5
+	}
+	*ratio = min(max(*ratio, 0.1), 0.9)
+
+	rect := u.LayoutNext()
+	gap := max(u.style.Spacing, 1)
+	a, b, div := splitRects(rect, gap, vertical, *ratio)
+
+	id := u.GetID(name)
+	u.UpdateControlOpt(id, div, OptNoNav)
+	if u.input.Focus == id && u.input.MouseDown[int(MouseLeft)] {
+		if vertical {
+			*ratio = float64(u.input.MousePos.X-rect.X) / float64(max(rect.W-gap, 1))
+		} else {
+			*ratio = float64(u.input.MousePos.Y-rect.Y) / float64(max(rect.H-gap, 1))
+		}
+		*ratio = min(max(*ratio, 0.1), 0.9)
+		a, b, div = splitRects(rect, gap, vertical, *ratio)
+	}
+	cnt.split = *ratio
+	if u.input.Hover == id || u.input.Focus == id {
+		u.DrawRect(div, u.style.Colors.BaseHover)
+	}
+
+	u.LayoutSetNext(a, false)
+	u.BeginPanel(name + "/1")
+	if first != nil {
+		first()
+	}
+	u.EndPanel()
+	u.LayoutSetNext(b, false)
+	u.BeginPanel(name + "/2")
+	if second != nil {
+		second()
+	}
+	u.EndPanel()
+}
+
+// splitRects divides rect into two panes and the divider between them.
+func splitRects(rect types.Rect, gap int, vertical bool, ratio float64) (a, b, div types.Rect) {
+	a, b = rect, rect
+	if vertical {
+		a.W = int(float64(rect.W-gap) * ratio)
+		b.X = rect.X + a.W + gap
+		b.W = rect.W - a.W - gap
+		div = types.Rect{X: a.X + a.W, Y: rect.Y, W: gap, H: rect.H}
+	} else {
+		a.H = int(float64(rect.H-gap) * ratio)
+		b.Y = rect.Y + a.H + gap
+		b.H = rect.H - a.H - gap
+		div = types.Rect{X: rect.X, Y: a.Y + a.H, W: rect.W, H: gap}
+	}
+	return a, b, div
+}
@@ -0,0 +1,75 @@
+package microui
+
+import (
+	"math"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func splitterFrame(ui *UI, ratio *float64) {
+	ui.BeginFrame()
+	if ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+		ui.LayoutRow(1, []int{-1}, -1)
+		ui.Splitter("split", func() { ui.Label("sidebar") }, func() { ui.Label("content") }, true, ratio)
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+func TestSplitter_DragResizesPanes(t *testing.T) {
+	ui := New(Config{})
+	splitterFrame(ui, nil)
+	a, b := ui.GetContainer("split/1").Rect(), ui.GetContainer("split/2").Rect()
+	total := a.W + b.W
+	if a.W != total/2 || b.X != a.X+a.W+4 || a.H != b.H {
+		t.Fatalf("panes %v and %v, want halves side by side with a 4px divider", a, b)
+	}
+
+	// Drag the divider a quarter of the way across
+	x, y := a.X+a.W+2, a.Y+20
+	ui.MouseMove(x, y)
+	splitterFrame(ui, nil)
+	ui.MouseDown(x, y, MouseLeft)
+	splitterFrame(ui, nil)
+	ui.MouseMove(a.X+total/4, y)
+	splitterFrame(ui, nil)
+	if got := ui.GetContainer("split").split; math.Abs(got-0.25) > 0.01 {
+		t.Errorf("ratio = %v, want 0.25", got)
+	}
+	if a := ui.GetContainer("split/1").Rect(); a.W != total/4 {
+		t.Errorf("first pane width = %d, want %d", a.W, total/4)
+	}
+
+	// Dragging past the edge clamps
+	ui.MouseMove(0, y)
+	splitterFrame(ui, nil)
+	ui.MouseUp(0, y, MouseLeft)
+	splitterFrame(ui, nil)
+	if got := ui.GetContainer("split").split; got != 0.1 {
+		t.Errorf("ratio = %v, want it clamped to 0.1", got)
+	}
+
+	// The ratio survives SaveLayout and LoadLayout
+	data, err := ui.SaveLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := New(Config{})
+	if err := restored.LoadLayout(data); err != nil {
+		t.Fatal(err)
+	}
+	splitterFrame(restored, nil)
+	if got := restored.GetContainer("split/1").Rect().W; got != int(float64(total)*0.1) {
+		t.Errorf("restored first pane width = %d, want %d", got, int(float64(total)*0.1))
+	}
+}
+
+func TestSplitter_CallerRatio(t *testing.T) {
+	ui := New(Config{})
+	ratio := 0.95
+	splitterFrame(ui, &ratio)
+	if ratio != 0.9 {
+		t.Errorf("ratio = %v, want it clamped to 0.9", ratio)
+	}
+}
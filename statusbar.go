@@ -0,0 +1,144 @@
+package microui
+
+import "github.com/user/microui-go/types"
+
+// statusBarNames are the container names of the bars BeginStatusBarAt
+// opens, by edge.
+var statusBarNames = map[int]string{DockTop: "!statusbar.top", DockBottom: "!statusbar.bottom"}
+
+// statusBarState is a status bar's height, kept so windows can leave room
+// for it.
+type statusBarState struct {
+	height int
+	frame  int // Frame the bar was last begun in
+}
+
+// BeginStatusBar starts a status bar along the bottom of the screen. See
+// BeginStatusBarAt.
+func (u *UI) BeginStatusBar() bool {
+	return u.BeginStatusBarAt(DockBottom)
+}
+
+// BeginStatusBarAt starts a status bar: a strip one row high across the
+// screen set with SetScreenSize, along its top or bottom edge (DockTop or
+// DockBottom). It is drawn above every window, pinned ones included, but
+// beneath popups, so a menu or dropdown opened from it shows. It can't be
+// moved or resized, and takes input like a window. Maximized windows and,
+// with Config.ConstrainToScreen, windows kept on screen leave room for it.
+//
+// Its layout is one row across the bar; StatusBarSection splits it into
+// sections aligned left, centered and right. It returns false if the
+// screen size isn't known; otherwise call it once per frame, outside any
+// window, and finish with EndStatusBar.
+func (u *UI) BeginStatusBarAt(edge int) bool {
+	name, ok := statusBarNames[edge]
+	if !ok || u.screen.Empty() {
+		return false
+	}
+	h := u.style.Size.Y + u.style.Padding.Y*2
+	rect := types.Rect{X: u.screen.X, Y: u.screen.Y, W: u.screen.W, H: h}
+	if edge == DockBottom {
+		rect.Y += u.screen.H - h
+	}
+	u.statusBars[edge] = statusBarState{height: h, frame: u.frame}
+
+	cnt := u.GetContainer(name)
+	cnt.rect, cnt.body = rect, rect
+	cnt.open = true
+	cnt.opt = OptNoTitle | OptNoScroll | OptNoResize
+
+	u.PushID(name)
+	u.containerStack.Push(cnt)
+	u.beginRootContainer(cnt)
+	u.accessContainer(cnt, RoleStatusBar, "")
+	u.drawBackground(cnt, rect, ColorStatusBar)
+	u.PushClip(rect)
+	u.pushLayout(u.statusBarBody(rect), types.Vec2{})
+	u.LayoutRow(1, []int{-1}, h)
+	return true
+}
+
+// isStatusBar reports whether cnt is a status bar.
+func isStatusBar(cnt *Container) bool {
+	return cnt.name == statusBarNames[DockTop] || cnt.name == statusBarNames[DockBottom]
+}
+
+// statusBarBody returns the part of a status bar's rect its controls are
+// laid out in.
+func (u *UI) statusBarBody(rect types.Rect) types.Rect {
+	pad := u.style.Padding.X
+	return types.Rect{X: rect.X + pad, Y: rect.Y, W: max(0, rect.W-pad*2), H: rect.H}
+}
+
+// StatusBarSection starts a section of the status bar holding a row of
+// controls widths wide (0 = the default width, as for LayoutRow), placed
+// by align: 0 at the left, OptAlignCenter in the middle, OptAlignRight at
+// the right. Size a label with TextWidth:
+//
+//	if ui.BeginStatusBar() {
+//		ui.StatusBarSection(0, ui.TextWidth(hint))
+//		ui.Label(hint)
+//		ui.StatusBarSection(microui.OptAlignRight, 60, ui.TextWidth(fps))
+//		ui.ProgressBar(load, 0)
+//		ui.Label(fps)
+//		ui.EndStatusBar()
+//	}
+func (u *UI) StatusBarSection(align int, widths ...int) {
+	cnt := u.GetCurrentContainer()
+	if cnt == nil || !isStatusBar(cnt) {
+		return
+	}
+	w := 0
+	for _, cw := range widths {
+		if cw == 0 {
+			cw = u.style.Size.X + u.style.Padding.X*2
+		}
+		w += cw
+	}
+	w += u.style.Spacing * max(0, len(widths)-1)
+
+	body := u.statusBarBody(cnt.rect)
+	switch {
+	case align&OptAlignRight != 0:
+		body.X += body.W - w
+	case align&OptAlignCenter != 0:
+		body.X += (body.W - w) / 2
+	}
+	body.W = w
+	u.PopLayout()
+	u.pushLayout(body, types.Vec2{})
+	u.LayoutRow(len(widths), widths, body.H)
+}
+
+// EndStatusBar finishes the bar started by BeginStatusBar.
+func (u *UI) EndStatusBar() {
+	u.PopLayout()
+	u.PopClip()
+	if cnt := u.GetCurrentContainer(); cnt != nil {
+		u.endRootContainer(cnt)
+	}
+	u.containerStack.Pop()
+	u.PopID()
+}
+
+// TextWidth returns the width of a control that fits text: its width in
+// the style's font plus padding on both sides.
+func (u *UI) TextWidth(text string) int {
+	return u.style.Font.Width(text) + u.style.Padding.X*2
+}
+
+// workArea returns the screen less the status bars drawn this frame or
+// the last, which is where maximized windows go.
+func (u *UI) workArea() types.Rect {
+	r := u.screen
+	for edge, bar := range u.statusBars {
+		if bar.height == 0 || bar.frame < u.frame-1 {
+			continue
+		}
+		r.H -= bar.height
+		if edge == DockTop {
+			r.Y += bar.height
+		}
+	}
+	return r
+}
@@ -0,0 +1,99 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestStatusBar_Sections(t *testing.T) {
+	ui := New(Config{})
+	ui.SetScreenSize(640, 480)
+	var left, center, right types.Rect
+	ui.BeginFrame()
+	if !ui.BeginStatusBar() {
+		t.Fatal("BeginStatusBar = false with a screen size")
+	}
+	ui.StatusBarSection(0, 50)
+	left = ui.LayoutNext()
+	ui.StatusBarSection(OptAlignCenter, 40)
+	center = ui.LayoutNext()
+	ui.StatusBarSection(OptAlignRight, 30, 20)
+	ui.LayoutNext()
+	right = ui.LayoutNext()
+	ui.EndStatusBar()
+	ui.EndFrame()
+
+	style := ui.Style()
+	h := style.Size.Y + style.Padding.Y*2
+	pad := style.Padding.X
+	if bar := ui.GetContainer("!statusbar.bottom").Rect(); bar != (types.Rect{X: 0, Y: 480 - h, W: 640, H: h}) {
+		t.Errorf("bar rect = %v, want the bottom %d pixels of the screen", bar, h)
+	}
+	if left.X != pad || left.Y != 480-h || left.H != h {
+		t.Errorf("left section = %v, want it at the bar's left edge", left)
+	}
+	if want := pad + (640-pad*2-40)/2; center.X != want {
+		t.Errorf("center section x = %d, want %d", center.X, want)
+	}
+	if right.X+right.W != 640-pad || right.W != 20 {
+		t.Errorf("right section's last cell = %v, want it to end at the bar's right edge", right)
+	}
+}
+
+func TestStatusBar_NeedsScreen(t *testing.T) {
+	ui := New(Config{})
+	ui.BeginFrame()
+	if ui.BeginStatusBar() {
+		t.Error("BeginStatusBar = true without a screen size")
+	}
+	ui.EndFrame()
+}
+
+func TestStatusBar_AboveWindows(t *testing.T) {
+	ui := New(Config{ConstrainToScreen: true})
+	ui.SetScreenSize(640, 480)
+	clicked := false
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginStatusBarAt(DockTop)
+		if ui.Button("Menu") {
+			clicked = true
+		}
+		ui.EndStatusBar()
+		if ui.BeginWindowOpt("Pinned", types.Rect{X: 0, Y: 0, W: 200, H: 100}, OptAlwaysOnTop) {
+			ui.EndWindow()
+		}
+		if ui.BeginWindowOpt("Big", types.Rect{X: 300, Y: 0, W: 100, H: 100}, OptMaximizable) {
+			ui.EndWindow()
+		}
+		ui.EndFrame()
+	}
+	frame()
+	frame()
+
+	h := ui.Style().Size.Y + ui.Style().Padding.Y*2
+	if r := ui.GetContainer("Pinned").Rect(); r.Y != h {
+		t.Errorf("pinned window y = %d, want it kept below the bar at %d", r.Y, h)
+	}
+	roots := ui.RootContainersSorted()
+	if top := roots[len(roots)-1]; top.Name() != "!statusbar.top" {
+		t.Errorf("frontmost container = %q, want the status bar", top.Name())
+	}
+
+	ui.MouseMove(10, h/2)
+	frame()
+	ui.MouseDown(10, h/2, MouseLeft)
+	frame()
+	ui.MouseUp(10, h/2, MouseLeft)
+	frame()
+	if !clicked {
+		t.Error("button in the status bar didn't get the click")
+	}
+
+	big := ui.GetContainer("Big")
+	ui.toggleMaximized(big)
+	if r := big.Rect(); r != (types.Rect{X: 0, Y: h, W: 640, H: 480 - h}) {
+		t.Errorf("maximized rect = %v, want the screen below the bar", r)
+	}
+}
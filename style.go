@@ -1,6 +1,11 @@
 package microui
 
-import "github.com/user/microui-go/types"
+import (
+	"image/color"
+	"math"
+
+	"github.com/user/microui-go/types"
+)
 
 // Style configures the visual appearance of UI controls.
 type Style struct {
@@ -10,6 +15,13 @@ type Style struct {
 	// Colors
 	Colors types.ThemeColors
 
+	// Icons maps icon IDs to image regions (nil = renderer glyphs)
+	Icons IconProvider
+
+	// Frames maps frame color IDs (ColorButton, ColorWindowBG, ...) to
+	// nine-patch textures drawn instead of flat frames
+	Frames map[int]NineSlice
+
 	// Sizing
 	Size          types.Vec2 // Default control size
 	Padding       types.Vec2 // Internal padding
@@ -21,8 +33,38 @@ type Style struct {
 	BorderWidth   int        // Window border width - content is inset by this amount
 	                         // GUI: 0 (borders drawn outside/expanded, no inset needed)
 	                         // TUI: 1 (borders drawn on-edge, content must be inset)
+
+	// WindowShadow is the drop shadow drawn behind windows and popups
+	WindowShadow Shadow
+
+	// ScrollbarArrows puts arrow buttons at the ends of scrollbars, as in
+	// Turbo Vision, that scroll a line at a time while held. They take a
+	// square of ScrollbarSize each from the track; a track too short for
+	// them and a thumb goes without.
+	ScrollbarArrows bool
+
+	// ScrollbarOverlay floats scrollbars over the inside edge of the body
+	// instead of taking room from it, so content keeps its width when a
+	// scrollbar appears. They are drawn flat in Colors.ScrollThumb, without
+	// a track, over the content.
+	ScrollbarOverlay bool
+
+	// ScrollbarAutoHide shows scrollbars only while their container
+	// scrolls or the mouse is over them, fading them out a second after.
+	// Without ScrollbarOverlay the room they take stays empty.
+	ScrollbarAutoHide bool
+}
+
+// Shadow describes a drop shadow. It is drawn by renderers implementing
+// ShadowRenderer and skipped by others.
+type Shadow struct {
+	Offset types.Vec2  // How far the shadow falls right and down (zero = no shadow)
+	Color  color.Color // Shadow color; its alpha is how much it darkens (nil = black at 60%)
 }
 
+// defaultShadowColor darkens what is under a shadow to 40% brightness.
+var defaultShadowColor = color.RGBA{A: 153}
+
 // GUIStyle returns a style optimized for pixel-based GUI rendering.
 func GUIStyle() Style {
 	return Style{
@@ -53,6 +95,8 @@ func TUIStyle() Style {
 		ScrollbarSize: 1,                       // 1 cell scrollbar width
 		ThumbSize:     1,                       // 1 cell slider thumb
 		BorderWidth:   1,                       // 1 cell border - content inset for on-edge borders
+
+		ScrollbarArrows: true, // ▲ and ▼ at the ends, Borland style
 	}
 }
 
@@ -61,3 +105,49 @@ func TUIStyle() Style {
 func DefaultStyle() Style {
 	return GUIStyle()
 }
+
+// scaled returns s with its layout metrics multiplied by f. BorderWidth
+// is left alone: it selects between GUI and TUI border placement.
+func (s Style) scaled(f float64) Style {
+	if f == 1 {
+		return s
+	}
+	scale := func(v int) int {
+		if v == 0 {
+			return 0
+		}
+		return max(1, int(math.Round(float64(v)*f)))
+	}
+	s.Size = types.Vec2{X: scale(s.Size.X), Y: scale(s.Size.Y)}
+	s.Padding = types.Vec2{X: scale(s.Padding.X), Y: scale(s.Padding.Y)}
+	s.Spacing = scale(s.Spacing)
+	s.Indent = scale(s.Indent)
+	s.TitleHeight = scale(s.TitleHeight)
+	s.ScrollbarSize = scale(s.ScrollbarSize)
+	s.ThumbSize = scale(s.ThumbSize)
+	s.WindowShadow.Offset = types.Vec2{X: scale(s.WindowShadow.Offset.X), Y: scale(s.WindowShadow.Offset.Y)}
+	return s
+}
+
+// SetScale sets the UI scale factor for high-DPI displays. Layout metrics
+// (Size, Padding, Spacing, Indent, TitleHeight, ScrollbarSize, ThumbSize,
+// WindowShadow.Offset) are multiplied by f; Style still returns the
+// unscaled values. The scale is passed to renderers implementing
+// ScaleRenderer so they can pick a matching font size; the layout Font
+// must report scaled metrics too.
+func (u *UI) SetScale(f float64) {
+	if f <= 0 {
+		f = 1
+	}
+	if f != u.scale {
+		u.dirtyAll = true
+		u.Invalidate()
+	}
+	u.scale = f
+	u.style = u.baseStyle.scaled(f)
+}
+
+// Scale returns the UI scale factor.
+func (u *UI) Scale() float64 {
+	return u.scale
+}
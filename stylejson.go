@@ -0,0 +1,181 @@
+package microui
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/user/microui-go/types"
+)
+
+// styleJSON is the JSON form of a Style. Colors are keyed by the
+// snake_case name of their ThemeColors field.
+type styleJSON struct {
+	Colors            map[string]hexColor `json:"colors,omitempty"`
+	Size              types.Vec2          `json:"size"`
+	Padding           types.Vec2          `json:"padding"`
+	Spacing           int                 `json:"spacing"`
+	Indent            int                 `json:"indent"`
+	TitleHeight       int                 `json:"title_height"`
+	ScrollbarSize     int                 `json:"scrollbar_size"`
+	ThumbSize         int                 `json:"thumb_size"`
+	BorderWidth       int                 `json:"border_width"`
+	WindowShadow      shadowJSON          `json:"window_shadow"`
+	ScrollbarArrows   bool                `json:"scrollbar_arrows"`
+	ScrollbarOverlay  bool                `json:"scrollbar_overlay"`
+	ScrollbarAutoHide bool                `json:"scrollbar_auto_hide"`
+}
+
+type shadowJSON struct {
+	Offset types.Vec2 `json:"offset"`
+	Color  hexColor   `json:"color,omitzero"`
+}
+
+// MarshalJSON encodes the style's colors and metrics, with colors as
+// "#rrggbb" or "#rrggbbaa" strings. Font, Icons and Frames hold
+// application objects and are left out.
+func (s Style) MarshalJSON() ([]byte, error) {
+	sj := styleJSON{
+		Colors:            make(map[string]hexColor),
+		Size:              s.Size,
+		Padding:           s.Padding,
+		Spacing:           s.Spacing,
+		Indent:            s.Indent,
+		TitleHeight:       s.TitleHeight,
+		ScrollbarSize:     s.ScrollbarSize,
+		ThumbSize:         s.ThumbSize,
+		BorderWidth:       s.BorderWidth,
+		WindowShadow:      shadowJSON{Offset: s.WindowShadow.Offset, Color: hexColor{s.WindowShadow.Color}},
+		ScrollbarArrows:   s.ScrollbarArrows,
+		ScrollbarOverlay:  s.ScrollbarOverlay,
+		ScrollbarAutoHide: s.ScrollbarAutoHide,
+	}
+	colors := reflect.ValueOf(s.Colors)
+	for i := 0; i < colors.NumField(); i++ {
+		if c, _ := colors.Field(i).Interface().(color.Color); c != nil {
+			sj.Colors[snakeCase(colors.Type().Field(i).Name)] = hexColor{c}
+		}
+	}
+	return json.Marshal(sj)
+}
+
+// UnmarshalJSON updates the style from JSON written by MarshalJSON. Fields
+// and colors missing from data keep their current values, so a file only
+// needs the settings it changes:
+//
+//	style := microui.TUIStyle()
+//	err := json.Unmarshal([]byte(`{"colors": {"window_bg": "#000080"}}`), &style)
+//
+// A color set to null is cleared. Unknown colors are an error.
+func (s *Style) UnmarshalJSON(data []byte) error {
+	sj := styleJSON{
+		Size:              s.Size,
+		Padding:           s.Padding,
+		Spacing:           s.Spacing,
+		Indent:            s.Indent,
+		TitleHeight:       s.TitleHeight,
+		ScrollbarSize:     s.ScrollbarSize,
+		ThumbSize:         s.ThumbSize,
+		BorderWidth:       s.BorderWidth,
+		WindowShadow:      shadowJSON{Offset: s.WindowShadow.Offset, Color: hexColor{s.WindowShadow.Color}},
+		ScrollbarArrows:   s.ScrollbarArrows,
+		ScrollbarOverlay:  s.ScrollbarOverlay,
+		ScrollbarAutoHide: s.ScrollbarAutoHide,
+	}
+	if err := json.Unmarshal(data, &sj); err != nil {
+		return err
+	}
+	colors := s.Colors
+	cv := reflect.ValueOf(&colors).Elem()
+	for name, c := range sj.Colors {
+		f := cv.FieldByNameFunc(func(field string) bool { return snakeCase(field) == name })
+		if !f.IsValid() {
+			return fmt.Errorf("microui: unknown style color %q", name)
+		}
+		if c.Color == nil {
+			f.SetZero()
+		} else {
+			f.Set(reflect.ValueOf(c.Color))
+		}
+	}
+	s.Colors = colors
+	s.Size, s.Padding = sj.Size, sj.Padding
+	s.Spacing, s.Indent = sj.Spacing, sj.Indent
+	s.TitleHeight, s.ScrollbarSize = sj.TitleHeight, sj.ScrollbarSize
+	s.ThumbSize, s.BorderWidth = sj.ThumbSize, sj.BorderWidth
+	s.WindowShadow = Shadow{Offset: sj.WindowShadow.Offset, Color: sj.WindowShadow.Color.Color}
+	s.ScrollbarArrows = sj.ScrollbarArrows
+	s.ScrollbarOverlay, s.ScrollbarAutoHide = sj.ScrollbarOverlay, sj.ScrollbarAutoHide
+	return nil
+}
+
+// LoadStyle reads a style saved with MarshalJSON, or written by hand, from
+// the file at path. Settings the file leaves out come from DefaultStyle;
+// to start from another style, json.Unmarshal into it instead. Calling it
+// again when the file changes and passing the result to UI.SetStyle
+// reloads a theme while the application runs.
+func LoadStyle(path string) (Style, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Style{}, err
+	}
+	style := DefaultStyle()
+	if err := json.Unmarshal(data, &style); err != nil {
+		return Style{}, fmt.Errorf("microui: %s: %w", path, err)
+	}
+	return style, nil
+}
+
+// hexColor is a color written as "#rrggbb", or "#rrggbbaa" when not
+// opaque.
+type hexColor struct{ color.Color }
+
+func (h hexColor) IsZero() bool { return h.Color == nil }
+
+func (h hexColor) MarshalJSON() ([]byte, error) {
+	if h.Color == nil {
+		return []byte("null"), nil
+	}
+	c := color.NRGBAModel.Convert(h.Color).(color.NRGBA)
+	s := fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	if c.A != 255 {
+		s += fmt.Sprintf("%02x", c.A)
+	}
+	return json.Marshal(s)
+}
+
+func (h *hexColor) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		h.Color = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	c, ok := parseHexColor(s)
+	if !ok {
+		return fmt.Errorf("microui: invalid color %q, want #rrggbb or #rrggbbaa", s)
+	}
+	h.Color = c
+	return nil
+}
+
+// snakeCase converts a Go field name such as "WindowBg" to "window_bg".
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package microui
+
+import (
+	"encoding/json"
+	"image/color"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestStyleJSON_RoundTrip(t *testing.T) {
+	want := TUIStyle()
+	want.Colors.Selection = color.NRGBA{R: 10, G: 20, B: 30, A: 128}
+	want.WindowShadow = Shadow{Offset: types.Vec2{X: 2, Y: 1}, Color: color.RGBA{A: 200}}
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"selection":"#0a141e80"`) {
+		t.Errorf("JSON %s should hold the selection color as #rrggbbaa", data)
+	}
+
+	got := Style{Font: want.Font}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	gc, wc := reflect.ValueOf(got.Colors), reflect.ValueOf(want.Colors)
+	for i := 0; i < wc.NumField(); i++ {
+		if g, w := gc.Field(i).Interface(), wc.Field(i).Interface(); !sameColor(g, w) {
+			t.Errorf("Colors.%s = %v, want %v", wc.Type().Field(i).Name, g, w)
+		}
+	}
+	if !sameColor(got.WindowShadow.Color, want.WindowShadow.Color) {
+		t.Errorf("shadow color = %v, want %v", got.WindowShadow.Color, want.WindowShadow.Color)
+	}
+	got.Colors, want.Colors = types.ThemeColors{}, types.ThemeColors{}
+	got.WindowShadow.Color, want.WindowShadow.Color = nil, nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("metrics = %+v, want %+v", got, want)
+	}
+}
+
+func TestStyleJSON_PartialUpdate(t *testing.T) {
+	style := TUIStyle()
+	err := json.Unmarshal([]byte(`{"colors": {"window_bg": "#000080", "focus_ring": null}, "spacing": 3}`), &style)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameColor(style.Colors.WindowBg, color.RGBA{B: 0x80, A: 255}) {
+		t.Errorf("window_bg = %v, want #000080", style.Colors.WindowBg)
+	}
+	if style.Colors.FocusRing != nil {
+		t.Errorf("focus_ring = %v, want cleared by null", style.Colors.FocusRing)
+	}
+	if style.Spacing != 3 || style.TitleHeight != 1 || !sameColor(style.Colors.Text, types.DarkTheme().Text) {
+		t.Errorf("style = %+v, want only the listed settings changed", style)
+	}
+
+	for _, bad := range []string{`{"colors": {"windowbg": "#000000"}}`, `{"colors": {"text": "blue"}}`} {
+		if err := json.Unmarshal([]byte(bad), &style); err == nil {
+			t.Errorf("%s: want an error", bad)
+		}
+	}
+}
+
+func TestLoadStyle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.json")
+	if err := os.WriteFile(path, []byte(`{"title_height": 30, "colors": {"text": "#ff0000"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	style, err := LoadStyle(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if style.TitleHeight != 30 || style.Padding != DefaultStyle().Padding || !sameColor(style.Colors.Text, color.RGBA{R: 255, A: 255}) {
+		t.Errorf("LoadStyle = %+v, want the file's settings over DefaultStyle", style)
+	}
+	if _, err := LoadStyle(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadStyle of a missing file should fail")
+	}
+}
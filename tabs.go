@@ -0,0 +1,180 @@
+package microui
+
+import "github.com/user/microui-go/types"
+
+// tabBarState persists per tab bar across frames.
+type tabBarState struct {
+	active   ID   // Active tab ID (0 = pick the first tab)
+	scroll   int  // Horizontal scroll of the tab strip
+	overflow bool // Tabs were wider than the strip last frame
+}
+
+// tabBarFrame tracks the tab bar being built this frame.
+type tabBarFrame struct {
+	state     *tabBarState
+	rect      types.Rect // Full strip rect
+	visible   types.Rect // Strip area available to tabs (excludes scroll buttons)
+	x         int        // Next tab position relative to the strip start
+	firstID   ID         // First tab submitted this frame
+	sawActive bool       // The active tab was submitted this frame
+}
+
+// BeginTabBar starts a tab strip occupying a full-width row.
+// Follow with TabItem calls and finish with EndTabBar.
+func (u *UI) BeginTabBar(name string) bool {
+	id := u.GetID(name)
+	st, ok := u.tabBars[id]
+	if !ok {
+		st = &tabBarState{}
+		u.tabBars[id] = st
+	}
+
+	u.LayoutRow(1, []int{-1}, 0)
+	rect := u.LayoutNext()
+	visible := rect
+	if st.overflow {
+		visible.W -= rect.H * 2
+	}
+	u.tabBarStack.Push(tabBarFrame{state: st, rect: rect, visible: visible})
+
+	u.PushID(name)
+	return true
+}
+
+// TabItem adds a tab to the current tab bar.
+// Returns true if the tab is active; draw its content after the call.
+func (u *UI) TabItem(label string) bool {
+	return u.tabItem(label, nil)
+}
+
+// TabItemClosable adds a tab with a close button. Clicking it sets *open to
+// false; tabs with *open == false are skipped.
+func (u *UI) TabItemClosable(label string, open *bool) bool {
+	if !*open {
+		return false
+	}
+	return u.tabItem(label, open)
+}
+
+func (u *UI) tabItem(label string, open *bool) bool {
+	if u.tabBarStack.Len() == 0 {
+		return false
+	}
+	tb := u.currentTabBar()
+	st := tb.state
+	id := u.GetID(label)
+	if tb.firstID == 0 {
+		tb.firstID = id
+	}
+	if st.active == 0 {
+		st.active = id
+	}
+
+	w := u.style.Font.Width(label) + u.style.Padding.X*2
+	if open != nil {
+		w += tb.rect.H
+	}
+	r := types.Rect{X: tb.visible.X + tb.x - st.scroll, Y: tb.rect.Y, W: w, H: tb.rect.H}
+	tabX := tb.x
+	tb.x += w + u.style.Spacing
+
+	// Clip to the strip for the tab only, not the content drawn under it
+	u.PushClip(tb.visible)
+	defer u.PopClip()
+	u.UpdateControl(id, r)
+	if u.activated(id) {
+		st.active = id
+		// Scroll a partially hidden tab fully into view
+		if tabX < st.scroll {
+			st.scroll = tabX
+		} else if tabX+w > st.scroll+tb.visible.W {
+			st.scroll = tabX + w - tb.visible.W
+		}
+	}
+
+	active := st.active == id
+	if active {
+		u.DrawFrame(r, ColorButtonFocus)
+	} else {
+		u.DrawControlFrame(id, r, ColorButton, 0)
+	}
+	textRect := r
+	if open != nil {
+		textRect.W -= r.H
+	}
+	u.DrawControlText(label, textRect, ColorText, OptAlignCenter)
+	var state AccessState
+	if active {
+		state = AccessSelected
+	}
+	u.accessControl(id, r, RoleTab, label, "", state)
+
+	if open != nil {
+		closeID := u.GetID("!close:" + label)
+		closeRect := types.Rect{X: r.X + r.W - r.H, Y: r.Y, W: r.H, H: r.H}
+		u.UpdateControlOpt(closeID, closeRect, OptNoNav)
+		u.DrawIcon(IconClose, closeRect, u.style.Colors.Text)
+		if u.input.MousePressed[int(MouseLeft)] && u.input.Focus == closeID {
+			*open = false
+			if active {
+				st.active = 0
+			}
+			return false
+		}
+	}
+
+	if active {
+		tb.sawActive = true
+	}
+	return active
+}
+
+// EndTabBar finishes the current tab strip, drawing scroll buttons when the
+// tabs overflow the available width.
+func (u *UI) EndTabBar() {
+	if u.tabBarStack.Len() == 0 {
+		return
+	}
+	tb := u.currentTabBar()
+	st := tb.state
+
+	total := tb.x - u.style.Spacing
+	st.overflow = total > tb.rect.W
+	if st.overflow {
+		visibleW := tb.rect.W - tb.rect.H*2
+		step := max(visibleW/4, 1)
+		left := types.Rect{X: tb.rect.X + tb.rect.W - tb.rect.H*2, Y: tb.rect.Y, W: tb.rect.H, H: tb.rect.H}
+		right := types.Rect{X: left.X + left.W, Y: tb.rect.Y, W: tb.rect.H, H: tb.rect.H}
+		if u.tabScrollButton("!tableft", "<", left) {
+			st.scroll -= step
+		}
+		if u.tabScrollButton("!tabright", ">", right) {
+			st.scroll += step
+		}
+		st.scroll = max(min(st.scroll, total-visibleW), 0)
+	} else {
+		st.scroll = 0
+	}
+
+	// Active tab vanished (closed or no longer submitted): fall back to the first
+	if !tb.sawActive {
+		st.active = tb.firstID
+	}
+
+	u.PopID()
+	u.tabBarStack.Pop()
+}
+
+// currentTabBar returns the innermost tab bar being built.
+func (u *UI) currentTabBar() *tabBarFrame {
+	return &u.tabBarStack.items[u.tabBarStack.count-1]
+}
+
+// tabScrollButton draws a tab strip scroll button and reports clicks.
+func (u *UI) tabScrollButton(name, text string, r types.Rect) bool {
+	id := u.GetID(name)
+	u.UpdateControlOpt(id, r, OptNoNav)
+	u.DrawControlFrame(id, r, ColorButton, 0)
+	u.DrawControlText(text, r, ColorText, OptAlignCenter)
+	return u.activatedRepeat(id)
+}
@@ -0,0 +1,157 @@
+package microui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// tabFrame draws a tab bar in the test window. The strip starts at (5,29);
+// a one-character tab is 18px wide and tabs are 4px apart.
+func tabFrame(ui *UI, build func()) {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	if ui.BeginTabBar("tabs") {
+		build()
+		ui.EndTabBar()
+	}
+	ui.EndWindow()
+	ui.EndFrame()
+}
+
+func tabClick(ui *UI, x, y int, build func()) {
+	ui.MouseMove(x, y)
+	tabFrame(ui, build)
+	ui.MouseDown(x, y, MouseLeft)
+	tabFrame(ui, build)
+	ui.MouseUp(x, y, MouseLeft)
+	tabFrame(ui, build)
+}
+
+func TestTabBar_FirstTabActiveByDefault(t *testing.T) {
+	ui := New(Config{})
+	var a, b bool
+	tabFrame(ui, func() {
+		a = ui.TabItem("A")
+		b = ui.TabItem("B")
+	})
+	if !a || b {
+		t.Errorf("active = (%v, %v), want (true, false)", a, b)
+	}
+}
+
+func TestTabBar_ClickSwitchesAndPersists(t *testing.T) {
+	ui := New(Config{})
+	var a, b bool
+	build := func() {
+		a = ui.TabItem("A")
+		b = ui.TabItem("B")
+	}
+
+	tabClick(ui, 30, 35, build) // B spans x=27..45
+	if a || !b {
+		t.Fatalf("after click active = (%v, %v), want (false, true)", a, b)
+	}
+
+	ui.MouseMove(300, 200)
+	tabFrame(ui, build)
+	tabFrame(ui, build)
+	if a || !b {
+		t.Errorf("active tab not persisted: (%v, %v)", a, b)
+	}
+}
+
+func TestTabBar_CloseButton(t *testing.T) {
+	ui := New(Config{})
+	openA, openB := true, true
+	var b bool
+	build := func() {
+		ui.TabItemClosable("A", &openA)
+		b = ui.TabItemClosable("B", &openB)
+	}
+
+	// A is 18px + 20px close button: x=5..43, close at x=23..43
+	tabClick(ui, 35, 35, build)
+	if openA {
+		t.Fatal("clicking the close button should clear *open")
+	}
+	tabFrame(ui, build)
+	if !b {
+		t.Error("closing the active tab should activate the remaining tab")
+	}
+}
+
+func TestTabBar_OverflowScrolls(t *testing.T) {
+	ui := New(Config{})
+	build := func() {
+		for i := 0; i < 30; i++ {
+			ui.TabItem(fmt.Sprintf("T%02d", i))
+		}
+	}
+	tabFrame(ui, build)
+	tabFrame(ui, build)
+
+	st := ui.tabBars[tabBarID(ui, "tabs")]
+	if !st.overflow {
+		t.Fatal("expected overflow with 30 tabs")
+	}
+
+	// Right scroll button is the last 20px of the strip
+	strip := ui.GetContainer("Test").body
+	x := strip.X + strip.W - ui.style.Padding.X - 10
+	tabClick(ui, x, 35, build)
+	if st.scroll <= 0 {
+		t.Errorf("scroll = %d, want > 0 after clicking the right button", st.scroll)
+	}
+
+	for i := 0; i < 50; i++ {
+		tabClick(ui, x, 35, build)
+	}
+	total := 30*(24+10+4) - 4
+	maxScroll := total - (strip.W - ui.style.Padding.X*2 - 40)
+	if st.scroll != maxScroll {
+		t.Errorf("scroll = %d, want clamped to %d", st.scroll, maxScroll)
+	}
+}
+
+// tabBarID returns the ID of a tab bar declared inside the "Test" window.
+func tabBarID(ui *UI, name string) ID {
+	ui.PushID("Test")
+	id := ui.GetID(name)
+	ui.PopID()
+	return id
+}
+
+func TestTabBar_ContentNotClipped(t *testing.T) {
+	ui := New(Config{})
+	tabFrame(ui, func() {
+		if ui.TabItem("A") {
+			ui.LayoutRow(1, []int{-1}, 0)
+			ui.Label("content")
+		}
+		ui.TabItem("B")
+	})
+	if _, ok := drawnTexts(ui)["content"]; !ok {
+		t.Error("content under the active tab drew nothing, want it outside the strip's clip")
+	}
+}
+
+func TestTabBar_Nested(t *testing.T) {
+	ui := New(Config{})
+	var inner, b bool
+	tabFrame(ui, func() {
+		if ui.TabItem("A") {
+			ui.BeginTabBar("inner")
+			inner = ui.TabItem("x")
+			ui.EndTabBar()
+		}
+		b = ui.TabItem("B")
+	})
+	if !inner || b {
+		t.Errorf("inner tab active %v, outer B active %v; want true, false", inner, b)
+	}
+	if n := ui.tabBarStack.Len(); n != 0 {
+		t.Errorf("%d tab bars left after the frame, want 0", n)
+	}
+}
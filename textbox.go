@@ -0,0 +1,683 @@
+package microui
+
+import (
+	"bytes"
+	"image/color"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/user/microui-go/types"
+)
+
+// Textbox adds a text input field to the current layout.
+// buf is the text buffer, maxLen is the maximum length.
+// Returns ResChange if text changed, ResSubmit if Enter pressed.
+func (u *UI) Textbox(buf *[]byte, maxLen int) int {
+	return u.TextboxOpt(buf, maxLen, 0)
+}
+
+// TextboxOpt adds a text input field with options.
+// opt can include OptNoInteract (display only), OptReadOnly (selectable and
+// copyable but not editable), OptNoFrame (no background), OptHoldFocus,
+// OptNumeric (only digits, sign and decimal point can be typed) and
+// OptPassword (characters are shown as '*' and can't be copied).
+// Its ID comes from the address of buf, so a textbox whose buffer moves
+// loses focus and cursor; TextboxID avoids that.
+func (u *UI) TextboxOpt(buf *[]byte, maxLen int, opt int) int {
+	rect := u.LayoutNext()
+	id := u.getIDFromPtr(buf)
+	return u.textbox(buf, maxLen, id, rect, opt, nil)
+}
+
+// TextboxID is TextboxOpt with an ID from id (scoped by PushID) instead of
+// the address of buf.
+func (u *UI) TextboxID(id string, buf *[]byte, maxLen int, opt int) int {
+	rect := u.LayoutNext()
+	return u.textbox(buf, maxLen, u.getID(id), rect, opt, nil)
+}
+
+// TextboxRules filters and validates what a textbox accepts.
+type TextboxRules struct {
+	// Accept reports whether a typed or pasted rune may be inserted
+	// (nil = any rune, or the OptNumeric runes).
+	Accept func(r rune) bool
+	// Pattern must match the text after every edit; an edit that leaves
+	// text it doesn't match is undone. It sees partial input, so anchor it
+	// and let it match every prefix of a valid value, e.g. `^\d{0,4}$`.
+	Pattern *regexp.Regexp
+	// Validate reports whether the text is valid. Invalid text is
+	// outlined in the theme's Invalid color and sets ResInvalid.
+	Validate func(text string) bool
+}
+
+// TextboxValidated is TextboxID with input filtering and validation. The
+// buffer can only hold text that rules accept while typing; Validate
+// checks the finished value, e.g. an e-mail address, so the caller can
+// show why it is invalid.
+func (u *UI) TextboxValidated(id string, buf *[]byte, maxLen int, opt int, rules TextboxRules) int {
+	rect := u.LayoutNext()
+	return u.textbox(buf, maxLen, u.getID(id), rect, opt, &rules)
+}
+
+// textbox is textboxRaw for the Textbox functions, which also add the
+// textbox to the accessibility tree. A password's text is left out.
+func (u *UI) textbox(buf *[]byte, maxLen int, id ID, rect types.Rect, opt int, rules *TextboxRules) int {
+	res := u.textboxRaw(buf, maxLen, id, rect, opt, rules)
+	if u.accessOn {
+		value, state := string(*buf), accessOptState(opt)
+		if opt&OptPassword != 0 {
+			value, state = "", state|AccessProtected
+		}
+		if opt&OptReadOnly != 0 {
+			state |= AccessReadOnly
+		}
+		if res&ResInvalid != 0 {
+			state |= AccessInvalid
+		}
+		u.accessControl(id, rect, RoleTextbox, "", value, state)
+	}
+	return res
+}
+
+// numericRune reports whether r can be typed into an OptNumeric textbox.
+func numericRune(r rune) bool {
+	return r >= '0' && r <= '9' || r == '-' || r == '+' || r == '.'
+}
+
+// textboxDisplay returns the text a textbox shows for b: b itself, or one
+// '*' per rune with OptPassword.
+func textboxDisplay(b []byte, opt int) string {
+	if opt&OptPassword != 0 {
+		return strings.Repeat("*", utf8.RuneCount(b))
+	}
+	return string(b)
+}
+
+// TextboxSelection returns the selected byte range [start, end) of the
+// focused textbox. start == end when nothing is selected.
+func (u *UI) TextboxSelection() (start, end int) {
+	return u.textboxSelRange()
+}
+
+// textboxSelRange returns the ordered selection range of the active textbox.
+func (u *UI) textboxSelRange() (start, end int) {
+	if !u.textboxSelActive {
+		return u.textboxCursor, u.textboxCursor
+	}
+	if u.textboxAnchor < u.textboxCursor {
+		return u.textboxAnchor, u.textboxCursor
+	}
+	return u.textboxCursor, u.textboxAnchor
+}
+
+// textboxHasSelection returns true if the active textbox has a non-empty selection.
+func (u *UI) textboxHasSelection() bool {
+	return u.textboxSelActive && u.textboxAnchor != u.textboxCursor
+}
+
+// textboxMoveCursor moves the cursor to pos. If extend is true the selection
+// grows from the current anchor (starting one at the old cursor if needed);
+// otherwise any selection is cleared.
+func (u *UI) textboxMoveCursor(pos int, extend bool) {
+	if extend && !u.textboxSelActive {
+		u.textboxAnchor = u.textboxCursor
+		u.textboxSelActive = true
+	} else if !extend {
+		u.textboxSelActive = false
+	}
+	u.textboxCursor = pos
+}
+
+// textboxDeleteSelection removes the selected bytes from buf and collapses
+// the cursor to the start of the removed range.
+func (u *UI) textboxDeleteSelection(buf *[]byte) {
+	start, end := u.textboxSelRange()
+	newBuf := make([]byte, len(*buf)-(end-start))
+	copy(newBuf, (*buf)[:start])
+	copy(newBuf[start:], (*buf)[end:])
+	*buf = newBuf
+	u.textboxMoveCursor(start, false)
+}
+
+// textboxUndoLimit bounds each textbox's undo stack.
+const textboxUndoLimit = 100
+
+// textboxHistory holds a textbox's undo and redo stacks.
+type textboxHistory struct {
+	undo, redo []textboxState
+	text       string // Text after the last edit, to notice changes made by the app
+	typing     bool   // Last edit was typing, which more typing joins
+	cursor     int    // Cursor after the last edit
+}
+
+// textboxState is a textbox's text and cursor at one point in its history.
+type textboxState struct {
+	text   []byte
+	cursor int
+}
+
+// textboxRecord pushes the state before an edit of textbox id onto its
+// undo stack and clears the redo stack. A run of typing at the cursor
+// becomes a single step.
+func (u *UI) textboxRecord(id ID, before []byte, cursor int, typing bool, after []byte) {
+	h := u.textboxHist[id]
+	if h == nil {
+		h = &textboxHistory{}
+		u.textboxHist[id] = h
+	}
+	if !typing || !h.typing || cursor != h.cursor {
+		if len(h.undo) == textboxUndoLimit {
+			h.undo = append(h.undo[:0], h.undo[1:]...)
+		}
+		h.undo = append(h.undo, textboxState{bytes.Clone(before), cursor})
+	}
+	h.redo = h.redo[:0]
+	h.text, h.typing, h.cursor = string(after), typing, u.textboxCursor
+}
+
+// textboxUndo restores the previous state of textbox id, or the next one
+// if redo is set. Returns false if there is nothing to restore.
+func (u *UI) textboxUndo(id ID, buf *[]byte, redo bool) bool {
+	h := u.textboxHist[id]
+	if h == nil {
+		return false
+	}
+	from, to := &h.undo, &h.redo
+	if redo {
+		from, to = to, from
+	}
+	if len(*from) == 0 {
+		return false
+	}
+	st := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, textboxState{bytes.Clone(*buf), u.textboxCursor})
+	*buf = bytes.Clone(st.text)
+	u.textboxMoveCursor(st.cursor, false)
+	h.text, h.typing = string(*buf), false
+	return true
+}
+
+// isWordRune reports whether r is part of a word for word-wise movement.
+// Combining marks belong to the letter before them.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_'
+}
+
+// textboxWordLeft returns the start of the word before pos in buf,
+// skipping any non-word runes in between.
+func textboxWordLeft(buf []byte, pos int) int {
+	inWord := false
+	for pos > 0 {
+		r, size := utf8.DecodeLastRune(buf[:pos])
+		if isWordRune(r) {
+			inWord = true
+		} else if inWord {
+			break
+		}
+		pos -= size
+	}
+	return pos
+}
+
+// textboxWordRight returns the end of the word after pos in buf,
+// skipping any non-word runes in between.
+func textboxWordRight(buf []byte, pos int) int {
+	inWord := false
+	for pos < len(buf) {
+		r, size := utf8.DecodeRune(buf[pos:])
+		if isWordRune(r) {
+			inWord = true
+		} else if inWord {
+			break
+		}
+		pos += size
+	}
+	return pos
+}
+
+// textboxInsert inserts text at the cursor, rune by rune, skipping runes that
+// would exceed maxLen or that accept (if not nil) rejects. Returns true if
+// anything was inserted.
+func (u *UI) textboxInsert(buf *[]byte, maxLen int, text string, accept func(rune) bool) bool {
+	inserted := false
+	for _, r := range text {
+		if accept != nil && !accept(r) {
+			continue
+		}
+		runeBytes := []byte(string(r))
+		if len(*buf)+len(runeBytes) > maxLen-1 {
+			continue
+		}
+		// Insert at cursor position
+		newBuf := make([]byte, len(*buf)+len(runeBytes))
+		copy(newBuf, (*buf)[:u.textboxCursor])
+		copy(newBuf[u.textboxCursor:], runeBytes)
+		copy(newBuf[u.textboxCursor+len(runeBytes):], (*buf)[u.textboxCursor:])
+		*buf = newBuf
+		u.textboxCursor += len(runeBytes)
+		inserted = true
+	}
+	return inserted
+}
+
+// textboxRaw renders a textbox at the given rect with the given ID.
+// It is shared by TextboxOpt and the number control's shift-click edit mode,
+// which have already called LayoutNext themselves. rules may be nil.
+func (u *UI) textboxRaw(buf *[]byte, maxLen int, id ID, rect types.Rect, opt int, rules *TextboxRules) int {
+	// Update control state - textboxes need OptHoldFocus to keep focus after click
+	hover, active := u.UpdateControlOpt(id, rect, opt|OptHoldFocus)
+
+	result := 0
+	shift := u.input.KeyDown[KeyShift]
+
+	// Handle focus change - position cursor at click location
+	if active && u.lastTextboxID != id {
+		u.lastTextboxID = id
+		u.textboxScrollX = 0 // Reset scroll on focus change
+		// Position cursor at click location (not just at end)
+		u.textboxMoveCursor(u.textboxCursorFromClick(buf, rect, opt), false)
+		u.textboxSelecting = u.input.MousePressed[int(MouseLeft)]
+	} else if active && hover && u.input.MousePressed[int(MouseLeft)] {
+		// Click while already focused: reposition cursor, shift+click extends selection
+		u.textboxMoveCursor(u.textboxCursorFromClick(buf, rect, opt), shift)
+		u.textboxSelecting = true
+	}
+
+	// Mouse drag extends the selection from the anchor (only when the mouse moves,
+	// so typing while the button is still held doesn't snap the cursor back)
+	if active && u.textboxSelecting {
+		if u.input.MouseDown[int(MouseLeft)] {
+			if !u.input.MousePressed[int(MouseLeft)] && u.input.MouseDelta != (types.Vec2{}) {
+				u.textboxMoveCursor(u.textboxCursorFromClick(buf, rect, opt), true)
+			}
+		} else {
+			u.textboxSelecting = false
+		}
+	}
+
+	// Clamp cursor to valid range - ONLY for active textbox!
+	// Otherwise inactive textboxes with shorter buffers would clamp the cursor
+	if active {
+		if u.textboxCursor > len(*buf) {
+			u.textboxCursor = len(*buf)
+		}
+		if u.textboxCursor < 0 {
+			u.textboxCursor = 0
+		}
+		if u.textboxAnchor > len(*buf) {
+			u.textboxAnchor = len(*buf)
+		}
+		// A collapsed selection must not leave a stale anchor behind
+		if !u.textboxHasSelection() {
+			u.textboxSelActive = false
+		}
+	}
+
+	// Typed and pasted runes go through the filter
+	var accept func(rune) bool
+	if opt&OptNumeric != 0 {
+		accept = numericRune
+	}
+	if rules != nil && rules.Accept != nil {
+		accept = rules.Accept
+	}
+	// Snapshot to undo an edit that breaks the pattern; edits replace
+	// *buf rather than modify it in place
+	oldBuf, oldCursor := *buf, u.textboxCursor
+	oldAnchor, oldSelActive := u.textboxAnchor, u.textboxSelActive
+	typing := false
+
+	// History recorded against other text is stale once the app changes buf
+	if h := u.textboxHist[id]; h != nil && active && h.text != string(*buf) {
+		delete(u.textboxHist, id)
+	}
+
+	// Handle text input when focused and interactive
+	editable := opt&OptReadOnly == 0
+	if active && opt&OptNoInteract == 0 {
+		// Typing replaces the selection
+		if editable && len(u.input.TextInput) > 0 && u.textboxHasSelection() {
+			u.textboxDeleteSelection(buf)
+			result |= ResChange
+		}
+
+		// Add typed text at cursor position (UTF-8 aware)
+		if editable && len(u.input.TextInput) > 0 && u.textboxInsert(buf, maxLen, u.input.TextInput, accept) {
+			typing = result&ResChange == 0
+			result |= ResChange
+		}
+
+		// Clipboard: Ctrl+C copies, Ctrl+X cuts, Ctrl+V pastes over the selection.
+		// Meta (Cmd) works as Ctrl for these and the other shortcuts.
+		if u.shortcutDown() {
+			if u.input.KeyPressed[KeyA] {
+				u.textboxMoveCursor(0, false)
+				u.textboxMoveCursor(len(*buf), true)
+			}
+
+			cut := editable && u.input.KeyPressed[KeyX]
+			copyable := opt&OptPassword == 0
+			if copyable && (u.input.KeyPressed[KeyC] || cut) && u.textboxHasSelection() {
+				start, end := u.textboxSelRange()
+				u.clipboard.Set(string((*buf)[start:end]))
+				if cut {
+					u.textboxDeleteSelection(buf)
+					result |= ResChange
+				}
+			}
+			// Ctrl+Z undoes; Ctrl+Y and Ctrl+Shift+Z redo
+			if editable && u.input.KeyPressed[KeyZ] && !shift && u.textboxUndo(id, buf, false) {
+				result |= ResChange | ResUndo
+			}
+			if editable && (u.input.KeyPressed[KeyY] || u.input.KeyPressed[KeyZ] && shift) && u.textboxUndo(id, buf, true) {
+				result |= ResChange | ResRedo
+			}
+			if editable && u.input.KeyPressed[KeyV] {
+				if text := u.clipboard.Get(); text != "" {
+					if u.textboxHasSelection() {
+						u.textboxDeleteSelection(buf)
+					}
+					if u.textboxInsert(buf, maxLen, text, accept) {
+						result |= ResChange
+					}
+				}
+			}
+		}
+
+		if editable && (u.input.KeyPressed[KeyBackspace] || u.input.KeyPressed[KeyDelete]) && u.textboxHasSelection() {
+			// Backspace/Delete remove the whole selection
+			u.textboxDeleteSelection(buf)
+			result |= ResChange
+		} else if editable {
+			// Handle backspace (delete the character before the cursor,
+			// with its combining marks or emoji modifiers)
+			if u.input.KeyPressed[KeyBackspace] && u.textboxCursor > 0 {
+				i := prevGrapheme(*buf, u.textboxCursor)
+				// Delete from i to cursor
+				newBuf := make([]byte, len(*buf)-(u.textboxCursor-i))
+				copy(newBuf, (*buf)[:i])
+				copy(newBuf[i:], (*buf)[u.textboxCursor:])
+				*buf = newBuf
+				u.textboxCursor = i
+				result |= ResChange
+			}
+
+			// Delete (whole grapheme clusters)
+			if u.input.KeyPressed[KeyDelete] && u.textboxCursor < len(*buf) {
+				i := nextGrapheme(*buf, u.textboxCursor)
+				newBuf := make([]byte, len(*buf)-(i-u.textboxCursor))
+				copy(newBuf, (*buf)[:u.textboxCursor])
+				copy(newBuf[u.textboxCursor:], (*buf)[i:])
+				*buf = newBuf
+				result |= ResChange
+			}
+		}
+
+		// Left/Right step over grapheme clusters, in the text's direction. Shift extends the selection; without shift
+		// an existing selection collapses to its start/end. Ctrl or Alt
+		// moves by word, Meta to the start/end like Home/End.
+		word := u.input.KeyDown[KeyCtrl] || u.input.KeyDown[KeyAlt]
+		meta := u.input.KeyDown[KeyMeta]
+		left, right := u.input.KeyPressed[KeyLeft], u.input.KeyPressed[KeyRight]
+		if (left || right) && baseRTL([]rune(textboxDisplay(*buf, opt)), u.textDirection(opt)) {
+			// Right-to-left text starts at the right
+			left, right = right, left
+		}
+		if left {
+			pos := u.textboxCursor
+			if meta {
+				pos = 0
+			} else if word {
+				pos = textboxWordLeft(*buf, pos)
+			} else if !shift && u.textboxHasSelection() {
+				pos, _ = u.textboxSelRange()
+			} else if pos > 0 {
+				pos = prevGrapheme(*buf, pos)
+			}
+			u.textboxMoveCursor(pos, shift)
+		}
+		if right {
+			pos := u.textboxCursor
+			if meta {
+				pos = len(*buf)
+			} else if word {
+				pos = textboxWordRight(*buf, pos)
+			} else if !shift && u.textboxHasSelection() {
+				_, pos = u.textboxSelRange()
+			} else if pos < len(*buf) {
+				pos = nextGrapheme(*buf, pos)
+			}
+			u.textboxMoveCursor(pos, shift)
+		}
+
+		if u.input.KeyPressed[KeyHome] {
+			u.textboxMoveCursor(0, shift)
+		}
+		if u.input.KeyPressed[KeyEnd] {
+			u.textboxMoveCursor(len(*buf), shift)
+		}
+		if editable && u.input.KeyPressed[KeyEnter] {
+			result |= ResSubmit
+		}
+	}
+
+	if result&ResChange != 0 && rules != nil && rules.Pattern != nil && !rules.Pattern.Match(*buf) {
+		*buf, u.textboxCursor = oldBuf, oldCursor
+		u.textboxAnchor, u.textboxSelActive = oldAnchor, oldSelActive
+		result &^= ResChange
+	}
+	if result&ResChange != 0 && result&(ResUndo|ResRedo) == 0 {
+		u.textboxRecord(id, oldBuf, oldCursor, typing, *buf)
+	}
+	if rules != nil && rules.Validate != nil && !rules.Validate(string(*buf)) {
+		result |= ResInvalid
+	}
+
+	if active {
+		result |= ResActive
+		if u.textboxHasSelection() {
+			result |= ResSelection
+		}
+	}
+
+	// Keep cursor visible
+	layout := u.newTextboxLayout(*buf, opt, rect.W-u.style.Padding.X*2)
+	if active {
+		textWidth := rect.W - u.style.Padding.X*2
+		cursorX := layout.caret(*buf, u.textboxCursor)
+		if cursorX-u.textboxScrollX > textWidth-10 {
+			u.textboxScrollX = cursorX - textWidth + 20
+		}
+		if cursorX < u.textboxScrollX+10 {
+			u.textboxScrollX = cursorX - 10
+			if u.textboxScrollX < 0 {
+				u.textboxScrollX = 0
+			}
+		}
+		if layout.aligned {
+			u.textboxScrollX = 0 // Its caret is always in view
+		}
+	}
+
+	// Draw textbox background
+	bgColor := u.style.Colors.Base
+	if bgColor == nil {
+		bgColor = u.style.Colors.CheckBg
+	}
+	if hover && opt&OptNoInteract == 0 {
+		if u.style.Colors.BaseHover != nil {
+			bgColor = u.style.Colors.BaseHover
+		} else {
+			bgColor = u.style.Colors.ButtonHover
+		}
+	}
+	if active {
+		if u.style.Colors.BaseFocus != nil {
+			bgColor = u.style.Colors.BaseFocus
+		} else {
+			bgColor = u.style.Colors.ButtonActive
+		}
+	}
+
+	if opt&OptNoFrame == 0 {
+		u.PushCommand(Command{
+			Kind:  CmdRect,
+			Rect:  rect,
+			Pos:   types.Vec2{X: rect.X, Y: rect.Y},
+			Size:  types.Vec2{X: rect.W, Y: rect.H},
+			Color: bgColor,
+		})
+	}
+	if result&ResInvalid != 0 {
+		invalid := u.style.Colors.Invalid
+		if invalid == nil {
+			invalid = color.RGBA{R: 220, G: 80, B: 80, A: 255}
+		}
+		u.DrawBox(rect, invalid)
+	}
+
+	// Push clip rect to prevent text drawing outside textbox bounds
+	textClipRect := types.Rect{
+		X: rect.X + u.style.Padding.X,
+		Y: rect.Y,
+		W: rect.W - u.style.Padding.X*2,
+		H: rect.H,
+	}
+	u.PushClip(textClipRect)
+
+	// Apply scroll offset to text position
+	// Vertically center text within the control (like DrawControlText does)
+	textX := rect.X + u.style.Padding.X - u.textboxScrollX
+	textHeight := u.style.Font.Height()
+	textY := rect.Y + (rect.H-textHeight)/2
+
+	// Draw selection highlight behind the text
+	if active && u.textboxHasSelection() {
+		start, end := u.textboxSelRange()
+		selColor := u.style.Colors.Selection
+		if selColor == nil {
+			selColor = u.style.Colors.ButtonActive
+		}
+		// Mixed-direction text can select several separate spans
+		for _, span := range layout.spans(*buf, start, end) {
+			u.DrawRect(types.Rect{X: textX + span[0], Y: textY, W: span[1] - span[0], H: textHeight}, selColor)
+		}
+	}
+
+	// Draw text content (without cursor - cursor drawn separately)
+	u.PushCommand(Command{
+		Kind:  CmdText,
+		Text:  layout.text,
+		Pos:   types.Vec2{X: textX + layout.shift, Y: textY},
+		Color: u.style.Colors.Text,
+		Font:  u.style.Font,
+	})
+
+	// Pop clip rect before drawing cursor (cursor should overlay text)
+	u.PopClip()
+
+	// Draw cursor as thin vertical line (modern style, doesn't shift text)
+	// Drawn after PopClip so it's not clipped by text area
+	if active && opt&(OptNoInteract|OptReadOnly) == 0 {
+		cursorPixelX := textX + layout.caret(*buf, u.textboxCursor)
+		cursorHeight := u.style.Font.Height()
+		cursorRect := types.Rect{X: cursorPixelX, Y: textY, W: 1, H: cursorHeight}
+		u.DrawRect(cursorRect, u.style.Colors.Text)
+	}
+
+	u.item.res |= result
+	return result
+}
+
+// textboxCursorFromClick calculates cursor position from mouse click location.
+// It picks the caret position closest to the click that doesn't split a
+// grapheme cluster.
+func (u *UI) textboxCursorFromClick(buf *[]byte, rect types.Rect, opt int) int {
+	textStartX := rect.X + u.style.Padding.X - u.textboxScrollX
+	clickX := u.input.MousePos.X - textStartX
+
+	layout := u.newTextboxLayout(*buf, opt, rect.W-u.style.Padding.X*2)
+	bestPos, bestDist := 0, abs(clickX-layout.caret(*buf, 0))
+	for pos := 0; pos < len(*buf); {
+		pos = nextGrapheme(*buf, pos)
+		if dist := abs(clickX - layout.caret(*buf, pos)); dist < bestDist {
+			bestPos, bestDist = pos, dist
+		}
+	}
+	return bestPos
+}
+
+// textboxLayout is a textbox's text as displayed.
+type textboxLayout struct {
+	text    string     // Display text, in visual order
+	runes   []rune     // Runes of text
+	bidi    *bidiLine  // Reordering, nil for left-to-right text
+	shift   int        // Offset of right-aligned right-to-left text
+	aligned bool       // Right-to-left text fits and is right-aligned
+	font    types.Font // Font the text is measured with
+	offsets []int      // x of each visual rune boundary, measured on first use
+}
+
+// newTextboxLayout lays out buf for display in a text area width wide.
+func (u *UI) newTextboxLayout(buf []byte, opt int, width int) *textboxLayout {
+	text := textboxDisplay(buf, opt)
+	l := &textboxLayout{text: text, font: u.style.Font}
+	l.runes = []rune(text)
+	if dir := u.textDirection(opt); dir == DirRTL || hasRTL(text) {
+		b := newBidiLine(l.runes, dir)
+		l.bidi, l.runes = &b, b.visual
+		l.text = string(b.visual)
+		if w := types.MeasureRunes(l.font, l.runes); b.rtl && w < width {
+			l.shift = width - w - 1 // Leave room for the cursor
+			l.aligned = true
+		}
+	}
+	return l
+}
+
+func (l *textboxLayout) measure() []int {
+	if l.offsets == nil {
+		l.offsets = types.RuneOffsets(l.font, l.runes, nil)
+	}
+	return l.offsets
+}
+
+// caret returns the x offset of the caret at byte pos of buf.
+func (l *textboxLayout) caret(buf []byte, pos int) int {
+	i := min(utf8.RuneCount(buf[:pos]), len(l.runes))
+	if l.bidi == nil {
+		return l.measure()[i]
+	}
+	return l.shift + l.bidi.caret(l.measure(), i)
+}
+
+// spans returns the x ranges covered by bytes start to end of buf, left
+// to right.
+func (l *textboxLayout) spans(buf []byte, start, end int) [][2]int {
+	first := utf8.RuneCount(buf[:start])
+	last := min(first+utf8.RuneCount(buf[start:end]), len(l.runes))
+	offsets := l.measure()
+	if l.bidi == nil {
+		return [][2]int{{offsets[first], offsets[last]}}
+	}
+	selected := make([]bool, len(l.runes))
+	for i := first; i < last; i++ {
+		selected[l.bidi.pos[i]] = true
+	}
+	var spans [][2]int
+	for v := 0; v < len(selected); v++ {
+		if !selected[v] {
+			continue
+		}
+		w := v
+		for w < len(selected) && selected[w] {
+			w++
+		}
+		spans = append(spans, [2]int{l.shift + offsets[v], l.shift + offsets[w]})
+		v = w
+	}
+	return spans
+}
@@ -0,0 +1,208 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// selectFrame runs one frame with a single 200px textbox and returns its result.
+// With the default GUI style the text starts at x=10 and each MockFont char is 8px.
+func selectFrame(ui *UI, buf *[]byte, text string) int {
+	ui.BeginFrame()
+	if text != "" {
+		ui.TextInput(text)
+	}
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 30)
+	res := ui.Textbox(buf, 128)
+	ui.EndWindow()
+	ui.EndFrame()
+	return res
+}
+
+// focusTextbox clicks at the end of the textbox and releases the mouse.
+func focusTextbox(ui *UI, buf *[]byte) {
+	ui.MouseMove(150, 39)
+	selectFrame(ui, buf, "")
+	ui.MouseDown(150, 39, MouseLeft)
+	selectFrame(ui, buf, "")
+	ui.MouseUp(150, 39, MouseLeft)
+	selectFrame(ui, buf, "")
+}
+
+func pressKey(ui *UI, key Key) {
+	ui.KeyDown(key)
+	ui.KeyUp(key)
+}
+
+func TestTextboxSelect_ShiftArrowAndBackspace(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("hello")
+	focusTextbox(ui, &buf)
+
+	ui.KeyDown(KeyShift)
+	pressKey(ui, KeyLeft)
+	selectFrame(ui, &buf, "")
+	pressKey(ui, KeyLeft)
+	res := selectFrame(ui, &buf, "")
+	ui.KeyUp(KeyShift)
+
+	if res&ResSelection == 0 {
+		t.Fatal("expected ResSelection after shift+left")
+	}
+	if start, end := ui.TextboxSelection(); start != 3 || end != 5 {
+		t.Fatalf("selection = [%d,%d), want [3,5)", start, end)
+	}
+
+	pressKey(ui, KeyBackspace)
+	res = selectFrame(ui, &buf, "")
+	if string(buf) != "hel" {
+		t.Errorf("buf = %q, want %q", buf, "hel")
+	}
+	if res&ResSelection != 0 {
+		t.Error("selection should be cleared after delete")
+	}
+}
+
+func TestTextboxSelect_TypingReplacesSelection(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("hello")
+	focusTextbox(ui, &buf)
+
+	ui.KeyDown(KeyShift)
+	pressKey(ui, KeyHome)
+	selectFrame(ui, &buf, "")
+	ui.KeyUp(KeyShift)
+
+	selectFrame(ui, &buf, "X")
+	if string(buf) != "X" {
+		t.Errorf("buf = %q, want %q", buf, "X")
+	}
+	if ui.textboxCursor != 1 {
+		t.Errorf("cursor = %d, want 1", ui.textboxCursor)
+	}
+}
+
+func TestTextboxSelect_MouseDrag(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("hello")
+	focusTextbox(ui, &buf)
+
+	// Press at start of text, drag to after the third character
+	ui.MouseMove(10, 39)
+	ui.MouseDown(10, 39, MouseLeft)
+	selectFrame(ui, &buf, "")
+	ui.MouseMove(34, 39)
+	res := selectFrame(ui, &buf, "")
+
+	if res&ResSelection == 0 {
+		t.Fatal("expected ResSelection while dragging")
+	}
+	if start, end := ui.TextboxSelection(); start != 0 || end != 3 {
+		t.Errorf("selection = [%d,%d), want [0,3)", start, end)
+	}
+
+	ui.MouseUp(34, 39, MouseLeft)
+	pressKey(ui, KeyDelete)
+	selectFrame(ui, &buf, "")
+	if string(buf) != "lo" {
+		t.Errorf("buf = %q, want %q", buf, "lo")
+	}
+}
+
+func TestTextboxSelect_ArrowCollapses(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("hello")
+	focusTextbox(ui, &buf)
+
+	ui.KeyDown(KeyShift)
+	pressKey(ui, KeyHome)
+	selectFrame(ui, &buf, "")
+	ui.KeyUp(KeyShift)
+
+	pressKey(ui, KeyLeft)
+	res := selectFrame(ui, &buf, "")
+	if res&ResSelection != 0 {
+		t.Error("left arrow without shift should collapse selection")
+	}
+	if ui.textboxCursor != 0 {
+		t.Errorf("cursor = %d, want 0 (start of selection)", ui.textboxCursor)
+	}
+	if string(buf) != "hello" {
+		t.Errorf("buf = %q, want unchanged", buf)
+	}
+}
+
+func TestTextboxSelect_WordMovement(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("foo bar_1, baz")
+	focusTextbox(ui, &buf)
+
+	tests := []struct {
+		mod  Key
+		key  Key
+		want int
+	}{
+		{KeyCtrl, KeyLeft, 11}, // Start of "baz"
+		{KeyCtrl, KeyLeft, 4},  // Start of "bar_1", skipping ", "
+		{KeyAlt, KeyLeft, 0},
+		{KeyCtrl, KeyRight, 3}, // End of "foo"
+		{KeyAlt, KeyRight, 9},  // End of "bar_1"
+		{KeyMeta, KeyRight, 14},
+		{KeyMeta, KeyLeft, 0},
+	}
+	for _, tt := range tests {
+		ui.KeyDown(tt.mod)
+		pressKey(ui, tt.key)
+		selectFrame(ui, &buf, "")
+		ui.KeyUp(tt.mod)
+		if ui.textboxCursor != tt.want {
+			t.Errorf("after %s+%s cursor = %d, want %d", keyName(tt.mod), keyName(tt.key), ui.textboxCursor, tt.want)
+		}
+	}
+
+	// Shift extends the selection by words
+	ui.KeyDown(KeyCtrl)
+	ui.KeyDown(KeyShift)
+	pressKey(ui, KeyRight)
+	selectFrame(ui, &buf, "")
+	ui.KeyUp(KeyShift)
+	ui.KeyUp(KeyCtrl)
+	if start, end := ui.TextboxSelection(); start != 0 || end != 3 {
+		t.Errorf("selection = [%d,%d), want [0,3)", start, end)
+	}
+}
+
+func TestTextboxSelect_SelectAll(t *testing.T) {
+	for _, mod := range []Key{KeyCtrl, KeyMeta} {
+		ui := New(Config{})
+		buf := []byte("hello")
+		focusTextbox(ui, &buf)
+
+		ui.KeyDown(mod)
+		pressKey(ui, KeyA)
+		res := selectFrame(ui, &buf, "")
+		ui.KeyUp(mod)
+		if start, end := ui.TextboxSelection(); res&ResSelection == 0 || start != 0 || end != 5 {
+			t.Errorf("%s+A: selection = [%d,%d), want [0,5)", keyName(mod), start, end)
+		}
+	}
+}
+
+func TestKeyMods(t *testing.T) {
+	ui := New(Config{})
+	ui.KeyDown(KeyCtrl)
+	ui.KeyDown(KeyMeta)
+	ui.BeginFrame()
+	if got := ui.KeyMods(); got != ModCtrl|ModMeta {
+		t.Errorf("KeyMods() = %b, want Ctrl|Meta", got)
+	}
+	ui.EndFrame()
+	ui.KeyUp(KeyCtrl)
+	ui.BeginFrame()
+	if got := ui.KeyMods(); got != ModMeta {
+		t.Errorf("KeyMods() = %b, want Meta", got)
+	}
+	ui.EndFrame()
+}
@@ -632,3 +632,47 @@ func TestTextbox_MultipleTextboxesSameFrame(t *testing.T) {
 		t.Errorf("buf2 = %q, want %q (should be unchanged)", string(buf2), "second")
 	}
 }
+
+// kerningFont is a 10px font that tucks 'V' under a preceding 'A' by 6px.
+type kerningFont struct{}
+
+func (f kerningFont) Width(text string) int { return types.MeasureRunes(f, []rune(text)) }
+func (kerningFont) Height() int             { return 10 }
+
+func (kerningFont) Advance(prev, r rune) int {
+	if prev == 'A' && r == 'V' {
+		return 4
+	}
+	return 10
+}
+
+func TestTextbox_ClickUsesKerning(t *testing.T) {
+	ui := New(Config{})
+	style := ui.Style()
+	style.Font = kerningFont{}
+	ui.SetStyle(style)
+	buf := []byte("AVAV")
+
+	var rect types.Rect
+	frame := func() {
+		ui.BeginFrame()
+		ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+		ui.LayoutRow(1, []int{200}, 30)
+		ui.Textbox(&buf, 128)
+		rect = ui.GetItemRect()
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	frame()
+
+	// Boundaries are at 0, 10, 14, 24 and 28; 13 is closest to the one
+	// after "AV", where plain 10px runes would put it after "A"
+	x, y := rect.X+style.Padding.X+13, rect.Y+rect.H/2
+	ui.MouseMove(x, y)
+	frame()
+	ui.MouseDown(x, y, MouseLeft)
+	frame()
+	if ui.textboxCursor != 2 {
+		t.Errorf("cursor = %d, want 2", ui.textboxCursor)
+	}
+}
@@ -0,0 +1,113 @@
+package microui
+
+import "testing"
+
+// ctrlKey presses Ctrl+key (with Shift if shift is set) for the next frame.
+func ctrlKey(ui *UI, key Key, shift bool) {
+	ui.KeyDown(KeyCtrl)
+	if shift {
+		ui.KeyDown(KeyShift)
+	}
+	pressKey(ui, key)
+}
+
+func releaseCtrl(ui *UI) {
+	ui.KeyUp(KeyCtrl)
+	ui.KeyUp(KeyShift)
+}
+
+func TestTextbox_UndoRedo(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("ab")
+	focusTextbox(ui, &buf)
+
+	selectFrame(ui, &buf, "c")
+	selectFrame(ui, &buf, "d")
+	pressKey(ui, KeyBackspace)
+	selectFrame(ui, &buf, "")
+	if string(buf) != "abc" {
+		t.Fatalf("buf = %q, want %q", buf, "abc")
+	}
+
+	steps := []struct {
+		key   Key
+		shift bool
+		flag  int
+		want  string
+	}{
+		{KeyZ, false, ResUndo, "abcd"}, // Undo the backspace
+		{KeyZ, false, ResUndo, "ab"},   // Typing "cd" is one step
+		{KeyY, false, ResRedo, "abcd"},
+		{KeyZ, true, ResRedo, "abc"},
+	}
+	for _, st := range steps {
+		ctrlKey(ui, st.key, st.shift)
+		res := selectFrame(ui, &buf, "")
+		releaseCtrl(ui)
+		if res&st.flag == 0 || res&ResChange == 0 {
+			t.Errorf("result %b lacks flag %b", res, st.flag)
+		}
+		if string(buf) != st.want {
+			t.Errorf("buf = %q, want %q", buf, st.want)
+		}
+	}
+
+	// Nothing left to redo
+	ctrlKey(ui, KeyY, false)
+	if res := selectFrame(ui, &buf, ""); res&(ResRedo|ResChange) != 0 {
+		t.Errorf("redo with an empty stack returned %b", res)
+	}
+	releaseCtrl(ui)
+}
+
+func TestTextbox_EditClearsRedo(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("")
+	focusTextbox(ui, &buf)
+
+	selectFrame(ui, &buf, "x")
+	ctrlKey(ui, KeyZ, false)
+	selectFrame(ui, &buf, "")
+	releaseCtrl(ui)
+	selectFrame(ui, &buf, "y")
+
+	ctrlKey(ui, KeyY, false)
+	selectFrame(ui, &buf, "")
+	releaseCtrl(ui)
+	if string(buf) != "y" {
+		t.Errorf("buf = %q, want %q", buf, "y")
+	}
+}
+
+func TestTextbox_UndoForgetsAppChanges(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("")
+	focusTextbox(ui, &buf)
+	selectFrame(ui, &buf, "x")
+
+	// The app replaces the text; undo must not bring back the old edit
+	buf = []byte("reset")
+	selectFrame(ui, &buf, "")
+	ctrlKey(ui, KeyZ, false)
+	selectFrame(ui, &buf, "")
+	releaseCtrl(ui)
+	if string(buf) != "reset" {
+		t.Errorf("buf = %q, want %q", buf, "reset")
+	}
+}
+
+func TestTextbox_UndoLimit(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("")
+	focusTextbox(ui, &buf)
+	for i := 0; i < textboxUndoLimit+10; i++ {
+		selectFrame(ui, &buf, "x")
+		pressKey(ui, KeyBackspace) // Breaks the typing run
+		selectFrame(ui, &buf, "")
+	}
+	for _, h := range ui.textboxHist {
+		if len(h.undo) != textboxUndoLimit {
+			t.Errorf("undo stack holds %d steps, want %d", len(h.undo), textboxUndoLimit)
+		}
+	}
+}
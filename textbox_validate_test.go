@@ -0,0 +1,113 @@
+package microui
+
+import (
+	"image/color"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/user/microui-go/types"
+)
+
+// validateFrame runs one frame with a 200px TextboxValidated at (5,29),
+// typing text after BeginFrame, and returns its result.
+func validateFrame(ui *UI, buf *[]byte, opt int, rules TextboxRules, text string) int {
+	ui.BeginFrame()
+	if text != "" {
+		ui.TextInput(text)
+	}
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 30)
+	res := ui.TextboxValidated("field", buf, 32, opt, rules)
+	ui.EndWindow()
+	ui.EndFrame()
+	return res
+}
+
+// typeValidated focuses the textbox and types text into it.
+func typeValidated(ui *UI, buf *[]byte, opt int, rules TextboxRules, text string) int {
+	ui.MouseMove(150, 39)
+	validateFrame(ui, buf, opt, rules, "")
+	ui.MouseDown(150, 39, MouseLeft)
+	validateFrame(ui, buf, opt, rules, "")
+	ui.MouseUp(150, 39, MouseLeft)
+	return validateFrame(ui, buf, opt, rules, text)
+}
+
+func TestTextbox_Numeric(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	typeValidated(ui, &buf, OptNumeric, TextboxRules{}, "-1a2.5x")
+	if string(buf) != "-12.5" {
+		t.Errorf("buf = %q, want %q", buf, "-12.5")
+	}
+}
+
+func TestTextbox_AcceptFunc(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	rules := TextboxRules{Accept: unicode.IsUpper}
+	typeValidated(ui, &buf, 0, rules, "aBcD")
+	if string(buf) != "BD" {
+		t.Errorf("buf = %q, want %q", buf, "BD")
+	}
+}
+
+func TestTextbox_PatternUndoesEdit(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	rules := TextboxRules{Pattern: regexp.MustCompile(`^\d{0,3}$`)}
+	if res := typeValidated(ui, &buf, 0, rules, "12"); res&ResChange == 0 || string(buf) != "12" {
+		t.Fatalf("buf = %q res = %b, want %q changed", buf, res, "12")
+	}
+	if res := validateFrame(ui, &buf, 0, rules, "34"); res&ResChange != 0 || string(buf) != "12" {
+		t.Errorf("edit past the pattern: buf = %q res = %b, want %q unchanged", buf, res, "12")
+	}
+	validateFrame(ui, &buf, 0, rules, "3")
+	if string(buf) != "123" {
+		t.Errorf("buf = %q, want %q", buf, "123")
+	}
+}
+
+func TestTextbox_Validate(t *testing.T) {
+	ui := New(Config{})
+	buf := []byte("user")
+	rules := TextboxRules{Validate: func(s string) bool { return strings.Contains(s, "@") }}
+
+	if res := validateFrame(ui, &buf, 0, rules, ""); res&ResInvalid == 0 {
+		t.Error("text without @ should be invalid")
+	}
+	invalid := color.NRGBAModel.Convert(ui.style.Colors.Invalid).(color.NRGBA)
+	boxes := 0
+	for _, cmd := range ui.RecordFrame().Commands {
+		if cmd.Kind == CmdBox && cmd.Color != nil && *cmd.Color == invalid {
+			boxes++
+		}
+	}
+	if boxes != 1 {
+		t.Errorf("found %d outlines in the Invalid color, want 1", boxes)
+	}
+
+	if res := typeValidated(ui, &buf, 0, rules, "@example.com"); res&ResInvalid != 0 {
+		t.Errorf("%q should be valid", buf)
+	}
+}
+
+func TestTextbox_Password(t *testing.T) {
+	ui := New(Config{})
+	var buf []byte
+	typeValidated(ui, &buf, OptPassword, TextboxRules{}, "héllo")
+	if string(buf) != "héllo" {
+		t.Fatalf("buf = %q, want the typed text", buf)
+	}
+	for _, cmd := range ui.RecordFrame().Commands {
+		if cmd.Kind == CmdText && strings.Contains(cmd.Text, "h") {
+			t.Errorf("password text drawn as %q", cmd.Text)
+		}
+		if cmd.Kind == CmdText && cmd.Text == "*****" {
+			return
+		}
+	}
+	t.Error("password should be drawn as one * per character")
+}
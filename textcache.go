@@ -0,0 +1,54 @@
+package microui
+
+import "github.com/user/microui-go/types"
+
+// wrapKey identifies one wrapping of a text: a hash of its segments,
+// colors, font and direction, and the width it was wrapped to.
+type wrapKey struct {
+	hash  uint64
+	width int
+}
+
+// wrappedText is a cached wrapping, kept while the text is drawn.
+type wrappedText struct {
+	lines [][]textRun
+	frame int // Last frame the text was drawn
+}
+
+// wrapText returns segs wrapped to width, from the cache when the same
+// text was drawn at the same width last frame, so long static text isn't
+// re-measured every frame.
+func (u *UI) wrapText(segs []TextSegment, font types.Font, width int) [][]textRun {
+	h := newFrameHasher()
+	h.value(font)
+	h.color(u.style.Colors.Text)
+	h.int(int(u.textDir))
+	for _, seg := range segs {
+		h.string(seg.Text)
+		h.color(seg.Color)
+	}
+	key := wrapKey{hash: uint64(*h), width: width}
+	if wt := u.textCache[key]; wt != nil {
+		wt.frame = u.frame
+		return wt.lines
+	}
+	lines := wrapSegments(segs, u.style.Colors.Text, font, width, u.textDir)
+	u.textCache[key] = &wrappedText{lines: lines, frame: u.frame}
+	return lines
+}
+
+// InvalidateTextCache drops all cached text wrapping. Wrapping is cached
+// by font identity, so call it after changing a font's metrics in place,
+// e.g. loading a new size into the same font value.
+func (u *UI) InvalidateTextCache() {
+	clear(u.textCache)
+}
+
+// pruneTextCache drops wrapping for text not drawn this frame.
+func (u *UI) pruneTextCache() {
+	for key, wt := range u.textCache {
+		if wt.frame < u.frame {
+			delete(u.textCache, key)
+		}
+	}
+}
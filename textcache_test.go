@@ -0,0 +1,77 @@
+package microui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// countingFont is a MockFont that counts measurements of text containing
+// "ipsum".
+type countingFont struct {
+	types.MockFont
+	calls int
+}
+
+func (f *countingFont) Width(text string) int {
+	if strings.Contains(text, "ipsum") {
+		f.calls++
+	}
+	return f.MockFont.Width(text)
+}
+
+func TestTextCache_SkipsMeasuringUnchangedText(t *testing.T) {
+	const text = "lorem ipsum dolor sit amet, consectetur adipiscing elit"
+	ui := New(Config{})
+	font := &countingFont{}
+	style := ui.Style()
+	style.Font = font
+	ui.SetStyle(style)
+
+	frame := func() []Command {
+		font.calls = 0
+		ui.BeginFrame()
+		ui.BeginWindow("T", types.Rect{X: 0, Y: 0, W: 200, H: 300})
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Text(text)
+		ui.EndWindow()
+		ui.EndFrame()
+		var cmds []Command
+		ui.commands.Each(func(cmd Command) {
+			if cmd.Kind == CmdText && cmd.Text != "T" {
+				cmds = append(cmds, cmd)
+			}
+		})
+		return cmds
+	}
+
+	first := frame()
+	if font.calls == 0 {
+		t.Fatal("first frame didn't measure the text")
+	}
+	second := frame()
+	if font.calls != 0 {
+		t.Errorf("unchanged text measured %d times, want 0", font.calls)
+	}
+	if len(second) != len(first) {
+		t.Fatalf("cached frame has %d lines, want %d", len(second), len(first))
+	}
+	for i := range first {
+		if second[i].Text != first[i].Text || second[i].Pos != first[i].Pos {
+			t.Errorf("line %d = %q at %v, want %q at %v", i, second[i].Text, second[i].Pos, first[i].Text, first[i].Pos)
+		}
+	}
+
+	ui.GetContainer("T").SetRect(types.Rect{X: 0, Y: 0, W: 150, H: 300})
+	if frame(); font.calls == 0 {
+		t.Error("text not re-wrapped after the width changed")
+	}
+	ui.InvalidateTextCache()
+	if frame(); font.calls == 0 {
+		t.Error("text not re-wrapped after InvalidateTextCache")
+	}
+	if n := len(ui.textCache); n != 1 {
+		t.Errorf("cache holds %d wrappings, want only the one drawn", n)
+	}
+}
@@ -0,0 +1,190 @@
+package microui
+
+import (
+	"image/color"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/user/microui-go/types"
+)
+
+// Built-in theme names.
+const (
+	ThemeDark         = "Dark"
+	ThemeLight        = "Light"
+	ThemeBorland      = "Borland"
+	ThemeHighContrast = "HighContrast"
+)
+
+var (
+	themesMu sync.RWMutex
+	themes   = map[string]Style{
+		ThemeDark:         themeStyle(types.DarkTheme()),
+		ThemeLight:        themeStyle(types.LightTheme()),
+		ThemeBorland:      themeStyle(types.BorlandTheme()),
+		ThemeHighContrast: themeStyle(types.HighContrastTheme()),
+	}
+)
+
+// themeStyle returns GUI metrics with the given colors and no font, so
+// SetStyle keeps whatever font the application already uses.
+func themeStyle(colors types.ThemeColors) Style {
+	s := GUIStyle()
+	s.Font = nil
+	s.Colors = colors
+	return s
+}
+
+// RegisterTheme adds or replaces a named theme. It is safe to call from
+// multiple goroutines, e.g. from package init functions.
+func RegisterTheme(name string, style Style) {
+	themesMu.Lock()
+	themes[name] = style
+	themesMu.Unlock()
+}
+
+// Theme returns the registered theme with the given name.
+func Theme(name string) (Style, bool) {
+	themesMu.RLock()
+	s, ok := themes[name]
+	themesMu.RUnlock()
+	return s, ok
+}
+
+// Themes returns the names of all registered themes, sorted.
+func Themes() []string {
+	themesMu.RLock()
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	themesMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// SetStyle replaces the style at runtime. A nil Font keeps the current
+// font. To switch colors only (e.g. keeping TUI metrics), copy the
+// theme's Colors into the current style:
+//
+//	st := ui.Style()
+//	theme, _ := microui.Theme(microui.ThemeLight)
+//	st.Colors = theme.Colors
+//	ui.SetStyle(st)
+func (u *UI) SetStyle(style Style) {
+	if style.Font == nil {
+		style.Font = u.baseStyle.Font
+	}
+	u.baseStyle = style
+	u.style = style.scaled(u.scale)
+}
+
+// ThemeFromPalette derives a complete set of theme colors from three: the
+// accent for buttons and highlights, the surface windows are filled with,
+// and the text color. Hover, active and focus states, borders, title bars,
+// inputs and scrollbars are shades of these, made lighter on a dark
+// surface and darker on a light one. Shades are taken in the OKLab color
+// space, so steps look even across hues.
+//
+//	style := microui.GUIStyle()
+//	style.Colors = microui.ThemeFromPalette(teal, charcoal, offWhite)
+func ThemeFromPalette(primary, surface, text color.Color) types.ThemeColors {
+	// Which way states move: towards the text, away from the surface
+	d := 1.0
+	if l, _, _, _ := toOklab(surface); l > 0.6 {
+		d = -1
+	}
+	base := shade(surface, -0.06*d)
+	invalid := color.Color(color.RGBA{R: 220, G: 80, B: 80, A: 255})
+	if d < 0 {
+		invalid = color.RGBA{R: 200, G: 40, B: 40, A: 255}
+	}
+	return types.ThemeColors{
+		Text:         text,
+		Border:       shade(surface, -0.12*d),
+		WindowBg:     surface,
+		WindowTitle:  shade(surface, -0.08*d),
+		WindowBorder: shade(surface, -0.12*d),
+		TitleText:    text,
+		PanelBg:      shade(surface, -0.03*d),
+		Button:       primary,
+		ButtonHover:  shade(primary, 0.06*d),
+		ButtonActive: shade(primary, 0.12*d),
+		Base:         base,
+		BaseHover:    shade(base, 0.02*d),
+		BaseFocus:    shade(base, 0.04*d),
+		CheckBg:      shade(surface, 0.05*d),
+		CheckActive:  shade(primary, 0.15*d),
+		ScrollBase:   shade(surface, -0.03*d),
+		ScrollThumb:  shade(surface, 0.12*d),
+		Selection:    mixOklab(primary, surface, 0.5),
+		FocusRing:    shade(primary, 0.2*d),
+		Invalid:      invalid,
+	}
+}
+
+// shade returns c with its OKLab lightness moved by dl.
+func shade(c color.Color, dl float64) color.Color {
+	l, a, b, alpha := toOklab(c)
+	return fromOklab(l+dl, a, b, alpha)
+}
+
+// mixOklab blends from x towards y by t in OKLab.
+func mixOklab(x, y color.Color, t float64) color.Color {
+	l1, a1, b1, al1 := toOklab(x)
+	l2, a2, b2, al2 := toOklab(y)
+	mix := func(p, q float64) float64 { return p + (q-p)*t }
+	return fromOklab(mix(l1, l2), mix(a1, a2), mix(b1, b2), mix(al1, al2))
+}
+
+// toOklab converts c to OKLab lightness and a/b axes, plus its alpha.
+func toOklab(c color.Color) (l, a, b, alpha float64) {
+	if c == nil {
+		return 0, 0, 0, 0
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	lin := func(v uint8) float64 {
+		x := float64(v) / 255
+		if x <= 0.04045 {
+			return x / 12.92
+		}
+		return math.Pow((x+0.055)/1.055, 2.4)
+	}
+	r, g, bl := lin(n.R), lin(n.G), lin(n.B)
+	lm := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*bl)
+	mm := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*bl)
+	sm := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*bl)
+	return 0.2104542553*lm + 0.7936177850*mm - 0.0040720468*sm,
+		1.9779984951*lm - 2.4285922050*mm + 0.4505937099*sm,
+		0.0259040371*lm + 0.7827717662*mm - 0.8086757660*sm,
+		float64(n.A) / 255
+}
+
+// fromOklab converts back to sRGB, clamping lightness and out-of-gamut
+// channels.
+func fromOklab(l, a, b, alpha float64) color.Color {
+	l = max(0, min(l, 1))
+	lm := l + 0.3963377774*a + 0.2158037573*b
+	mm := l - 0.1055613458*a - 0.0638541728*b
+	sm := l - 0.0894841775*a - 1.2914855480*b
+	lm, mm, sm = lm*lm*lm, mm*mm*mm, sm*sm*sm
+	enc := func(x float64) uint8 {
+		if x <= 0.0031308 {
+			x *= 12.92
+		} else {
+			x = 1.055*math.Pow(x, 1/2.4) - 0.055
+		}
+		return uint8(max(0, min(x, 1))*255 + 0.5)
+	}
+	n := color.NRGBA{
+		R: enc(+4.0767416621*lm - 3.3077115913*mm + 0.2309699292*sm),
+		G: enc(-1.2684380046*lm + 2.6097574011*mm - 0.3413193965*sm),
+		B: enc(-0.0041960863*lm - 0.7034186147*mm + 1.7076147010*sm),
+		A: uint8(max(0, min(alpha, 1))*255 + 0.5),
+	}
+	if n.A == 255 {
+		return color.RGBA{R: n.R, G: n.G, B: n.B, A: 255}
+	}
+	return n
+}
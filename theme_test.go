@@ -0,0 +1,101 @@
+package microui
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestTheme_BuiltinsRegistered(t *testing.T) {
+	names := Themes()
+	for _, want := range []string{ThemeBorland, ThemeDark, ThemeHighContrast, ThemeLight} {
+		s, ok := Theme(want)
+		if !ok {
+			t.Errorf("theme %q not registered (have %v)", want, names)
+			continue
+		}
+		if s.Colors.Text == nil || s.Colors.WindowBg == nil {
+			t.Errorf("theme %q has nil colors", want)
+		}
+	}
+	for i := 1; i < len(names); i++ {
+		if names[i-1] > names[i] {
+			t.Errorf("Themes() not sorted: %v", names)
+		}
+	}
+}
+
+func TestTheme_Register(t *testing.T) {
+	custom := GUIStyle()
+	custom.Colors.WindowBg = color.RGBA{R: 1, G: 2, B: 3, A: 255}
+	RegisterTheme("test-custom", custom)
+
+	s, ok := Theme("test-custom")
+	if !ok || s.Colors.WindowBg != custom.Colors.WindowBg {
+		t.Errorf("registered theme not returned")
+	}
+}
+
+func TestSetStyle_AppliesNextFrame(t *testing.T) {
+	ui := New(Config{})
+	font := ui.Style().Font
+
+	light, _ := Theme(ThemeLight)
+	ui.SetStyle(light)
+	if ui.Style().Font != font {
+		t.Error("SetStyle with nil Font should keep the current font")
+	}
+
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 200, H: 100})
+	ui.EndWindow()
+	ui.EndFrame()
+
+	want := types.RGBAFromColor(types.LightTheme().WindowBg)
+	found := false
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdRect && types.RGBAFromColor(cmd.Color) == want {
+			found = true
+		}
+	})
+	if !found {
+		t.Error("window background should be drawn with the light theme color")
+	}
+}
+
+func TestThemeFromPalette(t *testing.T) {
+	primary := color.RGBA{R: 40, G: 120, B: 200, A: 255}
+	lum := func(c color.Color) float64 { l, _, _, _ := toOklab(c); return l }
+
+	dark := ThemeFromPalette(primary, color.RGBA{R: 40, G: 40, B: 48, A: 255}, color.White)
+	v := reflect.ValueOf(dark)
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).IsNil() {
+			t.Errorf("Colors.%s not set", v.Type().Field(i).Name)
+		}
+	}
+	if !sameColor(dark.Button, primary) {
+		t.Errorf("Button = %v, want the primary color", dark.Button)
+	}
+	if !(lum(dark.Button) < lum(dark.ButtonHover) && lum(dark.ButtonHover) < lum(dark.ButtonActive)) {
+		t.Errorf("on a dark surface button states should lighten: %v %v %v", dark.Button, dark.ButtonHover, dark.ButtonActive)
+	}
+	if lum(dark.Base) >= lum(dark.WindowBg) {
+		t.Errorf("on a dark surface inputs should be darker than the window: %v vs %v", dark.Base, dark.WindowBg)
+	}
+
+	light := ThemeFromPalette(primary, color.RGBA{R: 240, G: 240, B: 235, A: 255}, color.Black)
+	if !(lum(light.Button) > lum(light.ButtonHover) && lum(light.ButtonHover) > lum(light.ButtonActive)) {
+		t.Errorf("on a light surface button states should darken: %v %v %v", light.Button, light.ButtonHover, light.ButtonActive)
+	}
+}
+
+func TestOklabRoundTrip(t *testing.T) {
+	for _, c := range []color.RGBA{{A: 255}, {R: 255, G: 255, B: 255, A: 255}, {R: 200, G: 30, B: 90, A: 255}, {R: 12, G: 250, B: 3, A: 255}} {
+		if got := fromOklab(toOklab(c)); got != c {
+			t.Errorf("round trip of %v = %v", c, got)
+		}
+	}
+}
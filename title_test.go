@@ -0,0 +1,48 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func TestDrawTitleBar(t *testing.T) {
+	var got types.Rect
+	var gotTitle string
+	ui := New(Config{DrawTitleBar: func(ui *UI, title string, rect types.Rect) {
+		gotTitle, got = title, rect
+		ui.DrawText("custom", types.Vec2{X: rect.X, Y: rect.Y}, nil, ui.style.Colors.Text)
+	}})
+	var own types.Rect
+	ui.BeginFrame()
+	if ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+		ui.EndWindow()
+	}
+	if ui.BeginWindowTitled("Other", types.Rect{X: 0, Y: 0, W: 200, H: 100}, OptNoClose, func(r types.Rect) { own = r }) {
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+
+	h := ui.style.TitleHeight
+	if gotTitle != "Test" || got.Y != 0 || got.H != h || got.W >= 400-h+1 {
+		t.Errorf("callback got %q %v, want the Test title bar left of the close button", gotTitle, got)
+	}
+	if own.W != 200-2*ui.style.BorderWidth || own.H != h {
+		t.Errorf("BeginWindowTitled rect = %v, want the whole title bar without a close button", own)
+	}
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdText && (cmd.Text == "Test" || cmd.Text == "Other") {
+			t.Errorf("title text %q drawn despite the custom title", cmd.Text)
+		}
+	})
+}
+
+func TestTruncateText(t *testing.T) {
+	ui := New(Config{})
+	if got := ui.TruncateText("Hello", 40); got != "Hello" {
+		t.Errorf("fitting text = %q, want it unchanged", got)
+	}
+	if got := ui.TruncateText("Hello world", 56); got != "Hell..." {
+		t.Errorf("truncated = %q, want %q", got, "Hell...")
+	}
+}
@@ -0,0 +1,45 @@
+package microui
+
+import (
+	"image/color"
+
+	"github.com/user/microui-go/types"
+)
+
+// titleButton is an extra title-bar button added with AddTitleButton.
+type titleButton struct {
+	icon    int
+	onClick func()
+}
+
+// AddTitleButton adds a button showing icon to the title bar of the named
+// window, left of its close, maximize and pin buttons. onClick runs when
+// it is clicked, from within the window's BeginWindow. Buttons stay until
+// ClearTitleButtons; later ones go further left. Call it once, e.g. before
+// the first frame, rather than every frame.
+func (u *UI) AddTitleButton(window string, icon int, onClick func()) {
+	cnt := u.GetContainer(window)
+	cnt.titleButtons = append(cnt.titleButtons, titleButton{icon: icon, onClick: onClick})
+}
+
+// ClearTitleButtons removes the buttons added to the named window with
+// AddTitleButton.
+func (u *UI) ClearTitleButtons(window string) {
+	u.GetContainer(window).titleButtons = nil
+}
+
+// titleBarButton draws a button at the right end of titleRect, shrinking
+// it, and reports whether it was clicked.
+func (u *UI) titleBarButton(name string, icon int, titleRect *types.Rect, c color.Color, opt int) bool {
+	id := u.GetID(name)
+	r := types.Rect{
+		X: titleRect.X + titleRect.W - titleRect.H - 1,
+		Y: titleRect.Y,
+		W: titleRect.H,
+		H: titleRect.H,
+	}
+	titleRect.W -= r.W
+	u.DrawIcon(icon, r, c)
+	u.UpdateControlOpt(id, r, opt|OptNoNav)
+	return u.input.MousePressed[int(MouseLeft)] && u.input.Focus == id
+}
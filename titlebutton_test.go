@@ -0,0 +1,121 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// pinFrame draws a pinnable window "A" and a plain window "B" covering it.
+func pinFrame(ui *UI, aOpt int) {
+	ui.BeginFrame()
+	if ui.BeginWindowOpt("A", types.Rect{X: 0, Y: 0, W: 200, H: 150}, aOpt) {
+		ui.EndWindow()
+	}
+	if ui.BeginWindow("B", types.Rect{X: 50, Y: 50, W: 200, H: 150}) {
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+// iconRect returns the rect of the first icon drawn with id.
+func iconRect(ui *UI, id int) (r types.Rect) {
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdIcon && cmd.Icon == id && r.Empty() {
+			r = cmd.Rect
+		}
+	})
+	return r
+}
+
+func frontName(ui *UI) string {
+	sorted := ui.RootContainersSorted()
+	return sorted[len(sorted)-1].Name()
+}
+
+func TestWindow_AlwaysOnTop(t *testing.T) {
+	ui := New(Config{})
+	pinFrame(ui, OptAlwaysOnTop)
+	pinFrame(ui, OptAlwaysOnTop)
+	if !ui.GetContainer("A").Pinned() {
+		t.Fatal("OptAlwaysOnTop should pin the window")
+	}
+	if got := frontName(ui); got != "A" {
+		t.Errorf("front window = %q, want the pinned A above the later B", got)
+	}
+
+	// The overlap belongs to the pinned window
+	ui.MouseMove(100, 100)
+	pinFrame(ui, OptAlwaysOnTop)
+	pinFrame(ui, OptAlwaysOnTop)
+	if ui.hoverRoot != ui.GetContainer("A") {
+		t.Errorf("hover root = %v, want the pinned window", ui.hoverRoot)
+	}
+
+	ui.GetContainer("A").SetPinned(false)
+	pinFrame(ui, OptAlwaysOnTop)
+	if got := frontName(ui); got != "B" {
+		t.Errorf("front window after unpinning = %q, want B", got)
+	}
+}
+
+func TestWindow_PinButton(t *testing.T) {
+	ui := New(Config{})
+	opt := OptPinnable | OptNoClose
+	pinFrame(ui, opt)
+	pin := iconRect(ui, IconPin)
+	if pin.Empty() {
+		t.Fatal("OptPinnable should draw a pin button")
+	}
+	x, y := pin.X+pin.W/2, pin.Y+pin.H/2
+
+	ui.MouseMove(x, y)
+	pinFrame(ui, opt)
+	ui.MouseDown(x, y, MouseLeft)
+	pinFrame(ui, opt)
+	ui.MouseUp(x, y, MouseLeft)
+	pinFrame(ui, opt)
+	if !ui.GetContainer("A").Pinned() {
+		t.Fatal("clicking the pin button should pin the window")
+	}
+	if got := frontName(ui); got != "A" {
+		t.Errorf("front window = %q, want the pinned A", got)
+	}
+
+	ui.MouseDown(x, y, MouseLeft)
+	pinFrame(ui, opt)
+	ui.MouseUp(x, y, MouseLeft)
+	pinFrame(ui, opt)
+	if ui.GetContainer("A").Pinned() {
+		t.Error("clicking the pin button again should unpin the window")
+	}
+}
+
+func TestAddTitleButton(t *testing.T) {
+	ui := New(Config{})
+	clicks := 0
+	ui.AddTitleButton("A", IconCheck, func() { clicks++ })
+	pinFrame(ui, 0)
+	check, closeBtn := iconRect(ui, IconCheck), iconRect(ui, IconClose)
+	if check.Empty() || check.X+check.W > closeBtn.X {
+		t.Fatalf("title button at %v, want it left of the close button at %v", check, closeBtn)
+	}
+	ui.GetContainer("A").SetPinned(true) // Keep A in front of B
+
+	x, y := check.X+check.W/2, check.Y+check.H/2
+	ui.MouseMove(x, y)
+	pinFrame(ui, 0)
+	ui.MouseDown(x, y, MouseLeft)
+	pinFrame(ui, 0)
+	ui.MouseUp(x, y, MouseLeft)
+	pinFrame(ui, 0)
+	if clicks != 1 {
+		t.Errorf("callback ran %d times, want 1", clicks)
+	}
+
+	ui.ClearTitleButtons("A")
+	pinFrame(ui, 0)
+	if !iconRect(ui, IconCheck).Empty() {
+		t.Error("ClearTitleButtons should remove the button")
+	}
+}
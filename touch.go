@@ -0,0 +1,262 @@
+package microui
+
+import (
+	"math"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+// TouchPhase is the stage of a touch a TouchEvent reports.
+type TouchPhase int
+
+const (
+	TouchBegan TouchPhase = iota
+	TouchMoved
+	TouchEnded
+)
+
+// TouchEvent represents a finger touching, moving on or leaving the
+// screen. ID tells simultaneous touches apart.
+type TouchEvent struct {
+	ID    int
+	X, Y  int
+	Phase TouchPhase
+	Time  time.Time
+}
+
+func (TouchEvent) isInput()               {}
+func (e TouchEvent) Timestamp() time.Time { return e.Time }
+
+const (
+	// touchSlop is how far a finger moves before a touch is a drag
+	// rather than a tap or long press.
+	touchSlop = 8
+	// defaultLongPressDelay is used when Config.LongPressDelay is 0.
+	defaultLongPressDelay = 500 * time.Millisecond
+)
+
+// touchPoint is one finger on the screen.
+type touchPoint struct {
+	id         int
+	pos, start types.Vec2
+	began      time.Time // Frame time it was first seen; zero until then
+	ended      bool
+}
+
+// touchGesture is what the primary touch has turned into.
+type touchGesture int
+
+const (
+	gesturePending   touchGesture = iota // Could still be a tap, drag or long press
+	gesturePress                         // Left button held: tap or drag
+	gestureLongPress                     // Right-clicked
+	gestureCancelled                     // Became a pinch; ignored until lifted
+)
+
+// touchInput turns touches into mouse input, see TouchBegin.
+type touchInput struct {
+	points    []*touchPoint // In the order they began; the first is primary
+	gesture   touchGesture
+	sentFrame int        // Frame the last button press was sent in
+	pinchMid  types.Vec2 // Two-finger midpoint last frame
+	pinchDist float64    // Two-finger distance last frame (0 = no pinch)
+	fling     [2]float64 // Two-finger drag speed, kept after lifting with Config.SmoothScroll
+}
+
+// TouchBegin reports finger id touching the screen at x, y. Touches drive
+// the mouse: a tap clicks, a drag moves with the left button held, and a
+// touch held still for Config.LongPressDelay right-clicks, e.g. to open a
+// context menu. Two fingers scroll the panel under them (see TouchPan)
+// and pinch to zoom canvases (see Pinch).
+func (u *UI) TouchBegin(id, x, y int) {
+	u.sendInput(TouchEvent{ID: id, X: x, Y: y, Phase: TouchBegan})
+}
+
+// TouchMove reports finger id moving to x, y.
+func (u *UI) TouchMove(id, x, y int) {
+	u.sendInput(TouchEvent{ID: id, X: x, Y: y, Phase: TouchMoved})
+}
+
+// TouchEnd reports finger id leaving the screen at x, y.
+func (u *UI) TouchEnd(id, x, y int) {
+	u.sendInput(TouchEvent{ID: id, X: x, Y: y, Phase: TouchEnded})
+}
+
+// Pinch returns how much a two-finger pinch zoomed this frame, as a scale
+// factor: above 1 when the fingers moved apart, 1 without a pinch.
+func (u *UI) Pinch() float64 {
+	if u.input.PinchScale == 0 {
+		return 1
+	}
+	return u.input.PinchScale
+}
+
+// TouchPan returns how far a two-finger drag scrolled this frame,
+// including the coasting after the fingers lift with Config.SmoothScroll.
+// It is included in ScrollDelta.
+func (u *UI) TouchPan() types.Vec2 {
+	return u.input.TouchPan
+}
+
+// touchPoint returns the point for finger id, or nil.
+func (t *touchInput) point(id int) *touchPoint {
+	for _, p := range t.points {
+		if p.id == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// touch applies a touch event. The caller holds u.mu.
+func (u *UI) touch(e TouchEvent) {
+	pos := types.Vec2{X: e.X, Y: e.Y}
+	p := u.touches.point(e.ID)
+	switch {
+	case e.Phase == TouchBegan && p == nil:
+		if len(u.touches.points) == 0 {
+			u.touches.gesture = gesturePending
+		}
+		u.touches.fling = [2]float64{} // A new touch catches a coasting scroll
+		u.touches.points = append(u.touches.points, &touchPoint{id: e.ID, pos: pos, start: pos})
+	case p != nil:
+		p.pos = pos
+		p.ended = p.ended || e.Phase == TouchEnded
+	}
+}
+
+// updateTouch turns the touches into this frame's mouse input. Called
+// from BeginFrameAt after the input events are applied. Button changes
+// are a frame apart so controls see the hover, press and release in turn.
+func (u *UI) updateTouch() {
+	t := &u.touches
+	if len(t.points) == 0 {
+		u.coastTouch()
+		return
+	}
+	fresh := false
+	for _, p := range t.points {
+		if p.began.IsZero() {
+			p.began, fresh = u.frameTime, true
+		}
+	}
+
+	// Two fingers: scroll by the midpoint's movement and pinch-zoom by the
+	// change in distance
+	var live []*touchPoint
+	for _, p := range t.points {
+		if !p.ended {
+			live = append(live, p)
+		}
+	}
+	if len(live) >= 2 {
+		u.cancelTouchPress()
+		a, b := live[0].pos, live[1].pos
+		mid := types.Vec2{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+		dist := math.Hypot(float64(b.X-a.X), float64(b.Y-a.Y))
+		if t.pinchDist > 0 {
+			pan := types.Vec2{X: t.pinchMid.X - mid.X, Y: t.pinchMid.Y - mid.Y}
+			u.input.TouchPan = pan
+			u.input.ScrollDelta.X += pan.X
+			u.input.ScrollDelta.Y += pan.Y
+			t.fling[0] = (t.fling[0] + float64(pan.X)) / 2
+			t.fling[1] = (t.fling[1] + float64(pan.Y)) / 2
+			if dist > 0 {
+				u.input.PinchScale = dist / t.pinchDist
+			}
+		}
+		t.pinchMid, t.pinchDist = mid, dist
+		u.mouseMove(mid.X, mid.Y)
+		u.pruneTouches()
+		return
+	}
+	t.pinchDist = 0
+	u.coastTouch()
+
+	p := t.points[0]
+	left, right := u.input.MouseDown[MouseLeft], u.input.MouseDown[MouseRight]
+	switch t.gesture {
+	case gesturePending:
+		moved := abs(p.pos.X-p.start.X) > touchSlop || abs(p.pos.Y-p.start.Y) > touchSlop
+		switch {
+		case fresh:
+			u.mouseMove(p.start.X, p.start.Y) // Hover first, like a mouse
+		case moved || p.ended:
+			// Press where the finger went down; the drag follows next frame
+			u.mouseButton(p.start.X, p.start.Y, MouseLeft, true)
+			t.gesture, t.sentFrame = gesturePress, u.frame
+		case u.frameTime.Sub(p.began) >= u.longPressDelay:
+			u.mouseButton(p.pos.X, p.pos.Y, MouseRight, true)
+			t.gesture, t.sentFrame = gestureLongPress, u.frame
+		}
+	case gesturePress:
+		if t.sentFrame == u.frame {
+			break
+		}
+		u.mouseMove(p.pos.X, p.pos.Y)
+		if p.ended && left {
+			u.mouseButton(p.pos.X, p.pos.Y, MouseLeft, false)
+		}
+	case gestureLongPress:
+		if t.sentFrame != u.frame && right {
+			u.mouseButton(p.pos.X, p.pos.Y, MouseRight, false)
+		}
+	}
+	u.pruneTouches()
+}
+
+// coastTouch keeps a two-finger drag scrolling once the fingers lift,
+// losing speed each frame, if Config.SmoothScroll is set. Like the drag,
+// it pans canvases rather than zooming them.
+func (u *UI) coastTouch() {
+	t := &u.touches
+	if !u.smoothScroll || math.Abs(t.fling[0]) < 0.5 && math.Abs(t.fling[1]) < 0.5 {
+		t.fling = [2]float64{}
+		return
+	}
+	pan := types.Vec2{X: int(math.Round(t.fling[0])), Y: int(math.Round(t.fling[1]))}
+	u.input.TouchPan = pan
+	u.input.ScrollDelta.X += pan.X
+	u.input.ScrollDelta.Y += pan.Y
+	u.input.PinchScale = 1
+	keep := 1 - u.frameFraction(1-scrollFriction)
+	t.fling[0] *= keep
+	t.fling[1] *= keep
+}
+
+// cancelTouchPress stops the primary touch from clicking once a second
+// finger arrives, releasing the button if it was already pressed.
+func (u *UI) cancelTouchPress() {
+	t := &u.touches
+	switch t.gesture {
+	case gesturePress:
+		pos := u.input.MousePos
+		u.mouseButton(pos.X, pos.Y, MouseLeft, false)
+	case gestureLongPress:
+		pos := u.input.MousePos
+		u.mouseButton(pos.X, pos.Y, MouseRight, false)
+	}
+	t.gesture = gestureCancelled
+}
+
+// pruneTouches forgets lifted fingers, once any button the primary touch
+// pressed has been released.
+func (u *UI) pruneTouches() {
+	t := &u.touches
+	n := 0
+	for i, p := range t.points {
+		keep := !p.ended
+		if i == 0 && p.ended && (t.gesture == gesturePending ||
+			u.input.MouseDown[MouseLeft] && t.gesture == gesturePress ||
+			u.input.MouseDown[MouseRight] && t.gesture == gestureLongPress) {
+			keep = true // Still to click or release
+		}
+		if keep {
+			t.points[n] = p
+			n++
+		}
+	}
+	clear(t.points[n:])
+	t.points = t.points[:n]
+}
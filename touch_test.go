@@ -0,0 +1,164 @@
+package microui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+// touchApp is a window with a button at (5,29)-(105,49) that counts clicks
+// and right presses.
+type touchApp struct {
+	ui            *UI
+	now           time.Time
+	clicks, right int
+}
+
+func (a *touchApp) frame(dt time.Duration) {
+	a.now = a.now.Add(dt)
+	a.ui.BeginFrameAt(a.now)
+	if a.ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+		a.ui.LayoutRow(1, []int{100}, 0)
+		if a.ui.Button("Tap") {
+			a.clicks++
+		}
+		if a.ui.MousePressed(MouseRight) {
+			a.right++
+		}
+		a.ui.EndWindow()
+	}
+	a.ui.EndFrame()
+}
+
+func newTouchApp() *touchApp {
+	return &touchApp{ui: New(Config{}), now: time.Unix(0, 0)}
+}
+
+func TestTouch_TapClicks(t *testing.T) {
+	a := newTouchApp()
+	a.frame(16 * time.Millisecond)
+	// Begin and end between two frames still hovers, presses and releases
+	a.ui.TouchBegin(1, 50, 40)
+	a.ui.TouchEnd(1, 50, 40)
+	for i := 0; i < 4; i++ {
+		a.frame(16 * time.Millisecond)
+	}
+	if a.clicks != 1 || a.right != 0 {
+		t.Errorf("clicks=%d right=%d, want a single click", a.clicks, a.right)
+	}
+	if a.ui.input.MouseDown[MouseLeft] || len(a.ui.touches.points) != 0 {
+		t.Error("the touch should be released and forgotten")
+	}
+}
+
+func TestTouch_LongPressRightClicks(t *testing.T) {
+	a := newTouchApp()
+	a.ui.TouchBegin(1, 50, 40)
+	a.frame(16 * time.Millisecond)
+	a.frame(300 * time.Millisecond)
+	if a.right != 0 {
+		t.Fatal("right press before the long press delay")
+	}
+	a.ui.TouchMove(1, 53, 42) // Within the slop
+	a.frame(300 * time.Millisecond)
+	a.frame(16 * time.Millisecond)
+	a.ui.TouchEnd(1, 53, 42)
+	a.frame(16 * time.Millisecond)
+	a.frame(16 * time.Millisecond)
+	if a.right != 1 || a.clicks != 0 {
+		t.Errorf("right=%d clicks=%d, want a right press and no click", a.right, a.clicks)
+	}
+	if a.ui.input.MouseDown[MouseRight] {
+		t.Error("right button should be released")
+	}
+}
+
+func TestTouch_DragMovesWindow(t *testing.T) {
+	a := newTouchApp()
+	a.ui.TouchBegin(1, 50, 10) // Title bar
+	a.frame(16 * time.Millisecond)
+	a.ui.TouchMove(1, 150, 110)
+	a.frame(16 * time.Millisecond)
+	a.frame(16 * time.Millisecond)
+	a.ui.TouchEnd(1, 150, 110)
+	a.frame(16 * time.Millisecond)
+	a.frame(16 * time.Millisecond)
+	if r := a.ui.GetContainer("Test").rect; r.X != 100 || r.Y != 100 {
+		t.Errorf("window at %d,%d, want 100,100 after the drag", r.X, r.Y)
+	}
+	if a.clicks != 0 {
+		t.Error("a drag should not click")
+	}
+}
+
+func TestTouch_SecondFingerCancelsPress(t *testing.T) {
+	a := newTouchApp()
+	a.ui.TouchBegin(1, 50, 40)
+	a.frame(16 * time.Millisecond)
+	a.ui.TouchBegin(2, 90, 40)
+	a.frame(16 * time.Millisecond)
+	a.ui.TouchEnd(1, 50, 40)
+	a.ui.TouchEnd(2, 90, 40)
+	for i := 0; i < 3; i++ {
+		a.frame(600 * time.Millisecond)
+	}
+	if a.clicks != 0 || a.right != 0 {
+		t.Errorf("clicks=%d right=%d, want none for a two-finger touch", a.clicks, a.right)
+	}
+}
+
+func TestTouch_PinchZoomsCanvas(t *testing.T) {
+	ui := New(Config{})
+	canvasFrame(ui)
+	ui.TouchBegin(1, 85, 129)
+	ui.TouchBegin(2, 125, 129)
+	c := canvasFrame(ui)
+	bx, by := c.Mouse()
+	ui.TouchMove(1, 65, 129)
+	ui.TouchMove(2, 145, 129)
+	c = canvasFrame(ui)
+	if c.Zoom != 2 {
+		t.Fatalf("zoom = %v, want 2 after spreading the fingers twice as far", c.Zoom)
+	}
+	if ax, ay := c.Mouse(); ax != bx || ay != by {
+		t.Errorf("point between the fingers moved: (%v, %v) -> (%v, %v)", bx, by, ax, ay)
+	}
+
+	// Moving both fingers pans with them
+	panX := c.PanX
+	ui.TouchMove(1, 85, 129)
+	ui.TouchMove(2, 165, 129)
+	c = canvasFrame(ui)
+	if c.Zoom != 2 || c.PanX != panX-10 {
+		t.Errorf("zoom=%v panX=%v, want 2 and %v after a 20px drag right", c.Zoom, c.PanX, panX-10)
+	}
+	if ui.GetContainer("Test").scroll.Y != 0 {
+		t.Error("the pinch should not scroll the window")
+	}
+}
+
+func TestTouch_TwoFingerDragScrollsPanel(t *testing.T) {
+	ui := New(Config{})
+	frame := func() {
+		ui.BeginFrame()
+		if ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300}) {
+			for i := 0; i < 40; i++ {
+				ui.Label(fmt.Sprint("row ", i))
+			}
+			ui.EndWindow()
+		}
+		ui.EndFrame()
+	}
+	frame()
+	ui.TouchBegin(1, 100, 200)
+	ui.TouchBegin(2, 140, 200)
+	frame()
+	ui.TouchMove(1, 100, 150)
+	ui.TouchMove(2, 140, 150)
+	frame()
+	if got := ui.GetContainer("Test").scroll.Y; got != 50 {
+		t.Errorf("scroll = %d, want 50 after dragging up 50px", got)
+	}
+}
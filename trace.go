@@ -0,0 +1,131 @@
+package microui
+
+// InputTrace is a recording of input events by frame, made with
+// StartInputRecording and played back with PlayInput. It encodes to JSON,
+// e.g. to attach to a bug report.
+type InputTrace struct {
+	Frames int          `json:"frames"` // Frames the recording spans
+	Events []TraceEvent `json:"events"`
+}
+
+// TraceEvent is one recorded input event.
+type TraceEvent struct {
+	Frame  int         `json:"frame"`       // Frame it took effect in, from 0 at the start of the recording
+	Type   string      `json:"type"`        // "move", "button", "scroll", "key", "text", "nav" or "touch"
+	X      int         `json:"x,omitempty"` // move, button, touch: position; scroll: delta
+	Y      int         `json:"y,omitempty"`
+	Button MouseButton `json:"button,omitempty"`
+	Key    Key         `json:"key,omitempty"`
+	Down   bool        `json:"down,omitempty"` // button, key: pressed rather than released
+	Text   string      `json:"text,omitempty"`
+	Nav    Nav         `json:"nav,omitempty"`
+	Touch  int         `json:"touch,omitempty"` // touch: touch ID
+	Phase  TouchPhase  `json:"phase,omitempty"`
+}
+
+// StartInputRecording starts recording the input the UI receives, from
+// the input methods, PushInput and InputChan alike. Call it between
+// frames; a recording already running is discarded.
+func (u *UI) StartInputRecording() {
+	u.mu.Lock()
+	u.recording = &InputTrace{}
+	u.recordBase = u.inputFrame()
+	u.mu.Unlock()
+}
+
+// StopInputRecording stops recording and returns the trace, or nil if no
+// recording was running.
+func (u *UI) StopInputRecording() *InputTrace {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	trace := u.recording
+	if trace != nil {
+		trace.Frames = u.inputFrame() - u.recordBase
+	}
+	u.recording = nil
+	return trace
+}
+
+// PlayInput replays trace: each event is applied in the same frame,
+// counted from the next one, as it was recorded in. Live input is ignored
+// until the last event has played, so the UI sees exactly the recorded
+// input. Call it between frames; a nil trace stops playback.
+func (u *UI) PlayInput(trace *InputTrace) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.playback = nil
+	if trace != nil && len(trace.Events) > 0 {
+		u.playback = trace.Events
+		u.playBase = u.inputFrame()
+	}
+}
+
+// PlayingInput reports whether a trace is playing.
+func (u *UI) PlayingInput() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.playback != nil
+}
+
+// inputFrame returns the frame that input applied now takes effect in.
+func (u *UI) inputFrame() int {
+	if u.inFrame {
+		return u.frame
+	}
+	return u.frame + 1
+}
+
+// recordInput adds ev to the running recording. The caller holds u.mu.
+func (u *UI) recordInput(ev InputEvent) {
+	te := TraceEvent{Frame: u.inputFrame() - u.recordBase}
+	switch e := ev.(type) {
+	case MouseMoveEvent:
+		te.Type, te.X, te.Y = "move", e.X, e.Y
+	case MouseEvent:
+		te.Type, te.X, te.Y, te.Button, te.Down = "button", e.X, e.Y, e.Btn, e.Down
+	case ScrollEvent:
+		te.Type, te.X, te.Y = "scroll", e.DX, e.DY
+	case KeyEvent:
+		te.Type, te.Key, te.Down = "key", e.Key, e.Down
+	case TextEvent:
+		te.Type, te.Text = "text", string(e.Rune)
+	case NavEvent:
+		te.Type, te.Nav = "nav", e.Nav
+	case TouchEvent:
+		te.Type, te.X, te.Y, te.Touch, te.Phase = "touch", e.X, e.Y, e.ID, e.Phase
+	default:
+		return
+	}
+	u.recording.Events = append(u.recording.Events, te)
+}
+
+// playInput applies the trace events due by this frame. The caller holds
+// u.mu.
+func (u *UI) playInput() {
+	n := 0
+	for ; n < len(u.playback) && u.playBase+u.playback[n].Frame <= u.frame; n++ {
+		te := u.playback[n]
+		switch te.Type {
+		case "move":
+			u.handleInput(MouseMoveEvent{X: te.X, Y: te.Y})
+		case "button":
+			u.handleInput(MouseEvent{X: te.X, Y: te.Y, Btn: te.Button, Down: te.Down})
+		case "scroll":
+			u.handleInput(ScrollEvent{DX: te.X, DY: te.Y})
+		case "key":
+			u.handleInput(KeyEvent{Key: te.Key, Down: te.Down})
+		case "text":
+			for _, r := range te.Text {
+				u.handleInput(TextEvent{Rune: r})
+			}
+		case "nav":
+			u.handleInput(NavEvent{Nav: te.Nav})
+		case "touch":
+			u.handleInput(TouchEvent{ID: te.Touch, X: te.X, Y: te.Y, Phase: te.Phase})
+		}
+	}
+	u.playback = u.playback[n:]
+	if len(u.playback) == 0 {
+		u.playback = nil
+	}
+}
@@ -0,0 +1,159 @@
+package microui
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/user/microui-go/types"
+)
+
+// traceApp is a small app whose state shows what input it received.
+type traceApp struct {
+	ui     *UI
+	clicks int
+	text   []byte
+}
+
+func (a *traceApp) frame() {
+	a.typeFrame("")
+}
+
+// typeFrame runs a frame, typing text after BeginFrame as some backends do.
+func (a *traceApp) typeFrame(text string) {
+	a.ui.BeginFrame()
+	if text != "" {
+		a.ui.TextInput(text)
+	}
+	if a.ui.BeginWindow("App", types.Rect{X: 0, Y: 0, W: 300, H: 200}) {
+		if a.ui.Button("Add") {
+			a.clicks++
+		}
+		a.ui.TextboxID("text", &a.text, 32, 0)
+		a.ui.EndWindow()
+	}
+	a.ui.EndFrame()
+}
+
+// recordSession drives a, recording its input from the first frame on.
+func recordSession(a *traceApp) *InputTrace {
+	a.ui.StartInputRecording()
+	a.ui.MouseMove(50, 40) // Add button
+	a.frame()
+	a.ui.MouseDown(50, 40, MouseLeft)
+	a.frame()
+	a.ui.MouseUp(50, 40, MouseLeft)
+	a.frame()
+	a.ui.MouseMove(50, 65) // Textbox
+	a.ui.MouseDown(50, 65, MouseLeft)
+	a.frame()
+	a.ui.MouseUp(50, 65, MouseLeft)
+	a.frame()
+	a.typeFrame("hi")
+	a.frame()
+	return a.ui.StopInputRecording()
+}
+
+func TestInputTrace_RecordAndPlay(t *testing.T) {
+	rec := &traceApp{ui: New(Config{})}
+	trace := recordSession(rec)
+	if rec.clicks != 1 || string(rec.text) != "hi" {
+		t.Fatalf("recorded session: clicks=%d text=%q", rec.clicks, rec.text)
+	}
+	if trace.Frames != 7 {
+		t.Errorf("trace spans %d frames, want 7", trace.Frames)
+	}
+
+	data, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded InputTrace
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	// Playback in a fresh UI reproduces the session, whatever the live
+	// mouse does meanwhile
+	play := &traceApp{ui: New(Config{})}
+	play.ui.PlayInput(&decoded)
+	for i := 0; i < decoded.Frames; i++ {
+		play.ui.MouseMove(290, 190)
+		play.frame()
+	}
+	if play.clicks != rec.clicks || string(play.text) != string(rec.text) {
+		t.Errorf("playback: clicks=%d text=%q, want clicks=%d text=%q", play.clicks, play.text, rec.clicks, rec.text)
+	}
+	if play.ui.PlayingInput() {
+		t.Error("playback should end after the last event")
+	}
+}
+
+func TestInputTrace_KeyRepeat(t *testing.T) {
+	t0 := time.Now()
+	// holdKey holds a key typing "x" for 10 frames 100ms apart and
+	// returns the text each frame received.
+	holdKey := func(ui *UI, live bool) string {
+		var typed string
+		for i := range 10 {
+			ui.BeginFrameAt(t0.Add(time.Duration(i) * 100 * time.Millisecond))
+			if live && i == 0 {
+				ui.KeyDown(KeyChar)
+				ui.TextInput("x")
+			}
+			typed += ui.input.TextInput
+			ui.EndFrame()
+		}
+		return typed
+	}
+
+	rec := New(Config{})
+	rec.StartInputRecording()
+	live := holdKey(rec, true)
+	trace := rec.StopInputRecording()
+	if len(live) < 2 {
+		t.Fatalf("held key typed %q live, want it to repeat", live)
+	}
+
+	play := New(Config{})
+	play.PlayInput(trace)
+	if replayed := holdKey(play, false); replayed != live {
+		t.Errorf("held key typed %q in playback, want %q as recorded", replayed, live)
+	}
+}
+
+func TestInputTrace_QueuedInput(t *testing.T) {
+	ui := New(Config{QueueInput: true})
+	ui.StartInputRecording()
+	ui.KeyDown(KeyEnter)
+	ui.BeginFrame()
+	ui.EndFrame()
+	ui.KeyUp(KeyEnter)
+	ui.BeginFrame()
+	ui.EndFrame()
+	trace := ui.StopInputRecording()
+
+	want := []TraceEvent{
+		{Frame: 0, Type: "key", Key: KeyEnter, Down: true},
+		{Frame: 1, Type: "key", Key: KeyEnter},
+	}
+	if len(trace.Events) != len(want) {
+		t.Fatalf("events = %+v, want %+v", trace.Events, want)
+	}
+	for i := range want {
+		if trace.Events[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, trace.Events[i], want[i])
+		}
+	}
+}
+
+func TestInputTrace_StopWithoutStart(t *testing.T) {
+	ui := New(Config{})
+	if ui.StopInputRecording() != nil {
+		t.Error("StopInputRecording without a recording should return nil")
+	}
+	ui.PlayInput(&InputTrace{})
+	if ui.PlayingInput() {
+		t.Error("an empty trace should not start playback")
+	}
+}
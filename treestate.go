@@ -0,0 +1,112 @@
+package microui
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// treeNode is the kept state of a header or tree node.
+type treeNode struct {
+	expanded bool
+	frame    int      // Last frame it was drawn or loaded in
+	window   ID       // Window it is in (0 = not known yet)
+	path     []string // Names passed to PushID around it, then its label
+}
+
+// savedTreeNode is an entry of the document written by SaveTreeState.
+type savedTreeNode struct {
+	Path     []string `json:"path"`
+	Expanded bool     `json:"expanded"`
+}
+
+// treeNodeExpanded returns whether the header or tree node id is expanded:
+// as it was left, or as opt says the first time it is drawn.
+func (u *UI) treeNodeExpanded(id ID, opt int) bool {
+	if n, ok := u.treeNodeState[id]; ok {
+		return n.expanded
+	}
+	return opt&OptExpanded != 0
+}
+
+// setTreeNode keeps the state of the header or tree node id, labelled
+// label, drawn this frame.
+func (u *UI) setTreeNode(id ID, label string, expanded bool) {
+	n := u.treeNodeState[id]
+	if n.path == nil {
+		// Found out once, when the node is first drawn
+		n.path = append(slices.Clip(u.idNames.items), label)
+		if root := u.rootContainer(); root != nil {
+			n.window = root.id
+		}
+	}
+	n.expanded, n.frame = expanded, u.frame
+	u.treeNodeState[id] = n
+}
+
+// treeNodeID returns the ID of the node at path, as GetID would inside
+// PushID for every name of path but the last.
+func (u *UI) treeNodeID(path []string) ID {
+	return u.getRawID(strings.Join(path, ""))
+}
+
+// SaveTreeState serializes which headers and tree nodes are expanded to
+// JSON, each keyed by its path: the names passed to PushID around it,
+// starting with its window's title, then its label. LoadTreeState
+// restores them, e.g. in the next run of a tool, so the branches the
+// user had open are open again. SaveLayout saves them too, keyed by ID.
+func (u *UI) SaveTreeState() ([]byte, error) {
+	nodes := make([]savedTreeNode, 0, len(u.treeNodeState))
+	for _, n := range u.treeNodeState {
+		if n.path != nil {
+			nodes = append(nodes, savedTreeNode{Path: n.path, Expanded: n.expanded})
+		}
+	}
+	slices.SortFunc(nodes, func(a, b savedTreeNode) int { return slices.Compare(a.Path, b.Path) })
+	return json.Marshal(nodes)
+}
+
+// LoadTreeState restores the expansion saved by SaveTreeState, replacing
+// the state of the nodes it lists and keeping the others. Nodes not drawn
+// since are kept as loaded until PruneTreeNodes drops them.
+func (u *UI) LoadTreeState(data []byte) error {
+	var nodes []savedTreeNode
+	if err := json.Unmarshal(data, &nodes); err != nil {
+		return err
+	}
+	for _, s := range nodes {
+		if len(s.Path) == 0 {
+			continue
+		}
+		u.treeNodeState[u.treeNodeID(s.Path)] = treeNode{
+			expanded: s.Expanded,
+			frame:    u.frame,
+			window:   u.getRawID(s.Path[0]),
+			path:     s.Path,
+		}
+	}
+	return nil
+}
+
+// PruneTreeNodes forgets the expansion of headers and tree nodes not drawn
+// for age frames, e.g. those with generated labels for items since
+// removed; they start over as their options say if drawn again. Nodes
+// inside a collapsed parent aren't drawn either, so pick an age long
+// enough to keep those. The state of a window's nodes also goes when the
+// window is freed with DeleteContainer or Config.RetireContainers.
+func (u *UI) PruneTreeNodes(age int) {
+	for id, n := range u.treeNodeState {
+		if u.frame-n.frame > age {
+			delete(u.treeNodeState, id)
+		}
+	}
+}
+
+// forgetTreeNodes drops the state of the nodes in window.
+func (u *UI) forgetTreeNodes(window ID) {
+	for id, n := range u.treeNodeState {
+		if n.window == window {
+			delete(u.treeNodeState, id)
+		}
+	}
+}
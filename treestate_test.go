@@ -0,0 +1,98 @@
+package microui
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// explorerFrame draws a file tree, returning whether "pkg" was expanded.
+func explorerFrame(ui *UI, files int) (pkg bool) {
+	ui.BeginFrame()
+	if ui.BeginWindow("Explorer", types.Rect{X: 0, Y: 0, W: 200, H: 300}) {
+		if ui.BeginTreeNode("src") {
+			if pkg = ui.BeginTreeNode("pkg"); pkg {
+				ui.EndTreeNode()
+			}
+			for i := range files {
+				if ui.BeginTreeNode(fmt.Sprintf("file%d.go", i)) {
+					ui.EndTreeNode()
+				}
+			}
+			ui.EndTreeNode()
+		}
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+	return pkg
+}
+
+func TestTreeState_SaveLoadByPath(t *testing.T) {
+	ui := New(Config{})
+	explorerFrame(ui, 0)
+	ui.PushID("Explorer")
+	ui.setTreeNode(ui.GetID("src"), "src", true)
+	ui.PushID("src")
+	ui.setTreeNode(ui.GetID("pkg"), "pkg", true)
+	ui.PopID()
+	ui.PopID()
+	if !explorerFrame(ui, 0) {
+		t.Fatal("pkg should be expanded")
+	}
+
+	data, err := ui.SaveTreeState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved []savedTreeNode
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	want := []savedTreeNode{
+		{Path: []string{"Explorer", "src"}, Expanded: true},
+		{Path: []string{"Explorer", "src", "pkg"}, Expanded: true},
+	}
+	if fmt.Sprint(saved) != fmt.Sprint(want) {
+		t.Errorf("saved %s, want %v", data, want)
+	}
+
+	ui2 := New(Config{})
+	if err := ui2.LoadTreeState(data); err != nil {
+		t.Fatal(err)
+	}
+	if !explorerFrame(ui2, 0) {
+		t.Error("pkg should be expanded after LoadTreeState")
+	}
+	if err := ui2.LoadTreeState([]byte("{")); err == nil {
+		t.Error("LoadTreeState should fail on bad JSON")
+	}
+}
+
+func TestTreeState_Prune(t *testing.T) {
+	ui := New(Config{})
+	ui.PushID("Explorer")
+	ui.setTreeNode(ui.GetID("src"), "src", true)
+	ui.PopID()
+	explorerFrame(ui, 5)
+	explorerFrame(ui, 2)
+	explorerFrame(ui, 2)
+	if n := len(ui.treeNodeState); n != 7 {
+		t.Fatalf("%d tree nodes kept, want 7", n)
+	}
+
+	ui.PruneTreeNodes(1)
+	if n := len(ui.treeNodeState); n != 4 {
+		t.Errorf("%d tree nodes after pruning, want src, pkg and the 2 files still drawn", n)
+	}
+}
+
+func TestTreeState_ForgottenWithWindow(t *testing.T) {
+	ui := New(Config{})
+	explorerFrame(ui, 3)
+	ui.DeleteContainer("Explorer")
+	if n := len(ui.treeNodeState); n != 0 {
+		t.Errorf("%d tree nodes kept after deleting their window, want 0", n)
+	}
+}
@@ -75,6 +75,9 @@ func DarkTheme() ThemeColors {
 		CheckActive:  color.RGBA{R: 100, G: 180, B: 100, A: 255},
 		ScrollBase:   color.RGBA{R: 43, G: 43, B: 43, A: 255},
 		ScrollThumb:  color.RGBA{R: 30, G: 30, B: 30, A: 255},
+		Selection:    color.RGBA{R: 60, G: 90, B: 140, A: 255},
+		FocusRing:    color.RGBA{R: 120, G: 170, B: 255, A: 255},
+		Invalid:      color.RGBA{R: 220, G: 80, B: 80, A: 255},
 	}
 }
 
@@ -98,6 +101,63 @@ func LightTheme() ThemeColors {
 		CheckActive:  color.RGBA{R: 60, G: 140, B: 60, A: 255},
 		ScrollBase:   color.RGBA{R: 220, G: 220, B: 220, A: 255},
 		ScrollThumb:  color.RGBA{R: 140, G: 140, B: 140, A: 255},
+		Selection:    color.RGBA{R: 170, G: 200, B: 240, A: 255},
+		FocusRing:    color.RGBA{R: 40, G: 100, B: 200, A: 255},
+		Invalid:      color.RGBA{R: 200, G: 40, B: 40, A: 255},
+	}
+}
+
+// BorlandTheme returns a theme inspired by Borland Turbo Vision: cyan
+// windows, blue title bars and green buttons.
+func BorlandTheme() ThemeColors {
+	return ThemeColors{
+		Text:         color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Border:       color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		WindowBg:     color.RGBA{R: 0, G: 170, B: 170, A: 255},
+		WindowTitle:  color.RGBA{R: 0, G: 0, B: 170, A: 255},
+		WindowBorder: color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		TitleText:    color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		PanelBg:      color.RGBA{R: 0, G: 170, B: 170, A: 255},
+		Button:       color.RGBA{R: 0, G: 170, B: 0, A: 255},
+		ButtonHover:  color.RGBA{R: 0, G: 255, B: 0, A: 255},
+		ButtonActive: color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Base:         color.RGBA{R: 0, G: 0, B: 170, A: 255},
+		BaseHover:    color.RGBA{R: 0, G: 0, B: 200, A: 255},
+		BaseFocus:    color.RGBA{R: 0, G: 0, B: 255, A: 255},
+		CheckBg:      color.RGBA{R: 0, G: 170, B: 170, A: 255},
+		CheckActive:  color.RGBA{R: 255, G: 255, B: 0, A: 255},
+		ScrollBase:   color.RGBA{R: 0, G: 85, B: 85, A: 255},
+		ScrollThumb:  color.RGBA{R: 0, G: 255, B: 255, A: 255},
+		Selection:    color.RGBA{R: 170, G: 170, B: 170, A: 255},
+		FocusRing:    color.RGBA{R: 255, G: 255, B: 0, A: 255},
+		Invalid:      color.RGBA{R: 255, G: 85, B: 85, A: 255},
+	}
+}
+
+// HighContrastTheme returns a black and white theme with yellow accents
+// for low-vision users.
+func HighContrastTheme() ThemeColors {
+	return ThemeColors{
+		Text:         color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Border:       color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		WindowBg:     color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		WindowTitle:  color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		WindowBorder: color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		TitleText:    color.RGBA{R: 255, G: 255, B: 0, A: 255},
+		PanelBg:      color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		Button:       color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		ButtonHover:  color.RGBA{R: 0, G: 0, B: 160, A: 255},
+		ButtonActive: color.RGBA{R: 0, G: 0, B: 255, A: 255},
+		Base:         color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		BaseHover:    color.RGBA{R: 0, G: 0, B: 160, A: 255},
+		BaseFocus:    color.RGBA{R: 0, G: 0, B: 255, A: 255},
+		CheckBg:      color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		CheckActive:  color.RGBA{R: 255, G: 255, B: 0, A: 255},
+		ScrollBase:   color.RGBA{R: 0, G: 0, B: 0, A: 255},
+		ScrollThumb:  color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		Selection:    color.RGBA{R: 0, G: 0, B: 255, A: 255},
+		FocusRing:    color.RGBA{R: 255, G: 255, B: 0, A: 255},
+		Invalid:      color.RGBA{R: 255, G: 0, B: 0, A: 255},
 	}
 }
 
@@ -120,4 +180,7 @@ type ThemeColors struct {
 	CheckActive  color.Color
 	ScrollBase   color.Color // Scrollbar track
 	ScrollThumb  color.Color // Scrollbar thumb
+	Selection    color.Color // Text selection highlight
+	FocusRing    color.Color // Keyboard focus outline
+	Invalid      color.Color // Outline of a textbox whose text fails validation
 }
@@ -27,6 +27,19 @@ func (m *MockFont) Width(text string) int {
 	return w
 }
 
+// WidthRunes returns the sum of rune widths.
+func (m *MockFont) WidthRunes(runes []rune) int {
+	w := 0
+	for _, r := range runes {
+		if rw, ok := m.Widths[r]; ok {
+			w += rw
+		} else {
+			w += 8 // default
+		}
+	}
+	return w
+}
+
 // Height returns the mock font height.
 func (m *MockFont) Height() int {
 	if m.H > 0 {
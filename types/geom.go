@@ -30,3 +30,48 @@ func (r Rect) Contains(p Vec2) bool {
 func (r Rect) Empty() bool {
 	return r.W <= 0 || r.H <= 0
 }
+
+// Insets are distances from the four edges of a rectangle.
+type Insets struct {
+	Left, Top, Right, Bottom int
+}
+
+// Slice9 splits the rectangle into a 3x3 grid by the insets: corners,
+// edges and center, row by row from the top-left. Insets that don't fit
+// are shrunk proportionally.
+func (r Rect) Slice9(in Insets) [9]Rect {
+	left, right := fitInsets(in.Left, in.Right, r.W)
+	top, bottom := fitInsets(in.Top, in.Bottom, r.H)
+	xs := [4]int{r.X, r.X + left, r.X + r.W - right, r.X + r.W}
+	ys := [4]int{r.Y, r.Y + top, r.Y + r.H - bottom, r.Y + r.H}
+
+	var parts [9]Rect
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			parts[row*3+col] = Rect{X: xs[col], Y: ys[row], W: xs[col+1] - xs[col], H: ys[row+1] - ys[row]}
+		}
+	}
+	return parts
+}
+
+// ShadowStrips returns the right and bottom strips of the drop shadow r
+// casts when the shadow is offset right and down by offset. Together they
+// cover the shadow without the part r hides, and don't overlap.
+func (r Rect) ShadowStrips(offset Vec2) [2]Rect {
+	return [2]Rect{
+		{X: r.X + r.W, Y: r.Y + offset.Y, W: offset.X, H: r.H},
+		{X: r.X + offset.X, Y: r.Y + r.H, W: max(0, r.W-offset.X), H: offset.Y},
+	}
+}
+
+// fitInsets shrinks two opposite insets to fit size.
+func fitInsets(a, b, size int) (int, int) {
+	if a+b <= size {
+		return a, b
+	}
+	if size <= 0 {
+		return 0, 0
+	}
+	a = a * size / (a + b)
+	return a, size - a
+}
@@ -70,3 +70,39 @@ func TestRect_Empty(t *testing.T) {
         })
     }
 }
+
+func TestRect_Slice9(t *testing.T) {
+    tests := []struct {
+        name  string
+        r     Rect
+        in    Insets
+        parts [9]Rect
+    }{
+        {"fits", Rect{X: 10, Y: 20, W: 30, H: 20}, Insets{Left: 4, Top: 2, Right: 6, Bottom: 8}, [9]Rect{
+            {10, 20, 4, 2}, {14, 20, 20, 2}, {34, 20, 6, 2},
+            {10, 22, 4, 10}, {14, 22, 20, 10}, {34, 22, 6, 10},
+            {10, 32, 4, 8}, {14, 32, 20, 8}, {34, 32, 6, 8},
+        }},
+        {"shrunk", Rect{W: 10, H: 4}, Insets{Left: 10, Top: 2, Right: 10, Bottom: 6}, [9]Rect{
+            {0, 0, 5, 1}, {5, 0, 0, 1}, {5, 0, 5, 1},
+            {0, 1, 5, 0}, {5, 1, 0, 0}, {5, 1, 5, 0},
+            {0, 1, 5, 3}, {5, 1, 0, 3}, {5, 1, 5, 3},
+        }},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := tt.r.Slice9(tt.in); got != tt.parts {
+                t.Errorf("Slice9() = %v, want %v", got, tt.parts)
+            }
+        })
+    }
+}
+
+func TestRect_ShadowStrips(t *testing.T) {
+    r := Rect{X: 10, Y: 20, W: 30, H: 10}
+    want := [2]Rect{{40, 21, 2, 10}, {12, 30, 28, 1}}
+    if got := r.ShadowStrips(Vec2{X: 2, Y: 1}); got != want {
+        t.Errorf("ShadowStrips() = %v, want %v", got, want)
+    }
+}
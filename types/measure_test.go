@@ -0,0 +1,66 @@
+package types
+
+import (
+	"slices"
+	"testing"
+)
+
+// kernFont is a 10px font that tucks 'V' under a preceding 'A' by 3px.
+type kernFont struct{}
+
+func (kernFont) Width(text string) int { return MeasureRunes(kernFont{}, []rune(text)) }
+func (kernFont) Height() int           { return 10 }
+
+func (kernFont) Advance(prev, r rune) int {
+	if prev == 'A' && r == 'V' {
+		return 7
+	}
+	return 10
+}
+
+func TestRuneCells(t *testing.T) {
+	tests := []struct {
+		r    rune
+		want int
+	}{
+		{'a', 1},
+		{'é', 1},
+		{'\t', 0},
+		{'\u0301', 0}, // combining acute accent
+		{'\u200d', 0}, // zero width joiner
+		{'漢', 2},
+		{'한', 2},
+		{'Ａ', 2}, // fullwidth A
+		{'😀', 2},
+		{'→', 1},
+	}
+	for _, tt := range tests {
+		if got := RuneCells(tt.r); got != tt.want {
+			t.Errorf("RuneCells(%q) = %d, want %d", tt.r, got, tt.want)
+		}
+	}
+	if got := StringCells("é漢字!"); got != 6 {
+		t.Errorf("StringCells = %d, want 6", got)
+	}
+}
+
+func TestMeasureRunes(t *testing.T) {
+	mock := &MockFont{Widths: map[rune]int{'a': 5}}
+	if got := MeasureRunes(mock, []rune("aab")); got != 18 {
+		t.Errorf("MeasureRunes(MockFont) = %d, want 18", got)
+	}
+	if got := MeasureRunes(kernFont{}, []rune("AVA")); got != 27 {
+		t.Errorf("MeasureRunes(kerning) = %d, want 27", got)
+	}
+}
+
+func TestRuneOffsets(t *testing.T) {
+	got := RuneOffsets(kernFont{}, []rune("AVA"), nil)
+	if want := []int{0, 10, 17, 27}; !slices.Equal(got, want) {
+		t.Errorf("RuneOffsets(kerning) = %v, want %v", got, want)
+	}
+	got = RuneOffsets(&MockFont{Widths: map[rune]int{'i': 3}}, []rune("iw"), got[:0])
+	if want := []int{0, 3, 11}; !slices.Equal(got, want) {
+		t.Errorf("RuneOffsets(MockFont) = %v, want %v", got, want)
+	}
+}
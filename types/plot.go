@@ -0,0 +1,89 @@
+package types
+
+import "math"
+
+// PlotKind is how a Plot draws its values.
+type PlotKind int
+
+const (
+	PlotLines     PlotKind = iota // Values joined by a line, left to right
+	PlotHistogram                 // One bar per value, up from Min
+)
+
+// Plot is a graph of values, drawn by microui's PlotLines and
+// PlotHistogram. A renderer can draw it its own way, e.g. with braille
+// characters in a terminal, or fill the rects from EachRect.
+type Plot struct {
+	Kind     PlotKind
+	Values   []float64
+	Min, Max float64 // Values drawn at the bottom and top of the rect
+}
+
+// Scale maps v to 0 at Min and 1 at Max, clamped to [0, 1]. A plot with
+// no range draws its values halfway up.
+func (p *Plot) Scale(v float64) float64 {
+	if p.Max <= p.Min {
+		return 0.5
+	}
+	return math.Max(0, math.Min((v-p.Min)/(p.Max-p.Min), 1))
+}
+
+// At returns the value drawn at column x of a plot w columns wide: the
+// line interpolated between its neighbouring values, or the bar's value.
+// It is NaN where there is nothing to draw.
+func (p *Plot) At(x, w int) float64 {
+	n := len(p.Values)
+	if n == 0 || w <= 0 {
+		return math.NaN()
+	}
+	if p.Kind == PlotHistogram {
+		return p.Values[min(x*n/w, n-1)]
+	}
+	if n == 1 || w == 1 {
+		return p.Values[0]
+	}
+	t := float64(x) * float64(n-1) / float64(w-1)
+	i := min(int(t), n-2)
+	f := t - float64(i)
+	return p.Values[i]*(1-f) + p.Values[i+1]*f
+}
+
+// EachRect calls fn with the filled rects that draw the plot in rect: a
+// one-pixel-wide segment per column for lines, a rect per bar for a
+// histogram.
+func (p *Plot) EachRect(rect Rect, fn func(Rect)) {
+	if rect.W <= 0 || rect.H <= 0 {
+		return
+	}
+	bottom := rect.Y + rect.H
+	if p.Kind == PlotHistogram {
+		n := len(p.Values)
+		for i, v := range p.Values {
+			x0, x1 := rect.X+i*rect.W/n, rect.X+(i+1)*rect.W/n
+			if x1-x0 > 2 {
+				x1-- // Gap between bars
+			}
+			h := int(math.Round(p.Scale(v) * float64(rect.H)))
+			if x1 > x0 && h > 0 && !math.IsNaN(v) {
+				fn(Rect{X: x0, Y: bottom - h, W: x1 - x0, H: h})
+			}
+		}
+		return
+	}
+
+	prev := -1
+	for x := range rect.W {
+		v := p.At(x, rect.W)
+		if math.IsNaN(v) {
+			prev = -1
+			continue
+		}
+		y := bottom - 1 - int(math.Round(p.Scale(v)*float64(rect.H-1)))
+		if prev < 0 {
+			prev = y
+		}
+		top, h := min(prev, y), max(prev, y)-min(prev, y)+1
+		fn(Rect{X: rect.X + x, Y: top, W: 1, H: h})
+		prev = y
+	}
+}
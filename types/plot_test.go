@@ -0,0 +1,66 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPlot_At(t *testing.T) {
+	p := Plot{Kind: PlotLines, Values: []float64{0, 10, 20}}
+	for x, want := range []float64{0, 5, 10, 15, 20} {
+		if got := p.At(x, 5); got != want {
+			t.Errorf("lines At(%d, 5) = %v, want %v", x, got, want)
+		}
+	}
+
+	p.Kind = PlotHistogram
+	for x, want := range []float64{0, 0, 10, 10, 20, 20} {
+		if got := p.At(x, 6); got != want {
+			t.Errorf("histogram At(%d, 6) = %v, want %v", x, got, want)
+		}
+	}
+
+	if v := (&Plot{}).At(0, 10); !math.IsNaN(v) {
+		t.Errorf("empty plot At = %v, want NaN", v)
+	}
+}
+
+func TestPlot_Scale(t *testing.T) {
+	p := Plot{Min: 10, Max: 20}
+	for v, want := range map[float64]float64{10: 0, 15: 0.5, 20: 1, 0: 0, 30: 1} {
+		if got := p.Scale(v); got != want {
+			t.Errorf("Scale(%v) = %v, want %v", v, got, want)
+		}
+	}
+	if got := (&Plot{Min: 3, Max: 3}).Scale(3); got != 0.5 {
+		t.Errorf("Scale with no range = %v, want 0.5", got)
+	}
+}
+
+func TestPlot_EachRectHistogram(t *testing.T) {
+	p := Plot{Kind: PlotHistogram, Values: []float64{1, 0, 2}, Min: 0, Max: 2}
+	var got []Rect
+	p.EachRect(Rect{X: 10, Y: 0, W: 30, H: 20}, func(r Rect) { got = append(got, r) })
+	want := []Rect{{X: 10, Y: 10, W: 9, H: 10}, {X: 30, Y: 0, W: 9, H: 20}}
+	if len(got) != len(want) {
+		t.Fatalf("bars = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("bar %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPlot_EachRectLinesJoin(t *testing.T) {
+	p := Plot{Kind: PlotLines, Values: []float64{0, 1}, Min: 0, Max: 1}
+	rect := Rect{X: 0, Y: 0, W: 2, H: 10}
+	var got []Rect
+	p.EachRect(rect, func(r Rect) { got = append(got, r) })
+	// The second column reaches back to the first so a steep line has no
+	// gaps
+	want := []Rect{{X: 0, Y: 9, W: 1, H: 1}, {X: 1, Y: 0, W: 1, H: 10}}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("segments = %v, want %v", got, want)
+	}
+}
@@ -1,12 +1,14 @@
 package microui
 
 import (
+	"cmp"
 	"fmt"
 	"image/color"
-	"sort"
+	"math"
 	"strconv"
-	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/user/microui-go/types"
 )
@@ -22,6 +24,11 @@ type (
 	IconRenderer interface {
 		DrawIcon(id int, rect types.Rect, c color.Color)
 	}
+	// NamedIconRenderer is an IconRenderer that also receives the icon's
+	// name, so it can draw application icons registered with RegisterIcon.
+	NamedIconRenderer interface {
+		DrawNamedIcon(id int, name string, rect types.Rect, c color.Color)
+	}
 	BoxRenderer interface {
 		DrawBox(rect types.Rect, c color.Color)
 	}
@@ -29,6 +36,45 @@ type (
 		DrawScrollTrack(rect types.Rect)
 		DrawScrollThumb(rect types.Rect)
 	}
+	// ScaleRenderer receives the UI scale before each Render so it can
+	// pick a matching font size.
+	ScaleRenderer interface {
+		SetScale(scale float64)
+	}
+	// ImageRenderer draws application images. img is whatever handle was
+	// passed to UI.Image; renderers ignore handles they don't understand.
+	ImageRenderer interface {
+		DrawImage(img any, rect, src types.Rect, tint color.Color)
+	}
+	// NineSliceRenderer draws nine-patch images. Without it, nine-slice
+	// commands are drawn as nine DrawImage calls.
+	NineSliceRenderer interface {
+		DrawNineSlice(img any, rect, src types.Rect, border types.Insets, tint color.Color)
+	}
+	// ShadowRenderer draws the drop shadows of Style.WindowShadow: rect is
+	// the window, and the shadow is rect moved by offset, less the part the
+	// window covers (see types.Rect.ShadowStrips). c's alpha is how much
+	// the shadow darkens.
+	ShadowRenderer interface {
+		DrawWindowShadow(rect types.Rect, offset types.Vec2, c color.Color)
+	}
+	// CustomRenderer receives the application's own commands added with
+	// DrawCustom. Renderers without it skip them.
+	CustomRenderer interface {
+		DrawCustom(rect types.Rect, data any)
+	}
+	// DirtyRenderer is told which areas changed before Render draws a
+	// frame, with Config.DirtyRegions set. It is still given every command
+	// and may skip drawing outside the regions.
+	DirtyRenderer interface {
+		SetDirtyRegions(regions []types.Rect)
+	}
+	// PlotRenderer draws graphs from PlotLines and PlotHistogram its own
+	// way, e.g. with braille characters in a terminal. Without it, plots
+	// are drawn as the rects of types.Plot.EachRect.
+	PlotRenderer interface {
+		DrawPlot(rect types.Rect, plot *types.Plot, c color.Color)
+	}
 )
 
 // Config configures a new UI instance.
@@ -37,44 +83,200 @@ type Config struct {
 	CommandBuf    int
 	InputChanSize int
 	DrawFrame     func(ui *UI, rect types.Rect, colorID int) // Custom frame drawing callback
+	Clipboard     Clipboard                                  // Clipboard for text controls (nil = process-local)
+	ModalOverlay  func(ui *UI, rect types.Rect)              // Draws the backdrop behind modals (nil = translucent black)
+	Animations    bool                                       // Animate hover colors, header expand/collapse and window open/close
+
+	// DrawTitleBar draws window titles in place of the title text (nil =
+	// the text). rect is the part of the title bar the window's buttons
+	// leave free, for icons, status dots, extra buttons or truncated text.
+	// BeginWindowTitled overrides it per window.
+	DrawTitleBar func(ui *UI, title string, rect types.Rect)
+
+	// ConstrainToScreen keeps windows inside the screen set by SetScreenSize
+	// while they are dragged or resized.
+	ConstrainToScreen bool
+
+	// RetireContainers frees a window, panel or popup not begun or looked
+	// up with GetContainer for that many frames, so containers with
+	// generated names don't pile up (0 = keep them all). A window drawn
+	// again afterwards starts over from the rect passed to BeginWindow.
+	// See UI.DeleteContainer and UI.Containers.
+	RetireContainers int
+
+	// QueueInput makes MouseMove, MouseDown, KeyDown and the other input
+	// methods queue timestamped events instead of changing the input state.
+	// BeginFrame applies them in timestamp order, so input can be fed from
+	// any goroutine while a frame is being built.
+	QueueInput bool
+
+	// CheckGoroutine makes the UI panic, naming both goroutines, when
+	// BeginFrame, EndFrame, Render or an input method is called on a
+	// goroutine other than the one that called BeginFrame first. The UI
+	// isn't safe for concurrent use apart from queued input, so this
+	// catches e.g. a ticker goroutine calling MouseMove without
+	// QueueInput. It reads the stack on every such call, so turn it on in
+	// debug builds and tests. Leave it off under js/wasm, where every
+	// JavaScript callback runs on a goroutine of its own.
+	CheckGoroutine bool
+
+	// KeyRepeatDelay is how long a key is held before it repeats (0 =
+	// 400ms, negative = no repeat). While it repeats, KeyPressed fires
+	// again every KeyRepeatInterval (0 = 50ms) along with the text the
+	// key typed. Backends report presses and releases only.
+	KeyRepeatDelay    time.Duration
+	KeyRepeatInterval time.Duration
+
+	// ButtonRepeatDelay is how long an OptRepeat button is held before it
+	// clicks again (0 = 400ms), and ButtonRepeatInterval how often it then
+	// clicks (0 = 50ms). Number spinner and tab scroll buttons repeat too.
+	ButtonRepeatDelay    time.Duration
+	ButtonRepeatInterval time.Duration
+
+	// LongPressDelay is how long a touch is held still before it counts as
+	// a right-click (0 = 500ms). See TouchBegin.
+	LongPressDelay time.Duration
+
+	// SmoothScroll makes the scroll wheel ease containers to their new
+	// offset over a few frames, and two-finger touch drags keep scrolling
+	// after the fingers lift, slowing down until they stop.
+	SmoothScroll bool
+
+	// DirtyRegions makes EndFrame work out which areas of the screen changed
+	// since the previous frame, see UI.DirtyRegions. Render passes them to
+	// a DirtyRenderer. It keeps last frame's commands, like Animations.
+	DirtyRegions bool
+
+	// TextDirection is the base direction of text (DirAuto = from the
+	// text's first strong character). Right-to-left text is reordered for
+	// display and aligned to the right; see UI.SetTextDirection.
+	TextDirection TextDirection
+
+	// Accessibility makes each frame collect an accessibility tree of its
+	// windows and controls, see UI.AccessTree. Setting AccessBridge turns
+	// it on too, and passes the tree to the bridge at the end of a frame.
+	Accessibility bool
+	AccessBridge  AccessBridge
+
+	// OnUIEvent is called during the frame when a button is clicked, a
+	// checkbox or radio button changes, a slider moves, a popup opens or a
+	// window opens or closes, e.g. to play click sounds or rumble a
+	// controller. See UIEvent.
+	OnUIEvent func(event UIEvent)
+
+	// OnInvalidate is called when the UI goes from needing no frame to
+	// needing one, see UI.NeedsRedraw: on input, at the end of a frame
+	// that leaves an animation running, and on UI.Invalidate. Event-driven
+	// hosts use it to wake their loop, e.g. by sending a Bubble Tea
+	// message. It may be called on an input goroutine or during EndFrame,
+	// so it must not build a frame itself. Events sent on InputChan don't
+	// call it, though NeedsRedraw sees them.
+	OnInvalidate func()
 }
 
 // UI is the main context for immediate-mode UI.
 type UI struct {
-	style    Style
-	commands CommandBuffer
-	input    InputState
-	inputCh  chan InputEvent
+	style     Style   // Style in use: baseStyle with metrics scaled
+	baseStyle Style   // Style as set by the application
+	scale     float64 // UI scale factor (1 = unscaled)
+	commands  CommandBuffer
+	input     InputState
+	inputCh   chan InputEvent
+
+	// Queued input (see Config.QueueInput and PushInput), guarded by mu
+	queueInput bool
+	inputQueue []InputEvent
+	inputBatch []InputEvent // Reused by processInput
+
+	// Screen to UI mapping of mouse and touch input (see SetInputTransform),
+	// guarded by mu
+	inputScale  float64 // 0 = no transform
+	inputOffset types.Vec2
+
+	// Input recording and playback (see StartInputRecording), guarded by mu
+	recording  *InputTrace
+	recordBase int          // Frame the recording started in
+	playback   []TraceEvent // Events still to play
+	playBase   int          // Frame the playback started in
+	inFrame    bool         // Between BeginFrame and EndFrame
+
+	// Key repeat (see Config.KeyRepeatDelay)
+	frameTime      time.Time     // Time passed to BeginFrameAt
+	frameDelta     time.Duration // Time animations advance by this frame
+	repeatDelay    time.Duration // Negative disables repeat
+	repeatInterval time.Duration
+	repeatKey      Key       // Last key pressed, which is the one that repeats
+	repeatArmed    bool      // repeatKey is held
+	repeatText     string    // Text typed by repeatKey
+	repeatFired    bool      // repeatKey has repeated, so new text isn't its own
+	repeatNext     time.Time // When repeatKey next fires (zero = not yet timed)
+
+	// Button repeat (see Config.ButtonRepeatDelay)
+	buttonRepeatDelay    time.Duration
+	buttonRepeatInterval time.Duration
+	buttonRepeat         ID        // Repeating control being held
+	buttonRepeatNext     time.Time // When buttonRepeat next clicks
+
+	// Touch input (see TouchBegin)
+	touches        touchInput
+	longPressDelay time.Duration
+	smoothScroll   bool
 
 	// Pools
 	windowPool     growPool[Window]
 	layoutStack    growStack[Layout]
 	clipStack      growStack[types.Rect]
 	idStack        growStack[ID]
+	idNames        growStack[string] // Names passed to PushID, for tree node paths
 	panelStack     growStack[Panel]
 	columnStack    growStack[ColumnLayout]
+	groupStack     growStack[groupFrame]
 	containerStack growStack[*Container]
 
 	// Container management
-	containers map[ID]*Container
-	lastZIndex int
+	containers   map[ID]*Container
+	lastZIndex   int
+	retireFrames int // Config.RetireContainers
 
 	// Root container system (for z-order and hover-root gating)
 	rootList      []*Container // Containers rendered this frame (in submission order)
+	zOrder        []*Container // Root containers of any frame, back to front (see restack)
+	rootsSorted   []*Container // Reused by RootContainersSorted
 	hoverRoot     *Container   // Container that should receive input this frame
 	nextHoverRoot *Container   // Candidate hover root for next frame
-	scrollTarget  *Container   // Container receiving scroll input
+	scrollTarget  *Container   // Root container receiving scroll input
+	scrollPanel   *Container   // Innermost panel under the mouse
+	overlay       []Command    // Drawn above every root container, see OverlayCommand
 
 	// Current state
 	currentWindowRect types.Rect // Direct storage instead of pointer
+	screen            types.Rect // Screen area set by SetScreenSize (maximized windows fill it)
+	constrainToScreen bool       // Keep windows inside screen
 
 	// State tracking
-	treeNodeState map[ID]bool // Tracks expanded/collapsed state for headers/tree nodes
+	treeNodeState map[ID]treeNode // Tracks expanded/collapsed state for headers/tree nodes
+	listBoxes     map[ID]*listBoxState
+	tabBars       map[ID]*tabBarState    // Active tab and scroll per tab bar
+	tabBarStack   growStack[tabBarFrame] // Tab bars being built, innermost last
+	listClip      listClipFrame          // List currently being clipped
+	canvases      map[ID]*Canvas         // Pan/zoom view per canvas
 
 	// Textbox state
-	textboxCursor   int // Cursor position in current textbox (byte offset)
-	textboxScrollX  int // Horizontal scroll offset for current textbox (pixels)
-	lastTextboxID   ID  // ID of last focused textbox (reset cursor on focus change)
+	textboxCursor    int                    // Cursor position in current textbox (byte offset)
+	textboxScrollX   int                    // Horizontal scroll offset for current textbox (pixels)
+	lastTextboxID    ID                     // ID of last focused textbox (reset cursor on focus change)
+	textboxAnchor    int                    // Selection anchor (byte offset); selection spans anchor..cursor
+	textboxSelActive bool                   // Whether textboxAnchor marks a live selection
+	textboxSelecting bool                   // Mouse drag selection in progress
+	textboxHist      map[ID]*textboxHistory // Undo/redo stacks per edited textbox
+
+	// Combo state (only one dropdown list is open at a time)
+	comboID        ID  // ID of the combo whose list is open
+	comboHighlight int // Highlighted row in the open list
+
+	// Date/time picker state (only one picker popup is open at a time)
+	pickerID    ID        // ID of the picker whose popup is open
+	pickerMonth time.Time // First day of the month the calendar shows
 
 	// Number textbox edit mode (shift-click)
 	numberTextboxID  ID     // ID of number being edited as textbox
@@ -83,6 +285,9 @@ type UI struct {
 	// Frame counter for pool management
 	frame int
 
+	// Drawing commands culled this frame (see Stats)
+	culled int
+
 	// Window interaction state
 	dragID           ID         // ID of container being dragged
 	dragOffset       types.Vec2 // Offset from container origin to drag start point
@@ -90,17 +295,101 @@ type UI struct {
 	resizeStartRect  types.Rect // Window rect when resize started
 	resizeStartMouse types.Vec2 // Mouse position when resize started
 
-	// Custom drawing callback
-	drawFrame func(ui *UI, rect types.Rect, colorID int)
+	// Custom drawing callbacks
+	drawFrame    func(ui *UI, rect types.Rect, colorID int)
+	modalOverlay func(ui *UI, rect types.Rect)
+	drawTitleBar func(ui *UI, title string, rect types.Rect)
+	nextTitle    func(rect types.Rect) // BeginWindowTitled's callback for the window beginning
+
+	// Application icon names by ID (see RegisterIcon)
+	iconNames map[int]string
+
+	// Keyboard focus traversal
+	focusList        []focusEntry // Focusable controls in submission order this frame
+	navFocus         ID           // Control focused via Tab/Shift-Tab (0 = none)
+	focusNext        bool         // FocusNext: focus the next focusable control
+	focusWindow      *Container   // FocusNextWindow: focus this window's first control
+	focusWindowBegun bool         // focusWindow began since it was requested
+	revealFocus      bool         // Scroll navFocus into view when it registers
+
+	// Docking
+	dockSpaces map[string]*dockSpace
+	dockDrag   *Container // Floating window being dragged (drop candidate)
+
+	// Animation state
+	animations   bool
+	anims        map[animKey]*animState
+	reveals      []revealSection // Header sections being revealed, innermost last
+	prevCommands CommandBuffer   // Last frame's commands, drawn by closing windows
+	prevRoots    []*Container    // Last frame's root containers
+	fading       []*Container    // Closed windows still fading out
+
+	// Dirty region tracking (Config.DirtyRegions)
+	trackDirty   bool
+	dirty        []types.Rect // Areas changed this frame
+	dirtyPending []types.Rect // Added with AddDirtyRegion, reported at EndFrame
+	dirtyAll     bool         // Report the whole screen next frame
+	prevOverlay  []Command    // Last frame's overlay
+
+	// Text wrapping cache (wrapText)
+	textCache map[wrapKey]*wrappedText
+	textDir   TextDirection // Base text direction (SetTextDirection)
+
+	// Accessibility tree (Config.Accessibility)
+	accessOn      bool
+	accessBridge  AccessBridge
+	accessRoots   []*AccessNode // Last frame's windows, back to front
+	accessPending []*Container  // Windows begun this frame
+	accessLast    *AccessNode   // Node of the last control, for AccessItem
+
+	onUIEvent func(event UIEvent) // Config.OnUIEvent
+
+	// Frame requests (see NeedsRedraw)
+	onInvalidate func()      // Config.OnInvalidate
+	redraw       atomic.Bool // A frame is needed
+	inputSeen    atomic.Bool // Input arrived since BeginFrame
+	settle       bool        // This frame had input, so draw one more
+	busy         bool        // This frame drew a busy indicator, which moves every frame
+
+	// Frame hashing (FrameChanged)
+	frameHash      uint64 // Hash of the last frame FrameChanged checked
+	frameHashFrame int    // Frame it was taken in
+	frameChanged   bool   // Result for that frame
+
+	// Modal dialog state
+	modal      *Container // Open modal dialog (nil = none)
+	modalFrame int        // Last frame the modal was submitted
+
+	// Clipboard provider for copy/paste in text controls
+	clipboard Clipboard
 
 	// Last layout rect returned
 	lastRect types.Rect
 
+	// Last control updated, for IsItemHovered and friends
+	item      itemState
+	itemTrack itemTrack
+
 	mu sync.Mutex
 
+	// Goroutine building the frames, with Config.CheckGoroutine
+	checkGo bool
+	owner   atomic.Uint64
+
 	// Debug support
-	debug    bool
-	debugLog func(format string, args ...any)
+	debug     bool
+	debugLog  func(format string, args ...any)
+	debugCur  debugFrame // This frame, for DebugWindow
+	debugPrev debugFrame // Last complete frame, shown by DebugWindow
+
+	metrics *frameMetrics // Timings for MetricsWindow, nil until it is first called
+
+	statusBars [DockBottom + 1]statusBarState // By edge, for workArea
+
+	shortcuts    []*Shortcut // Registered with RegisterShortcut, in order
+	shortcutsOff bool        // SetShortcutsEnabled(false)
+
+	viewports []*Viewport // Created with Viewport, in order
 }
 
 // Panel represents a scrollable panel state.
@@ -125,8 +414,10 @@ func New(cfg Config) *UI {
 	}
 
 	ui := &UI{
-		style:   cfg.Style,
-		inputCh: make(chan InputEvent, cfg.InputChanSize),
+		style:     cfg.Style,
+		baseStyle: cfg.Style,
+		scale:     1,
+		inputCh:   make(chan InputEvent, cfg.InputChanSize),
 		input: InputState{
 			KeyDown:    make(map[Key]bool),
 			KeyPressed: make(map[Key]bool),
@@ -138,11 +429,20 @@ func New(cfg Config) *UI {
 	ui.layoutStack.Init(16)
 	ui.clipStack.Init(16)
 	ui.idStack.Init(32)
+	ui.idNames.Init(32)
 	ui.panelStack.Init(8)
 	ui.columnStack.Init(8)
+	ui.groupStack.Init(8)
+	ui.tabBarStack.Init(4)
 	ui.containerStack.Init(8)
 	ui.containers = make(map[ID]*Container)
-	ui.treeNodeState = make(map[ID]bool)
+	ui.treeNodeState = make(map[ID]treeNode)
+	ui.listBoxes = make(map[ID]*listBoxState)
+	ui.textboxHist = make(map[ID]*textboxHistory)
+	ui.tabBars = make(map[ID]*tabBarState)
+	ui.canvases = make(map[ID]*Canvas)
+	ui.dockSpaces = make(map[string]*dockSpace)
+	ui.iconNames = make(map[int]string)
 	ui.rootList = make([]*Container, 0, 16)
 
 	// Initialize DrawFrame callback
@@ -151,6 +451,36 @@ func New(cfg Config) *UI {
 	} else {
 		ui.drawFrame = defaultDrawFrame
 	}
+	ui.animations = cfg.Animations
+	ui.trackDirty = cfg.DirtyRegions
+	ui.constrainToScreen = cfg.ConstrainToScreen
+	ui.retireFrames = cfg.RetireContainers
+	ui.anims = make(map[animKey]*animState)
+	ui.frameDelta = nominalFrame
+	ui.textCache = make(map[wrapKey]*wrappedText)
+	if ui.animations || ui.trackDirty {
+		ui.prevCommands.Init(cfg.CommandBuf)
+	}
+	ui.modalOverlay = cfg.ModalOverlay
+	if ui.modalOverlay == nil {
+		ui.modalOverlay = defaultModalOverlay
+	}
+	ui.drawTitleBar = cfg.DrawTitleBar
+	ui.SetClipboard(cfg.Clipboard)
+	ui.queueInput = cfg.QueueInput
+	ui.checkGo = cfg.CheckGoroutine
+	ui.repeatDelay = cmp.Or(cfg.KeyRepeatDelay, defaultKeyRepeatDelay)
+	ui.repeatInterval = cmp.Or(cfg.KeyRepeatInterval, defaultKeyRepeatInterval)
+	ui.buttonRepeatDelay = cmp.Or(cfg.ButtonRepeatDelay, defaultKeyRepeatDelay)
+	ui.buttonRepeatInterval = cmp.Or(cfg.ButtonRepeatInterval, defaultKeyRepeatInterval)
+	ui.longPressDelay = cmp.Or(cfg.LongPressDelay, defaultLongPressDelay)
+	ui.smoothScroll = cfg.SmoothScroll
+	ui.textDir = cfg.TextDirection
+	ui.accessOn = cfg.Accessibility || cfg.AccessBridge != nil
+	ui.accessBridge = cfg.AccessBridge
+	ui.onUIEvent = cfg.OnUIEvent
+	ui.onInvalidate = cfg.OnInvalidate
+	ui.redraw.Store(true)
 
 	return ui
 }
@@ -163,10 +493,72 @@ func (u *UI) SetDebug(logFunc func(format string, args ...any)) {
 
 // BeginFrame prepares for a new frame of UI rendering.
 func (u *UI) BeginFrame() {
+	u.BeginFrameAt(time.Now())
+}
+
+// BeginFrameAt is BeginFrame for a frame at time now, which drives key
+// repeat and touch long presses. Use it with a fixed timestep or to replay input
+// deterministically.
+func (u *UI) BeginFrameAt(now time.Time) {
+	u.beginFrameAt(now, nominalFrame)
+}
+
+// BeginFrameDelta is BeginFrame for a frame dt after the last one. Key
+// repeat, button repeat and touch long presses are timed by the UI's clock
+// that dt advances, and animations, smooth scrolling and touch flings move
+// as far as they would at 60 frames per second over dt. BeginFrame and
+// BeginFrameAt advance animations a 60th of a second per frame whatever
+// the frame rate, so hosts that don't run at 60 FPS, such as Ebiten at 120
+// TPS or a terminal drawing only on events, should pass the time since the
+// last frame here:
+//
+//	ui.BeginFrameDelta(time.Second / time.Duration(ebiten.TPS()))
+//
+// Animations count at most 100ms of a longer dt, so one that starts after
+// an idle pause doesn't jump to its end. The clock starts at the current
+// time on the first frame.
+func (u *UI) BeginFrameDelta(dt time.Duration) {
+	now := u.frameTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	u.beginFrameAt(now.Add(max(dt, 0)), max(dt, 0))
+}
+
+// FrameDelta returns the time animations advance by this frame: dt as
+// passed to BeginFrameDelta, or a 60th of a second.
+func (u *UI) FrameDelta() time.Duration {
+	return u.frameDelta
+}
+
+func (u *UI) beginFrameAt(now time.Time, dt time.Duration) {
+	u.checkGoroutine("BeginFrame")
+	u.beginRedraw()
+	u.frameTime = now
+	u.frameDelta = dt
 	u.frame++
+	u.inFrame = true
+	if u.metrics != nil {
+		u.metrics.beginFrame(now)
+	}
+	u.culled = 0
+	if u.animations || u.trackDirty {
+		u.commands, u.prevCommands = u.prevCommands, u.commands
+	}
+	if u.animations || u.trackDirty || u.onUIEvent != nil {
+		u.prevRoots = append(u.prevRoots[:0], u.rootList...)
+	}
+	if u.trackDirty {
+		u.snapshotRoots()
+	}
 	u.commands.Reset()
 	u.clipStack.Reset()
 	u.input.TextInput = ""
+	u.itemTrack.hoverPrev, u.itemTrack.hoverCur = u.itemTrack.hoverCur, 0
+
+	// Queued and played back input lands first, so the mouse delta and
+	// drags below see it the same as input given between frames
+	u.processInput()
 
 	if !u.input.MouseDown[int(MouseLeft)] {
 		u.dragID = 0
@@ -177,57 +569,85 @@ func (u *UI) BeginFrame() {
 
 	u.hoverRoot = u.nextHoverRoot
 	u.nextHoverRoot = nil
+
+	// An open modal captures input even when the mouse is outside it.
+	// Drop it if it was closed or not submitted last frame.
+	if u.modal != nil {
+		if !u.modal.open || u.modalFrame < u.frame-1 {
+			u.modal = nil
+		} else if u.hoverRoot == nil {
+			u.hoverRoot = u.modal
+		}
+	}
 	u.scrollTarget = nil
+	u.scrollPanel = nil
 	u.rootList = u.rootList[:0]
+	u.overlay = u.overlay[:0]
+	u.focusList = u.focusList[:0]
+	u.reveals = u.reveals[:0]
 
 	u.input.MouseDelta = types.Vec2{
 		X: u.input.MousePos.X - u.input.LastMousePos.X,
 		Y: u.input.MousePos.Y - u.input.LastMousePos.Y,
 	}
 	u.input.LastMousePos = u.input.MousePos
-	u.processInput()
+	u.repeatKeys()
+	u.updateTouch()
+	u.runShortcuts()
 }
 
 // EndFrame finalizes the current frame.
 func (u *UI) EndFrame() {
+	u.checkGoroutine("EndFrame")
+	if u.metrics != nil {
+		u.metrics.endPhase(phaseUpdate)
+		defer u.metrics.endPhase(phaseEnd)
+		u.countWindows()
+	}
+	u.inFrame = false
 	if !u.input.UpdatedFocus {
 		u.input.Focus = 0
 	}
 	u.input.UpdatedFocus = false
+	u.updateFocusNav()
+	u.focusNext = false
+	if u.focusWindowBegun {
+		u.focusWindow, u.focusWindowBegun = nil, false
+	}
+	if !u.input.MouseDown[int(MouseLeft)] {
+		u.dockDrag = nil
+	}
 	u.input.MousePressed = [3]bool{}
+	u.input.NavPressed = [navCount]bool{}
+	if u.animations {
+		u.updateWindowFades()
+	}
+	u.pruneAnims()
+	u.pruneTextCache()
+	if u.retireFrames > 0 {
+		u.pruneContainers()
+	}
+	u.endRedraw()
+	if u.accessOn {
+		u.finishAccessTree()
+	}
+	if u.onUIEvent != nil {
+		u.emitWindowEvents()
+	}
+	if u.trackDirty {
+		u.updateDirtyRegions()
+	}
 
 	for k := range u.input.KeyPressed {
 		delete(u.input.KeyPressed, k)
 	}
 
-	// Apply scroll wheel to target
-	if u.scrollTarget != nil && (u.input.ScrollDelta.X != 0 || u.input.ScrollDelta.Y != 0) {
-		u.scrollTarget.scroll.Y += u.input.ScrollDelta.Y
-		u.scrollTarget.scroll.X += u.input.ScrollDelta.X
-
-		maxScrollY := u.scrollTarget.contentSize.Y + u.style.Padding.Y*2 - u.scrollTarget.body.H
-		maxScrollX := u.scrollTarget.contentSize.X + u.style.Padding.X*2 - u.scrollTarget.body.W
-		if maxScrollY < 0 {
-			maxScrollY = 0
-		}
-		if maxScrollX < 0 {
-			maxScrollX = 0
-		}
-		if u.scrollTarget.scroll.Y < 0 {
-			u.scrollTarget.scroll.Y = 0
-		}
-		if u.scrollTarget.scroll.Y > maxScrollY {
-			u.scrollTarget.scroll.Y = maxScrollY
-		}
-		if u.scrollTarget.scroll.X < 0 {
-			u.scrollTarget.scroll.X = 0
-		}
-		if u.scrollTarget.scroll.X > maxScrollX {
-			u.scrollTarget.scroll.X = maxScrollX
-		}
-	}
+	u.routeScroll()
 
 	u.input.ScrollDelta = types.Vec2{}
+	u.input.TouchPan = types.Vec2{}
+	u.input.PinchScale = 0
+	u.debugEndFrame()
 }
 
 // UpdateControl updates focus/hover state for a control.
@@ -237,12 +657,27 @@ func (u *UI) UpdateControl(id ID, rect types.Rect) (hover bool, active bool) {
 
 // UpdateControlOpt updates focus/hover state with options.
 func (u *UI) UpdateControlOpt(id ID, rect types.Rect, opt int) (hover bool, active bool) {
+	u.item = itemState{id: id, rect: rect, holdFocus: opt&OptHoldFocus != 0}
+	defer u.updateItemResult(id)
 	if opt&OptNoInteract != 0 {
 		return false, false
 	}
+	if cnt := u.GetCurrentContainer(); cnt != nil && cnt.disabled {
+		return false, false
+	}
+
+	// Controls scrolled out of view can still be tabbed to, and are
+	// scrolled back into view when they are
+	if opt&OptNoNav == 0 {
+		u.registerFocusable(id, rect)
+	}
 
 	clipped := u.CheckClip(rect)
 	if clipped == ClipAll {
+		// Keyboard focus stays while the control scrolls into view
+		if u.input.Focus == id && u.navFocus == id {
+			u.input.UpdatedFocus = true
+		}
 		return false, false
 	}
 
@@ -271,14 +706,15 @@ func (u *UI) UpdateControlOpt(id ID, rect types.Rect, opt int) (hover bool, acti
 	// Only set hover when mouse is not down (prevents stealing during drag)
 	if mouseOver && !u.input.MouseDown[int(MouseLeft)] {
 		u.input.Hover = id
+		u.debugCur.hoverIDs = append(u.debugCur.hoverIDs[:0], u.idStack.items...)
 	}
 
 	if u.input.Focus == id {
 		if u.input.MousePressed[int(MouseLeft)] && !mouseOver {
 			u.SetFocus(0)
 		}
-		// If mouse released, lose focus (unless HOLDFOCUS option)
-		if opt&OptHoldFocus == 0 && !u.input.MouseDown[int(MouseLeft)] {
+		// If mouse released, lose focus (unless HOLDFOCUS option or keyboard focus)
+		if opt&OptHoldFocus == 0 && !u.input.MouseDown[int(MouseLeft)] && u.navFocus != id {
 			u.SetFocus(0)
 		}
 	}
@@ -293,6 +729,7 @@ func (u *UI) UpdateControlOpt(id ID, rect types.Rect, opt int) (hover bool, acti
 		u.SetFocus(id)
 	}
 
+	u.item.hovered = mouseOver && (!u.input.MouseDown[int(MouseLeft)] || u.input.Focus == id)
 	u.input.LastID = id
 	hover = u.input.Hover == id
 	active = u.input.Focus == id
@@ -313,6 +750,25 @@ func (u *UI) MouseOver(rect types.Rect) bool {
 		u.GetClipRect().Contains(u.input.MousePos)
 }
 
+// HoveredWindow returns the window or popup that takes the mouse this
+// frame: the one in front under the mouse as of the last frame, or the
+// modal dialog while one is open. It returns nil when the mouse is over
+// no window, or only over windows with OptNoInteract or
+// OptInputPassthrough, so the application can handle it instead:
+//
+//	if ui.HoveredWindow() == nil && ui.MousePressed(microui.MouseLeft) {
+//		game.Click(ui.MousePos())
+//	}
+func (u *UI) HoveredWindow() *Container {
+	return u.hoverRoot
+}
+
+// passthrough reports whether root container cnt lets input through to
+// what is beneath it, so it is never counted as the window in front.
+func passthrough(cnt *Container) bool {
+	return cnt.opt&OptInputPassthrough != 0
+}
+
 // MousePos returns the current mouse position.
 func (u *UI) MousePos() types.Vec2 {
 	return u.input.MousePos
@@ -323,82 +779,113 @@ func (u *UI) MouseDelta() types.Vec2 {
 	return u.input.MouseDelta
 }
 
+// MousePressed reports whether btn was pressed this frame. A touch long
+// press is a MouseRight press, so check it to open context menus.
+func (u *UI) MousePressed(btn MouseButton) bool {
+	return u.input.MousePressed[btn]
+}
+
+// KeyPressed reports whether key was pressed this frame, or repeated
+// while held.
+func (u *UI) KeyPressed(key Key) bool {
+	return u.input.KeyPressed[key]
+}
+
 // Render executes all queued commands using the given renderer.
 // Commands are rendered in z-order by container (lowest zindex first).
 func (u *UI) Render(renderer interface{}) {
-	r, ok := renderer.(BaseRenderer)
-	if !ok {
-		return
+	u.checkGoroutine("Render")
+	if u.metrics != nil {
+		u.metrics.start = time.Now()
+		defer u.metrics.endPhase(phaseRender)
 	}
-	ir, _ := renderer.(IconRenderer)
-	br, _ := renderer.(BoxRenderer)
-	sr, _ := renderer.(ScrollRenderer)
-
-	renderCmd := func(cmd Command) {
-		switch cmd.Kind {
-		case CmdRect:
-			r.DrawRect(cmd.Pos, cmd.Size, cmd.Color)
-		case CmdText:
-			r.DrawText(cmd.Text, cmd.Pos, cmd.Font, cmd.Color)
-		case CmdClip:
-			r.SetClip(cmd.Rect)
-		case CmdIcon:
-			if ir != nil {
-				ir.DrawIcon(cmd.Icon, cmd.Rect, cmd.Color)
-			}
-		case CmdBox:
-			if br != nil {
-				br.DrawBox(cmd.Rect, cmd.Color)
-			}
-		case CmdScrollTrack:
-			if sr != nil {
-				sr.DrawScrollTrack(cmd.Rect)
-			}
-		case CmdScrollThumb:
-			if sr != nil {
-				sr.DrawScrollThumb(cmd.Rect)
-			}
-		}
+	if dr, ok := renderer.(DirtyRenderer); ok && u.trackDirty {
+		dr.SetDirtyRegions(u.dirty)
+	}
+	if renderCmd := commandRenderer(renderer, u.scale); renderCmd != nil {
+		u.eachRendered(renderCmd, func(cnt *Container) bool { return cnt.viewport == nil })
 	}
+}
+
+// eachRendered calls renderCmd for every command of the frame in drawing
+// order: root containers back to front, with fading windows' colors faded,
+// then the overlay. If show isn't nil, only the root containers it
+// returns true for are drawn.
+func (u *UI) eachRendered(renderCmd func(Command), show func(*Container) bool) {
+	u.eachWindow(renderCmd, show)
+	u.eachOverlay(renderCmd)
+}
 
+// eachWindow is eachRendered without the overlay.
+func (u *UI) eachWindow(renderCmd func(Command), show func(*Container) bool) {
 	if len(u.rootList) == 0 {
 		u.commands.Each(renderCmd)
 		return
 	}
 
-	sorted := make([]*Container, len(u.rootList))
-	copy(sorted, u.rootList)
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].zindex < sorted[j].zindex
-	})
-
-	for _, cnt := range sorted {
-		u.commands.EachRange(cnt.headIdx, cnt.tailIdx, renderCmd)
+	for _, cnt := range u.zOrder {
+		if cnt.fadeCmds == nil && !u.begunThisFrame(cnt) || show != nil && !show(cnt) {
+			continue
+		}
+		draw := renderCmd
+		if alpha := u.windowAlpha(cnt); alpha < 1 {
+			draw = func(cmd Command) {
+				cmd.Color = fadeColor(cmd.Color, alpha)
+				renderCmd(cmd)
+			}
+		}
+		if cnt.fadeCmds != nil {
+			for _, cmd := range cnt.fadeCmds {
+				draw(cmd)
+			}
+			continue
+		}
+		u.commands.EachRange(cnt.headIdx, cnt.tailIdx, draw)
 	}
 }
 
-// RootContainersSorted returns all root containers sorted by z-index (back to front).
-// This is useful for custom rendering with per-container effects like shadows.
+// RootContainersSorted returns the root containers of the frame back to
+// front. This is useful for custom rendering with per-container effects.
+// The slice is reused by the next call.
 func (u *UI) RootContainersSorted() []*Container {
-	sorted := make([]*Container, len(u.rootList))
-	copy(sorted, u.rootList)
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].zindex < sorted[j].zindex
-	})
-	return sorted
+	u.rootsSorted = u.rootsSorted[:0]
+	for _, cnt := range u.zOrder {
+		if u.begunThisFrame(cnt) {
+			u.rootsSorted = append(u.rootsSorted, cnt)
+		}
+	}
+	return u.rootsSorted
 }
 
 // RenderContainer renders just the commands for a single container.
 func (u *UI) RenderContainer(cnt *Container, renderer interface{}) {
+	if renderCmd := commandRenderer(renderer, u.scale); renderCmd != nil {
+		u.commands.EachRange(cnt.headIdx, cnt.tailIdx, renderCmd)
+	}
+}
+
+// commandRenderer returns a function drawing one command with renderer,
+// using whichever optional renderer interfaces it implements, and passes
+// scale to a ScaleRenderer. It returns nil if renderer isn't a BaseRenderer.
+func commandRenderer(renderer interface{}, scale float64) func(Command) {
 	r, ok := renderer.(BaseRenderer)
 	if !ok {
-		return
+		return nil
 	}
 	ir, _ := renderer.(IconRenderer)
+	nir, _ := renderer.(NamedIconRenderer)
 	br, _ := renderer.(BoxRenderer)
 	sr, _ := renderer.(ScrollRenderer)
+	imr, _ := renderer.(ImageRenderer)
+	nsr, _ := renderer.(NineSliceRenderer)
+	shr, _ := renderer.(ShadowRenderer)
+	cr, _ := renderer.(CustomRenderer)
+	pr, _ := renderer.(PlotRenderer)
+	if s, ok := renderer.(ScaleRenderer); ok {
+		s.SetScale(scale)
+	}
 
-	u.commands.EachRange(cnt.headIdx, cnt.tailIdx, func(cmd Command) {
+	return func(cmd Command) {
 		switch cmd.Kind {
 		case CmdRect:
 			r.DrawRect(cmd.Pos, cmd.Size, cmd.Color)
@@ -407,7 +894,9 @@ func (u *UI) RenderContainer(cnt *Container, renderer interface{}) {
 		case CmdClip:
 			r.SetClip(cmd.Rect)
 		case CmdIcon:
-			if ir != nil {
+			if nir != nil {
+				nir.DrawNamedIcon(cmd.Icon, cmd.Text, cmd.Rect, cmd.Color)
+			} else if ir != nil {
 				ir.DrawIcon(cmd.Icon, cmd.Rect, cmd.Color)
 			}
 		case CmdBox:
@@ -422,13 +911,62 @@ func (u *UI) RenderContainer(cnt *Container, renderer interface{}) {
 			if sr != nil {
 				sr.DrawScrollThumb(cmd.Rect)
 			}
+		case CmdImage:
+			if imr != nil {
+				imr.DrawImage(cmd.Image, cmd.Rect, cmd.Src, cmd.Color)
+			}
+		case CmdNineSlice:
+			if nsr != nil {
+				nsr.DrawNineSlice(cmd.Image, cmd.Rect, cmd.Src, cmd.Slice, cmd.Color)
+			} else if imr != nil {
+				dst, src := cmd.Rect.Slice9(cmd.Slice), cmd.Src.Slice9(cmd.Slice)
+				for i := range dst {
+					if !dst[i].Empty() && !src[i].Empty() {
+						imr.DrawImage(cmd.Image, dst[i], src[i], cmd.Color)
+					}
+				}
+			}
+		case CmdShadow:
+			if shr != nil {
+				shr.DrawWindowShadow(cmd.Rect, cmd.Pos, cmd.Color)
+			}
+		case CmdCustom:
+			if cr != nil {
+				cr.DrawCustom(cmd.Rect, cmd.Data)
+			}
+		case CmdPlot:
+			switch {
+			case cmd.Plot == nil:
+			case pr != nil:
+				pr.DrawPlot(cmd.Rect, cmd.Plot, cmd.Color)
+			default:
+				cmd.Plot.EachRect(cmd.Rect, func(rect types.Rect) {
+					r.DrawRect(types.Vec2{X: rect.X, Y: rect.Y}, types.Vec2{X: rect.W, Y: rect.H}, cmd.Color)
+				})
+			}
 		}
-	})
+	}
 }
 
-// Style returns the current style.
+// Style returns the current style, without scaling applied.
 func (u *UI) Style() Style {
-	return u.style
+	return u.baseStyle
+}
+
+// SetScreenSize tells the UI the size of the screen (or terminal) it is
+// drawn to. Maximized windows fill it; until it is set windows can't be
+// maximized. Call it whenever the screen is resized.
+func (u *UI) SetScreenSize(w, h int) {
+	if w != u.screen.W || h != u.screen.H {
+		u.dirtyAll = true
+		u.Invalidate()
+	}
+	u.screen = types.Rect{W: w, H: h}
+}
+
+// ScreenSize returns the size set by SetScreenSize.
+func (u *UI) ScreenSize() types.Vec2 {
+	return types.Vec2{X: u.screen.W, Y: u.screen.H}
 }
 
 // Frame returns the current frame number.
@@ -443,7 +981,9 @@ func (u *UI) ScrollDelta() types.Vec2 {
 
 // Label adds a text label to the current layout.
 func (u *UI) Label(text string) {
-	u.DrawControlText(text, u.LayoutNext(), ColorText, 0)
+	rect := u.LayoutNext()
+	u.DrawControlText(text, rect, ColorText, u.cellAlign(0))
+	u.accessStatic(RoleLabel, text, rect)
 }
 
 // Space adds vertical spacing without any control or extra spacing.
@@ -456,7 +996,9 @@ func (u *UI) Space(height int) {
 
 // LabelOpt adds a text label with alignment options.
 func (u *UI) LabelOpt(text string, opt int) {
-	u.DrawControlText(text, u.LayoutNext(), ColorText, opt)
+	rect := u.LayoutNext()
+	u.DrawControlText(text, rect, ColorText, u.cellAlign(opt))
+	u.accessStatic(RoleLabel, text, rect)
 }
 
 // Button adds a button to the current layout.
@@ -465,7 +1007,9 @@ func (u *UI) Button(label string) bool {
 	return u.ButtonOpt(label, 0, 0)
 }
 
-// ButtonOpt adds a button with icon and options.
+// ButtonOpt adds a button with icon and options. With OptRepeat it keeps
+// clicking while held, e.g. for arrows that step a value. OptDefault makes
+// it the window's default button, which Enter clicks.
 func (u *UI) ButtonOpt(label string, icon int, opt int) bool {
 	var id ID
 	if label != "" {
@@ -475,7 +1019,14 @@ func (u *UI) ButtonOpt(label string, icon int, opt int) bool {
 	}
 	rect := u.LayoutNext()
 	u.UpdateControlOpt(id, rect, opt)
-	clicked := u.input.MousePressed[int(MouseLeft)] && u.input.Focus == id
+	clicked := u.activated(id)
+	if opt&OptRepeat != 0 {
+		clicked = u.activatedRepeat(id)
+	}
+	if opt&OptDefault != 0 && u.defaultActivated() {
+		clicked = true
+	}
+	u.itemClicked(clicked)
 	u.DrawControlFrame(id, rect, ColorButton, opt)
 	if label != "" {
 		u.DrawControlText(label, rect, ColorText, opt|OptAlignCenter)
@@ -483,6 +1034,10 @@ func (u *UI) ButtonOpt(label string, icon int, opt int) bool {
 	if icon != 0 {
 		u.DrawIcon(icon, rect, u.style.Colors.Text)
 	}
+	u.accessControl(id, rect, RoleButton, label, "", accessOptState(opt))
+	if clicked {
+		u.emit(UIEventButton, id, label, 0, rect)
+	}
 	return clicked
 }
 
@@ -499,20 +1054,92 @@ func (u *UI) OpenWindow(title string) {
 }
 
 // BeginWindowOpt starts a new window with options.
-// opt can include OptNoTitle, OptNoClose, OptNoResize, OptAutoSize, OptPopup, OptClosed.
+// opt can include OptNoTitle, OptNoClose, OptNoResize, OptAutoSize,
+// OptFitContent, OptPopup, OptClosed, OptInputPassthrough.
 // Returns false if the window is closed.
 func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
+	return u.beginWindow(title, rect, opt)
+}
+
+// BeginWindowTitled is BeginWindowOpt with drawTitle drawing the title in
+// place of the title text, like Config.DrawTitleBar for this window only.
+// rect is the part of the title bar the window's buttons leave free.
+// Controls in it need a rect from LayoutSetNext, as the window's layout
+// has not started:
+//
+//	ui.BeginWindowTitled("Tools", rect, 0, func(r types.Rect) {
+//		ui.DrawIcon(microui.IconCheck, types.Rect{X: r.X, Y: r.Y, W: r.H, H: r.H}, green)
+//		r.X += r.H
+//		ui.DrawText(ui.TruncateText("Tools - unsaved", r.W), types.Vec2{X: r.X, Y: r.Y + 4}, nil, white)
+//	})
+func (u *UI) BeginWindowTitled(title string, rect types.Rect, opt int, drawTitle func(rect types.Rect)) bool {
+	u.nextTitle = drawTitle
+	return u.beginWindow(title, rect, opt)
+}
+
+// WindowResult reports what happened to a window during BeginWindowEx.
+type WindowResult struct {
+	Visible        bool       // Body is shown; call EndWindow
+	Closed         bool       // Closed this frame (close button or click outside a popup)
+	Moved          bool       // Position changed (dragged, maximized, restored)
+	Resized        bool       // Size changed (resize handle, maximize, auto-size)
+	BroughtToFront bool       // Raised above the other windows
+	Rect           types.Rect // Window rect after this frame's changes
+}
+
+// BeginWindowEx is BeginWindowOpt returning what happened to the window
+// this frame. A window closed by its close button is still drawn in the
+// frame that reports Closed, so check Closed independently of Visible:
+//
+//	res := ui.BeginWindowEx("Tools", rect, microui.OptClosed)
+//	if res.Visible {
+//		// window content
+//		ui.EndWindow()
+//	}
+//	if res.Closed {
+//		toolsOpen = false
+//	}
+func (u *UI) BeginWindowEx(title string, rect types.Rect, opt int) WindowResult {
+	cnt := u.GetContainer(title)
+	wasOpen := cnt.open || opt&OptClosed == 0
+	before, zindex := cnt.rect, cnt.zindex
+	wasTop := zindex == u.lastZIndex
+
+	res := WindowResult{Visible: u.beginWindow(title, rect, opt)}
+	res.Rect = cnt.rect
+	res.Closed = wasOpen && !cnt.open
+	if zindex != 0 {
+		// A new window's first placement isn't a change
+		res.Moved = cnt.rect.X != before.X || cnt.rect.Y != before.Y
+		res.Resized = cnt.rect.W != before.W || cnt.rect.H != before.H
+		res.BroughtToFront = cnt.zindex != zindex && !wasTop
+	}
+	return res
+}
+
+func (u *UI) beginWindow(title string, rect types.Rect, opt int) bool {
+	drawTitle := u.nextTitle
+	u.nextTitle = nil
+
 	// Get or create container BEFORE pushing ID (container ID should be stable)
 	cnt := u.GetContainer(title)
 	// Only set rect on first frame (when zindex is 0, meaning not yet initialized)
 	// After that, the container maintains its own position (for dragging, etc.)
 	if cnt.zindex == 0 {
 		cnt.rect = rect
+		cnt.pinned = opt&OptAlwaysOnTop != 0
 	}
 
 	// Use container's rect for all subsequent operations (supports dragging/resizing)
 	rect = cnt.rect
 
+	// A window fitting its content never overflows, so it doesn't scroll;
+	// scrollbars would narrow the content and shrink it each frame
+	if opt&OptFitContent != 0 || cnt.fitNext {
+		opt |= OptFitContent | OptNoScroll | OptNoResize
+	}
+	cnt.fitNext = false
+
 	// Store options for EndWindow to use (e.g., for AutoSize)
 	cnt.opt = opt
 
@@ -532,6 +1159,33 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 		return false
 	}
 
+	// Docked windows fill their pane; only the active tab is shown
+	dock := u.findDock(title)
+	if dock != nil {
+		if dock.Windows[dock.Active] != title {
+			return false
+		}
+		cnt.rect = dock.rect
+		rect = cnt.rect
+		opt |= OptNoResize
+		cnt.opt = opt
+		cnt.collapsed, cnt.maximized = false, false
+	}
+
+	// Maximized windows follow the screen size and can't be resized
+	if area, ok := u.windowArea(cnt); cnt.maximized && ok {
+		cnt.rect = area
+		opt |= OptNoResize
+		cnt.opt = opt
+	} else if dock == nil {
+		if opt&OptPopup != 0 {
+			u.placePopup(cnt)
+		}
+		u.constrainWindow(cnt)
+	}
+	collapsed := cnt.collapsed && opt&OptNoTitle == 0
+	rect = u.rootRect(cnt)
+
 	u.PushID(title)
 	if cnt.zindex == 0 {
 		u.lastZIndex++
@@ -539,8 +1193,16 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 	}
 	u.containerStack.Push(cnt)
 	u.beginRootContainer(cnt)
+	switch {
+	case cnt == u.modal:
+		u.accessContainer(cnt, RoleDialog, title)
+	case opt&OptPopup != 0:
+		u.accessContainer(cnt, RolePopup, title)
+	default:
+		u.accessContainer(cnt, RoleWindow, title)
+	}
 
-	if cnt == u.hoverRoot && u.input.MousePressed[int(MouseLeft)] && opt&OptNoInteract == 0 {
+	if cnt == u.hoverRoot && u.input.MousePressed[int(MouseLeft)] && opt&OptNoInteract == 0 && dock == nil {
 		u.BringToFront(cnt)
 	}
 
@@ -549,8 +1211,21 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 		u.commands.Push(Command{Kind: CmdClip, Rect: unclippedRect})
 	}
 
+	if cnt == u.modal {
+		u.drawModalOverlay()
+	}
+
+	// The shadow comes first so windows further back stay beneath it
+	if s := u.style.WindowShadow; (s.Offset.X > 0 || s.Offset.Y > 0) && opt&(OptNoFrame|OptNoBackground) == 0 && dock == nil {
+		c := s.Color
+		if c == nil {
+			c = defaultShadowColor
+		}
+		u.commands.Push(Command{Kind: CmdShadow, Rect: rect, Pos: s.Offset, Color: c})
+	}
+
 	if opt&OptNoFrame == 0 {
-		u.DrawFrame(rect, ColorWindowBG)
+		u.drawBackground(cnt, rect, ColorWindowBG)
 	}
 	u.PushClip(rect)
 
@@ -576,13 +1251,13 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 		titleID := u.GetID("!title")
 
 		mouseOnTitle := titleRect.Contains(u.input.MousePos)
-		if u.input.MousePressed[int(MouseLeft)] && mouseOnTitle && cnt == u.hoverRoot {
+		if u.input.MousePressed[int(MouseLeft)] && mouseOnTitle && cnt == u.hoverRoot && dock == nil {
 			if u.debug {
 				u.debugLog("TitleBarClick: window=%q titleRect=%v mousePos=%v -> BringToFront", title, titleRect, u.input.MousePos)
 			}
 			u.BringToFront(cnt)
 		}
-		u.UpdateControlOpt(titleID, titleRect, opt)
+		u.UpdateControlOpt(titleID, titleRect, opt|OptNoNav)
 
 		if u.input.Focus == titleID && u.input.MouseDown[int(MouseLeft)] {
 			if u.input.MousePressed[int(MouseLeft)] {
@@ -592,7 +1267,16 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 					Y: u.input.MousePos.Y - cnt.rect.Y,
 				}
 			}
-			if u.dragID == titleID {
+			if u.dragID == titleID && dock != nil {
+				// Tear a docked window off once the drag passes a small threshold
+				dx := u.input.MousePos.X - u.dragOffset.X - cnt.rect.X
+				dy := u.input.MousePos.Y - u.dragOffset.Y - cnt.rect.Y
+				if abs(dx)+abs(dy) >= max(titleHeight/2, 1) {
+					u.undockWindow(title)
+					u.BringToFront(cnt)
+				}
+			} else if u.dragID == titleID && !cnt.maximized {
+				u.dockDrag = cnt
 				newX := u.input.MousePos.X - u.dragOffset.X
 				newY := u.input.MousePos.Y - u.dragOffset.Y
 				if u.debug {
@@ -601,6 +1285,7 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 				}
 				cnt.rect.X = newX
 				cnt.rect.Y = newY
+				u.constrainWindow(cnt)
 			}
 		}
 
@@ -617,7 +1302,7 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 			}
 			titleRect.W -= closeRect.W
 			u.DrawIcon(IconClose, closeRect, u.style.Colors.TitleText)
-			u.UpdateControlOpt(closeID, closeRect, opt)
+			u.UpdateControlOpt(closeID, closeRect, opt|OptNoNav)
 
 			if u.debug && u.input.MousePressed[int(MouseLeft)] {
 				mouseOver := closeRect.Contains(u.input.MousePos)
@@ -630,20 +1315,91 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 					u.debugLog("CloseButton: CLOSING WINDOW!")
 				}
 				cnt.open = false
+				if dock != nil {
+					u.undockWindow(title)
+				}
+			}
+		}
+
+		if opt&OptMaximizable != 0 && dock == nil && !u.screen.Empty() {
+			icon := IconMaximize
+			if cnt.maximized {
+				icon = IconRestore
+			}
+			if u.titleBarButton("!maximize", icon, &titleRect, u.style.Colors.TitleText, opt) {
+				u.toggleMaximized(cnt)
+			}
+		}
+
+		if opt&OptPinnable != 0 && dock == nil {
+			// Dimmed while unpinned, like a pin lying loose
+			c := u.style.Colors.TitleText
+			if !cnt.pinned {
+				c = blendColor(u.style.Colors.WindowTitle, c, 0.5)
+			}
+			if u.titleBarButton("!pin", IconPin, &titleRect, c, opt) {
+				cnt.pinned = !cnt.pinned
+				u.BringToFront(cnt)
+			}
+		}
+
+		for i, b := range cnt.titleButtons {
+			name := "!button" + strconv.Itoa(i)
+			if u.titleBarButton(name, b.icon, &titleRect, u.style.Colors.TitleText, opt) && b.onClick != nil {
+				b.onClick()
+			}
+		}
+
+		if opt&OptCollapsible != 0 && dock == nil {
+			collapseID := u.GetID("!collapse")
+			collapseRect := types.Rect{X: titleRect.X, Y: titleRect.Y, W: titleRect.H, H: titleRect.H}
+			titleRect.X += collapseRect.W
+			titleRect.W -= collapseRect.W
+			icon := IconExpanded
+			if cnt.collapsed {
+				icon = IconCollapsed
+			}
+			u.DrawIcon(icon, collapseRect, u.style.Colors.TitleText)
+			u.UpdateControlOpt(collapseID, collapseRect, opt|OptNoNav)
+			if u.input.MousePressed[int(MouseLeft)] && u.input.Focus == collapseID {
+				cnt.collapsed = !cnt.collapsed
 			}
 		}
 
 	
-		u.DrawControlText(title, titleRect, ColorTitleText, opt)
+		switch {
+		case dock != nil && len(dock.Windows) > 1:
+			u.drawDockTabs(dock, titleRect)
+		case drawTitle != nil:
+			drawTitle(titleRect)
+		case u.drawTitleBar != nil:
+			u.drawTitleBar(u, title, titleRect)
+		default:
+			u.DrawControlText(title, titleRect, ColorTitleText, opt)
+		}
 
 		contentRect = body
 	}
 
-	if opt&OptAutoSize != 0 {
+	if collapsed {
+		// Only the title bar is shown: finish the window here
+		u.PopClip()
+		if opt&OptPopup != 0 {
+			u.PopClip()
+		}
+		u.endRootContainer(cnt)
+		u.containerStack.Pop()
+		u.PopID()
+		return false
+	}
+
+	u.beginFooterSpace(cnt)
+	fit := opt&OptFitContent != 0 && cnt.measured && dock == nil
+	if (opt&OptAutoSize != 0 || fit) && !cnt.maximized {
 		overheadW := rect.W - contentRect.W
 		overheadH := rect.H - contentRect.H
 		newW := cnt.contentSize.X + overheadW + u.style.Padding.X*2
-		newH := cnt.contentSize.Y + overheadH + u.style.Padding.Y*2
+		newH := cnt.contentSize.Y + overheadH + u.style.Padding.Y*2 + cnt.footer
 
 		minW := u.style.Size.X + u.style.Padding.X*2
 		minH := u.style.Size.Y + u.style.Padding.Y*2
@@ -659,9 +1415,17 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 		if newH < minH {
 			newH = minH
 		}
+		if fit {
+			newW = clampLimit(newW, cnt.minSize.X, cnt.maxSize.X)
+			newH = clampLimit(newH, cnt.minSize.Y, cnt.maxSize.Y)
+		}
 
 		cnt.rect.W = newW
 		cnt.rect.H = newH
+		if opt&OptPopup != 0 {
+			u.placePopup(cnt)
+		}
+		u.constrainWindow(cnt)
 		rect = cnt.rect
 		contentRect = rect
 		if borderWidth > 0 {
@@ -679,6 +1443,7 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 		}
 	}
 
+	u.reserveFooter(cnt, &contentRect)
 	u.scrollbars(cnt, &contentRect)
 
 	if opt&OptNoResize == 0 {
@@ -690,7 +1455,7 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 			W: sz,
 			H: sz,
 		}
-		u.UpdateControlOpt(resizeID, resizeRect, opt)
+		u.UpdateControlOpt(resizeID, resizeRect, opt|OptNoNav)
 		u.DrawIcon(IconResize, resizeRect, u.style.Colors.Text)
 
 		if u.input.Focus == resizeID && u.input.MouseDown[int(MouseLeft)] {
@@ -711,9 +1476,15 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 				if desiredH < 5 {
 					desiredH = 5
 				}
+				if area, ok := u.windowArea(cnt); u.constrainToScreen && ok {
+					// Grow up to the screen edge rather than pushing the window back
+					desiredW = min(desiredW, area.X+area.W-cnt.rect.X)
+					desiredH = min(desiredH, area.Y+area.H-cnt.rect.Y)
+				}
 
 				cnt.rect.W = desiredW
 				cnt.rect.H = desiredH
+				u.constrainWindow(cnt)
 			}
 		}
 	}
@@ -736,26 +1507,12 @@ func (u *UI) BeginWindowOpt(title string, rect types.Rect, opt int) bool {
 
 // EndWindow finishes the current window.
 func (u *UI) EndWindow() {
+	u.endColumns()
+	u.endReveals(u.layoutStack.Len())
 	cnt := u.GetCurrentContainer()
 	if cnt != nil {
-		layout := u.getLayout()
-		cnt.contentSize.X = layout.max.X - layout.body.X
-		cnt.contentSize.Y = layout.max.Y - layout.body.Y
-
-		maxScrollX := cnt.contentSize.X + u.style.Padding.X*2 - cnt.body.W
-		maxScrollY := cnt.contentSize.Y + u.style.Padding.Y*2 - cnt.body.H
-		if maxScrollX < 0 {
-			maxScrollX = 0
-		}
-		if maxScrollY < 0 {
-			maxScrollY = 0
-		}
-		if cnt.scroll.X > maxScrollX {
-			cnt.scroll.X = maxScrollX
-		}
-		if cnt.scroll.Y > maxScrollY {
-			cnt.scroll.Y = maxScrollY
-		}
+		u.endScroll(cnt)
+		u.overlayScrollbars(cnt)
 	}
 
 	u.PopLayout()
@@ -788,13 +1545,15 @@ func (u *UI) GetCurrentContainer() *Container {
 func (u *UI) GetContainer(name string) *Container {
 	id := u.getRawID(name) // Use raw ID - containers ignore ID stack
 	if cnt, ok := u.containers[id]; ok {
+		cnt.lastUsed = u.frame
 		return cnt
 	}
 	// Create new container (starts closed)
 	cnt := &Container{
-		id:   id,
-		name: name,
-		open: false,
+		id:       id,
+		name:     name,
+		open:     false,
+		lastUsed: u.frame,
 	}
 	u.containers[id] = cnt
 	return cnt
@@ -804,6 +1563,7 @@ func (u *UI) GetContainer(name string) *Container {
 func (u *UI) BringToFront(cnt *Container) {
 	u.lastZIndex++
 	cnt.zindex = u.lastZIndex
+	u.restack(cnt)
 }
 
 // beginRootContainer marks the start of a root container (window/popup).
@@ -811,31 +1571,75 @@ func (u *UI) BringToFront(cnt *Container) {
 func (u *UI) beginRootContainer(cnt *Container) {
 	// Add to root list
 	u.rootList = append(u.rootList, cnt)
+	cnt.parent = nil
+	cnt.disabled = cnt.opt&(OptNoInteract|OptInputPassthrough) != 0
+	cnt.rootFrame = u.frame
+	if cnt.zpos == 0 || cnt.zlayer != u.zLayer(cnt) {
+		u.restack(cnt)
+	}
+	if cnt == u.focusWindow {
+		u.focusWindowBegun = true
+	}
 
 	// Record command buffer start index
 	cnt.headIdx = u.commands.Len()
 
-	// Non-interactive containers don't receive mouse input
-	if cnt.opt&OptNoInteract != 0 {
+	// Non-interactive containers and those beneath a modal don't receive mouse input
+	if cnt.opt&(OptNoInteract|OptInputPassthrough) != 0 || u.inputBlockedByModal(cnt) {
 		return
 	}
 
-	// Track hover root: if mouse is inside and zindex >= current candidate, update
-	mouseInRect := u.input.MousePos.X >= cnt.rect.X &&
-		u.input.MousePos.X < cnt.rect.X+cnt.rect.W &&
-		u.input.MousePos.Y >= cnt.rect.Y &&
-		u.input.MousePos.Y < cnt.rect.Y+cnt.rect.H
+	// Track hover root: if mouse is inside and not behind the current candidate, update
+	mouseInRect := u.rootRect(cnt).Contains(u.input.MousePos)
+	if vp := cnt.viewport; vp != nil {
+		mouseInRect = mouseInRect && vp.rect.Contains(u.input.MousePos)
+	}
 
-	if mouseInRect && (u.nextHoverRoot == nil || cnt.zindex >= u.nextHoverRoot.zindex) {
+	if mouseInRect && (u.nextHoverRoot == nil || !u.inFront(u.nextHoverRoot, cnt)) {
 		u.nextHoverRoot = cnt
 	}
 
 	// Track scroll target: container under mouse for scroll wheel routing
-	if mouseInRect && (u.scrollTarget == nil || cnt.zindex >= u.scrollTarget.zindex) {
+	if mouseInRect && (u.scrollTarget == nil || !u.inFront(u.scrollTarget, cnt)) {
 		u.scrollTarget = cnt
 	}
 }
 
+// rootRect returns the area a root container covers on screen. A collapsed
+// window covers only its title bar.
+func (u *UI) rootRect(cnt *Container) types.Rect {
+	r := cnt.rect
+	if cnt.collapsed && cnt.opt&OptNoTitle == 0 {
+		r.H = u.style.TitleHeight + u.style.BorderWidth
+	}
+	return r
+}
+
+// constrainWindow applies a window's size limits and, with
+// ConstrainToScreen, keeps it inside its viewport or the screen, clear of
+// status bars. A window larger than that is pinned to the top-left corner.
+func (u *UI) constrainWindow(cnt *Container) {
+	r := cnt.rect
+	r.W, r.H = cnt.constrainSize(r.W, r.H)
+	if area, ok := u.windowArea(cnt); u.constrainToScreen && ok {
+		r.X = max(area.X, min(r.X, area.X+area.W-r.W))
+		r.Y = max(area.Y, min(r.Y, area.Y+area.H-r.H))
+	}
+	cnt.rect = r
+}
+
+// toggleMaximized maximizes a window to the screen, or its viewport, or
+// restores the rect it had before.
+func (u *UI) toggleMaximized(cnt *Container) {
+	if cnt.maximized {
+		cnt.rect = cnt.restoreRect
+	} else {
+		cnt.restoreRect = cnt.rect
+		cnt.rect, _ = u.windowArea(cnt)
+	}
+	cnt.maximized = !cnt.maximized
+}
+
 // endRootContainer marks the end of a root container.
 // It records the tail command index for z-order rendering.
 func (u *UI) endRootContainer(cnt *Container) {
@@ -866,14 +1670,88 @@ func (u *UI) inHoverRoot() bool {
 	return false
 }
 
-// PushCommand adds a command to the buffer.
+// PushCommand adds a command to the buffer. Drawing commands that lie
+// entirely outside the current clip rect are dropped and counted in Stats.
 func (u *UI) PushCommand(cmd Command) {
+	if bounds, ok := u.commandBounds(cmd); ok && u.CheckClip(bounds) == ClipAll {
+		u.culled++
+		return
+	}
 	u.commands.Push(cmd)
 }
 
+// commandBounds returns the screen area a drawing command covers.
+// Clip commands have no bounds and are never culled.
+func (u *UI) commandBounds(cmd Command) (types.Rect, bool) {
+	switch cmd.Kind {
+	case CmdClip:
+		return types.Rect{}, false
+	case CmdRect:
+		return types.Rect{X: cmd.Pos.X, Y: cmd.Pos.Y, W: cmd.Size.X, H: cmd.Size.Y}, true
+	case CmdText:
+		font := cmd.Font
+		if font == nil {
+			font = u.style.Font
+		}
+		return types.Rect{X: cmd.Pos.X, Y: cmd.Pos.Y, W: font.Width(cmd.Text), H: font.Height()}, true
+	case CmdShadow:
+		r := cmd.Rect
+		return types.Rect{X: r.X, Y: r.Y, W: r.W + cmd.Pos.X, H: r.H + cmd.Pos.Y}, true
+	default:
+		return cmd.Rect, true
+	}
+}
+
+// FrameStats reports how many draw commands a frame produced.
+type FrameStats struct {
+	Commands int // Commands in the buffer, including clip changes
+	Culled   int // Drawing commands dropped for lying outside the clip rect
+}
+
+// Stats returns command counts for the current frame. Call it after
+// EndFrame for totals.
+func (u *UI) Stats() FrameStats {
+	return FrameStats{Commands: u.commands.Len(), Culled: u.culled}
+}
+
+// TruncateText shortens text to fit width pixels in the style font,
+// ending it with "..." when it is cut.
+func (u *UI) TruncateText(text string, width int) string {
+	font := u.style.Font
+	if font.Width(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	buf := make([]rune, 0, len(runes)+2)
+	for n := len(runes) - 1; n > 0; n-- {
+		buf = append(append(buf[:0], runes[:n]...), '.', '.', '.')
+		if types.MeasureRunes(font, buf) <= width {
+			return string(buf)
+		}
+	}
+	return ""
+}
+
+// DrawText draws text with its top-left corner at pos. A nil font uses
+// the style font. Right-to-left text is reordered for display in the UI's
+// text direction.
+func (u *UI) DrawText(text string, pos types.Vec2, font types.Font, c color.Color) {
+	if font == nil {
+		font = u.style.Font
+	}
+	text, _ = visualText(text, u.textDir)
+	u.PushCommand(Command{
+		Kind:  CmdText,
+		Text:  text,
+		Pos:   pos,
+		Color: c,
+		Font:  font,
+	})
+}
+
 // DrawBox draws an outline rectangle at the specified position.
 func (u *UI) DrawBox(rect types.Rect, c color.Color) {
-	u.commands.Push(Command{
+	u.PushCommand(Command{
 		Kind:  CmdBox,
 		Rect:  rect,
 		Pos:   types.Vec2{X: rect.X, Y: rect.Y},
@@ -884,7 +1762,7 @@ func (u *UI) DrawBox(rect types.Rect, c color.Color) {
 
 // DrawRect draws a filled rectangle at the specified position.
 func (u *UI) DrawRect(rect types.Rect, c color.Color) {
-	u.commands.Push(Command{
+	u.PushCommand(Command{
 		Kind:  CmdRect,
 		Rect:  rect,
 		Pos:   types.Vec2{X: rect.X, Y: rect.Y},
@@ -893,9 +1771,17 @@ func (u *UI) DrawRect(rect types.Rect, c color.Color) {
 	})
 }
 
+// DrawCustom adds an application command covering rect, such as a shader
+// effect or a block of terminal cells. It is z-ordered and clipped with the
+// rest of the container and handed to a renderer implementing
+// CustomRenderer, which gets data untouched; other renderers skip it.
+func (u *UI) DrawCustom(rect types.Rect, data any) {
+	u.PushCommand(Command{Kind: CmdCustom, Rect: rect, Data: data})
+}
+
 // drawScrollTrack adds a scrollbar track command.
 func (u *UI) drawScrollTrack(rect types.Rect) {
-	u.commands.Push(Command{
+	u.PushCommand(Command{
 		Kind: CmdScrollTrack,
 		Rect: rect,
 	})
@@ -903,7 +1789,7 @@ func (u *UI) drawScrollTrack(rect types.Rect) {
 
 // drawScrollThumb adds a scrollbar thumb command.
 func (u *UI) drawScrollThumb(rect types.Rect) {
-	u.commands.Push(Command{
+	u.PushCommand(Command{
 		Kind: CmdScrollThumb,
 		Rect: rect,
 	})
@@ -920,18 +1806,50 @@ func (u *UI) DrawControlFrame(id ID, rect types.Rect, colorID int, opt int) {
 	if opt&OptNoFrame != 0 {
 		return
 	}
-	// Adjust color based on focus/hover state
-	if u.input.Focus == id {
+	// Adjust color based on focus/hover state.
+	// Keyboard focus shows as hover plus a focus ring rather than pressed.
+	// With animations, hover blends in and out over a few frames.
+	hover := u.input.Hover == id
+	if u.animations {
+		t := u.hoverFade(id, hover || u.input.Focus == id)
+		hover = t >= 1
+		if !hover && t > 0 && u.navFocus != id && u.input.Focus != id {
+			u.DrawFrame(rect, colorID)
+			u.DrawRect(rect, blendColor(u.GetColorByID(colorID), u.GetColorByID(colorID+1), t))
+			return
+		}
+	}
+	if u.navFocus == id {
+		colorID += 1
+	} else if u.input.Focus == id {
 		colorID += 2
-	} else if u.input.Hover == id {
+	} else if hover {
 		colorID += 1
 	}
 	u.DrawFrame(rect, colorID)
+	if u.navFocus == id {
+		u.drawFocusRing(rect)
+	}
+}
+
+// drawFocusRing draws the keyboard focus ring around rect.
+func (u *UI) drawFocusRing(rect types.Rect) {
+	ring := u.style.Colors.FocusRing
+	if ring == nil {
+		ring = u.style.Colors.Text
+	}
+	u.DrawBox(types.Rect{X: rect.X - 2, Y: rect.Y - 2, W: rect.W + 4, H: rect.H + 4}, ring)
 }
 
 // DrawControlText draws text inside a control rect with alignment options.
+// Right-to-left text (see OptRTL) is reordered for display and, without
+// an alignment option, aligned to the right.
 func (u *UI) DrawControlText(text string, rect types.Rect, colorID int, opt int) {
 	font := u.style.Font
+	text, rtl := visualText(text, u.textDirection(opt))
+	if rtl && opt&OptAlignCenter == 0 {
+		opt |= OptAlignRight
+	}
 	textWidth := font.Width(text)
 	textHeight := font.Height()
 
@@ -950,7 +1868,7 @@ func (u *UI) DrawControlText(text string, rect types.Rect, colorID int, opt int)
 		pos.X = rect.X + u.style.Padding.X
 	}
 
-	u.commands.Push(Command{
+	u.PushCommand(Command{
 		Kind:  CmdText,
 		Text:  text,
 		Pos:   pos,
@@ -961,20 +1879,53 @@ func (u *UI) DrawControlText(text string, rect types.Rect, colorID int, opt int)
 	u.PopClip()
 }
 
+// drawBackground draws the background of a window or panel: its frame,
+// or a plain rect and border with the container's own background, or
+// just the border with OptNoBackground.
+func (u *UI) drawBackground(cnt *Container, rect types.Rect, colorID int) {
+	switch {
+	case cnt.opt&OptNoBackground != 0:
+		u.drawBorder(rect)
+	case cnt.bg == nil && cnt.bgFade == 0:
+		u.DrawFrame(rect, colorID)
+	default:
+		c := cnt.bg
+		if c == nil {
+			c = u.GetColorByID(colorID)
+		}
+		if c != nil {
+			u.DrawRect(rect, fadeColor(c, 1-cnt.bgFade))
+		}
+		u.drawBorder(rect)
+	}
+}
+
 // defaultDrawFrame draws a filled rectangle with border.
 func defaultDrawFrame(ui *UI, rect types.Rect, colorID int) {
+	if ns, ok := ui.style.Frames[colorID]; ok {
+		ui.DrawNineSlice(rect, ns, nil)
+		return
+	}
+	if colorID == ColorGroup {
+		ui.DrawBox(rect, ui.GetColorByID(colorID))
+		return
+	}
+
 	c := ui.GetColorByID(colorID)
 	ui.DrawRect(rect, c)
 
 	// Draw border if border color has non-zero alpha
-	// Skip border for scrollbar elements and title bar
-	if colorID == ColorScrollBase || colorID == ColorScrollThumb || colorID == ColorTitleBG {
+	// Skip border for scrollbar elements, title bar and progress fill
+	if colorID == ColorScrollBase || colorID == ColorScrollThumb || colorID == ColorTitleBG || colorID == ColorProgressFill || colorID == ColorStatusBar {
 		return
 	}
+	ui.drawBorder(rect)
+}
 
-	// Check if border color has alpha
-	if ui.style.Colors.Border != nil {
-		_, _, _, a := ui.style.Colors.Border.RGBA()
+// drawBorder draws the style's border around rect, if it has one.
+func (u *UI) drawBorder(rect types.Rect) {
+	if u.style.Colors.Border != nil {
+		_, _, _, a := u.style.Colors.Border.RGBA()
 		if a > 0 {
 			// Draw border
 			borderRect := types.Rect{
@@ -983,7 +1934,7 @@ func defaultDrawFrame(ui *UI, rect types.Rect, colorID int) {
 				W: rect.W + 2,
 				H: rect.H + 2,
 			}
-			ui.DrawBox(borderRect, ui.style.Colors.Border)
+			u.DrawBox(borderRect, u.style.Colors.Border)
 		}
 	}
 }
@@ -1023,16 +1974,44 @@ func (u *UI) GetColorByID(colorID int) color.Color {
 		return u.style.Colors.ScrollBase
 	case ColorScrollThumb:
 		return u.style.Colors.ScrollThumb
+	case ColorProgressBase:
+		return u.style.Colors.Base
+	case ColorProgressFill:
+		return u.style.Colors.ButtonActive
+	case ColorRadio:
+		return u.style.Colors.Base
+	case ColorRadioHover:
+		return u.style.Colors.BaseHover
+	case ColorRadioFocus:
+		return u.style.Colors.BaseFocus
+	case ColorStatusBar:
+		return u.style.Colors.WindowTitle
+	case ColorGroup:
+		if c := u.style.Colors.Border; c != nil {
+			if _, _, _, a := c.RGBA(); a > 0 {
+				return c
+			}
+		}
+		return u.style.Colors.Text
 	default:
 		return u.style.Colors.Text
 	}
 }
 
-// DrawIcon draws an icon at the specified rect.
+// DrawIcon draws an icon at the specified rect. Icons found in
+// Style.Icons are drawn as images tinted with c.
 func (u *UI) DrawIcon(iconID int, rect types.Rect, c color.Color) {
+	if u.style.Icons != nil {
+		if img, src, ok := u.style.Icons.IconImage(iconID); ok {
+			u.Image(img, fitImage(rect, src, u.scale), src, c)
+			return
+		}
+	}
+
 	// Check clipping
 	clipped := u.CheckClip(rect)
 	if clipped == ClipAll {
+		u.culled++
 		return
 	}
 
@@ -1044,9 +2023,10 @@ func (u *UI) DrawIcon(iconID int, rect types.Rect, c color.Color) {
 		})
 	}
 
-	u.commands.Push(Command{
+	u.PushCommand(Command{
 		Kind:  CmdIcon,
 		Icon:  iconID,
+		Text:  u.IconName(iconID),
 		Rect:  rect,
 		Color: c,
 	})
@@ -1069,6 +2049,7 @@ func (u *UI) PushClip(rect types.Rect) {
 		rect = intersectRect(rect, current)
 	}
 	u.clipStack.Push(rect)
+	u.debugCur.clipDepth = max(u.debugCur.clipDepth, u.clipStack.Len())
 	u.commands.Push(Command{
 		Kind: CmdClip,
 		Rect: rect,
@@ -1157,17 +2138,29 @@ func (u *UI) PopWindowRect() {
 	u.currentWindowRect = types.Rect{}
 }
 
-// Checkbox adds a checkbox to the current layout.
+// Checkbox adds a checkbox to the current layout. Its ID comes from the
+// address of checked, so state such as focus is lost if the value moves
+// (e.g. a reallocated slice); CheckboxID avoids that.
 func (u *UI) Checkbox(label string, checked *bool) bool {
-	id := u.getIDFromPtr(checked)
+	return u.checkbox(u.getIDFromPtr(checked), label, checked)
+}
+
+// CheckboxID is Checkbox with an ID from id (scoped by PushID) instead of
+// the address of checked.
+func (u *UI) CheckboxID(id, label string, checked *bool) bool {
+	return u.checkbox(u.getID(id), label, checked)
+}
+
+func (u *UI) checkbox(id ID, label string, checked *bool) bool {
 	rect := u.LayoutNext()
 	box := types.Rect{X: rect.X, Y: rect.Y, W: rect.H, H: rect.H}
 	u.UpdateControl(id, rect)
 
 	changed := false
-	if u.input.MousePressed[int(MouseLeft)] && u.input.Focus == id {
+	if u.itemClicked(u.activated(id)) {
 		*checked = !*checked
-		changed = true
+		changed = u.itemChanged(true)
+		u.emit(UIEventToggle, id, label, boolValue(*checked), rect)
 	}
 
 	u.DrawControlFrame(id, box, ColorBase, 0)
@@ -1175,6 +2168,11 @@ func (u *UI) Checkbox(label string, checked *bool) bool {
 		u.DrawIcon(IconCheck, box, u.style.Colors.Text)
 	}
 	u.DrawControlText(label, types.Rect{X: rect.X + box.W, Y: rect.Y, W: rect.W - box.W, H: rect.H}, ColorText, 0)
+	var state AccessState
+	if *checked {
+		state = AccessChecked
+	}
+	u.accessControl(id, rect, RoleCheckbox, label, "", state)
 	return changed
 }
 
@@ -1186,9 +2184,19 @@ func (u *UI) Slider(value *float64, low, high float64) bool {
 
 // SliderOpt adds a slider with step, format, and options.
 // step: value increment (0 for smooth), format: display format string (empty to hide value)
+// Its ID comes from the address of value, like Checkbox; see SliderID.
 func (u *UI) SliderOpt(value *float64, low, high, step float64, format string, opt int) bool {
+	return u.slider(u.getIDFromPtr(value), value, low, high, step, format, opt)
+}
+
+// SliderID is SliderOpt with an ID from id (scoped by PushID) instead of
+// the address of value.
+func (u *UI) SliderID(id string, value *float64, low, high, step float64, format string, opt int) bool {
+	return u.slider(u.getID(id), value, low, high, step, format, opt)
+}
+
+func (u *UI) slider(id ID, value *float64, low, high, step float64, format string, opt int) bool {
 	rect := u.LayoutNext()
-	id := u.getIDFromPtr(value)
 
 	_, active := u.UpdateControl(id, rect)
 
@@ -1223,7 +2231,8 @@ func (u *UI) SliderOpt(value *float64, low, high, step float64, format string, o
 
 		if *value != newValue {
 			*value = newValue
-			changed = true
+			changed = u.itemChanged(true)
+			u.emit(UIEventSliderTick, id, "", newValue, rect)
 		}
 	}
 
@@ -1256,6 +2265,7 @@ func (u *UI) SliderOpt(value *float64, low, high, step float64, format string, o
 	}
 	text := fmt.Sprintf(displayFormat, *value)
 	u.DrawControlText(text, rect, ColorText, opt)
+	u.accessControl(id, rect, RoleSlider, "", text, accessOptState(opt))
 
 	return changed
 }
@@ -1268,11 +2278,85 @@ func (u *UI) Number(value *float64, step float64) bool {
 
 // NumberOpt adds a draggable number input with format and options.
 // format controls how the number is displayed (e.g., "%.2f", "%d").
-// opt can include OptAlignCenter, OptAlignRight, OptNoInteract.
+// opt can include OptAlignCenter, OptAlignRight, OptNoInteract and
+// OptSpinner, which adds -/+ buttons and steps the value with the mouse
+// wheel while hovered.
 // Shift+click enters textbox edit mode for direct value input.
+// Its ID comes from the address of value, like Checkbox; see NumberID.
 func (u *UI) NumberOpt(value *float64, step float64, format string, opt int) bool {
+	return u.number(u.getIDFromPtr(value), value, math.Inf(-1), math.Inf(1), step, format, opt)
+}
+
+// NumberRange is NumberOpt with the value clamped to [low, high], however
+// it is changed: dragging, typing, the spinner buttons or the wheel.
+func (u *UI) NumberRange(value *float64, low, high, step float64, format string, opt int) bool {
+	return u.number(u.getIDFromPtr(value), value, low, high, step, format, opt)
+}
+
+// NumberID is NumberOpt with an ID from id (scoped by PushID) instead of
+// the address of value.
+func (u *UI) NumberID(id string, value *float64, step float64, format string, opt int) bool {
+	return u.number(u.getID(id), value, math.Inf(-1), math.Inf(1), step, format, opt)
+}
+
+func (u *UI) number(id ID, value *float64, low, high, step float64, format string, opt int) bool {
 	rect := u.LayoutNext()
-	id := u.getIDFromPtr(value)
+	if u.accessOn {
+		defer func() {
+			u.accessControl(id, rect, RoleSpinButton, "", fmt.Sprintf(format, *value), accessOptState(opt))
+		}()
+	}
+	if opt&OptSpinner == 0 {
+		return u.itemChanged(u.numberField(id, rect, value, low, high, step, format, opt))
+	}
+
+	// Square -/+ buttons at the ends, the field between them
+	bw := min(rect.H, rect.W/3)
+	dec := types.Rect{X: rect.X, Y: rect.Y, W: bw, H: rect.H}
+	inc := types.Rect{X: rect.X + rect.W - bw, Y: rect.Y, W: bw, H: rect.H}
+	field := types.Rect{X: rect.X + bw, Y: rect.Y, W: rect.W - 2*bw, H: rect.H}
+
+	u.idStack.Push(id)
+	decID, incID := u.GetID("-"), u.GetID("+")
+	u.idStack.Pop()
+
+	changed := false
+	stepBy := func(n float64) {
+		*value = math.Max(low, math.Min(*value+n*step, high))
+		changed = true
+	}
+
+	// The wheel steps the value rather than scrolling the window
+	if opt&OptNoInteract == 0 && u.input.ScrollDelta.Y != 0 && u.MouseOver(rect) {
+		if u.input.ScrollDelta.Y < 0 {
+			stepBy(1)
+		} else {
+			stepBy(-1)
+		}
+		u.input.ScrollDelta.Y = 0
+	}
+
+	buttons := [...]struct {
+		id    ID
+		rect  types.Rect
+		label string
+		dir   float64
+	}{{decID, dec, "-", -1}, {incID, inc, "+", 1}}
+	for _, b := range buttons {
+		// Only the field is in the Tab order
+		u.UpdateControlOpt(b.id, b.rect, opt|OptNoNav)
+		if u.activatedRepeat(b.id) {
+			stepBy(b.dir)
+		}
+		u.DrawControlFrame(b.id, b.rect, ColorButton, opt)
+		u.DrawControlText(b.label, b.rect, ColorText, OptAlignCenter)
+	}
+
+	return u.itemChanged(u.numberField(id, field, value, low, high, step, format, opt) || changed)
+}
+
+// numberField is the draggable field of a Number, drawn in rect.
+func (u *UI) numberField(id ID, rect types.Rect, value *float64, low, high, step float64, format string, opt int) bool {
 
 	// Check if we're in textbox edit mode
 	if u.numberTextboxID == id {
@@ -1282,11 +2366,11 @@ func (u *UI) NumberOpt(value *float64, step float64, format string, opt int) boo
 			// Fall through to render as normal number control
 		} else {
 			// Render as textbox instead of number control
-			result := u.numberTextboxRaw(&u.numberTextboxBuf, 64, id, rect, 0)
+			result := u.textboxRaw(&u.numberTextboxBuf, 64, id, rect, OptNumeric, nil)
 			if result&ResSubmit != 0 {
 				// Parse and apply value on Enter
 				if parsed, err := strconv.ParseFloat(string(u.numberTextboxBuf), 64); err == nil {
-					*value = parsed
+					*value = math.Max(low, math.Min(parsed, high))
 				}
 				u.numberTextboxID = 0 // Exit textbox mode
 				return true           // Value changed
@@ -1319,7 +2403,7 @@ func (u *UI) NumberOpt(value *float64, step float64, format string, opt int) boo
 
 		// Drag to change value (normal click without shift)
 		if active && u.input.MouseDown[int(MouseLeft)] && !u.input.KeyDown[KeyShift] {
-			*value += float64(u.input.MouseDelta.X) * step
+			*value = math.Max(low, math.Min(*value+float64(u.input.MouseDelta.X)*step, high))
 			if u.input.MouseDelta.X != 0 {
 				changed = true
 			}
@@ -1346,7 +2430,7 @@ func (u *UI) NumberOpt(value *float64, step float64, format string, opt int) boo
 		}
 	}
 
-	u.commands.Push(Command{
+	u.PushCommand(Command{
 		Kind:  CmdRect,
 		Rect:  rect,
 		Pos:   types.Vec2{X: rect.X, Y: rect.Y},
@@ -1368,7 +2452,7 @@ func (u *UI) NumberOpt(value *float64, step float64, format string, opt int) boo
 
 	// Clip text to rect bounds
 	u.PushClip(rect)
-	u.commands.Push(Command{
+	u.PushCommand(Command{
 		Kind:  CmdText,
 		Text:  text,
 		Pos:   types.Vec2{X: textX, Y: textY},
@@ -1380,200 +2464,6 @@ func (u *UI) NumberOpt(value *float64, step float64, format string, opt int) boo
 	return changed
 }
 
-// numberTextboxRaw renders an inline textbox for number editing.
-// This is similar to TextboxOpt but takes the id and rect directly
-// since LayoutNext() was already called by NumberOpt.
-func (u *UI) numberTextboxRaw(buf *[]byte, maxLen int, id ID, rect types.Rect, opt int) int {
-	// Update control state - textboxes need OptHoldFocus to keep focus after click
-	hover, active := u.UpdateControlOpt(id, rect, opt|OptHoldFocus)
-
-	result := 0
-
-	// Handle focus change - position cursor at click location
-	if active && u.lastTextboxID != id {
-		u.lastTextboxID = id
-		u.textboxScrollX = 0 // Reset scroll on focus change
-		// Position cursor at click location (not just at end)
-		u.textboxCursor = u.textboxCursorFromClick(buf, rect)
-	}
-
-	// Handle click-to-reposition cursor (clicking while already focused)
-	if active && hover && u.input.MousePressed[int(MouseLeft)] && u.lastTextboxID == id {
-		u.textboxCursor = u.textboxCursorFromClick(buf, rect)
-	}
-
-	// Clamp cursor to valid range - ONLY for active textbox!
-	// Otherwise inactive textboxes with shorter buffers would clamp the cursor
-	if active {
-		if u.textboxCursor > len(*buf) {
-			u.textboxCursor = len(*buf)
-		}
-		if u.textboxCursor < 0 {
-			u.textboxCursor = 0
-		}
-	}
-
-	// Handle text input when focused and interactive
-	if active && opt&OptNoInteract == 0 {
-		// Add typed text at cursor position (UTF-8 aware)
-		if len(u.input.TextInput) > 0 {
-			for _, r := range u.input.TextInput {
-				runeBytes := []byte(string(r))
-				if len(*buf)+len(runeBytes) <= maxLen-1 {
-					// Insert at cursor position
-					newBuf := make([]byte, len(*buf)+len(runeBytes))
-					copy(newBuf, (*buf)[:u.textboxCursor])
-					copy(newBuf[u.textboxCursor:], runeBytes)
-					copy(newBuf[u.textboxCursor+len(runeBytes):], (*buf)[u.textboxCursor:])
-					*buf = newBuf
-					u.textboxCursor += len(runeBytes)
-					result |= ResChange
-				}
-			}
-		}
-
-		// Handle backspace (delete character before cursor, UTF-8 aware)
-		if u.input.KeyPressed[KeyBackspace] && u.textboxCursor > 0 {
-			// Find start of previous UTF-8 character
-			i := u.textboxCursor - 1
-			for i > 0 && (*buf)[i]&0xC0 == 0x80 {
-				i--
-			}
-			// Delete from i to cursor
-			newBuf := make([]byte, len(*buf)-(u.textboxCursor-i))
-			copy(newBuf, (*buf)[:i])
-			copy(newBuf[i:], (*buf)[u.textboxCursor:])
-			*buf = newBuf
-			u.textboxCursor = i
-			result |= ResChange
-		}
-
-		// Delete (UTF-8 aware)
-		if u.input.KeyPressed[KeyDelete] && u.textboxCursor < len(*buf) {
-			i := u.textboxCursor + 1
-			for i < len(*buf) && (*buf)[i]&0xC0 == 0x80 {
-				i++
-			}
-			newBuf := make([]byte, len(*buf)-(i-u.textboxCursor))
-			copy(newBuf, (*buf)[:u.textboxCursor])
-			copy(newBuf[u.textboxCursor:], (*buf)[i:])
-			*buf = newBuf
-			result |= ResChange
-		}
-
-		// Left/Right (UTF-8 aware)
-		if u.input.KeyPressed[KeyLeft] && u.textboxCursor > 0 {
-			u.textboxCursor--
-			for u.textboxCursor > 0 && (*buf)[u.textboxCursor]&0xC0 == 0x80 {
-				u.textboxCursor--
-			}
-		}
-		if u.input.KeyPressed[KeyRight] && u.textboxCursor < len(*buf) {
-			u.textboxCursor++
-			for u.textboxCursor < len(*buf) && (*buf)[u.textboxCursor]&0xC0 == 0x80 {
-				u.textboxCursor++
-			}
-		}
-
-		if u.input.KeyPressed[KeyHome] {
-			u.textboxCursor = 0
-		}
-		if u.input.KeyPressed[KeyEnd] {
-			u.textboxCursor = len(*buf)
-		}
-		if u.input.KeyPressed[KeyEnter] {
-			result |= ResSubmit
-		}
-	}
-
-	if active {
-		result |= ResActive
-	}
-
-	// Keep cursor visible
-	if active {
-		textWidth := rect.W - u.style.Padding.X*2
-		cursorX := u.style.Font.Width(string((*buf)[:u.textboxCursor]))
-		if cursorX-u.textboxScrollX > textWidth-10 {
-			u.textboxScrollX = cursorX - textWidth + 20
-		}
-		if cursorX < u.textboxScrollX+10 {
-			u.textboxScrollX = cursorX - 10
-			if u.textboxScrollX < 0 {
-				u.textboxScrollX = 0
-			}
-		}
-	}
-
-	// Draw textbox background
-	bgColor := u.style.Colors.Base
-	if bgColor == nil {
-		bgColor = u.style.Colors.CheckBg
-	}
-	if hover && opt&OptNoInteract == 0 {
-		if u.style.Colors.BaseHover != nil {
-			bgColor = u.style.Colors.BaseHover
-		} else {
-			bgColor = u.style.Colors.ButtonHover
-		}
-	}
-	if active {
-		if u.style.Colors.BaseFocus != nil {
-			bgColor = u.style.Colors.BaseFocus
-		} else {
-			bgColor = u.style.Colors.ButtonActive
-		}
-	}
-
-	u.commands.Push(Command{
-		Kind:  CmdRect,
-		Rect:  rect,
-		Pos:   types.Vec2{X: rect.X, Y: rect.Y},
-		Size:  types.Vec2{X: rect.W, Y: rect.H},
-		Color: bgColor,
-	})
-
-	// Push clip rect to prevent text drawing outside textbox bounds
-	textClipRect := types.Rect{
-		X: rect.X + u.style.Padding.X,
-		Y: rect.Y,
-		W: rect.W - u.style.Padding.X*2,
-		H: rect.H,
-	}
-	u.PushClip(textClipRect)
-
-	// Apply scroll offset to text position
-	// Vertically center text within the control (like DrawControlText does)
-	textX := rect.X + u.style.Padding.X - u.textboxScrollX
-	textHeight := u.style.Font.Height()
-	textY := rect.Y + (rect.H-textHeight)/2
-
-	// Draw text content (without cursor - cursor drawn separately)
-	text := string(*buf)
-	u.commands.Push(Command{
-		Kind:  CmdText,
-		Text:  text,
-		Pos:   types.Vec2{X: textX, Y: textY},
-		Color: u.style.Colors.Text,
-		Font:  u.style.Font,
-	})
-
-	// Pop clip rect before drawing cursor (cursor should overlay text)
-	u.PopClip()
-
-	// Draw cursor as thin vertical line (modern style, doesn't shift text)
-	// Drawn after PopClip so it's not clipped by text area
-	if active && opt&OptNoInteract == 0 {
-		textBeforeCursor := string((*buf)[:u.textboxCursor])
-		cursorPixelX := textX + u.style.Font.Width(textBeforeCursor)
-		cursorHeight := u.style.Font.Height()
-		cursorRect := types.Rect{X: cursorPixelX, Y: textY, W: 1, H: cursorHeight}
-		u.DrawRect(cursorRect, u.style.Colors.Text)
-	}
-
-	return result
-}
-
 // BeginPanel starts a scrollable panel.
 // Use a unique name for each panel.
 func (u *UI) BeginPanel(name string) bool {
@@ -1581,16 +2471,29 @@ func (u *UI) BeginPanel(name string) bool {
 }
 
 // BeginPanelOpt starts a panel with options.
-// opt can include OptNoFrame (no background), OptNoScroll (disable scrolling).
+// opt can include OptNoFrame (no background), OptNoScroll (disable scrolling),
+// OptNoInteract (controls inside, including nested panels, ignore input),
+// OptFitContent (size to the content measured last frame).
 func (u *UI) BeginPanelOpt(name string, opt int) bool {
 	// Push panel name onto ID stack for scoping
 	u.PushID(name)
 
-	// Get rect from layout
-	rect := u.LayoutNext()
-
 	// Get or create container for this panel (for scroll persistence)
 	cnt := u.GetContainer(name)
+	u.beginFooterSpace(cnt)
+
+	// A panel fitting its content takes its size from last frame's
+	if opt&OptFitContent != 0 || cnt.fitNext {
+		opt |= OptFitContent | OptNoScroll
+		if cnt.measured {
+			u.LayoutWidth(max(0, cnt.contentSize.X) + u.style.Padding.X*2)
+			u.LayoutHeight(max(0, cnt.contentSize.Y) + u.style.Padding.Y*2 + cnt.footer)
+		}
+	}
+	cnt.fitNext = false
+
+	// Get rect from layout
+	rect := u.LayoutNext()
 
 	// Update rect (panels use layout rect, not stored rect)
 	cnt.rect = rect
@@ -1598,17 +2501,21 @@ func (u *UI) BeginPanelOpt(name string, opt int) bool {
 	// Store options for scrollbar check
 	cnt.opt = opt
 
-	// Push container onto stack
+	// Nest in the current container, which a disabled panel passes on
+	cnt.parent = u.GetCurrentContainer()
+	cnt.disabled = opt&OptNoInteract != 0 || cnt.parent != nil && cnt.parent.disabled
 	u.containerStack.Push(cnt)
+	u.accessContainer(cnt, RolePanel, "")
 
-	// Track scroll target: if mouse is inside panel, it takes priority over parent window
-	if rect.Contains(u.input.MousePos) {
-		u.scrollTarget = cnt
+	// Track scroll target: the innermost panel under the mouse, from which
+	// routeScroll walks out to the window
+	if !cnt.disabled && u.MouseOver(rect) {
+		u.scrollPanel = cnt
 	}
 
 	// Draw panel background unless OptNoFrame
 	if opt&OptNoFrame == 0 {
-		u.DrawFrame(rect, ColorPanelBG)
+		u.drawBackground(cnt, rect, ColorPanelBG)
 	}
 
 	// Calculate body (content area)
@@ -1619,6 +2526,7 @@ func (u *UI) BeginPanelOpt(name string, opt int) bool {
 		H: rect.H,
 	}
 
+	u.reserveFooter(cnt, &body)
 	u.scrollbars(cnt, &body)
 	cnt.body = body
 	u.PushClip(cnt.body)
@@ -1638,26 +2546,12 @@ func (u *UI) BeginPanelOpt(name string, opt int) bool {
 
 // EndPanel finishes the current panel.
 func (u *UI) EndPanel() {
+	u.endColumns()
+	u.endReveals(u.layoutStack.Len())
 	cnt := u.GetCurrentContainer()
 	if cnt != nil {
-		layout := u.getLayout()
-		cnt.contentSize.X = layout.max.X - layout.body.X
-		cnt.contentSize.Y = layout.max.Y - layout.body.Y
-
-		maxScrollX := cnt.contentSize.X + u.style.Padding.X*2 - cnt.body.W
-		maxScrollY := cnt.contentSize.Y + u.style.Padding.Y*2 - cnt.body.H
-		if maxScrollX < 0 {
-			maxScrollX = 0
-		}
-		if maxScrollY < 0 {
-			maxScrollY = 0
-		}
-		if cnt.scroll.X > maxScrollX {
-			cnt.scroll.X = maxScrollX
-		}
-		if cnt.scroll.Y > maxScrollY {
-			cnt.scroll.Y = maxScrollY
-		}
+		u.endScroll(cnt)
+		u.overlayScrollbars(cnt)
 	}
 
 	u.PopLayout()
@@ -1678,19 +2572,18 @@ func (u *UI) Header(label string) bool {
 
 // HeaderEx adds a collapsible header with options.
 func (u *UI) HeaderEx(label string, opt int) bool {
+	u.endReveals(u.layoutStack.Len())
 	u.LayoutRow(1, []int{-1}, 0)
 	id := u.GetID(label)
-	expanded, exists := u.treeNodeState[id]
-	if !exists {
-		expanded = (opt & OptExpanded) != 0
-	}
+	expanded := u.treeNodeExpanded(id, opt)
 	rect := u.LayoutNext()
 	u.UpdateControl(id, rect)
 
-	if u.input.MousePressed[int(MouseLeft)] && u.input.Focus == id {
+	toggled := u.activated(id)
+	if toggled {
 		expanded = !expanded
 	}
-	u.treeNodeState[id] = expanded
+	u.setTreeNode(id, label, expanded)
 	u.DrawControlFrame(id, rect, ColorButton, 0)
 
 	iconID := IconCollapsed
@@ -1704,6 +2597,10 @@ func (u *UI) HeaderEx(label string, opt int) bool {
 		iconOffset = 2
 	}
 	u.DrawControlText(label, types.Rect{X: rect.X + iconOffset, Y: rect.Y, W: rect.W - iconOffset, H: rect.H}, ColorText, 0)
+	u.accessControl(id, rect, RoleHeader, label, "", accessExpanded(expanded))
+	if u.animations {
+		return u.headerReveal(id, expanded, toggled)
+	}
 	return expanded
 }
 
@@ -1719,18 +2616,15 @@ func (u *UI) BeginTreeNodeEx(label string, opt int) bool {
 	rect := u.LayoutNext()
 	id := u.GetID(label)
 
-	expanded, exists := u.treeNodeState[id]
-	if !exists {
-		expanded = (opt & OptExpanded) != 0
-	}
+	expanded := u.treeNodeExpanded(id, opt)
 	u.UpdateControl(id, rect)
 
-	if u.input.MousePressed[int(MouseLeft)] && u.input.Focus == id {
+	if u.activated(id) {
 		expanded = !expanded
 	}
-	u.treeNodeState[id] = expanded
+	u.setTreeNode(id, label, expanded)
 
-	if u.input.Hover == id {
+	if u.input.Hover == id || u.navFocus == id {
 		u.DrawFrame(rect, ColorButtonHover)
 	}
 
@@ -1745,10 +2639,12 @@ func (u *UI) BeginTreeNodeEx(label string, opt int) bool {
 		iconOffset = 2
 	}
 	u.DrawControlText(label, types.Rect{X: rect.X + iconOffset, Y: rect.Y, W: rect.W - iconOffset, H: rect.H}, ColorText, 0)
+	node := u.accessControl(id, rect, RoleTreeItem, label, "", accessExpanded(expanded))
 
 	if expanded {
 		u.getLayout().indent += u.style.Indent
 		u.PushID(label)
+		u.accessOpen(node)
 		return true
 	}
 	return false
@@ -1758,259 +2654,7 @@ func (u *UI) BeginTreeNodeEx(label string, opt int) bool {
 func (u *UI) EndTreeNode() {
 	u.getLayout().indent -= u.style.Indent
 	u.PopID()
-}
-
-// Textbox adds a text input field to the current layout.
-// buf is the text buffer, maxLen is the maximum length.
-// Returns ResChange if text changed, ResSubmit if Enter pressed.
-func (u *UI) Textbox(buf *[]byte, maxLen int) int {
-	return u.TextboxOpt(buf, maxLen, 0)
-}
-
-// TextboxOpt adds a text input field with options.
-// opt can include OptNoInteract (read-only), OptHoldFocus (keep focus).
-func (u *UI) TextboxOpt(buf *[]byte, maxLen int, opt int) int {
-	rect := u.LayoutNext()
-	id := u.getIDFromPtr(buf)
-
-	// Update control state - textboxes need OptHoldFocus to keep focus after click
-	hover, active := u.UpdateControlOpt(id, rect, opt|OptHoldFocus)
-
-	result := 0
-
-	// Handle focus change - position cursor at click location
-	if active && u.lastTextboxID != id {
-		u.lastTextboxID = id
-		u.textboxScrollX = 0 // Reset scroll on focus change
-		// Position cursor at click location (not just at end)
-		u.textboxCursor = u.textboxCursorFromClick(buf, rect)
-	}
-
-	// Handle click-to-reposition cursor (clicking while already focused)
-	if active && hover && u.input.MousePressed[int(MouseLeft)] && u.lastTextboxID == id {
-		u.textboxCursor = u.textboxCursorFromClick(buf, rect)
-	}
-
-	// Clamp cursor to valid range - ONLY for active textbox!
-	// Otherwise inactive textboxes with shorter buffers would clamp the cursor
-	if active {
-		if u.textboxCursor > len(*buf) {
-			u.textboxCursor = len(*buf)
-		}
-		if u.textboxCursor < 0 {
-			u.textboxCursor = 0
-		}
-	}
-
-	// Handle text input when focused and interactive
-	if active && opt&OptNoInteract == 0 {
-		// Add typed text at cursor position (UTF-8 aware)
-		if len(u.input.TextInput) > 0 {
-			for _, r := range u.input.TextInput {
-				runeBytes := []byte(string(r))
-				if len(*buf)+len(runeBytes) <= maxLen-1 {
-					// Insert at cursor position
-					newBuf := make([]byte, len(*buf)+len(runeBytes))
-					copy(newBuf, (*buf)[:u.textboxCursor])
-					copy(newBuf[u.textboxCursor:], runeBytes)
-					copy(newBuf[u.textboxCursor+len(runeBytes):], (*buf)[u.textboxCursor:])
-					*buf = newBuf
-					u.textboxCursor += len(runeBytes)
-					result |= ResChange
-				}
-			}
-		}
-
-		// Handle backspace (delete character before cursor, UTF-8 aware)
-		if u.input.KeyPressed[KeyBackspace] && u.textboxCursor > 0 {
-			// Find start of previous UTF-8 character
-			i := u.textboxCursor - 1
-			for i > 0 && (*buf)[i]&0xC0 == 0x80 {
-				i--
-			}
-			// Delete from i to cursor
-			newBuf := make([]byte, len(*buf)-(u.textboxCursor-i))
-			copy(newBuf, (*buf)[:i])
-			copy(newBuf[i:], (*buf)[u.textboxCursor:])
-			*buf = newBuf
-			u.textboxCursor = i
-			result |= ResChange
-		}
-
-		// Delete (UTF-8 aware)
-		if u.input.KeyPressed[KeyDelete] && u.textboxCursor < len(*buf) {
-			i := u.textboxCursor + 1
-			for i < len(*buf) && (*buf)[i]&0xC0 == 0x80 {
-				i++
-			}
-			newBuf := make([]byte, len(*buf)-(i-u.textboxCursor))
-			copy(newBuf, (*buf)[:u.textboxCursor])
-			copy(newBuf[u.textboxCursor:], (*buf)[i:])
-			*buf = newBuf
-			result |= ResChange
-		}
-
-		// Left/Right (UTF-8 aware)
-		if u.input.KeyPressed[KeyLeft] && u.textboxCursor > 0 {
-			u.textboxCursor--
-			for u.textboxCursor > 0 && (*buf)[u.textboxCursor]&0xC0 == 0x80 {
-				u.textboxCursor--
-			}
-		}
-		if u.input.KeyPressed[KeyRight] && u.textboxCursor < len(*buf) {
-			u.textboxCursor++
-			for u.textboxCursor < len(*buf) && (*buf)[u.textboxCursor]&0xC0 == 0x80 {
-				u.textboxCursor++
-			}
-		}
-
-		if u.input.KeyPressed[KeyHome] {
-			u.textboxCursor = 0
-		}
-		if u.input.KeyPressed[KeyEnd] {
-			u.textboxCursor = len(*buf)
-		}
-		if u.input.KeyPressed[KeyEnter] {
-			result |= ResSubmit
-		}
-	}
-
-	if active {
-		result |= ResActive
-	}
-
-	// Keep cursor visible
-	if active {
-		textWidth := rect.W - u.style.Padding.X*2
-		cursorX := u.style.Font.Width(string((*buf)[:u.textboxCursor]))
-		if cursorX-u.textboxScrollX > textWidth-10 {
-			u.textboxScrollX = cursorX - textWidth + 20
-		}
-		if cursorX < u.textboxScrollX+10 {
-			u.textboxScrollX = cursorX - 10
-			if u.textboxScrollX < 0 {
-				u.textboxScrollX = 0
-			}
-		}
-	}
-
-	// Draw textbox background
-	bgColor := u.style.Colors.Base
-	if bgColor == nil {
-		bgColor = u.style.Colors.CheckBg
-	}
-	if hover && opt&OptNoInteract == 0 {
-		if u.style.Colors.BaseHover != nil {
-			bgColor = u.style.Colors.BaseHover
-		} else {
-			bgColor = u.style.Colors.ButtonHover
-		}
-	}
-	if active {
-		if u.style.Colors.BaseFocus != nil {
-			bgColor = u.style.Colors.BaseFocus
-		} else {
-			bgColor = u.style.Colors.ButtonActive
-		}
-	}
-
-	u.commands.Push(Command{
-		Kind:  CmdRect,
-		Rect:  rect,
-		Pos:   types.Vec2{X: rect.X, Y: rect.Y},
-		Size:  types.Vec2{X: rect.W, Y: rect.H},
-		Color: bgColor,
-	})
-
-	// Push clip rect to prevent text drawing outside textbox bounds
-	textClipRect := types.Rect{
-		X: rect.X + u.style.Padding.X,
-		Y: rect.Y,
-		W: rect.W - u.style.Padding.X*2,
-		H: rect.H,
-	}
-	u.PushClip(textClipRect)
-
-	// Apply scroll offset to text position
-	// Vertically center text within the control (like DrawControlText does)
-	textX := rect.X + u.style.Padding.X - u.textboxScrollX
-	textHeight := u.style.Font.Height()
-	textY := rect.Y + (rect.H-textHeight)/2
-
-	// Draw text content (without cursor - cursor drawn separately)
-	text := string(*buf)
-	u.commands.Push(Command{
-		Kind:  CmdText,
-		Text:  text,
-		Pos:   types.Vec2{X: textX, Y: textY},
-		Color: u.style.Colors.Text,
-		Font:  u.style.Font,
-	})
-
-	// Pop clip rect before drawing cursor (cursor should overlay text)
-	u.PopClip()
-
-	// Draw cursor as thin vertical line (modern style, doesn't shift text)
-	// Drawn after PopClip so it's not clipped by text area
-	if active && opt&OptNoInteract == 0 {
-		textBeforeCursor := string((*buf)[:u.textboxCursor])
-		cursorPixelX := textX + u.style.Font.Width(textBeforeCursor)
-		cursorHeight := u.style.Font.Height()
-		cursorRect := types.Rect{X: cursorPixelX, Y: textY, W: 1, H: cursorHeight}
-		u.DrawRect(cursorRect, u.style.Colors.Text)
-	}
-
-	return result
-}
-
-// textboxCursorFromClick calculates cursor position from mouse click location.
-// It walks through the text measuring character widths to find the closest position.
-func (u *UI) textboxCursorFromClick(buf *[]byte, rect types.Rect) int {
-	// Calculate click X position relative to text start
-	textStartX := rect.X + u.style.Padding.X - u.textboxScrollX
-	clickX := u.input.MousePos.X - textStartX
-
-	// If clicked before text start, cursor goes to beginning
-	if clickX <= 0 {
-		return 0
-	}
-
-	// Walk through text to find position closest to click
-	text := string(*buf)
-	font := u.style.Font
-	bestPos := len(*buf)
-	bestDist := clickX // Distance if cursor at end
-
-	pos := 0
-	for i, r := range text {
-		// Measure width up to this character
-		charWidth := font.Width(string(r))
-		textWidthBefore := font.Width(text[:i])
-
-		// Distance from click to position before this character
-		dist := clickX - textWidthBefore
-		if dist < 0 {
-			dist = -dist
-		}
-		if dist < bestDist {
-			bestDist = dist
-			bestPos = pos
-		}
-
-		// Distance from click to position after this character
-		dist = clickX - (textWidthBefore + charWidth)
-		if dist < 0 {
-			dist = -dist
-		}
-		if dist < bestDist {
-			bestDist = dist
-			bestPos = pos + len(string(r))
-		}
-
-		pos += len(string(r))
-	}
-
-	return bestPos
+	u.accessClose()
 }
 
 // GetID returns an ID for the given name, combined with current ID stack.
@@ -2045,6 +2689,7 @@ func (u *UI) getRawID(name string) ID {
 func (u *UI) PushID(name string) {
 	id := u.GetID(name)
 	u.idStack.Push(id)
+	u.idNames.Push(name)
 }
 
 // PopID removes the top ID context from the stack.
@@ -2052,6 +2697,9 @@ func (u *UI) PopID() {
 	if u.idStack.Len() > 0 {
 		u.idStack.Pop()
 	}
+	if u.idNames.Len() > 0 {
+		u.idNames.Pop()
+	}
 }
 
 // getID generates an ID from a string (internal, uses GetID).
@@ -2059,7 +2707,9 @@ func (u *UI) getID(name string) ID {
 	return u.GetID(name)
 }
 
-// getIDFromPtr generates an ID from a pointer address.
+// getIDFromPtr generates an ID from a pointer address. It is kept for the
+// controls that predate explicit IDs; the ID changes whenever the value
+// moves, so new controls take an ID string instead.
 func (u *UI) getIDFromPtr(ptr interface{}) ID {
 	h := uint32(2166136261)
 	s := fmt.Sprintf("%p", ptr)
@@ -2075,20 +2725,10 @@ func (u *UI) getIDFromInt(val int) ID {
 	return u.GetID(fmt.Sprintf("!icon:%d", val))
 }
 
-// OpenPopup opens a popup at the current mouse position.
+// OpenPopup opens a popup at the current mouse position, below and right
+// of it, flipping to stay on screen like OpenPopupAt.
 func (u *UI) OpenPopup(name string) {
-	cnt := u.GetContainer(name)
-	u.hoverRoot = cnt
-	u.nextHoverRoot = cnt
-	cnt.rect = types.Rect{
-		X: u.input.MousePos.X,
-		Y: u.input.MousePos.Y,
-		W: 1,
-		H: 1,
-	}
-
-	cnt.open = true
-	u.BringToFront(cnt)
+	u.OpenPopupAt(name, types.Rect{X: u.input.MousePos.X, Y: u.input.MousePos.Y})
 }
 
 // BeginPopup begins a popup container.
@@ -2102,32 +2742,24 @@ func (u *UI) EndPopup() {
 	u.EndWindow()
 }
 
-func (u *UI) processInput() {
-	for {
-		select {
-		case ev := <-u.inputCh:
-			u.handleInput(ev)
-		default:
-			return
-		}
-	}
-}
-
 // InputState tracks the current input state.
 type InputState struct {
-	MousePos      types.Vec2
-	MouseDelta    types.Vec2   // Mouse movement this frame
-	LastMousePos  types.Vec2   // Previous frame mouse position
-	MouseDown     [3]bool
-	MousePressed  [3]bool      // Cleared each frame
-	ScrollDelta   types.Vec2   // Accumulated scroll this frame
-	KeyDown       map[Key]bool
-	KeyPressed    map[Key]bool // Key presses this frame (cleared each frame)
-	Focus         ID           // Currently focused control (has input capture)
-	Hover         ID           // Control under mouse (only when mouse not down)
-	LastID        ID           // Last control ID processed
-	UpdatedFocus  bool         // Was focus used this frame
-	TextInput     string       // Text input this frame
+	MousePos     types.Vec2
+	MouseDelta   types.Vec2 // Mouse movement this frame
+	LastMousePos types.Vec2 // Previous frame mouse position
+	MouseDown    [3]bool
+	MousePressed [3]bool    // Cleared each frame
+	ScrollDelta  types.Vec2 // Accumulated scroll this frame
+	KeyDown      map[Key]bool
+	KeyPressed   map[Key]bool   // Key presses this frame (cleared each frame)
+	Focus        ID             // Currently focused control (has input capture)
+	Hover        ID             // Control under mouse (only when mouse not down)
+	LastID       ID             // Last control ID processed
+	UpdatedFocus bool           // Was focus used this frame
+	TextInput    string         // Text input this frame
+	NavPressed   [navCount]bool // Directional navigation this frame (cleared each frame)
+	TouchPan     types.Vec2     // Two-finger drag this frame, also in ScrollDelta
+	PinchScale   float64        // Two-finger pinch zoom this frame (0 = none)
 }
 
 // ID is a unique identifier for UI elements.
@@ -2144,95 +2776,15 @@ type Window struct {
 // Unlike Label, Text wraps to fit the available width.
 // Explicit newlines (\n) in the text create line breaks.
 func (u *UI) Text(text string) {
-	layout := u.getLayout()
-	font := u.style.Font
-	if font == nil {
-		font = &types.MockFont{}
-	}
-
-	availWidth := layout.body.W - layout.indent - u.style.Padding.X*2
-
-	relY := layout.position.Y
-	paragraphs := strings.Split(text, "\n")
-	for _, para := range paragraphs {
-		if para == "" {
-			relY += font.Height()
-			continue
-		}
-
-		words := splitWords(para)
-		line := ""
-
-		for _, word := range words {
-			testLine := line
-			if len(testLine) > 0 {
-				testLine += " "
-			}
-			testLine += word
-
-			if font.Width(testLine) > availWidth && len(line) > 0 {
-				u.commands.Push(Command{
-					Kind:  CmdText,
-					Text:  line,
-					Pos:   types.Vec2{X: layout.body.X + layout.indent + u.style.Padding.X, Y: layout.body.Y + relY},
-					Color: u.style.Colors.Text,
-					Font:  font,
-				})
-				relY += font.Height()
-				line = word
-			} else {
-				line = testLine
-			}
-		}
-
-		if len(line) > 0 {
-			u.commands.Push(Command{
-				Kind:  CmdText,
-				Text:  line,
-				Pos:   types.Vec2{X: layout.body.X + layout.indent + u.style.Padding.X, Y: layout.body.Y + relY},
-				Color: u.style.Colors.Text,
-				Font:  font,
-			})
-			relY += font.Height()
-		}
-	}
-
-	absX := layout.body.X + layout.indent + u.style.Padding.X
-	absY := layout.body.Y + relY
-	if absX+availWidth > layout.max.X {
-		layout.max.X = absX + availWidth
-	}
-	if absY > layout.max.Y {
-		layout.max.Y = absY
-	}
-
-	layout.nextRow = relY + u.style.Spacing
-	layout.position.Y = layout.nextRow
-}
-
-// splitWords splits text into words.
-func splitWords(text string) []string {
-	var words []string
-	word := ""
-	for _, r := range text {
-		if r == ' ' || r == '\n' || r == '\t' {
-			if len(word) > 0 {
-				words = append(words, word)
-				word = ""
-			}
-		} else {
-			word += string(r)
-		}
-	}
-	if len(word) > 0 {
-		words = append(words, word)
-	}
-	return words
+	u.TextSegments(TextSegment{Text: text})
 }
 
 // scrollbars handles scrollbar rendering and interaction for containers.
+// Overlay scrollbars take no room from the body and are drawn over the
+// content by overlayScrollbars instead.
 func (u *UI) scrollbars(cnt *Container, body *types.Rect) {
-	if cnt.opt&OptNoScroll != 0 {
+	u.animateScroll(cnt)
+	if cnt.opt&OptNoScroll != 0 || u.style.ScrollbarOverlay {
 		return
 	}
 
@@ -2244,98 +2796,206 @@ func (u *UI) scrollbars(cnt *Container, body *types.Rect) {
 
 	u.PushClip(*body)
 
-	prevW := cnt.body.W
-	prevH := cnt.body.H
-	if prevW == 0 {
-		prevW = body.W
-	}
-	if prevH == 0 {
-		prevH = body.H
-	}
-
-	if cs.Y > prevH {
+	// Decide both bars from this frame's body, so they appear and go in
+	// the frame a resize needs them. Content was measured last frame:
+	// content that filled last frame's body is taken to fill this one
+	// too rather than overflow it.
+	fillX, fillY := cnt.fills[0], cnt.fills[1]
+	needY := !fillY && cs.Y > body.H
+	if needY {
 		body.W -= sz
 	}
-	if cs.X > prevW {
+	needX := !fillX && cs.X > body.W
+	if needX {
 		body.H -= sz
-	}
-
-	maxScrollY := cs.Y - body.H
-	if maxScrollY > 0 && body.H > 0 {
-		base := types.Rect{
-			X: body.X + body.W,
-			Y: body.Y,
-			W: sz,
-			H: body.H,
-		}
-		scrollID := u.GetID("!scrollbary")
-		u.UpdateControl(scrollID, base)
-		if u.input.Focus == scrollID && u.input.MouseDown[int(MouseLeft)] {
-			cnt.scroll.Y += u.input.MouseDelta.Y * cs.Y / base.H
-		}
-		if cnt.scroll.Y < 0 {
-			cnt.scroll.Y = 0
-		}
-		if cnt.scroll.Y > maxScrollY {
-			cnt.scroll.Y = maxScrollY
+		if !needY && !fillY && cs.Y > body.H {
+			// The horizontal bar pushed the content into needing both
+			needY = true
+			body.W -= sz
 		}
+	}
 
-		u.drawScrollTrack(base)
-
-		thumb := base
-		thumbMinSize := u.style.ThumbSize
-		thumb.H = base.H * body.H / cs.Y
-		if thumb.H < thumbMinSize {
-			thumb.H = thumbMinSize
-		}
-		thumb.Y += cnt.scroll.Y * (base.H - thumb.H) / maxScrollY
-		u.drawScrollThumb(thumb)
-
-		if u.MouseOver(*body) {
-			u.scrollTarget = cnt
+	var bars []scrollbarParts
+	if needY && body.H > 0 {
+		bar := types.Rect{X: body.X + body.W, Y: body.Y, W: sz, H: body.H}
+		if u.style.ScrollbarArrows {
+			// Keep the down arrow clear of the window's resize gripper
+			bar = u.aboveGripper(cnt, bar)
 		}
+		bars = append(bars, u.scrollbar(cnt, bar, true, body.H, cs.Y))
 	} else {
 		cnt.scroll.Y = 0
 	}
+	if needX && body.W > 0 {
+		bars = append(bars, u.scrollbar(cnt, types.Rect{X: body.X, Y: body.Y + body.H, W: body.W, H: sz}, false, body.W, cs.X))
+	} else {
+		cnt.scroll.X = 0
+	}
+	u.drawScrollbars(cnt, bars)
 
-	maxScrollX := cs.X - body.W
-	if maxScrollX > 0 && body.W > 0 {
-		base := types.Rect{
-			X: body.X,
-			Y: body.Y + body.H,
-			W: body.W,
-			H: sz,
-		}
-		scrollID := u.GetID("!scrollbarx")
-		u.UpdateControl(scrollID, base)
-		if u.input.Focus == scrollID && u.input.MouseDown[int(MouseLeft)] {
-			cnt.scroll.X += u.input.MouseDelta.X * cs.X / base.W
-		}
-		if cnt.scroll.X < 0 {
-			cnt.scroll.X = 0
-		}
-		if cnt.scroll.X > maxScrollX {
-			cnt.scroll.X = maxScrollX
+	u.PopClip()
+}
+
+// overlayScrollbars draws the scrollbars of Style.ScrollbarOverlay along
+// the inside edges of cnt's body, over the content, at the end of the
+// container. Coming last, they take the mouse from the content beneath.
+func (u *UI) overlayScrollbars(cnt *Container) {
+	if cnt.opt&OptNoScroll != 0 || !u.style.ScrollbarOverlay {
+		return
+	}
+	sz := u.style.ScrollbarSize
+	body := cnt.body
+	cs := cnt.contentSize
+	cs.X += u.style.Padding.X * 2
+	cs.Y += u.style.Padding.Y * 2
+	vertical := cs.Y > body.H && body.H > 0
+	horizontal := cs.X > body.W && body.W > 0
+
+	var bars []scrollbarParts
+	if vertical {
+		bar := types.Rect{X: body.X + body.W - sz, Y: body.Y, W: sz, H: body.H}
+		if horizontal {
+			bar.H -= sz
+		} else {
+			bar = u.aboveGripper(cnt, bar)
+		}
+		bars = append(bars, u.scrollbar(cnt, bar, true, body.H, cs.Y))
+	}
+	if horizontal {
+		bar := types.Rect{X: body.X, Y: body.Y + body.H - sz, W: body.W, H: sz}
+		if vertical {
+			bar.W -= sz
+		}
+		bars = append(bars, u.scrollbar(cnt, bar, false, body.W, cs.X))
+	}
+	u.drawScrollbars(cnt, bars)
+}
+
+// aboveGripper shortens a window's vertical scrollbar to end above its
+// resize gripper.
+func (u *UI) aboveGripper(cnt *Container, bar types.Rect) types.Rect {
+	if cnt.parent == nil && cnt.opt&OptNoResize == 0 {
+		bar.H = min(bar.H, cnt.rect.Y+cnt.rect.H-u.style.ScrollbarSize-bar.Y)
+	}
+	return bar
+}
+
+// scrollbarParts is where a scrollbar's track, thumb and arrow buttons lie,
+// kept to draw once both of a container's scrollbars have taken input.
+type scrollbarParts struct {
+	track, thumb types.Rect
+	arrows       []types.Rect
+	icons        [2]int
+	hot          bool // Under the mouse or being dragged
+}
+
+// scrollbar handles the input of cnt's scrollbar bar along one axis and
+// returns where its parts lie; view and content are the body's and the
+// content's length along it. The thumb follows the mouse from where it was
+// grabbed, pressing the track pages towards the mouse, again and again
+// while held, and the arrow buttons of Style.ScrollbarArrows scroll a line
+// at a time.
+func (u *UI) scrollbar(cnt *Container, bar types.Rect, vertical bool, view, content int) scrollbarParts {
+	// Work along the axis: spans of rects and the scroll offset
+	name, icons := "!scrollbarx", [2]int{IconArrowLeft, IconArrowRight}
+	scroll, mouse := &cnt.scroll.X, u.input.MousePos.X
+	span := func(r types.Rect) (int, int) { return r.X, r.W }
+	part := func(pos, n int) types.Rect { return types.Rect{X: pos, Y: bar.Y, W: n, H: bar.H} }
+	if vertical {
+		name, icons = "!scrollbary", [2]int{IconArrowUp, IconArrowDown}
+		scroll, mouse = &cnt.scroll.Y, u.input.MousePos.Y
+		span = func(r types.Rect) (int, int) { return r.Y, r.H }
+		part = func(pos, n int) types.Rect { return types.Rect{X: bar.X, Y: pos, W: bar.W, H: n} }
+	}
+	maxScroll := content - view
+	start, length := span(bar)
+	scrolled := false
+	parts := scrollbarParts{track: bar, icons: icons}
+	inUse := func(id ID) bool { return u.input.Hover == id || u.input.Focus == id }
+
+	if sz := u.style.ScrollbarSize; u.style.ScrollbarArrows && length >= 2*sz+u.style.ThumbSize {
+		parts.arrows = []types.Rect{part(start, sz), part(start+length-sz, sz)}
+		start, length = start+sz, length-2*sz
+		step := max(u.style.Size.Y, 1)
+		for i, r := range parts.arrows {
+			id := u.GetID(name + [2]string{"<", ">"}[i])
+			u.UpdateControlOpt(id, r, OptNoNav)
+			if u.activatedRepeat(id) {
+				*scroll += (i*2 - 1) * step
+				scrolled = true
+			}
+			parts.hot = parts.hot || inUse(id)
 		}
+	}
 
-		u.drawScrollTrack(base)
+	*scroll = max(0, min(*scroll, maxScroll))
+	thumbLen := max(length*view/content, u.style.ThumbSize)
+	thumbPos := func() int { return start + *scroll*(length-thumbLen)/maxScroll }
 
-		thumb := base
-		thumbMinSize := u.style.ThumbSize
-		thumb.W = base.W * body.W / cs.X
-		if thumb.W < thumbMinSize {
-			thumb.W = thumbMinSize
+	id := u.GetID(name)
+	u.UpdateControlOpt(id, part(start, length), OptNoNav)
+	pressed := u.activatedRepeat(id)
+	pos := thumbPos()
+	if pressed && u.input.MousePressed[int(MouseLeft)] {
+		cnt.thumbHeld = mouse >= pos && mouse < pos+thumbLen
+		cnt.thumbGrab = mouse - pos
+	}
+	switch {
+	case cnt.thumbHeld && u.input.Focus == id && u.input.MouseDown[int(MouseLeft)]:
+		if length > thumbLen {
+			// Round up so the thumb lands back under the mouse
+			n, l := max(mouse-cnt.thumbGrab-start, 0), length-thumbLen
+			*scroll = (n*maxScroll + l - 1) / l
+		}
+		scrolled = true
+	case pressed && mouse < pos:
+		*scroll -= view
+		scrolled = true
+	case pressed && mouse >= pos+thumbLen:
+		*scroll += view
+		scrolled = true
+	}
+	*scroll = max(0, min(*scroll, maxScroll))
+	if scrolled {
+		cnt.scrollAnim = false
+	}
+
+	parts.thumb = part(thumbPos(), thumbLen)
+	parts.hot = parts.hot || inUse(id)
+	return parts
+}
+
+// drawScrollbars draws a container's scrollbars. Overlay and auto-hiding
+// scrollbars are drawn flat in the style's colors so they can fade, and
+// overlay ones without a track.
+func (u *UI) drawScrollbars(cnt *Container, bars []scrollbarParts) {
+	if !u.style.ScrollbarOverlay && !u.style.ScrollbarAutoHide {
+		for _, b := range bars {
+			u.drawScrollTrack(b.track)
+			u.drawScrollThumb(b.thumb)
+			for i, r := range b.arrows {
+				u.DrawIcon(b.icons[i], r, u.style.Colors.Text)
+			}
 		}
-		thumb.X += cnt.scroll.X * (base.W - thumb.W) / maxScrollX
-		u.drawScrollThumb(thumb)
+		return
+	}
 
-		if u.MouseOver(*body) {
-			u.scrollTarget = cnt
+	hot := false
+	for _, b := range bars {
+		hot = hot || b.hot
+	}
+	alpha := u.scrollbarAlpha(cnt, len(bars) > 0, hot)
+	if alpha == 0 {
+		return
+	}
+	for _, b := range bars {
+		if !u.style.ScrollbarOverlay {
+			u.DrawRect(b.track, fadeColor(u.style.Colors.ScrollBase, alpha))
+		}
+		u.DrawRect(b.thumb, fadeColor(u.style.Colors.ScrollThumb, alpha))
+		for i, r := range b.arrows {
+			u.DrawIcon(b.icons[i], r, fadeColor(u.style.Colors.Text, alpha))
 		}
-	} else {
-		cnt.scroll.X = 0
 	}
-
-	u.PopClip()
 }
 
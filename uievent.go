@@ -0,0 +1,68 @@
+package microui
+
+import (
+	"strings"
+
+	"github.com/user/microui-go/types"
+)
+
+// UIEventKind says what happened in a UIEvent.
+type UIEventKind int
+
+const (
+	UIEventButton      UIEventKind = iota // Button or image button clicked
+	UIEventToggle                         // Checkbox or radio button changed; Value is 1 when on
+	UIEventWindowOpen                     // Window appeared this frame
+	UIEventWindowClose                    // Window no longer shown
+	UIEventSliderTick                     // Slider value changed; Value is the new value
+	UIEventPopupOpen                      // Popup, menu or dropdown opened
+)
+
+// UIEvent is a notable interaction reported to Config.OnUIEvent, for
+// attaching sounds or controller rumble to the UI.
+type UIEvent struct {
+	Kind  UIEventKind
+	ID    ID         // Control or container ID
+	Label string     // Control label, window title or popup name ("" for internal popups)
+	Value float64    // See the UIEventKind
+	Rect  types.Rect // Control or window rect, e.g. to pan a sound
+}
+
+// emit reports an event to Config.OnUIEvent.
+func (u *UI) emit(kind UIEventKind, id ID, label string, value float64, rect types.Rect) {
+	if u.onUIEvent == nil {
+		return
+	}
+	if strings.HasPrefix(label, "!") {
+		label = ""
+	}
+	u.onUIEvent(UIEvent{Kind: kind, ID: id, Label: label, Value: value, Rect: rect})
+}
+
+// boolValue returns 1 for true, as a UIEvent value.
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// emitWindowEvents reports the windows that appeared or disappeared this
+// frame. Windows shown in the first frame are not reported as opened.
+func (u *UI) emitWindowEvents() {
+	window := func(cnt *Container) bool {
+		return cnt.opt&OptPopup == 0 && cnt.name != backgroundName && !strings.HasPrefix(cnt.name, "!")
+	}
+	if u.frame > 1 {
+		for _, cnt := range u.rootList {
+			if window(cnt) && !containsRoot(u.prevRoots, cnt) {
+				u.emit(UIEventWindowOpen, cnt.id, cnt.name, 0, cnt.rect)
+			}
+		}
+	}
+	for _, cnt := range u.prevRoots {
+		if window(cnt) && !containsRoot(u.rootList, cnt) {
+			u.emit(UIEventWindowClose, cnt.id, cnt.name, 0, cnt.rect)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// eventFrame draws a checkbox (y=29) and a button (y=53), and a second
+// window while extra is set.
+func eventFrame(ui *UI, checked *bool, extra bool) {
+	ui.BeginFrame()
+	ui.BeginWindow("Test", types.Rect{X: 0, Y: 0, W: 400, H: 300})
+	ui.LayoutRow(1, []int{200}, 0)
+	ui.Checkbox("Mute", checked)
+	ui.Button("Play")
+	ui.EndWindow()
+	if extra {
+		ui.BeginWindow("Extra", types.Rect{X: 500, Y: 0, W: 100, H: 100})
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+func TestOnUIEvent_ControlsAndWindows(t *testing.T) {
+	var events []UIEvent
+	ui := New(Config{OnUIEvent: func(e UIEvent) { events = append(events, e) }})
+	checked := false
+	click := func(y int) {
+		ui.MouseMove(50, y)
+		eventFrame(ui, &checked, false)
+		ui.MouseDown(50, y, MouseLeft)
+		eventFrame(ui, &checked, false)
+		ui.MouseUp(50, y, MouseLeft)
+		eventFrame(ui, &checked, false)
+	}
+
+	click(35)
+	click(59)
+	eventFrame(ui, &checked, true)
+	eventFrame(ui, &checked, true)
+	eventFrame(ui, &checked, false)
+
+	want := []struct {
+		kind  UIEventKind
+		label string
+		value float64
+	}{
+		{UIEventToggle, "Mute", 1},
+		{UIEventButton, "Play", 0},
+		{UIEventWindowOpen, "Extra", 0},
+		{UIEventWindowClose, "Extra", 0},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events %v, want %d", len(events), events, len(want))
+	}
+	for i, w := range want {
+		if e := events[i]; e.Kind != w.kind || e.Label != w.label || e.Value != w.value {
+			t.Errorf("event %d = %v %q %v, want %v %q %v", i, e.Kind, e.Label, e.Value, w.kind, w.label, w.value)
+		}
+	}
+}
+
+func TestOnUIEvent_PopupOpen(t *testing.T) {
+	var events []UIEvent
+	ui := New(Config{OnUIEvent: func(e UIEvent) { events = append(events, e) }})
+	ui.BeginFrame()
+	ui.OpenPopup("menu")
+	ui.EndFrame()
+	if len(events) != 1 || events[0].Kind != UIEventPopupOpen || events[0].Label != "menu" {
+		t.Errorf("events = %v, want one popup open for \"menu\"", events)
+	}
+}
@@ -0,0 +1,367 @@
+// Package uitest drives a microui UI in tests without a window or renderer.
+//
+// A Harness runs the application's frame function and simulates input by
+// what is on screen rather than by coordinates: Click finds a control by its
+// label in the last frame's draw commands, DragTitle grabs a window by its
+// title bar, and so on. Every action runs as many frames as the UI needs to
+// see it, so hover and focus settle the way they do for a real user.
+//
+//	var clicked bool
+//	h := uitest.New(t, microui.Config{}, func(ui *microui.UI) {
+//		if ui.BeginWindow("Main", types.Rect{X: 0, Y: 0, W: 200, H: 100}) {
+//			if ui.Button("OK") {
+//				clicked = true
+//			}
+//			ui.EndWindow()
+//		}
+//	})
+//	h.Click("OK")
+//	if !clicked { ... }
+package uitest
+
+import (
+	"errors"
+	"image/color"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/types"
+)
+
+// Item is a piece of text drawn in the last frame.
+type Item struct {
+	Text   string
+	Rect   types.Rect // Where the text was drawn
+	Clip   types.Rect // Clip rect it was drawn with
+	Window string     // Root container it was drawn in
+}
+
+// Visible returns the part of the item inside its clip rect.
+func (it Item) Visible() types.Rect {
+	return intersect(it.Rect, it.Clip)
+}
+
+// Harness runs frames of a UI and simulates user input.
+type Harness struct {
+	UI *microui.UI
+
+	t       testing.TB
+	draw    func(ui *microui.UI)
+	text    string // Typed text for the next frame
+	items   []Item
+	windows []*microui.Container // Last frame's root containers, back to front
+}
+
+// New creates a UI from cfg and a harness that builds each frame with draw.
+// It runs one frame so the first action has something to look at.
+func New(t testing.TB, cfg microui.Config, draw func(ui *microui.UI)) *Harness {
+	h := &Harness{UI: microui.New(cfg), t: t, draw: draw}
+	h.Frame()
+	return h
+}
+
+// Frame runs one frame with the input queued so far and records what it
+// drew.
+func (h *Harness) Frame() {
+	h.UI.BeginFrame()
+	// BeginFrame clears text input, so it is added afterwards
+	if h.text != "" {
+		h.UI.TextInput(h.text)
+		h.text = ""
+	}
+	h.draw(h.UI)
+	h.UI.EndFrame()
+
+	rec := &recorder{font: h.UI.Style().Font}
+	h.windows = h.UI.RootContainersSorted()
+	if len(h.windows) == 0 {
+		rec.reset("")
+		h.UI.Render(rec)
+	}
+	for _, cnt := range h.windows {
+		rec.reset(cnt.Name())
+		h.UI.RenderContainer(cnt, rec)
+	}
+	h.items = rec.items
+}
+
+// Frames runs n frames.
+func (h *Harness) Frames(n int) {
+	for i := 0; i < n; i++ {
+		h.Frame()
+	}
+}
+
+// Items returns the text drawn in the last frame, back to front.
+func (h *Harness) Items() []Item {
+	return h.items
+}
+
+// Find returns the topmost visible item whose text is label.
+func (h *Harness) Find(label string) (Item, bool) {
+	return h.find("", label)
+}
+
+// MoveTo moves the mouse to x, y and runs a frame.
+func (h *Harness) MoveTo(x, y int) {
+	h.UI.MouseMove(x, y)
+	h.Frame()
+}
+
+// ClickAt clicks the left mouse button at x, y: it moves there, presses
+// and releases, running a frame after each step.
+func (h *Harness) ClickAt(x, y int) {
+	h.MoveTo(x, y)
+	h.UI.MouseDown(x, y, microui.MouseLeft)
+	h.Frame()
+	h.UI.MouseUp(x, y, microui.MouseLeft)
+	h.Frame()
+}
+
+// Click clicks the control labelled label. It fails the test if no such
+// text is visible.
+func (h *Harness) Click(label string) {
+	h.t.Helper()
+	it, ok := h.Find(label)
+	if !ok {
+		h.t.Fatalf("uitest: Click(%q): no visible text %q\n%s", label, label, h.dump())
+		return
+	}
+	p := center(it.Visible())
+	h.ClickAt(p.X, p.Y)
+}
+
+// Type sends text to the focused control and runs a frame.
+func (h *Harness) Type(text string) {
+	h.text += text
+	h.Frame()
+}
+
+// Press presses and releases key, running a frame after each.
+func (h *Harness) Press(key microui.Key) {
+	h.UI.KeyDown(key)
+	h.Frame()
+	h.UI.KeyUp(key)
+	h.Frame()
+}
+
+// DragTitle drags the window named window by its title bar by dx, dy.
+// It fails the test if the window isn't open.
+func (h *Harness) DragTitle(window string, dx, dy int) {
+	h.t.Helper()
+	cnt := h.window(window)
+	if cnt == nil {
+		h.t.Fatalf("uitest: DragTitle(%q): window not open", window)
+		return
+	}
+	// Grab the title text, which is clear of the title bar buttons
+	var p types.Vec2
+	if it, ok := h.find(window, window); ok {
+		p = center(it.Visible())
+	} else {
+		title := int(float64(h.UI.Style().TitleHeight) * h.UI.Scale())
+		p = types.Vec2{X: cnt.Rect().X + title + 1, Y: cnt.Rect().Y + title/2}
+	}
+	h.MoveTo(p.X, p.Y)
+	h.UI.MouseDown(p.X, p.Y, microui.MouseLeft)
+	h.Frame()
+	h.MoveTo(p.X+dx, p.Y+dy)
+	h.UI.MouseUp(p.X+dx, p.Y+dy, microui.MouseLeft)
+	h.Frame()
+}
+
+// ScrollPanel scrolls the panel or window named name by dy with the mouse
+// wheel over its center. It fails the test if it wasn't drawn in the last
+// frame.
+func (h *Harness) ScrollPanel(name string, dy int) {
+	h.t.Helper()
+	rect := h.UI.GetContainer(name).Rect()
+	if rect.Empty() {
+		h.t.Fatalf("uitest: ScrollPanel(%q): no such panel", name)
+		return
+	}
+	p := center(rect)
+	h.MoveTo(p.X, p.Y)
+	h.UI.Scroll(0, dy)
+	h.Frame()
+}
+
+// AssertVisible fails the test unless text label is visible: drawn in the
+// last frame, not clipped away and not covered by another window.
+func (h *Harness) AssertVisible(label string) {
+	h.t.Helper()
+	if _, ok := h.Find(label); !ok {
+		h.t.Errorf("uitest: %q is not visible\n%s", label, h.dump())
+	}
+}
+
+// AssertNotVisible fails the test if text label is visible.
+func (h *Harness) AssertNotVisible(label string) {
+	h.t.Helper()
+	if it, ok := h.Find(label); ok {
+		h.t.Errorf("uitest: %q is visible at %v in %q", label, it.Visible(), it.Window)
+	}
+}
+
+// UpdateEnv is the environment variable that makes AssertGolden write the
+// golden files instead of comparing against them, e.g.
+// "UITEST_UPDATE=1 go test ./...".
+const UpdateEnv = "UITEST_UPDATE"
+
+// AssertGolden fails the test unless the last frame's UI.DumpFrame matches
+// the golden file at path, reporting the first line that differs. With
+// UITEST_UPDATE set, or if the file doesn't exist yet, it writes the file
+// instead; commit it and review changes to it like code.
+func (h *Harness) AssertGolden(path string) {
+	h.t.Helper()
+	got := h.UI.DumpFrame()
+	want, err := os.ReadFile(path)
+	if os.Getenv(UpdateEnv) != "" || errors.Is(err, fs.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			h.t.Fatalf("uitest: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			h.t.Fatalf("uitest: %v", err)
+		}
+		return
+	}
+	if err != nil {
+		h.t.Fatalf("uitest: %v", err)
+	}
+	if got == string(want) {
+		return
+	}
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(string(want), "\n")
+	for i := 0; ; i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w || i >= len(gotLines) || i >= len(wantLines) {
+			h.t.Errorf("uitest: frame differs from %s at line %d\n got: %s\nwant: %s\nrun with %s=1 to update it",
+				path, i+1, g, w, UpdateEnv)
+			return
+		}
+	}
+}
+
+// visible reports whether it is inside its clip and its center isn't
+// covered by a window above the one it was drawn in.
+func (h *Harness) visible(it Item) bool {
+	vis := it.Visible()
+	if vis.Empty() {
+		return false
+	}
+	p := center(vis)
+	above := false
+	for _, cnt := range h.windows {
+		if above && h.cover(cnt).Contains(p) {
+			return false
+		}
+		if cnt.Name() == it.Window {
+			above = true
+		}
+	}
+	return true
+}
+
+// cover returns the area a window hides; collapsed windows only hide
+// their title bar.
+func (h *Harness) cover(cnt *microui.Container) types.Rect {
+	r := cnt.Rect()
+	if cnt.Collapsed() {
+		r.H = int(float64(h.UI.Style().TitleHeight) * h.UI.Scale())
+	}
+	return r
+}
+
+// window returns the open root container named name.
+func (h *Harness) window(name string) *microui.Container {
+	for _, cnt := range h.windows {
+		if cnt.Name() == name {
+			return cnt
+		}
+	}
+	return nil
+}
+
+// find is Find limited to one window, unless window is "".
+func (h *Harness) find(window, label string) (Item, bool) {
+	for i := len(h.items) - 1; i >= 0; i-- {
+		if it := h.items[i]; it.Text == label && (window == "" || it.Window == window) && h.visible(it) {
+			return it, true
+		}
+	}
+	return Item{}, false
+}
+
+// dump lists the visible text of the last frame, for failure messages.
+func (h *Harness) dump() string {
+	var sb strings.Builder
+	sb.WriteString("visible text:")
+	for _, it := range h.items {
+		if h.visible(it) {
+			sb.WriteString("\n  ")
+			if it.Window != "" {
+				sb.WriteString(it.Window + ": ")
+			}
+			sb.WriteString(strings.TrimSpace(it.Text))
+		}
+	}
+	return sb.String()
+}
+
+// recorder is a renderer that records the text drawn and its clip.
+type recorder struct {
+	font   types.Font // Used when a command carries no font
+	window string
+	clip   types.Rect
+	items  []Item
+}
+
+// noClip stands for an unclipped container start.
+var noClip = types.Rect{X: -1 << 20, Y: -1 << 20, W: 1 << 21, H: 1 << 21}
+
+func (r *recorder) reset(window string) {
+	r.window, r.clip = window, noClip
+}
+
+func (r *recorder) SetClip(rect types.Rect) {
+	r.clip = rect
+}
+
+func (r *recorder) DrawRect(pos, size types.Vec2, c color.Color) {}
+
+func (r *recorder) DrawText(text string, pos types.Vec2, font types.Font, c color.Color) {
+	if font == nil {
+		font = r.font
+	}
+	rect := types.Rect{X: pos.X, Y: pos.Y}
+	if font != nil {
+		rect.W, rect.H = font.Width(text), font.Height()
+	}
+	r.items = append(r.items, Item{Text: text, Rect: rect, Clip: r.clip, Window: r.window})
+}
+
+func center(r types.Rect) types.Vec2 {
+	return types.Vec2{X: r.X + r.W/2, Y: r.Y + r.H/2}
+}
+
+func intersect(a, b types.Rect) types.Rect {
+	x1, y1 := max(a.X, b.X), max(a.Y, b.Y)
+	x2, y2 := min(a.X+a.W, b.X+b.W), min(a.Y+a.H, b.Y+b.H)
+	if x2 < x1 {
+		x2 = x1
+	}
+	if y2 < y1 {
+		y2 = y1
+	}
+	return types.Rect{X: x1, Y: y1, W: x2 - x1, H: y2 - y1}
+}
@@ -0,0 +1,166 @@
+package uitest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	microui "github.com/user/microui-go"
+	"github.com/user/microui-go/types"
+)
+
+func TestHarness_Click(t *testing.T) {
+	clicks := 0
+	checked := false
+	h := New(t, microui.Config{}, func(ui *microui.UI) {
+		if ui.BeginWindow("Main", types.Rect{X: 0, Y: 0, W: 300, H: 200}) {
+			if ui.Button("OK") {
+				clicks++
+			}
+			ui.Checkbox("Enabled", &checked)
+			ui.EndWindow()
+		}
+	})
+
+	h.Click("OK")
+	if clicks != 1 {
+		t.Errorf("clicks = %d, want 1", clicks)
+	}
+	h.Click("Enabled")
+	if !checked {
+		t.Error("checkbox not checked after clicking its label")
+	}
+}
+
+func TestHarness_Type(t *testing.T) {
+	buf := []byte("Ann")
+	submitted := false
+	h := New(t, microui.Config{}, func(ui *microui.UI) {
+		if ui.BeginWindow("Form", types.Rect{X: 0, Y: 0, W: 300, H: 200}) {
+			if ui.Textbox(&buf, 64)&microui.ResSubmit != 0 {
+				submitted = true
+			}
+			ui.EndWindow()
+		}
+	})
+
+	h.Click("Ann") // A textbox is found by its contents
+	h.Press(microui.KeyEnd)
+	h.Type("ie")
+	h.Press(microui.KeyEnter)
+
+	if string(buf) != "Annie" {
+		t.Errorf("buf = %q, want %q", buf, "Annie")
+	}
+	if !submitted {
+		t.Error("Enter did not submit")
+	}
+	h.AssertVisible("Annie")
+}
+
+func TestHarness_DragTitle(t *testing.T) {
+	h := New(t, microui.Config{}, func(ui *microui.UI) {
+		if ui.BeginWindow("Drag", types.Rect{X: 20, Y: 10, W: 200, H: 150}) {
+			ui.EndWindow()
+		}
+	})
+
+	h.DragTitle("Drag", 50, 30)
+	if r := h.UI.GetContainer("Drag").Rect(); r.X != 70 || r.Y != 40 {
+		t.Errorf("window at (%d,%d), want (70,40)", r.X, r.Y)
+	}
+}
+
+func TestHarness_ScrollPanel(t *testing.T) {
+	h := New(t, microui.Config{}, func(ui *microui.UI) {
+		if ui.BeginWindow("Main", types.Rect{X: 0, Y: 0, W: 300, H: 250}) {
+			ui.LayoutRow(1, []int{-1}, 100)
+			if ui.BeginPanel("List") {
+				for i := 0; i < 20; i++ {
+					ui.Label(fmt.Sprintf("Item %d", i))
+				}
+				ui.EndPanel()
+			}
+			ui.EndWindow()
+		}
+	})
+
+	h.AssertVisible("Item 0")
+	h.AssertNotVisible("Item 15")
+
+	h.ScrollPanel("List", 1000)
+	h.Frame() // Scroll is clamped to the content when next drawn
+	h.AssertNotVisible("Item 0")
+	h.AssertVisible("Item 19")
+}
+
+func TestHarness_CoveredByWindow(t *testing.T) {
+	h := New(t, microui.Config{}, func(ui *microui.UI) {
+		if ui.BeginWindow("Back", types.Rect{X: 0, Y: 0, W: 200, H: 150}) {
+			ui.Label("Hidden")
+			ui.EndWindow()
+		}
+		if ui.BeginWindow("Front", types.Rect{X: 0, Y: 0, W: 200, H: 150}) {
+			ui.Label("Shown")
+			ui.EndWindow()
+		}
+	})
+
+	h.AssertVisible("Shown")
+	h.AssertNotVisible("Hidden")
+
+	h.DragTitle("Front", 150, 0)
+	h.AssertVisible("Hidden")
+}
+
+func TestHarness_ClickMissingLabel(t *testing.T) {
+	ft := &fakeT{TB: t}
+	h := New(ft, microui.Config{}, func(ui *microui.UI) {})
+	func() {
+		defer func() { recover() }()
+		h.Click("Nope")
+	}()
+	if !ft.failed {
+		t.Error("Click on a missing label did not fail the test")
+	}
+}
+
+// fakeT records failures instead of failing the real test.
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failed = true
+	panic("fatal")
+}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.failed = true
+}
+
+func TestHarness_AssertGolden(t *testing.T) {
+	label := "Hello"
+	h := New(t, microui.Config{}, func(ui *microui.UI) {
+		if ui.BeginWindow("Main", types.Rect{X: 0, Y: 0, W: 200, H: 100}) {
+			ui.Label(label)
+			ui.EndWindow()
+		}
+	})
+	path := filepath.Join(t.TempDir(), "testdata", "main.golden")
+	h.AssertGolden(path) // Writes the missing file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"Hello"`) {
+		t.Errorf("golden file doesn't hold the label:\n%s", data)
+	}
+	h.Frame()
+	h.AssertGolden(path)
+}
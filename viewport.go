@@ -0,0 +1,176 @@
+package microui
+
+import (
+	"slices"
+
+	"github.com/user/microui-go/types"
+)
+
+// Viewport is a region of the UI's coordinate space drawn to a render
+// target of its own: one pane of a split-screen editor, a second window's
+// render target, or one of two terminal panes. RenderViewport draws the
+// windows in the region, translated so its top-left corner lands at the
+// viewport's origin in the target and clipped to it, and the viewport's
+// input methods translate the other way.
+//
+// Windows are assigned to a viewport with Container.SetViewport. A window
+// without one is drawn by Render and by every viewport it shows in; one
+// with a viewport only by RenderViewport for it, and it takes the mouse
+// only inside the viewport's region. Give viewports separate regions of
+// the UI's space, e.g. side by side, so each window is under the mouse in
+// one of them only. Get one with UI.Viewport.
+type Viewport struct {
+	ui     *UI
+	name   string
+	rect   types.Rect // Region of the UI's space shown
+	origin types.Vec2 // Where rect's top-left corner is drawn in the target
+}
+
+// Viewport returns the viewport named name, creating it, showing nothing,
+// on first use.
+//
+//	left := ui.Viewport("left")
+//	left.SetRect(types.Rect{W: 640, H: 720})
+//	right := ui.Viewport("right")
+//	right.SetRect(types.Rect{X: 640, W: 640, H: 720})
+//	ui.GetContainer("Scene").SetViewport(left)
+func (u *UI) Viewport(name string) *Viewport {
+	for _, vp := range u.viewports {
+		if vp.name == name {
+			return vp
+		}
+	}
+	vp := &Viewport{ui: u, name: name}
+	u.viewports = append(u.viewports, vp)
+	return vp
+}
+
+// Viewports returns the viewports in the order they were created.
+func (u *UI) Viewports() []*Viewport {
+	return slices.Clone(u.viewports)
+}
+
+// RemoveViewport removes the viewport named name. Its windows are drawn by
+// Render again, like windows that never had a viewport.
+func (u *UI) RemoveViewport(name string) {
+	for i, vp := range u.viewports {
+		if vp.name != name {
+			continue
+		}
+		for _, cnt := range u.containers {
+			if cnt.viewport == vp {
+				cnt.viewport = nil
+			}
+		}
+		u.viewports = slices.Delete(u.viewports, i, i+1)
+		u.dirtyAll = true
+		return
+	}
+}
+
+// Name returns the viewport's name.
+func (vp *Viewport) Name() string {
+	return vp.name
+}
+
+// Rect returns the region of the UI's space the viewport shows.
+func (vp *Viewport) Rect() types.Rect {
+	return vp.rect
+}
+
+// SetRect sets the region of the UI's space the viewport shows, e.g. when
+// its target is resized. Maximized windows in the viewport fill it, and
+// with Config.ConstrainToScreen its windows are kept inside it.
+func (vp *Viewport) SetRect(r types.Rect) {
+	vp.rect = r
+	vp.ui.dirtyAll = true
+}
+
+// Origin returns where the top-left corner of Rect is drawn in the
+// viewport's target.
+func (vp *Viewport) Origin() types.Vec2 {
+	return vp.origin
+}
+
+// SetOrigin sets where the top-left corner of Rect is drawn in the
+// viewport's target; (0, 0), its top-left corner, by default.
+func (vp *Viewport) SetOrigin(p types.Vec2) {
+	vp.origin = p
+}
+
+// ToUI converts a position in the viewport's target to the UI's space.
+func (vp *Viewport) ToUI(p types.Vec2) types.Vec2 {
+	return types.Vec2{X: p.X - vp.origin.X + vp.rect.X, Y: p.Y - vp.origin.Y + vp.rect.Y}
+}
+
+// FromUI converts a position in the UI's space to the viewport's target.
+func (vp *Viewport) FromUI(p types.Vec2) types.Vec2 {
+	return types.Vec2{X: p.X - vp.rect.X + vp.origin.X, Y: p.Y - vp.rect.Y + vp.origin.Y}
+}
+
+// MouseMove is UI.MouseMove with x, y in the viewport's target.
+func (vp *Viewport) MouseMove(x, y int) {
+	p := vp.ToUI(types.Vec2{X: x, Y: y})
+	vp.ui.MouseMove(p.X, p.Y)
+}
+
+// MouseDown is UI.MouseDown with x, y in the viewport's target.
+func (vp *Viewport) MouseDown(x, y int, btn MouseButton) {
+	p := vp.ToUI(types.Vec2{X: x, Y: y})
+	vp.ui.MouseDown(p.X, p.Y, btn)
+}
+
+// MouseUp is UI.MouseUp with x, y in the viewport's target.
+func (vp *Viewport) MouseUp(x, y int, btn MouseButton) {
+	p := vp.ToUI(types.Vec2{X: x, Y: y})
+	vp.ui.MouseUp(p.X, p.Y, btn)
+}
+
+// RenderViewport renders the part of the frame vp shows to renderer: the
+// windows assigned to vp and those without a viewport, then the overlay,
+// translated to vp's origin and clipped to its rect. Call it once per
+// viewport after EndFrame, in place of or as well as Render.
+func (u *UI) RenderViewport(vp *Viewport, renderer interface{}) {
+	u.checkGoroutine("RenderViewport")
+	renderCmd := commandRenderer(renderer, u.scale)
+	if renderCmd == nil {
+		return
+	}
+	draw := vp.translate(renderCmd)
+	draw(Command{Kind: CmdClip, Rect: vp.rect})
+	u.eachRendered(draw, func(cnt *Container) bool {
+		return cnt.viewport == nil || cnt.viewport == vp
+	})
+}
+
+// translate returns renderCmd drawing commands in the viewport's target:
+// moved from its rect to its origin and clipped to its rect.
+func (vp *Viewport) translate(renderCmd func(Command)) func(Command) {
+	dx, dy := vp.origin.X-vp.rect.X, vp.origin.Y-vp.rect.Y
+	return func(cmd Command) {
+		switch cmd.Kind {
+		case CmdClip:
+			cmd.Rect = intersectRect(cmd.Rect, vp.rect)
+			if cmd.Rect.Empty() {
+				// Nothing shows; keep the empty clip inside the target
+				cmd.Rect = types.Rect{X: vp.rect.X, Y: vp.rect.Y}
+			}
+		case CmdRect, CmdText:
+			cmd.Pos.X += dx
+			cmd.Pos.Y += dy
+		}
+		cmd.Rect.X += dx
+		cmd.Rect.Y += dy
+		renderCmd(cmd)
+	}
+}
+
+// windowArea returns the area window cnt is maximized to and, with
+// ConstrainToScreen, kept inside: its viewport, or the screen less the
+// status bars. ok is false if neither is known.
+func (u *UI) windowArea(cnt *Container) (area types.Rect, ok bool) {
+	if vp := cnt.viewport; vp != nil {
+		return vp.rect, !vp.rect.Empty()
+	}
+	return u.workArea(), !u.screen.Empty()
+}
@@ -0,0 +1,186 @@
+package microui
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// viewportRenderer keeps where texts were drawn and the clips set.
+type viewportRenderer struct {
+	texts map[string]types.Vec2
+	clips []types.Rect
+}
+
+func (r *viewportRenderer) DrawRect(types.Vec2, types.Vec2, color.Color) {}
+func (r *viewportRenderer) DrawText(text string, pos types.Vec2, _ types.Font, _ color.Color) {
+	if r.texts == nil {
+		r.texts = map[string]types.Vec2{}
+	}
+	r.texts[text] = pos
+}
+func (r *viewportRenderer) SetClip(rect types.Rect) { r.clips = append(r.clips, rect) }
+
+// splitApp shows a window in each half of a split screen, 400 pixels wide
+// per half, and one window without a viewport in the left half.
+type splitApp struct {
+	ui          *UI
+	left, right *Viewport
+	clicks      int
+}
+
+func newSplitApp() *splitApp {
+	ui := New(Config{})
+	p := &splitApp{ui: ui, left: ui.Viewport("left"), right: ui.Viewport("right")}
+	p.left.SetRect(types.Rect{W: 400, H: 300})
+	p.right.SetRect(types.Rect{X: 400, W: 400, H: 300})
+	ui.GetContainer("Scene").SetViewport(p.left)
+	ui.GetContainer("Props").SetViewport(p.right)
+	return p
+}
+
+func (p *splitApp) frame() {
+	ui := p.ui
+	ui.BeginFrame()
+	if ui.BeginWindow("Scene", types.Rect{X: 10, Y: 10, W: 200, H: 150}) {
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Label("scene")
+		ui.EndWindow()
+	}
+	if ui.BeginWindow("Props", types.Rect{X: 410, Y: 10, W: 200, H: 150}) {
+		ui.LayoutRow(1, []int{-1}, 0)
+		if ui.Button("apply") {
+			p.clicks++
+		}
+		ui.EndWindow()
+	}
+	if ui.BeginWindow("Free", types.Rect{X: 10, Y: 200, W: 200, H: 80}) {
+		ui.LayoutRow(1, []int{-1}, 0)
+		ui.Label("free")
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+func TestViewport_RenderTranslatesAndFilters(t *testing.T) {
+	p := newSplitApp()
+	p.frame()
+
+	var left, right, main viewportRenderer
+	p.ui.RenderViewport(p.left, &left)
+	p.ui.RenderViewport(p.right, &right)
+	p.ui.Render(&main)
+
+	if _, ok := left.texts["apply"]; ok {
+		t.Error("the left viewport drew a window assigned to the right one")
+	}
+	if _, ok := left.texts["free"]; !ok {
+		t.Error("the left viewport should draw a window without a viewport")
+	}
+	apply, ok := right.texts["apply"]
+	switch {
+	case !ok:
+		t.Fatal("the right viewport didn't draw its window")
+	case apply.X >= 400:
+		t.Errorf("right viewport drew at x=%d, want it moved to the target's origin", apply.X)
+	}
+	for _, c := range right.clips {
+		if c.X < 0 || c.Y < 0 || c.X+c.W > 400 || c.Y+c.H > 300 {
+			t.Errorf("right viewport clip %v outside its target", c)
+		}
+	}
+	if _, ok := main.texts["scene"]; ok {
+		t.Error("Render drew a window assigned to a viewport")
+	}
+	if _, ok := main.texts["free"]; !ok {
+		t.Error("Render should draw a window without a viewport")
+	}
+}
+
+func TestViewport_Origin(t *testing.T) {
+	p := newSplitApp()
+	p.right.SetOrigin(types.Vec2{X: 50, Y: 20})
+	p.frame()
+
+	var direct, moved viewportRenderer
+	p.ui.Render(&direct)
+	p.ui.RenderViewport(p.right, &moved)
+	p.right.SetOrigin(types.Vec2{})
+	var plain viewportRenderer
+	p.ui.RenderViewport(p.right, &plain)
+
+	got, want := moved.texts["apply"], plain.texts["apply"].Add(types.Vec2{X: 50, Y: 20})
+	if got != want {
+		t.Errorf("text at %v with origin (50,20), want %v", got, want)
+	}
+	if back := p.right.ToUI(p.right.FromUI(types.Vec2{X: 500, Y: 40})); back != (types.Vec2{X: 500, Y: 40}) {
+		t.Errorf("ToUI(FromUI(p)) = %v, want p", back)
+	}
+}
+
+func TestViewport_Input(t *testing.T) {
+	p := newSplitApp()
+	p.frame()
+
+	// The button in the right viewport's target, which starts at UI x=400
+	var r viewportRenderer
+	p.ui.RenderViewport(p.right, &r)
+	pos := r.texts["apply"]
+	x, y := pos.X+5, pos.Y+2
+	p.right.MouseMove(x, y)
+	p.frame()
+	p.right.MouseDown(x, y, MouseLeft)
+	p.frame()
+	p.right.MouseUp(x, y, MouseLeft)
+	p.frame()
+	if p.clicks != 1 {
+		t.Errorf("button clicked %d times through its viewport, want 1", p.clicks)
+	}
+}
+
+func TestViewport_InputOutsideRegion(t *testing.T) {
+	ui := New(Config{})
+	vp := ui.Viewport("pane")
+	vp.SetRect(types.Rect{W: 100, H: 100})
+	ui.GetContainer("Wide").SetViewport(vp)
+
+	ui.MouseMove(150, 50) // On the window but outside its viewport
+	for range 2 {
+		ui.BeginFrame()
+		ui.BeginWindow("Wide", types.Rect{W: 200, H: 100})
+		ui.EndWindow()
+		ui.EndFrame()
+	}
+	if got := ui.HoveredWindow(); got != nil {
+		t.Errorf("HoveredWindow = %q outside its viewport, want nil", got.Name())
+	}
+}
+
+func TestViewport_MaximizeFillsViewport(t *testing.T) {
+	p := newSplitApp()
+	p.frame()
+	props := p.ui.GetContainer("Props")
+	p.ui.toggleMaximized(props)
+	p.frame()
+	if got := props.Rect(); got != p.right.Rect() {
+		t.Errorf("maximized window rect = %v, want the viewport's %v", got, p.right.Rect())
+	}
+}
+
+func TestViewport_Remove(t *testing.T) {
+	p := newSplitApp()
+	p.ui.RemoveViewport("right")
+	if p.ui.GetContainer("Props").Viewport() != nil {
+		t.Error("a removed viewport's windows should have no viewport")
+	}
+	if n := len(p.ui.Viewports()); n != 1 {
+		t.Errorf("%d viewports after removing one of two, want 1", n)
+	}
+	p.frame()
+	var main viewportRenderer
+	p.ui.Render(&main)
+	if _, ok := main.texts["apply"]; !ok {
+		t.Error("Render should draw the windows of a removed viewport")
+	}
+}
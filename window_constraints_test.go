@@ -0,0 +1,111 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+var constraintRect = types.Rect{X: 100, Y: 50, W: 200, H: 150}
+
+func constraintFrame(ui *UI) {
+	ui.BeginFrame()
+	if ui.BeginWindow("C", constraintRect) {
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+}
+
+// constraintDrag presses at (x0,y0), drags to (x1,y1) and releases.
+func constraintDrag(ui *UI, x0, y0, x1, y1 int) {
+	ui.MouseMove(x0, y0)
+	constraintFrame(ui)
+	ui.MouseDown(x0, y0, MouseLeft)
+	constraintFrame(ui)
+	ui.MouseMove(x1, y1)
+	constraintFrame(ui)
+	ui.MouseUp(x1, y1, MouseLeft)
+	constraintFrame(ui)
+}
+
+func TestWindow_MinMaxSize(t *testing.T) {
+	ui := New(Config{})
+	constraintFrame(ui)
+	cnt := ui.GetContainer("C")
+	cnt.SetMinSize(150, 120)
+	cnt.SetMaxSize(260, 0)
+
+	// Shrink well below the minimum from the resize corner at (295,195)
+	constraintDrag(ui, 295, 195, 150, 80)
+	if r := cnt.Rect(); r.W != 150 || r.H != 120 {
+		t.Errorf("after shrinking size = %dx%d, want 150x120", r.W, r.H)
+	}
+
+	// Grow past the maximum width; height has no maximum
+	constraintDrag(ui, 245, 165, 445, 365)
+	if r := cnt.Rect(); r.W != 260 || r.H != 320 {
+		t.Errorf("after growing size = %dx%d, want 260x320", r.W, r.H)
+	}
+}
+
+func TestWindow_MinSizeAppliesToRect(t *testing.T) {
+	ui := New(Config{})
+	constraintFrame(ui)
+	cnt := ui.GetContainer("C")
+	cnt.SetMinSize(240, 300)
+	constraintFrame(ui)
+	if r := cnt.Rect(); r.W != 240 || r.H != 300 {
+		t.Errorf("size = %dx%d, want the 240x300 minimum", r.W, r.H)
+	}
+}
+
+func TestWindow_Aspect(t *testing.T) {
+	ui := New(Config{})
+	constraintFrame(ui)
+	cnt := ui.GetContainer("C")
+	cnt.SetAspect(2)
+	constraintFrame(ui)
+	if r := cnt.Rect(); r.W != 200 || r.H != 100 {
+		t.Fatalf("size = %dx%d, want 200x100 for aspect 2", r.W, r.H)
+	}
+
+	constraintDrag(ui, 295, 145, 395, 145)
+	if r := cnt.Rect(); r.W != 300 || r.H != 150 {
+		t.Errorf("after resize size = %dx%d, want 300x150", r.W, r.H)
+	}
+}
+
+func TestWindow_ConstrainToScreen(t *testing.T) {
+	ui := New(Config{ConstrainToScreen: true})
+	ui.SetScreenSize(640, 480)
+	constraintFrame(ui)
+	cnt := ui.GetContainer("C")
+
+	// Drag the title bar far past the bottom-right corner
+	constraintDrag(ui, 150, 60, 1000, 1000)
+	if want := (types.Rect{X: 440, Y: 330, W: 200, H: 150}); cnt.Rect() != want {
+		t.Errorf("after drag rect = %v, want %v", cnt.Rect(), want)
+	}
+
+	// And past the top-left corner
+	constraintDrag(ui, 490, 340, -500, -500)
+	if want := (types.Rect{W: 200, H: 150}); cnt.Rect() != want {
+		t.Errorf("after drag rect = %v, want %v", cnt.Rect(), want)
+	}
+
+	// Resizing stops at the screen edge
+	constraintDrag(ui, 195, 145, 900, 900)
+	if want := (types.Rect{W: 640, H: 480}); cnt.Rect() != want {
+		t.Errorf("after resize rect = %v, want %v", cnt.Rect(), want)
+	}
+}
+
+func TestWindow_NoScreenConstraintByDefault(t *testing.T) {
+	ui := New(Config{})
+	ui.SetScreenSize(640, 480)
+	constraintFrame(ui)
+	constraintDrag(ui, 150, 60, 1000, 1000)
+	if r := ui.GetContainer("C").Rect(); r.X != 950 || r.Y != 990 {
+		t.Errorf("rect = %v, want the window dragged off-screen", r)
+	}
+}
@@ -0,0 +1,110 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+// resultFrame draws windows A (at 0,0) and B (at 200,0) and returns their
+// results.
+func resultFrame(ui *UI, opt int) (a, b WindowResult) {
+	ui.BeginFrame()
+	a = ui.BeginWindowEx("A", types.Rect{X: 0, Y: 0, W: 400, H: 300}, opt)
+	if a.Visible {
+		ui.EndWindow()
+	}
+	b = ui.BeginWindowEx("B", types.Rect{X: 200, Y: 0, W: 400, H: 300}, opt)
+	if b.Visible {
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+	return a, b
+}
+
+func TestWindowResult_FirstFrame(t *testing.T) {
+	ui := New(Config{})
+	a, _ := resultFrame(ui, 0)
+	want := WindowResult{Visible: true, Rect: types.Rect{W: 400, H: 300}}
+	if a != want {
+		t.Errorf("first frame result = %+v, want %+v", a, want)
+	}
+}
+
+func TestWindowResult_MovedAndResized(t *testing.T) {
+	ui := New(Config{})
+	resultFrame(ui, 0)
+
+	// Drag B's title bar
+	ui.MouseMove(500, 10)
+	resultFrame(ui, 0)
+	ui.MouseDown(500, 10, MouseLeft)
+	resultFrame(ui, 0)
+	ui.MouseMove(520, 30)
+	_, b := resultFrame(ui, 0)
+	if !b.Moved || b.Resized {
+		t.Errorf("drag: Moved=%v Resized=%v, want moved only", b.Moved, b.Resized)
+	}
+	if b.Rect.X != 220 || b.Rect.Y != 20 {
+		t.Errorf("drag: Rect = %v, want at 220,20", b.Rect)
+	}
+	ui.MouseUp(520, 30, MouseLeft)
+	if _, b = resultFrame(ui, 0); b.Moved {
+		t.Error("Moved should only be reported while the window moves")
+	}
+
+	// Resize B from its bottom-right corner (620,320)
+	ui.MouseMove(615, 315)
+	resultFrame(ui, 0)
+	ui.MouseDown(615, 315, MouseLeft)
+	resultFrame(ui, 0)
+	ui.MouseMove(635, 325)
+	_, b = resultFrame(ui, 0)
+	if !b.Resized || b.Moved {
+		t.Errorf("resize: Resized=%v Moved=%v, want resized only", b.Resized, b.Moved)
+	}
+	ui.MouseUp(635, 325, MouseLeft)
+}
+
+func TestWindowResult_BroughtToFront(t *testing.T) {
+	ui := New(Config{})
+	resultFrame(ui, 0)
+
+	// A is below B; click the part of A that B doesn't cover
+	ui.MouseMove(50, 100)
+	resultFrame(ui, 0)
+	ui.MouseDown(50, 100, MouseLeft)
+	a, b := resultFrame(ui, 0)
+	if !a.BroughtToFront || b.BroughtToFront {
+		t.Errorf("BroughtToFront A=%v B=%v, want A only", a.BroughtToFront, b.BroughtToFront)
+	}
+	ui.MouseUp(50, 100, MouseLeft)
+	resultFrame(ui, 0)
+
+	// Clicking the window already in front doesn't report it again
+	ui.MouseDown(50, 100, MouseLeft)
+	if a, _ = resultFrame(ui, 0); a.BroughtToFront {
+		t.Error("clicking the front window should not report BroughtToFront")
+	}
+}
+
+func TestWindowResult_Closed(t *testing.T) {
+	ui := New(Config{})
+	ui.OpenWindow("A")
+	ui.OpenWindow("B")
+	resultFrame(ui, OptClosed)
+
+	// B's close button is at the right of its title bar
+	ui.MouseMove(585, 10)
+	resultFrame(ui, OptClosed)
+	ui.MouseDown(585, 10, MouseLeft)
+	_, b := resultFrame(ui, OptClosed)
+	if !b.Closed || !b.Visible {
+		t.Errorf("close click: Closed=%v Visible=%v, want both", b.Closed, b.Visible)
+	}
+	ui.MouseUp(585, 10, MouseLeft)
+	_, b = resultFrame(ui, OptClosed)
+	if b.Closed || b.Visible {
+		t.Errorf("after close: Closed=%v Visible=%v, want neither", b.Closed, b.Visible)
+	}
+}
@@ -0,0 +1,143 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+var stateWinRect = types.Rect{X: 0, Y: 0, W: 400, H: 300}
+
+// stateFrame draws one window and reports whether its body was shown.
+func stateFrame(ui *UI, opt int) bool {
+	ui.BeginFrame()
+	shown := ui.BeginWindowOpt("W", stateWinRect, opt)
+	if shown {
+		ui.Label("body")
+		ui.EndWindow()
+	}
+	ui.EndFrame()
+	return shown
+}
+
+func stateClick(ui *UI, x, y, opt int) {
+	ui.MouseMove(x, y)
+	stateFrame(ui, opt)
+	ui.MouseDown(x, y, MouseLeft)
+	stateFrame(ui, opt)
+	ui.MouseUp(x, y, MouseLeft)
+	stateFrame(ui, opt)
+}
+
+func TestWindow_CollapseToTitle(t *testing.T) {
+	ui := New(Config{})
+	stateFrame(ui, OptCollapsible)
+
+	// The collapse button sits at the left of the title bar
+	stateClick(ui, 10, 10, OptCollapsible)
+	cnt := ui.GetContainer("W")
+	if !cnt.Collapsed() {
+		t.Fatal("clicking the collapse button should collapse the window")
+	}
+	if stateFrame(ui, OptCollapsible) {
+		t.Error("a collapsed window should not show its body")
+	}
+	if cnt.Rect() != stateWinRect {
+		t.Errorf("collapsing should keep the rect, got %v", cnt.Rect())
+	}
+
+	// The hidden body no longer captures the mouse
+	ui.MouseMove(100, 100)
+	stateFrame(ui, OptCollapsible)
+	stateFrame(ui, OptCollapsible)
+	if ui.hoverRoot == cnt {
+		t.Error("the area below a collapsed title bar should not hover the window")
+	}
+
+	stateClick(ui, 10, 10, OptCollapsible)
+	if cnt.Collapsed() || !stateFrame(ui, OptCollapsible) {
+		t.Error("clicking again should expand the window")
+	}
+}
+
+func TestWindow_CollapsedDrawsTitleOnly(t *testing.T) {
+	ui := New(Config{})
+	stateFrame(ui, OptCollapsible)
+	ui.GetContainer("W").SetCollapsed(true)
+	stateFrame(ui, OptCollapsible)
+
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdRect && cmd.Rect.Y+cmd.Rect.H > 25 {
+			t.Errorf("collapsed window drew below its title bar: %v", cmd.Rect)
+		}
+		if cmd.Kind == CmdText && cmd.Text == "body" {
+			t.Error("collapsed window drew its body")
+		}
+	})
+}
+
+func TestWindow_MaximizeAndRestore(t *testing.T) {
+	ui := New(Config{})
+	ui.SetScreenSize(800, 600)
+	opt := OptMaximizable
+	stateFrame(ui, opt)
+
+	// The maximize button sits left of the close button
+	stateClick(ui, 360, 10, opt)
+	cnt := ui.GetContainer("W")
+	if !cnt.Maximized() {
+		t.Fatal("clicking the maximize button should maximize the window")
+	}
+	if want := (types.Rect{W: 800, H: 600}); cnt.Rect() != want {
+		t.Errorf("maximized rect = %v, want %v", cnt.Rect(), want)
+	}
+
+	// Maximized windows follow the screen size
+	ui.SetScreenSize(640, 480)
+	stateFrame(ui, opt)
+	if want := (types.Rect{W: 640, H: 480}); cnt.Rect() != want {
+		t.Errorf("maximized rect after resize = %v, want %v", cnt.Rect(), want)
+	}
+
+	// The button is now at the right of the 640px wide title bar
+	stateClick(ui, 600, 10, opt)
+	if cnt.Maximized() || cnt.Rect() != stateWinRect {
+		t.Errorf("restore: maximized=%v rect=%v, want %v", cnt.Maximized(), cnt.Rect(), stateWinRect)
+	}
+}
+
+func TestWindow_MaximizeNeedsScreenSize(t *testing.T) {
+	ui := New(Config{})
+	stateFrame(ui, OptMaximizable)
+	ui.commands.Each(func(cmd Command) {
+		if cmd.Kind == CmdIcon && cmd.Icon == IconMaximize {
+			t.Error("maximize button should be hidden until SetScreenSize is called")
+		}
+	})
+}
+
+func TestWindow_CollapsedMaximizedPersist(t *testing.T) {
+	ui := New(Config{})
+	ui.SetScreenSize(800, 600)
+	stateFrame(ui, OptMaximizable)
+	stateClick(ui, 360, 10, OptMaximizable)
+	ui.GetContainer("W").SetCollapsed(true)
+
+	data, err := ui.SaveLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ui2 := New(Config{})
+	if err := ui2.LoadLayout(data); err != nil {
+		t.Fatal(err)
+	}
+	cnt := ui2.GetContainer("W")
+	if !cnt.Collapsed() || !cnt.Maximized() {
+		t.Fatalf("restored collapsed=%v maximized=%v, want both", cnt.Collapsed(), cnt.Maximized())
+	}
+	ui2.toggleMaximized(cnt)
+	if cnt.Rect() != stateWinRect {
+		t.Errorf("restoring after load gave %v, want %v", cnt.Rect(), stateWinRect)
+	}
+}
@@ -0,0 +1,69 @@
+package microui
+
+import (
+	"cmp"
+	"slices"
+)
+
+// The root containers are kept in u.zOrder, back to front, across frames.
+// A container is moved only when its place changes: when it is first
+// begun, brought to front or changes layer. Rendering walks the list
+// rather than sorting the frame's containers, and cnt.zpos makes "which
+// is in front" a comparison of two ints.
+
+// zLayer returns which band of the z-order a root container is drawn in:
+// the background layer, windows, then pinned windows, then status bars,
+// then popups and the modal dialog, which open from windows of any kind
+// and must show above them.
+func (u *UI) zLayer(cnt *Container) int {
+	switch {
+	case cnt.name == backgroundName:
+		return -1
+	case cnt.opt&OptPopup != 0 || cnt == u.modal:
+		return 3
+	case isStatusBar(cnt):
+		return 2
+	case cnt.pinned:
+		return 1
+	}
+	return 0
+}
+
+// inFront reports whether root container a is drawn above b.
+func (u *UI) inFront(a, b *Container) bool {
+	if a.zpos > 0 && b.zpos > 0 {
+		return a.zpos > b.zpos
+	}
+	if la, lb := u.zLayer(a), u.zLayer(b); la != lb {
+		return la > lb
+	}
+	return a.zindex > b.zindex
+}
+
+// restack moves cnt to its place in u.zOrder after its z-index or layer
+// changed, adding it if it isn't there yet. Among equal z-indexes the
+// latest one goes in front.
+func (u *UI) restack(cnt *Container) {
+	from := len(u.zOrder)
+	if cnt.zpos > 0 {
+		from = cnt.zpos - 1
+		u.zOrder = slices.Delete(u.zOrder, from, from+1)
+	}
+	cnt.zlayer = u.zLayer(cnt)
+	i, _ := slices.BinarySearchFunc(u.zOrder, cnt, func(e, t *Container) int {
+		if c := cmp.Or(cmp.Compare(e.zlayer, t.zlayer), cmp.Compare(e.zindex, t.zindex)); c != 0 {
+			return c
+		}
+		return -1
+	})
+	u.zOrder = slices.Insert(u.zOrder, i, cnt)
+	for j := min(from, i); j < len(u.zOrder); j++ {
+		u.zOrder[j].zpos = j + 1
+	}
+}
+
+// begunThisFrame reports whether cnt was begun as a root container in the
+// current frame.
+func (u *UI) begunThisFrame(cnt *Container) bool {
+	return cnt.rootFrame == u.frame
+}
@@ -0,0 +1,61 @@
+package microui
+
+import (
+	"testing"
+
+	"github.com/user/microui-go/types"
+)
+
+func zorderFrame(ui *UI, names ...string) {
+	ui.BeginFrame()
+	for i, name := range names {
+		if ui.BeginWindow(name, types.Rect{X: i * 20, Y: i * 20, W: 100, H: 100}) {
+			ui.EndWindow()
+		}
+	}
+	ui.EndFrame()
+}
+
+func rootNames(ui *UI) []string {
+	var names []string
+	for _, cnt := range ui.RootContainersSorted() {
+		names = append(names, cnt.Name())
+	}
+	return names
+}
+
+func TestZOrder_KeptAcrossFrames(t *testing.T) {
+	ui := New(Config{})
+	zorderFrame(ui, "A", "B", "C")
+	ui.BringToFront(ui.GetContainer("A"))
+	ui.GetContainer("B").SetPinned(true)
+	zorderFrame(ui, "A", "B", "C")
+	if got := rootNames(ui); len(got) != 3 || got[0] != "C" || got[1] != "A" || got[2] != "B" {
+		t.Errorf("back to front = %v, want [C A B]", got)
+	}
+
+	// Windows not begun this frame aren't listed, but keep their place
+	zorderFrame(ui, "A", "C")
+	if got := rootNames(ui); len(got) != 2 || got[0] != "C" || got[1] != "A" {
+		t.Errorf("back to front = %v, want [C A]", got)
+	}
+	zorderFrame(ui, "A", "B", "C")
+	if got := rootNames(ui); got[2] != "B" {
+		t.Errorf("back to front = %v, want the pinned B in front again", got)
+	}
+	for i, cnt := range ui.zOrder {
+		if cnt.zpos != i+1 {
+			t.Errorf("%s zpos = %d, want %d", cnt.name, cnt.zpos, i+1)
+		}
+	}
+}
+
+func TestZOrder_NoAllocs(t *testing.T) {
+	ui := New(Config{})
+	names := []string{"A", "B", "C", "D"}
+	zorderFrame(ui, names...)
+	ui.RootContainersSorted()
+	if n := testing.AllocsPerRun(10, func() { ui.RootContainersSorted() }); n != 0 {
+		t.Errorf("RootContainersSorted allocates %v times, want 0", n)
+	}
+}